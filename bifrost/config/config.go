@@ -40,17 +40,31 @@ type BackOff struct {
 
 // ChainConfiguration configuration
 type ChainConfiguration struct {
-	ChainID      common.Chain              `json:"chain_id" mapstructure:"chain_id"`
-	ChainHost    string                    `json:"chain_host" mapstructure:"chain_host"`
-	ChainNetwork string                    `json:"chain_network" mapstructure:"chain_network"`
-	UserName     string                    `json:"username" mapstructure:"username"`
-	Password     string                    `json:"password" mapstructure:"password"`
-	RPCHost      string                    `jsonn:"rpc_host" mapstructure:"rpc_host"`
-	HTTPostMode  bool                      `json:"http_post_mode" mapstructure:"http_post_mode"` // Bitcoin core only supports HTTP POST mode
-	DisableTLS   bool                      `json:"disable_tls" mapstructure:"disable_tls"`       // Bitcoin core does not provide TLS by default
-	BlockScanner BlockScannerConfiguration `json:"block_scanner" mapstructure:"block_scanner"`
-	BackOff      BackOff
-	OptToRetire  bool `json:"opt_to_retire" mapstructure:"opt_to_retire"` // don't emit support for this chain during keygen process
+	ChainID       common.Chain              `json:"chain_id" mapstructure:"chain_id"`
+	ChainHost     string                    `json:"chain_host" mapstructure:"chain_host"`
+	ChainNetwork  string                    `json:"chain_network" mapstructure:"chain_network"`
+	UserName      string                    `json:"username" mapstructure:"username"`
+	Password      string                    `json:"password" mapstructure:"password"`
+	RPCHost       string                    `jsonn:"rpc_host" mapstructure:"rpc_host"`
+	HTTPostMode   bool                      `json:"http_post_mode" mapstructure:"http_post_mode"` // Bitcoin core only supports HTTP POST mode
+	DisableTLS    bool                      `json:"disable_tls" mapstructure:"disable_tls"`       // Bitcoin core does not provide TLS by default
+	BlockScanner  BlockScannerConfiguration `json:"block_scanner" mapstructure:"block_scanner"`
+	BackOff       BackOff
+	OptToRetire   bool              `json:"opt_to_retire" mapstructure:"opt_to_retire"`     // don't emit support for this chain during keygen process
+	RouterAddress string            `json:"router_address" mapstructure:"router_address"`   // Ethereum only, the vault router contract inbound and outbound go through
+	StuckTxBlocks int64             `json:"stuck_tx_blocks" mapstructure:"stuck_tx_blocks"` // Ethereum only, number of blocks an outbound can stay in mempool before it get re-signed with a higher gas price
+	UTXO          UTXOConfiguration `json:"utxo" mapstructure:"utxo"`                       // UTXO chains only
+}
+
+// UTXOConfiguration settings for how UTXO chain clients spend their vault UTXOs, zero values fall back to the chain client defaults
+type UTXOConfiguration struct {
+	CoinSelection      string `json:"coin_selection" mapstructure:"coin_selection"`             // branch_and_bound or largest_first
+	MaxInputs          int    `json:"max_inputs" mapstructure:"max_inputs"`                     // maximum number of UTXOs an outbound tx can spend
+	DustThreshold      int64  `json:"dust_threshold" mapstructure:"dust_threshold"`             // change output below this amount(in sats) will be given to miners instead
+	ConsolidateFeeRate int64  `json:"consolidate_fee_rate" mapstructure:"consolidate_fee_rate"` // when fee rate(sats/vbyte) is at or below this, outbound tx will spend extra small UTXOs to consolidate
+	RescanStartHeight  int64  `json:"rescan_start_height" mapstructure:"rescan_start_height"`   // when the UTXO index is empty, rebuild it by rescanning blocks from this height
+	ReconcileBlocks    int64  `json:"reconcile_blocks" mapstructure:"reconcile_blocks"`         // how often(in blocks) the UTXO index get reconciled against the UTXOs reported by the chain node
+	FeeTargetBlocks    int64  `json:"fee_target_blocks" mapstructure:"fee_target_blocks"`       // number of blocks outbound txs are expected to confirm within, used to pick the fee rate tier
 }
 
 // TSSConfiguration
@@ -144,7 +158,7 @@ func applyDefaultConfig() {
 	viper.SetDefault("metrics.listen_port", "9000")
 	viper.SetDefault("metrics.read_timeout", "30s")
 	viper.SetDefault("metrics.write_timeout", "30s")
-	viper.SetDefault("metrics.chains", common.Chains{common.BNBChain, common.BTCChain, common.ETHChain})
+	viper.SetDefault("metrics.chains", common.Chains{common.BNBChain, common.BTCChain, common.BCHChain, common.LTCChain, common.ETHChain})
 	viper.SetDefault("thorchain.chain_id", "thorchain")
 	viper.SetDefault("thorchain.chain_host", "localhost:1317")
 	viper.SetDefault("back_off.initial_interval", 500*time.Millisecond)
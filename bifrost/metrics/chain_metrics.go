@@ -46,7 +46,11 @@ func SearchTxDuration(chain common.Chain) MetricName {
 	return MetricName(chain + "_search_tx_duration")
 }
 
-func AddChainMetrics(chain common.Chain, counters map[MetricName]prometheus.Counter, counterVecs map[MetricName]*prometheus.CounterVec, histograms map[MetricName]prometheus.Histogram) {
+func UTXOCount(chain common.Chain) MetricName {
+	return MetricName(chain + "_utxo_count")
+}
+
+func AddChainMetrics(chain common.Chain, counters map[MetricName]prometheus.Counter, counterVecs map[MetricName]*prometheus.CounterVec, histograms map[MetricName]prometheus.Histogram, gaugeVecs map[MetricName]*prometheus.GaugeVec) {
 	counters[BlockWithoutTx(chain)] = prometheus.NewCounter(prometheus.CounterOpts{
 		Namespace: "block_scanner",
 		Subsystem: chain.String() + "_block_scanner",
@@ -105,4 +109,13 @@ func AddChainMetrics(chain common.Chain, counters map[MetricName]prometheus.Coun
 		Name:      chain.String() + "_sign_and_broadcast_duration",
 		Help:      "how long it takes to sign and broadcast to " + chain.String(),
 	})
+
+	gaugeVecs[UTXOCount(chain)] = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "signer",
+		Subsystem: chain.String(),
+		Name:      chain.String() + "_utxo_count",
+		Help:      "number of unspent UTXOs the vault has on " + chain.String(),
+	}, []string{
+		"vault_pub_key",
+	})
 }
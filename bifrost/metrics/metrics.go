@@ -154,13 +154,15 @@ var (
 			Help:      "how long it takes to sign and broadcast to binance",
 		}),
 	}
+
+	gaugeVecs = map[MetricName]*prometheus.GaugeVec{}
 )
 
 // NewMetrics create a new instance of Metrics
 func NewMetrics(cfg config.MetricsConfiguration) (*Metrics, error) {
 	// Add chain metrics
 	for _, chain := range cfg.Chains {
-		AddChainMetrics(chain, counters, counterVecs, histograms)
+		AddChainMetrics(chain, counters, counterVecs, histograms, gaugeVecs)
 	}
 	// Register metrics
 	for _, item := range counterVecs {
@@ -172,6 +174,9 @@ func NewMetrics(cfg config.MetricsConfiguration) (*Metrics, error) {
 	for _, item := range histograms {
 		prometheus.MustRegister(item)
 	}
+	for _, item := range gaugeVecs {
+		prometheus.MustRegister(item)
+	}
 	// create a new mux server
 	server := http.NewServeMux()
 	// register a new handler for the /metrics endpoint
@@ -214,6 +219,14 @@ func (m *Metrics) GetCounterVec(name MetricName) *prometheus.CounterVec {
 	return nil
 }
 
+// GetGaugeVec return a gauge vec by name, if it doesn't exist, then it return nil
+func (m *Metrics) GetGaugeVec(name MetricName) *prometheus.GaugeVec {
+	if g, ok := gaugeVecs[name]; ok {
+		return g
+	}
+	return nil
+}
+
 // Start
 func (m *Metrics) Start() error {
 	if !m.cfg.Enabled {
@@ -26,6 +26,7 @@ import (
 	"gitlab.com/thorchain/thornode/bifrost/blockscanner"
 	"gitlab.com/thorchain/thornode/bifrost/config"
 	"gitlab.com/thorchain/thornode/bifrost/metrics"
+	"gitlab.com/thorchain/thornode/bifrost/pkg/chainclients"
 	"gitlab.com/thorchain/thornode/bifrost/thorclient"
 	stypes "gitlab.com/thorchain/thornode/bifrost/thorclient/types"
 	"gitlab.com/thorchain/thornode/bifrost/tss"
@@ -49,6 +50,15 @@ type Binance struct {
 	bnbScanner      *BinanceBlockScanner
 }
 
+func init() {
+	chainclients.Register(common.BNBChain, chainclients.Factory{
+		Capabilities: chainclients.Capabilities{SupportsMemo: true, SupportsMultiSend: true},
+		New: func(thorKeys *thorclient.Keys, cfg config.ChainConfiguration, server *tssp.TssServer, thorchainBridge *thorclient.ThorchainBridge, m *metrics.Metrics) (chainclients.ChainClient, error) {
+			return NewBinance(thorKeys, cfg, server, thorchainBridge, m)
+		},
+	})
+}
+
 // NewBinance create new instance of binance client
 func NewBinance(thorKeys *thorclient.Keys, cfg config.ChainConfiguration, server *tssp.TssServer, thorchainBridge *thorclient.ThorchainBridge, m *metrics.Metrics) (*Binance, error) {
 	tssKm, err := tss.NewKeySign(server)
@@ -21,6 +21,7 @@ import (
 	btypes "gitlab.com/thorchain/thornode/bifrost/blockscanner/types"
 	"gitlab.com/thorchain/thornode/bifrost/config"
 	"gitlab.com/thorchain/thornode/bifrost/metrics"
+	"gitlab.com/thorchain/thornode/bifrost/pkg/chainclients"
 	"gitlab.com/thorchain/thornode/bifrost/thorclient"
 	"gitlab.com/thorchain/thornode/bifrost/thorclient/types"
 	"gitlab.com/thorchain/thornode/bifrost/tss"
@@ -31,22 +32,42 @@ import (
 const BlockCacheSize = 100
 
 // Client observes bitcoin chain and allows to sign and broadcast tx
+// it is also used by the chains forked from Bitcoin, the differences between them are described by UTXOChain
 type Client struct {
 	logger            zerolog.Logger
 	cfg               config.ChainConfiguration
 	client            *rpcclient.Client
 	chain             common.Chain
+	utxoChain         UTXOChain
 	privateKey        *btcec.PrivateKey
 	blockScanner      *blockscanner.BlockScanner
 	blockMetaAccessor BlockMetaAccessor
+	utxoAccessor      UTXOAccessor
 	ksWrapper         *KeySignWrapper
 	bridge            *thorclient.ThorchainBridge
 	globalErrataQueue chan<- types.ErrataBlock
 	nodePubKey        common.PubKey
+	coinSelector      CoinSelector
+	m                 *metrics.Metrics
+	lastFeeRate       int64
+}
+
+func init() {
+	chainclients.Register(common.BTCChain, chainclients.Factory{
+		Capabilities: chainclients.Capabilities{UTXO: true, SupportsMemo: true},
+		New: func(thorKeys *thorclient.Keys, cfg config.ChainConfiguration, server *tssp.TssServer, thorchainBridge *thorclient.ThorchainBridge, m *metrics.Metrics) (chainclients.ChainClient, error) {
+			return NewClient(thorKeys, cfg, server, thorchainBridge, m)
+		},
+	})
 }
 
 // NewClient generates a new Client
 func NewClient(thorKeys *thorclient.Keys, cfg config.ChainConfiguration, server *tssp.TssServer, bridge *thorclient.ThorchainBridge, m *metrics.Metrics) (*Client, error) {
+	return NewUTXOClient(BitcoinChain{}, thorKeys, cfg, server, bridge, m)
+}
+
+// NewUTXOClient generates a new Client for the given UTXO chain
+func NewUTXOClient(utxoChain UTXOChain, thorKeys *thorclient.Keys, cfg config.ChainConfiguration, server *tssp.TssServer, bridge *thorclient.ThorchainBridge, m *metrics.Metrics) (*Client, error) {
 	client, err := rpcclient.New(&rpcclient.ConnConfig{
 		Host:         cfg.RPCHost,
 		User:         cfg.UserName,
@@ -55,7 +76,7 @@ func NewClient(thorKeys *thorclient.Keys, cfg config.ChainConfiguration, server
 		HTTPPostMode: cfg.HTTPostMode,
 	}, nil)
 	if err != nil {
-		return nil, fmt.Errorf("fail to create bitcoin rpc client: %w", err)
+		return nil, fmt.Errorf("fail to create %s rpc client: %w", utxoChain.GetChain(), err)
 	}
 	tssKm, err := tss.NewKeySign(server)
 	if err != nil {
@@ -78,16 +99,23 @@ func NewClient(thorKeys *thorclient.Keys, cfg config.ChainConfiguration, server
 	if err != nil {
 		return nil, fmt.Errorf("fail to get the node pubkey: %w", err)
 	}
+	coinSelector, err := NewCoinSelector(cfg.UTXO.CoinSelection)
+	if err != nil {
+		return nil, fmt.Errorf("fail to create coin selector: %w", err)
+	}
 
 	c := &Client{
-		logger:     log.Logger.With().Str("module", "bitcoin").Logger(),
-		cfg:        cfg,
-		chain:      cfg.ChainID,
-		client:     client,
-		privateKey: btcPrivateKey,
-		ksWrapper:  ksWrapper,
-		bridge:     bridge,
-		nodePubKey: nodePubKey,
+		logger:       log.Logger.With().Str("module", "bitcoin").Str("chain", utxoChain.GetChain().String()).Logger(),
+		cfg:          cfg,
+		chain:        utxoChain.GetChain(),
+		utxoChain:    utxoChain,
+		client:       client,
+		privateKey:   btcPrivateKey,
+		ksWrapper:    ksWrapper,
+		bridge:       bridge,
+		nodePubKey:   nodePubKey,
+		coinSelector: coinSelector,
+		m:            m,
 	}
 
 	var path string // if not set later, will in memory storage
@@ -105,6 +133,11 @@ func NewClient(thorKeys *thorclient.Keys, cfg config.ChainConfiguration, server
 	}
 
 	c.blockMetaAccessor, err = NewLevelDBBlockMetaAccessor(storage.GetInternalDb())
+	if err != nil {
+		return c, fmt.Errorf("fail to create block meta accessor: %w", err)
+	}
+
+	c.utxoAccessor, err = NewLevelDBUTXOAccessor(storage.GetInternalDb())
 	if err != nil {
 		return c, fmt.Errorf("fail to create utxo accessor: %w", err)
 	}
@@ -114,6 +147,9 @@ func NewClient(thorKeys *thorclient.Keys, cfg config.ChainConfiguration, server
 
 // Start starts the block scanner
 func (c *Client) Start(globalTxsQueue chan types.TxIn, globalErrataQueue chan types.ErrataBlock) {
+	if err := c.initUTXOIndex(); err != nil {
+		c.logger.Err(err).Msg("fail to initialise UTXO index")
+	}
 	c.blockScanner.Start(globalTxsQueue)
 	c.globalErrataQueue = globalErrataQueue
 }
@@ -128,9 +164,9 @@ func (c *Client) GetConfig() config.ChainConfiguration {
 	return c.cfg
 }
 
-// GetChain returns BTC Chain
+// GetChain returns the chain of the client
 func (c *Client) GetChain() common.Chain {
-	return common.BTCChain
+	return c.chain
 }
 
 // GetHeight returns current block height
@@ -140,7 +176,7 @@ func (c *Client) GetHeight() (int64, error) {
 
 // GetAddress returns address from pubkey
 func (c *Client) GetAddress(poolPubKey common.PubKey) string {
-	addr, err := poolPubKey.GetAddress(common.BTCChain)
+	addr, err := poolPubKey.GetAddress(c.chain)
 	if err != nil {
 		c.logger.Error().Err(err).Str("pool_pub_key", poolPubKey.String()).Msg("fail to get pool address")
 		return ""
@@ -151,15 +187,13 @@ func (c *Client) GetAddress(poolPubKey common.PubKey) string {
 // GetAccount returns account with balance for an address
 func (c *Client) GetAccount(pkey common.PubKey) (common.Account, error) {
 	acct := common.Account{}
-	blockMetas, err := c.blockMetaAccessor.GetBlockMetas()
+	utxos, err := c.utxoAccessor.GetUTXOs(pkey)
 	if err != nil {
-		return acct, fmt.Errorf("fail to get block meta: %w", err)
+		return acct, fmt.Errorf("fail to get UTXOs: %w", err)
 	}
 	total := 0.0
-	for _, item := range blockMetas {
-		for _, utxo := range item.GetUTXOs(pkey) {
-			total += utxo.Value
-		}
+	for _, utxo := range utxos {
+		total += utxo.Value
 	}
 
 	totalAmt, err := btcutil.NewAmount(total)
@@ -169,7 +203,7 @@ func (c *Client) GetAccount(pkey common.PubKey) (common.Account, error) {
 	return common.NewAccount(0, 0, common.AccountCoins{
 		common.AccountCoin{
 			Amount: uint64(totalAmt),
-			Denom:  common.BTCAsset.String(),
+			Denom:  c.chain.GetGasAsset().String(),
 		},
 	}), nil
 }
@@ -182,7 +216,7 @@ func (c *Client) OnObservedTxIn(txIn types.TxInItem, blockHeight int64) {
 		c.logger.Error().Err(err).Str("txID", txIn.Tx).Msg("fail to add spendable utxo to storage")
 		return
 	}
-	value := float64(txIn.Coins.GetCoin(common.BTCAsset).Amount.Uint64()) / common.One
+	value := float64(txIn.Coins.GetCoin(c.chain.GetGasAsset()).Amount.Uint64()) / common.One
 	blockMeta, err := c.blockMetaAccessor.GetBlockMeta(blockHeight)
 	if nil != err {
 		c.logger.Err(err).Msgf("fail to get block meta on block height(%d)", blockHeight)
@@ -196,6 +230,10 @@ func (c *Client) OnObservedTxIn(txIn types.TxInItem, blockHeight int64) {
 	if err := c.blockMetaAccessor.SaveBlockMeta(blockHeight, blockMeta); err != nil {
 		c.logger.Err(err).Msgf("fail to save block meta to storage,block height(%d)", blockHeight)
 	}
+	if err := c.utxoAccessor.AddUTXO(utxo); err != nil {
+		c.logger.Err(err).Msgf("fail to add utxo(%s) to index", utxo.GetKey())
+	}
+	c.updateUTXOMetrics(txIn.ObservedVaultPubKey)
 }
 
 func (c *Client) processReorg(block *btcjson.GetBlockVerboseTxResult) error {
@@ -239,10 +277,13 @@ func (c *Client) reConfirmTx() error {
 			// this means the tx doesn't exist in chain ,thus should errata it
 			errataTxs = append(errataTxs, types.ErrataTx{
 				TxID:  common.TxID(txID),
-				Chain: common.BTCChain,
+				Chain: c.chain,
 			})
-			// remove the UTXO from block meta , so signer will not spend it
+			// remove the UTXO from block meta and index , so signer will not spend it
 			blockMeta.RemoveUTXO(utxo.GetKey())
+			if err := c.utxoAccessor.RemoveUTXO(utxo.VaultPubKey, utxo.GetKey()); err != nil {
+				c.logger.Err(err).Msgf("fail to remove utxo(%s) from index", utxo.GetKey())
+			}
 		}
 		if len(errataTxs) == 0 {
 			continue
@@ -312,6 +353,12 @@ func (c *Client) FetchTxs(height int64) (types.TxIn, error) {
 		blockMeta.PreviousHash = block.PreviousHash
 		blockMeta.BlockHash = block.Hash
 	}
+	feeRatePercentiles, err := c.getBlockFeeRatePercentiles(block.Height)
+	if err != nil {
+		c.logger.Err(err).Int64("height", block.Height).Msg("fail to get block fee rate percentiles")
+	} else {
+		blockMeta.FeeRatePercentiles = feeRatePercentiles
+	}
 
 	if err := c.blockMetaAccessor.SaveBlockMeta(block.Height, blockMeta); err != nil {
 		return types.TxIn{}, fmt.Errorf("fail to save block meta into storage: %w", err)
@@ -324,6 +371,13 @@ func (c *Client) FetchTxs(height int64) (types.TxIn, error) {
 			}
 		}()
 	}
+	if c.shouldReconcile(block.Height) {
+		c.reconcileVaults(block.Height)
+	}
+	// only report network fee once caught up, old blocks don't reflect the current fee market
+	if block.Confirmations <= 1 {
+		c.reportNetworkFee(block.Height)
+	}
 	txs, err := c.extractTxs(block)
 	if err != nil {
 		return types.TxIn{}, fmt.Errorf("fail to extract txs from block: %w", err)
@@ -369,9 +423,9 @@ func (c *Client) extractTxs(block *btcjson.GetBlockVerboseTxResult) (types.TxIn,
 		txItems = append(txItems, types.TxInItem{
 			Tx:     tx.Txid,
 			Sender: sender,
-			To:     output.ScriptPubKey.Addresses[0],
+			To:     c.utxoChain.NormalizeAddress(output.ScriptPubKey.Addresses[0]),
 			Coins: common.Coins{
-				common.NewCoin(common.BTCAsset, sdk.NewUint(amount)),
+				common.NewCoin(c.chain.GetGasAsset(), sdk.NewUint(amount)),
 			},
 			Memo: memo,
 			Gas:  gas,
@@ -429,7 +483,7 @@ func (c *Client) ignoreTx(tx *btcjson.TxRawResult) bool {
 // txs with max 2 outputs with values
 func (c *Client) getOutput(sender string, tx *btcjson.TxRawResult) btcjson.Vout {
 	for _, vout := range tx.Vout {
-		if vout.Value > 0 && vout.ScriptPubKey.Addresses[0] != sender {
+		if vout.Value > 0 && c.utxoChain.NormalizeAddress(vout.ScriptPubKey.Addresses[0]) != sender {
 			return vout
 		}
 	}
@@ -453,7 +507,7 @@ func (c *Client) getSender(tx *btcjson.TxRawResult) (string, error) {
 	if len(vout.ScriptPubKey.Addresses) == 0 {
 		return "", fmt.Errorf("no address available in vout")
 	}
-	return vout.ScriptPubKey.Addresses[0], nil
+	return c.utxoChain.NormalizeAddress(vout.ScriptPubKey.Addresses[0]), nil
 }
 
 // getMemo returns memo for a btc tx, using vout OP_RETURN
@@ -492,6 +546,6 @@ func (c *Client) getGas(tx *btcjson.TxRawResult) (common.Gas, error) {
 	}
 	totalGas := sumVin - sumVout
 	return common.Gas{
-		common.NewCoin(common.BTCAsset, sdk.NewUint(totalGas)),
+		common.NewCoin(c.chain.GetGasAsset(), sdk.NewUint(totalGas)),
 	}, nil
 }
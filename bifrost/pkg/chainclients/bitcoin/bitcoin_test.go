@@ -535,6 +535,7 @@ func (s *BitcoinSuite) TestGetAccount(c *C) {
 
 	blockMeta.AddUTXO(utxo)
 	c.Assert(s.client.blockMetaAccessor.SaveBlockMeta(blockMeta.Height, blockMeta), IsNil)
+	c.Assert(s.client.utxoAccessor.AddUTXO(utxo), IsNil)
 
 	h2, _ := chainhash.NewHashFromStr("819e927b0377feae269e5bcdca3b194eb4bae60d6b5c32004bd878326efd31e4")
 	utxo1 := UnspentTransactionOutput{
@@ -549,6 +550,7 @@ func (s *BitcoinSuite) TestGetAccount(c *C) {
 
 	blockMeta1.AddUTXO(utxo1)
 	c.Assert(s.client.blockMetaAccessor.SaveBlockMeta(blockMeta1.Height, blockMeta1), IsNil)
+	c.Assert(s.client.utxoAccessor.AddUTXO(utxo1), IsNil)
 
 	acct1, err := s.client.GetAccount("")
 	c.Assert(err, IsNil)
@@ -606,6 +608,11 @@ func (s *BitcoinSuite) TestOnObservedTxIn(c *C) {
 			},
 		},
 	}
+	indexed, err := s.client.utxoAccessor.GetUTXOs(pkey)
+	c.Assert(err, IsNil)
+	c.Assert(indexed, HasLen, 1)
+	c.Assert(indexed[0].TxID, Equals, *txID)
+
 	blockMeta = NewBlockMeta("000000001ab8a8484eb89f04b87d90eb88e2cbb2829e84eb36b966dcb28af90b", 2, "00000000ffa57c95f4f226f751114e9b24fdf8dbe2dbc02a860da9320bebd63e")
 	c.Assert(s.client.blockMetaAccessor.SaveBlockMeta(blockMeta.Height, blockMeta), IsNil)
 	txID, _ = chainhash.NewHashFromStr("24ed2d26fd5d4e0e8fa86633e40faf1bdfc8d1903b1cd02855286312d48818a2")
@@ -678,6 +685,7 @@ func (s *BitcoinSuite) TestProcessReOrg(c *C) {
 	utxo := NewUnspentTransactionOutput(*hash, 0, 1.5, previousHeight, ttypes.GetRandomPubKey())
 	blockMeta.AddUTXO(utxo)
 	c.Assert(s.client.blockMetaAccessor.SaveBlockMeta(previousHeight, blockMeta), IsNil)
+	c.Assert(s.client.utxoAccessor.AddUTXO(utxo), IsNil)
 	s.client.globalErrataQueue = make(chan types.ErrataBlock, 1)
 	c.Assert(s.client.processReorg(&result), IsNil)
 	// make sure there is errata block in the queue
@@ -687,4 +695,7 @@ func (s *BitcoinSuite) TestProcessReOrg(c *C) {
 	c.Assert(blockMeta, NotNil)
 	// make sure the UTXO had been removed , thus signer won't spend it
 	c.Assert(blockMeta.UnspentTransactionOutputs, HasLen, 0)
+	indexed, err := s.client.utxoAccessor.GetUTXOs(utxo.VaultPubKey)
+	c.Assert(err, IsNil)
+	c.Assert(indexed, HasLen, 0)
 }
@@ -12,6 +12,7 @@ type BlockMeta struct {
 	Height                    int64                      `json:"height"`
 	BlockHash                 string                     `json:"block_hash"`
 	UnspentTransactionOutputs []UnspentTransactionOutput `json:"utxos"`
+	FeeRatePercentiles        []int64                    `json:"fee_rate_percentiles,omitempty"` // fee rate(sats/vbyte) at 10th, 25th, 50th, 75th and 90th percentile
 }
 
 // NewBlockMeta create a new instance of BlockMeta
@@ -0,0 +1,197 @@
+package bitcoin
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/btcsuite/btcutil"
+)
+
+const (
+	// CoinSelectionBranchAndBound search for a set of UTXOs that doesn't need a change output, fallback to largest first
+	CoinSelectionBranchAndBound = "branch_and_bound"
+	// CoinSelectionLargestFirst spend the largest UTXOs first, so the tx has as few inputs as possible
+	CoinSelectionLargestFirst = "largest_first"
+
+	// DefaultMaxInputs maximum number of UTXOs an outbound tx will spend, when it is not configured
+	DefaultMaxInputs = 50
+	// DefaultDustThreshold change output less than this(in sats) will be given to miners, when it is not configured
+	DefaultDustThreshold = 546
+	// DefaultConsolidateFeeRate fee rate(sats/vbyte) at or below which signer consolidate UTXOs, when it is not configured
+	DefaultConsolidateFeeRate = 5
+	// MinRelayFeeRate the minimum fee rate(sats/vbyte) bitcoin nodes require to relay a tx
+	MinRelayFeeRate = 1
+	// EstimatedInputVSize the size(in vbytes) a signed P2WPKH input adds to a tx
+	EstimatedInputVSize = 68
+
+	// bnbMaxTries the maximum number of branches branch and bound will explore before give up
+	bnbMaxTries = 100000
+)
+
+// ErrInsufficientUTXO indicate the UTXOs can't cover the target within the max inputs limit
+var ErrInsufficientUTXO = errors.New("insufficient UTXOs to cover the target")
+
+// CoinSelectionParams parameters used to select the UTXOs to spend
+type CoinSelectionParams struct {
+	Target        btcutil.Amount // total amount need to be spent, includes the gas
+	DustThreshold btcutil.Amount // excess less than this doesn't need a change output
+	MaxInputs     int
+}
+
+// CoinSelector select the UTXOs an outbound tx should spend
+type CoinSelector interface {
+	Select(utxos []UnspentTransactionOutput, params CoinSelectionParams) ([]UnspentTransactionOutput, error)
+}
+
+// NewCoinSelector create the CoinSelector of the given strategy name, empty name means branch and bound
+func NewCoinSelector(name string) (CoinSelector, error) {
+	switch name {
+	case "", CoinSelectionBranchAndBound:
+		return BranchAndBoundSelector{}, nil
+	case CoinSelectionLargestFirst:
+		return LargestFirstSelector{}, nil
+	default:
+		return nil, fmt.Errorf("unknown coin selection strategy: %s", name)
+	}
+}
+
+type candidate struct {
+	utxo   UnspentTransactionOutput
+	amount btcutil.Amount
+}
+
+// sortedCandidates sort the UTXOs from the largest to the smallest, older UTXO goes first when the value are the same
+func sortedCandidates(utxos []UnspentTransactionOutput) ([]candidate, error) {
+	candidates := make([]candidate, 0, len(utxos))
+	for _, u := range utxos {
+		amt, err := btcutil.NewAmount(u.Value)
+		if err != nil {
+			return nil, fmt.Errorf("fail to parse amount(%f): %w", u.Value, err)
+		}
+		candidates = append(candidates, candidate{utxo: u, amount: amt})
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		if candidates[i].amount == candidates[j].amount {
+			return candidates[i].utxo.BlockHeight < candidates[j].utxo.BlockHeight
+		}
+		return candidates[i].amount > candidates[j].amount
+	})
+	return candidates, nil
+}
+
+// LargestFirstSelector spend the largest UTXOs until the target is reached
+type LargestFirstSelector struct{}
+
+// Select implement CoinSelector
+func (LargestFirstSelector) Select(utxos []UnspentTransactionOutput, params CoinSelectionParams) ([]UnspentTransactionOutput, error) {
+	candidates, err := sortedCandidates(utxos)
+	if err != nil {
+		return nil, err
+	}
+	var selected []UnspentTransactionOutput
+	total := btcutil.Amount(0)
+	for _, item := range candidates {
+		if total >= params.Target {
+			break
+		}
+		if params.MaxInputs > 0 && len(selected) >= params.MaxInputs {
+			break
+		}
+		selected = append(selected, item.utxo)
+		total += item.amount
+	}
+	if total < params.Target {
+		return nil, ErrInsufficientUTXO
+	}
+	return selected, nil
+}
+
+// BranchAndBoundSelector search for a set of UTXOs add up to the target plus less than the dust threshold, so the tx doesn't need a change output
+// when no such set can be found , it fallback to largest first
+type BranchAndBoundSelector struct{}
+
+// Select implement CoinSelector
+func (BranchAndBoundSelector) Select(utxos []UnspentTransactionOutput, params CoinSelectionParams) ([]UnspentTransactionOutput, error) {
+	candidates, err := sortedCandidates(utxos)
+	if err != nil {
+		return nil, err
+	}
+	// remaining[i] is the sum of candidates[i:], used to cut branches that can't reach the target
+	remaining := make([]btcutil.Amount, len(candidates)+1)
+	for i := len(candidates) - 1; i >= 0; i-- {
+		remaining[i] = remaining[i+1] + candidates[i].amount
+	}
+	if remaining[0] < params.Target {
+		return nil, ErrInsufficientUTXO
+	}
+	upper := params.Target + params.DustThreshold
+	tries := 0
+	var picked []int
+	var search func(idx int, total btcutil.Amount) bool
+	search = func(idx int, total btcutil.Amount) bool {
+		tries++
+		if total >= params.Target {
+			return total <= upper
+		}
+		if tries > bnbMaxTries || idx >= len(candidates) || total+remaining[idx] < params.Target {
+			return false
+		}
+		if params.MaxInputs > 0 && len(picked) >= params.MaxInputs {
+			return false
+		}
+		// include the candidate first, then explore the branch without it
+		picked = append(picked, idx)
+		if search(idx+1, total+candidates[idx].amount) {
+			return true
+		}
+		picked = picked[:len(picked)-1]
+		return search(idx+1, total)
+	}
+	if !search(0, 0) {
+		return LargestFirstSelector{}.Select(utxos, params)
+	}
+	selected := make([]UnspentTransactionOutput, 0, len(picked))
+	for _, idx := range picked {
+		selected = append(selected, candidates[idx].utxo)
+	}
+	return selected, nil
+}
+
+// ConsolidationSelector select UTXOs with the Base selector, and then spend the smallest UTXOs left up to MaxInputs
+// it is used when fee is low, so the vault doesn't end up with lots of small UTXOs
+type ConsolidationSelector struct {
+	Base CoinSelector
+	// MaxInputs the number of inputs consolidation stops at, the max inputs of the params is used when it is 0 or higher
+	MaxInputs int
+}
+
+// Select implement CoinSelector
+func (s ConsolidationSelector) Select(utxos []UnspentTransactionOutput, params CoinSelectionParams) ([]UnspentTransactionOutput, error) {
+	selected, err := s.Base.Select(utxos, params)
+	if err != nil {
+		return nil, err
+	}
+	candidates, err := sortedCandidates(utxos)
+	if err != nil {
+		return nil, err
+	}
+	maxInputs := params.MaxInputs
+	if s.MaxInputs > 0 && (maxInputs <= 0 || s.MaxInputs < maxInputs) {
+		maxInputs = s.MaxInputs
+	}
+	spent := make(map[string]bool, len(selected))
+	for _, u := range selected {
+		spent[u.GetKey()] = true
+	}
+	for i := len(candidates) - 1; i >= 0; i-- {
+		if maxInputs > 0 && len(selected) >= maxInputs {
+			break
+		}
+		if spent[candidates[i].utxo.GetKey()] {
+			continue
+		}
+		selected = append(selected, candidates[i].utxo)
+	}
+	return selected, nil
+}
@@ -0,0 +1,125 @@
+package bitcoin
+
+import (
+	"github.com/btcsuite/btcutil"
+	. "gopkg.in/check.v1"
+)
+
+type CoinSelectionSuite struct{}
+
+var _ = Suite(&CoinSelectionSuite{})
+
+func getTestUTXOs(values ...float64) []UnspentTransactionOutput {
+	utxos := make([]UnspentTransactionOutput, 0, len(values))
+	for i, v := range values {
+		utxo := GetRandomUTXO(v)
+		utxo.BlockHeight = int64(i)
+		utxos = append(utxos, utxo)
+	}
+	return utxos
+}
+
+func sumUTXOs(utxos []UnspentTransactionOutput) btcutil.Amount {
+	total := btcutil.Amount(0)
+	for _, u := range utxos {
+		amt, _ := btcutil.NewAmount(u.Value)
+		total += amt
+	}
+	return total
+}
+
+func (s *CoinSelectionSuite) TestNewCoinSelector(c *C) {
+	selector, err := NewCoinSelector("")
+	c.Assert(err, IsNil)
+	c.Check(selector, FitsTypeOf, BranchAndBoundSelector{})
+	selector, err = NewCoinSelector(CoinSelectionLargestFirst)
+	c.Assert(err, IsNil)
+	c.Check(selector, FitsTypeOf, LargestFirstSelector{})
+	selector, err = NewCoinSelector("whatever")
+	c.Assert(err, NotNil)
+	c.Check(selector, IsNil)
+}
+
+func (s *CoinSelectionSuite) TestLargestFirst(c *C) {
+	utxos := getTestUTXOs(0.1, 0.5, 0.2, 0.5)
+	params := CoinSelectionParams{
+		Target:        btcutil.Amount(60000000),
+		DustThreshold: DefaultDustThreshold,
+		MaxInputs:     10,
+	}
+	selected, err := LargestFirstSelector{}.Select(utxos, params)
+	c.Assert(err, IsNil)
+	c.Assert(selected, HasLen, 2)
+	// older UTXO goes first when the value are the same
+	c.Check(selected[0].BlockHeight, Equals, int64(1))
+	c.Check(selected[1].BlockHeight, Equals, int64(3))
+
+	params.MaxInputs = 1
+	_, err = LargestFirstSelector{}.Select(utxos, params)
+	c.Check(err, Equals, ErrInsufficientUTXO)
+
+	params.MaxInputs = 10
+	params.Target = btcutil.Amount(200000000)
+	_, err = LargestFirstSelector{}.Select(utxos, params)
+	c.Check(err, Equals, ErrInsufficientUTXO)
+}
+
+func (s *CoinSelectionSuite) TestBranchAndBound(c *C) {
+	utxos := getTestUTXOs(0.5, 0.3, 0.25, 0.1)
+	params := CoinSelectionParams{
+		Target:        btcutil.Amount(35000000),
+		DustThreshold: DefaultDustThreshold,
+		MaxInputs:     10,
+	}
+	// 0.25 + 0.1 doesn't need change, while largest first would spend 0.5
+	selected, err := BranchAndBoundSelector{}.Select(utxos, params)
+	c.Assert(err, IsNil)
+	c.Assert(selected, HasLen, 2)
+	c.Check(sumUTXOs(selected), Equals, params.Target)
+
+	// no changeless solution, fallback to largest first
+	params.Target = btcutil.Amount(45000000)
+	selected, err = BranchAndBoundSelector{}.Select(utxos, params)
+	c.Assert(err, IsNil)
+	c.Assert(selected, HasLen, 1)
+	c.Check(selected[0].Value, Equals, 0.5)
+
+	// changeless solution need more inputs than allowed
+	params.Target = btcutil.Amount(65000000)
+	params.MaxInputs = 2
+	selected, err = BranchAndBoundSelector{}.Select(utxos, params)
+	c.Assert(err, IsNil)
+	c.Assert(selected, HasLen, 2)
+	c.Check(sumUTXOs(selected), Equals, btcutil.Amount(80000000))
+
+	params.Target = btcutil.Amount(120000000)
+	_, err = BranchAndBoundSelector{}.Select(utxos, params)
+	c.Check(err, Equals, ErrInsufficientUTXO)
+}
+
+func (s *CoinSelectionSuite) TestConsolidation(c *C) {
+	utxos := getTestUTXOs(1.0, 0.001, 0.002, 0.003, 0.004)
+	params := CoinSelectionParams{
+		Target:        btcutil.Amount(50000000),
+		DustThreshold: DefaultDustThreshold,
+		MaxInputs:     3,
+	}
+	selector := ConsolidationSelector{Base: LargestFirstSelector{}}
+	selected, err := selector.Select(utxos, params)
+	c.Assert(err, IsNil)
+	c.Assert(selected, HasLen, 3)
+	c.Check(selected[0].Value, Equals, 1.0)
+	c.Check(selected[1].Value, Equals, 0.001)
+	c.Check(selected[2].Value, Equals, 0.002)
+
+	// consolidation stops at its own limit, which is lower than the params one
+	selector.MaxInputs = 2
+	selected, err = selector.Select(utxos, params)
+	c.Assert(err, IsNil)
+	c.Assert(selected, HasLen, 2)
+	c.Check(selected[1].Value, Equals, 0.001)
+
+	params.Target = btcutil.Amount(200000000)
+	_, err = selector.Select(utxos, params)
+	c.Check(err, Equals, ErrInsufficientUTXO)
+}
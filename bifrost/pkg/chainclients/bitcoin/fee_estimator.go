@@ -0,0 +1,170 @@
+package bitcoin
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/btcsuite/btcd/btcjson"
+	"github.com/btcsuite/btcutil"
+)
+
+const (
+	// DefaultFeeTargetBlocks number of blocks outbound txs are expected to confirm within, when it is not configured
+	DefaultFeeTargetBlocks = 3
+	// DefaultTransactionSize the size(in vbytes) of a typical outbound tx, used to report network fee before any tx had been signed
+	DefaultTransactionSize = 250
+	// FeeSampleBlocks the number of recent blocks fee rate percentiles are sampled from
+	FeeSampleBlocks = 6
+
+	// maxBlockVSize the maximum virtual size of a block, used to work out how many blocks the mempool fill up
+	maxBlockVSize = 1000000
+)
+
+// feeRatePercentiles the percentiles getblockstats report in feerate_percentiles
+var feeRatePercentiles = []int64{10, 25, 50, 75, 90}
+
+// feeRatePercentileIndex map the confirmation target to an index of feeRatePercentiles, the sooner a tx need to be confirmed
+// the higher percentile it need to pay
+func feeRatePercentileIndex(targetBlocks int64) int {
+	switch {
+	case targetBlocks <= 1:
+		return 4
+	case targetBlocks <= 3:
+		return 3
+	case targetBlocks <= 6:
+		return 2
+	default:
+		return 1
+	}
+}
+
+// feeRateFromBlockMetas return the median fee rate(sats/vbyte) at the percentile of the given target, of the most recent blocks
+// it returns 0 when none of the block metas has fee rate percentiles
+func feeRateFromBlockMetas(blockMetas []*BlockMeta, targetBlocks int64) int64 {
+	idx := feeRatePercentileIndex(targetBlocks)
+	metas := make([]*BlockMeta, 0, len(blockMetas))
+	for _, item := range blockMetas {
+		if item != nil && len(item.FeeRatePercentiles) > idx {
+			metas = append(metas, item)
+		}
+	}
+	sort.SliceStable(metas, func(i, j int) bool {
+		return metas[i].Height > metas[j].Height
+	})
+	if len(metas) > FeeSampleBlocks {
+		metas = metas[:FeeSampleBlocks]
+	}
+	if len(metas) == 0 {
+		return 0
+	}
+	rates := make([]int64, len(metas))
+	for i, item := range metas {
+		rates[i] = item.FeeRatePercentiles[idx]
+	}
+	sort.Slice(rates, func(i, j int) bool {
+		return rates[i] < rates[j]
+	})
+	return rates[len(rates)/2]
+}
+
+// feeRateFromMempool return the fee rate(sats/vbyte) a tx need to pay to get in front of the mempool txs that can't be mined within
+// the target blocks, it returns 0 when the mempool can be cleared within the target
+func feeRateFromMempool(mempool map[string]btcjson.GetRawMempoolVerboseResult, targetBlocks int64) (int64, error) {
+	if targetBlocks < 1 {
+		targetBlocks = 1
+	}
+	type entry struct {
+		rate  int64
+		vSize int64
+	}
+	entries := make([]entry, 0, len(mempool))
+	for txID, item := range mempool {
+		vSize := int64(item.Vsize)
+		if vSize == 0 {
+			// chains without segwit only report size
+			vSize = int64(item.Size)
+		}
+		if vSize == 0 {
+			continue
+		}
+		fee, err := btcutil.NewAmount(item.Fee)
+		if err != nil {
+			return 0, fmt.Errorf("fail to parse fee of tx(%s): %w", txID, err)
+		}
+		entries = append(entries, entry{rate: int64(fee) / vSize, vSize: vSize})
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].rate > entries[j].rate
+	})
+	capacity := targetBlocks * maxBlockVSize
+	total := int64(0)
+	for _, item := range entries {
+		total += item.vSize
+		if total >= capacity {
+			return item.rate, nil
+		}
+	}
+	return 0, nil
+}
+
+func (c *Client) getFeeTargetBlocks() int64 {
+	if c.cfg.UTXO.FeeTargetBlocks > 0 {
+		return c.cfg.UTXO.FeeTargetBlocks
+	}
+	return DefaultFeeTargetBlocks
+}
+
+// getBlockFeeRatePercentiles get the fee rate percentiles of the block at the given height from the chain node
+func (c *Client) getBlockFeeRatePercentiles(height int64) ([]int64, error) {
+	stats, err := c.client.GetBlockStats(height, &[]string{"feerate_percentiles"})
+	if err != nil {
+		return nil, fmt.Errorf("fail to get block stats: %w", err)
+	}
+	return stats.FeeratePercentiles, nil
+}
+
+// EstimateFeeRate estimate the fee rate(sats/vbyte) a tx need to pay to be confirmed within the target blocks
+// it is the higher of the rate recent blocks paid at the percentile of the target, and the rate needed to get in front of the mempool
+// it returns 0 when neither of them is available
+func (c *Client) EstimateFeeRate(targetBlocks int64) int64 {
+	rate := int64(0)
+	blockMetas, err := c.blockMetaAccessor.GetBlockMetas()
+	if err != nil {
+		c.logger.Err(err).Msg("fail to get block metas")
+	} else {
+		rate = feeRateFromBlockMetas(blockMetas, targetBlocks)
+	}
+	mempool, err := c.client.GetRawMempoolVerbose()
+	if err != nil {
+		c.logger.Err(err).Msg("fail to get mempool")
+		return rate
+	}
+	mempoolRate, err := feeRateFromMempool(mempool, targetBlocks)
+	if err != nil {
+		c.logger.Err(err).Msg("fail to estimate fee rate from mempool")
+		return rate
+	}
+	if mempoolRate > rate {
+		rate = mempoolRate
+	}
+	return rate
+}
+
+// reportNetworkFee send the estimated fee rate to thorchain when it changed since last report, so thorchain can set the max gas of outbound txs
+func (c *Client) reportNetworkFee(height int64) {
+	rate := c.EstimateFeeRate(c.getFeeTargetBlocks())
+	if rate <= 0 || rate == c.lastFeeRate {
+		return
+	}
+	vSize := int64(DefaultTransactionSize)
+	if _, vBytes, err := c.blockMetaAccessor.GetTransactionFee(); err == nil && vBytes > 0 {
+		vSize = int64(vBytes)
+	}
+	txID, err := c.bridge.PostNetworkFee(height, c.chain, uint64(vSize), uint64(rate))
+	if err != nil {
+		c.logger.Err(err).Msg("fail to post network fee to thorchain")
+		return
+	}
+	c.lastFeeRate = rate
+	c.logger.Info().Str("txid", txID.String()).Int64("rate", rate).Int64("size", vSize).Msg("send network fee to thorchain")
+}
@@ -0,0 +1,59 @@
+package bitcoin
+
+import (
+	"github.com/btcsuite/btcd/btcjson"
+	. "gopkg.in/check.v1"
+)
+
+type FeeEstimatorSuite struct{}
+
+var _ = Suite(&FeeEstimatorSuite{})
+
+func (s *FeeEstimatorSuite) TestFeeRatePercentileIndex(c *C) {
+	c.Check(feeRatePercentiles[feeRatePercentileIndex(1)], Equals, int64(90))
+	c.Check(feeRatePercentiles[feeRatePercentileIndex(2)], Equals, int64(75))
+	c.Check(feeRatePercentiles[feeRatePercentileIndex(3)], Equals, int64(75))
+	c.Check(feeRatePercentiles[feeRatePercentileIndex(6)], Equals, int64(50))
+	c.Check(feeRatePercentiles[feeRatePercentileIndex(144)], Equals, int64(25))
+}
+
+func (s *FeeEstimatorSuite) TestFeeRateFromBlockMetas(c *C) {
+	c.Check(feeRateFromBlockMetas(nil, 1), Equals, int64(0))
+	var blockMetas []*BlockMeta
+	for i := int64(1); i <= 10; i++ {
+		blockMeta := NewBlockMeta("", i, "")
+		blockMeta.FeeRatePercentiles = []int64{i, 2 * i, 3 * i, 4 * i, 5 * i}
+		blockMetas = append(blockMetas, blockMeta)
+	}
+	// block meta without percentiles is ignored
+	blockMetas = append(blockMetas, NewBlockMeta("", 11, ""))
+	// median of the most recent FeeSampleBlocks blocks, 5 - 10
+	c.Check(feeRateFromBlockMetas(blockMetas, 1), Equals, int64(40))
+	c.Check(feeRateFromBlockMetas(blockMetas, 3), Equals, int64(32))
+	c.Check(feeRateFromBlockMetas(blockMetas, 6), Equals, int64(24))
+	c.Check(feeRateFromBlockMetas(blockMetas, 10), Equals, int64(16))
+}
+
+func (s *FeeEstimatorSuite) TestFeeRateFromMempool(c *C) {
+	rate, err := feeRateFromMempool(nil, 1)
+	c.Assert(err, IsNil)
+	c.Check(rate, Equals, int64(0))
+
+	mempool := map[string]btcjson.GetRawMempoolVerboseResult{
+		"tx1": {Vsize: 500000, Fee: 0.5},   // 100 sats/vbyte
+		"tx2": {Vsize: 500000, Fee: 0.25},  // 50 sats/vbyte
+		"tx3": {Vsize: 500000, Fee: 0.1},   // 20 sats/vbyte
+		"tx4": {Size: 500000, Fee: 0.05},   // 10 sats/vbyte
+		"tx5": {Vsize: 0, Size: 0, Fee: 1}, // ignored
+	}
+	rate, err = feeRateFromMempool(mempool, 1)
+	c.Assert(err, IsNil)
+	c.Check(rate, Equals, int64(50))
+	rate, err = feeRateFromMempool(mempool, 2)
+	c.Assert(err, IsNil)
+	c.Check(rate, Equals, int64(10))
+	// mempool can be cleared within the target
+	rate, err = feeRateFromMempool(mempool, 3)
+	c.Assert(err, IsNil)
+	c.Check(rate, Equals, int64(0))
+}
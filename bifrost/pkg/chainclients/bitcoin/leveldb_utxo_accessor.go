@@ -0,0 +1,132 @@
+package bitcoin
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/util"
+
+	"gitlab.com/thorchain/thornode/common"
+)
+
+// PrefixUTXO declares prefix of the UTXO index in leveldb , UTXOs are grouped by vault pubkey
+const PrefixUTXO = `utxo-`
+
+// LevelDBUTXOAccessor struct
+type LevelDBUTXOAccessor struct {
+	db *leveldb.DB
+}
+
+// NewLevelDBUTXOAccessor creates a new level db backed UTXO accessor
+func NewLevelDBUTXOAccessor(db *leveldb.DB) (*LevelDBUTXOAccessor, error) {
+	return &LevelDBUTXOAccessor{db: db}, nil
+}
+
+func (t *LevelDBUTXOAccessor) getVaultPrefix(pubKey common.PubKey) string {
+	return fmt.Sprintf(PrefixUTXO+"%s-", pubKey.String())
+}
+
+func (t *LevelDBUTXOAccessor) getUTXOKey(pubKey common.PubKey, key string) string {
+	return t.getVaultPrefix(pubKey) + key
+}
+
+func (t *LevelDBUTXOAccessor) iterate(prefix string) ([]UnspentTransactionOutput, error) {
+	utxos := make([]UnspentTransactionOutput, 0)
+	iterator := t.db.NewIterator(util.BytesPrefix([]byte(prefix)), nil)
+	defer iterator.Release()
+	for iterator.Next() {
+		buf := iterator.Value()
+		if len(buf) == 0 {
+			continue
+		}
+		var utxo UnspentTransactionOutput
+		if err := json.Unmarshal(buf, &utxo); err != nil {
+			return nil, fmt.Errorf("fail to unmarshal utxo: %w", err)
+		}
+		utxos = append(utxos, utxo)
+	}
+	return utxos, iterator.Error()
+}
+
+// GetUTXOs returns all the unspent UTXOs of the given vault
+func (t *LevelDBUTXOAccessor) GetUTXOs(pubKey common.PubKey) ([]UnspentTransactionOutput, error) {
+	all, err := t.iterate(t.getVaultPrefix(pubKey))
+	if err != nil {
+		return nil, err
+	}
+	utxos := make([]UnspentTransactionOutput, 0, len(all))
+	for _, item := range all {
+		if !item.Spent {
+			utxos = append(utxos, item)
+		}
+	}
+	return utxos, nil
+}
+
+// GetAllUTXOs returns all the UTXOs in the index of all vaults, including those had been spent by a pending tx
+func (t *LevelDBUTXOAccessor) GetAllUTXOs() ([]UnspentTransactionOutput, error) {
+	return t.iterate(PrefixUTXO)
+}
+
+func (t *LevelDBUTXOAccessor) getUTXO(pubKey common.PubKey, key string) (*UnspentTransactionOutput, error) {
+	buf, err := t.db.Get([]byte(t.getUTXOKey(pubKey, key)), nil)
+	if err != nil {
+		if err == leveldb.ErrNotFound {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("fail to get utxo(%s) from storage: %w", key, err)
+	}
+	var utxo UnspentTransactionOutput
+	if err := json.Unmarshal(buf, &utxo); err != nil {
+		return nil, fmt.Errorf("fail to unmarshal utxo from json: %w", err)
+	}
+	return &utxo, nil
+}
+
+func (t *LevelDBUTXOAccessor) saveUTXO(utxo UnspentTransactionOutput) error {
+	buf, err := json.Marshal(utxo)
+	if err != nil {
+		return fmt.Errorf("fail to marshal utxo to json: %w", err)
+	}
+	return t.db.Put([]byte(t.getUTXOKey(utxo.VaultPubKey, utxo.GetKey())), buf, nil)
+}
+
+// AddUTXO add the given UTXO to the index, it will not override the one already in the index
+func (t *LevelDBUTXOAccessor) AddUTXO(utxo UnspentTransactionOutput) error {
+	existing, err := t.getUTXO(utxo.VaultPubKey, utxo.GetKey())
+	if err != nil {
+		return err
+	}
+	if existing != nil {
+		return nil
+	}
+	return t.saveUTXO(utxo)
+}
+
+func (t *LevelDBUTXOAccessor) setSpent(pubKey common.PubKey, key string, spent bool) error {
+	utxo, err := t.getUTXO(pubKey, key)
+	if err != nil {
+		return err
+	}
+	if utxo == nil {
+		return nil
+	}
+	utxo.Spent = spent
+	return t.saveUTXO(*utxo)
+}
+
+// SpendUTXO mark the UTXO as spent, it stays in the index until it is removed, so it can be unspent when the tx fail
+func (t *LevelDBUTXOAccessor) SpendUTXO(pubKey common.PubKey, key string) error {
+	return t.setSpent(pubKey, key, true)
+}
+
+// UnspendUTXO mark the UTXO as unspent
+func (t *LevelDBUTXOAccessor) UnspendUTXO(pubKey common.PubKey, key string) error {
+	return t.setSpent(pubKey, key, false)
+}
+
+// RemoveUTXO remove the UTXO from the index
+func (t *LevelDBUTXOAccessor) RemoveUTXO(pubKey common.PubKey, key string) error {
+	return t.db.Delete([]byte(t.getUTXOKey(pubKey, key)), nil)
+}
@@ -0,0 +1,78 @@
+package bitcoin
+
+import (
+	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/storage"
+	. "gopkg.in/check.v1"
+
+	"gitlab.com/thorchain/thornode/x/thorchain"
+)
+
+type BitcoinUTXOAccessorTestSuite struct{}
+
+var _ = Suite(
+	&BitcoinUTXOAccessorTestSuite{},
+)
+
+func (s *BitcoinUTXOAccessorTestSuite) TestUTXOAccessor(c *C) {
+	memStorage := storage.NewMemStorage()
+	db, err := leveldb.Open(memStorage, nil)
+	c.Assert(err, IsNil)
+	accessor, err := NewLevelDBUTXOAccessor(db)
+	c.Assert(err, IsNil)
+	c.Assert(accessor, NotNil)
+
+	vault1 := thorchain.GetRandomPubKey()
+	vault2 := thorchain.GetRandomPubKey()
+	utxos, err := accessor.GetUTXOs(vault1)
+	c.Assert(err, IsNil)
+	c.Assert(utxos, HasLen, 0)
+
+	utxo1 := GetRandomUTXO(1.0)
+	utxo1.VaultPubKey = vault1
+	utxo2 := GetRandomUTXO(2.0)
+	utxo2.VaultPubKey = vault1
+	utxo3 := GetRandomUTXO(3.0)
+	utxo3.VaultPubKey = vault2
+	for _, u := range []UnspentTransactionOutput{utxo1, utxo2, utxo3} {
+		c.Assert(accessor.AddUTXO(u), IsNil)
+	}
+	utxos, err = accessor.GetUTXOs(vault1)
+	c.Assert(err, IsNil)
+	c.Assert(utxos, HasLen, 2)
+	utxos, err = accessor.GetAllUTXOs()
+	c.Assert(err, IsNil)
+	c.Assert(utxos, HasLen, 3)
+
+	// spent UTXO is not spendable, but still in the index
+	c.Assert(accessor.SpendUTXO(vault1, utxo1.GetKey()), IsNil)
+	utxos, err = accessor.GetUTXOs(vault1)
+	c.Assert(err, IsNil)
+	c.Assert(utxos, HasLen, 1)
+	c.Assert(utxos[0].GetKey(), Equals, utxo2.GetKey())
+	// adding it again doesn't override the spent flag
+	c.Assert(accessor.AddUTXO(utxo1), IsNil)
+	utxos, err = accessor.GetUTXOs(vault1)
+	c.Assert(err, IsNil)
+	c.Assert(utxos, HasLen, 1)
+	utxos, err = accessor.GetAllUTXOs()
+	c.Assert(err, IsNil)
+	c.Assert(utxos, HasLen, 3)
+
+	c.Assert(accessor.UnspendUTXO(vault1, utxo1.GetKey()), IsNil)
+	utxos, err = accessor.GetUTXOs(vault1)
+	c.Assert(err, IsNil)
+	c.Assert(utxos, HasLen, 2)
+
+	// UTXO of another vault is not touched
+	c.Assert(accessor.SpendUTXO(vault1, utxo3.GetKey()), IsNil)
+	c.Assert(accessor.RemoveUTXO(vault1, utxo3.GetKey()), IsNil)
+	utxos, err = accessor.GetUTXOs(vault2)
+	c.Assert(err, IsNil)
+	c.Assert(utxos, HasLen, 1)
+
+	c.Assert(accessor.RemoveUTXO(vault2, utxo3.GetKey()), IsNil)
+	utxos, err = accessor.GetUTXOs(vault2)
+	c.Assert(err, IsNil)
+	c.Assert(utxos, HasLen, 0)
+}
@@ -4,12 +4,10 @@ import (
 	"bytes"
 	"errors"
 	"fmt"
-	"sort"
 
 	"github.com/btcsuite/btcd/btcec"
 	"github.com/btcsuite/btcd/btcjson"
 	"github.com/btcsuite/btcd/chaincfg"
-	"github.com/btcsuite/btcd/chaincfg/chainhash"
 	"github.com/btcsuite/btcd/mempool"
 	"github.com/btcsuite/btcd/wire"
 	"github.com/btcsuite/btcutil"
@@ -19,6 +17,7 @@ import (
 	"github.com/tendermint/tendermint/crypto/secp256k1"
 	"gitlab.com/thorchain/txscript"
 
+	"gitlab.com/thorchain/thornode/bifrost/metrics"
 	stypes "gitlab.com/thorchain/thornode/bifrost/thorclient/types"
 	"gitlab.com/thorchain/thornode/bifrost/tss"
 	"gitlab.com/thorchain/thornode/common"
@@ -41,29 +40,27 @@ func getBTCPrivateKey(key crypto.PrivKey) (*btcec.PrivateKey, error) {
 }
 
 func (c *Client) getChainCfg() *chaincfg.Params {
-	cn := common.GetCurrentChainNetwork()
-	switch cn {
-	case common.MockNet:
-		return &chaincfg.RegressionNetParams
-	case common.TestNet:
-		return &chaincfg.TestNet3Params
-	case common.MainNet:
-		return &chaincfg.MainNetParams
-	}
-	return nil
+	return c.chain.GetNetParams(common.GetCurrentChainNetwork())
 }
 
+// getGasCoin return the fee the outbound tx pays, it is the max gas thorchain set on the tx, so every signer of the vault build the same tx
 func (c *Client) getGasCoin(tx stypes.TxOutItem, vSize int64) common.Coin {
 	if !tx.MaxGas.IsEmpty() {
-		return tx.MaxGas.ToCoins().GetCoin(common.BTCAsset)
+		return tx.MaxGas.ToCoins().GetCoin(c.chain.GetGasAsset())
 	}
+	return common.NewCoin(c.chain.GetGasAsset(), sdk.NewUint(uint64(c.getFeeRate()*vSize)))
+}
+
+// getFeeRate return the fee rate(sats/vbyte) of the last outbound tx signed, it fallback to SatsPervBytes when it is not available
+// the local fee estimate is not used, as it depends on the mempool of the node, it is only reported to thorchain as network fee
+func (c *Client) getFeeRate() int64 {
 	gasRate := int64(SatsPervBytes)
 	fee, vBytes, err := c.blockMetaAccessor.GetTransactionFee()
 	if err != nil {
 		c.logger.Error().Err(err).Msg("fail to get previous transaction fee from local storage")
-		return common.NewCoin(common.BTCAsset, sdk.NewUint(uint64(vSize*gasRate)))
+		return gasRate
 	}
-	if fee != 0.0 && vSize != 0 {
+	if fee != 0.0 && vBytes != 0 {
 		amt, err := btcutil.NewAmount(fee)
 		if err != nil {
 			c.logger.Err(err).Msg("fail to convert amount from float64 to int64")
@@ -71,7 +68,7 @@ func (c *Client) getGasCoin(tx stypes.TxOutItem, vSize int64) common.Coin {
 			gasRate = int64(amt) / int64(vBytes) // sats per vbyte
 		}
 	}
-	return common.NewCoin(common.BTCAsset, sdk.NewUint(uint64(gasRate*vSize)))
+	return gasRate
 }
 
 // isYggdrasil - when the pubkey and node pubkey is the same that means it is signing from yggdrasil
@@ -89,46 +86,99 @@ func (c *Client) isYggdrasil(key common.PubKey) bool {
 	return key.Equals(c.nodePubKey)
 }
 
-// getAllUtxos go through all the block meta in the local storage, it will spend all UTXOs in  block that might be evicted from local storage soon
-// it also try to spend enough UTXOs that can add up to more than the given total
-func (c *Client) getAllUtxos(height int64, pubKey common.PubKey, total float64) ([]UnspentTransactionOutput, error) {
-	utxoes := make([]UnspentTransactionOutput, 0)
+// getMaxGasFeeRate return the fee rate(sats/vbyte) the max gas of the outbound pays for a tx of DefaultTransactionSize, it returns 0
+// when the max gas is not set. Max gas is set by thorchain, so unlike the local fee estimate, every signer of the vault sees the same rate
+func (c *Client) getMaxGasFeeRate(tx stypes.TxOutItem) int64 {
+	if tx.MaxGas.IsEmpty() {
+		return 0
+	}
+	gas := tx.MaxGas.ToCoins().GetCoin(c.chain.GetGasAsset())
+	return int64(gas.Amount.Uint64()) / DefaultTransactionSize
+}
+
+// getConsolidateMaxInputs return the number of inputs a consolidation tx can have while the max gas still pays MinRelayFeeRate for it
+// max gas pays the given fee rate for a tx of DefaultTransactionSize, which has one input, every extra input adds EstimatedInputVSize
+func getConsolidateMaxInputs(feeRate int64) int {
+	maxVSize := feeRate * DefaultTransactionSize / MinRelayFeeRate
+	return 1 + int((maxVSize-DefaultTransactionSize)/EstimatedInputVSize)
+}
+
+// getUtxosToSpend go through all the UTXOs of the vault in the index, and select the UTXOs that can add up to more than the given total
+// the index keeps UTXOs until they are spent, so old UTXOs go through coin selection like any other one. When the given fee rate is low,
+// extra small UTXOs are spent to consolidate them. The fee rate must be one every signer of the vault agrees on, as it changes the inputs of the tx
+func (c *Client) getUtxosToSpend(height int64, pubKey common.PubKey, total float64, feeRate int64) ([]UnspentTransactionOutput, error) {
 	stopHeight := height
 	if !c.isYggdrasil(pubKey) {
 		stopHeight = height - MinUTXOConfirmation
 	}
-
-	// as bifrost only keep the last BlockCacheSize(100) blocks , so it will need to consume all the utxos that is older than that.
-	consumeAllHeight := height - BlockCacheSize + 1
-	blockMetas, err := c.blockMetaAccessor.GetBlockMetas()
+	utxos, err := c.utxoAccessor.GetUTXOs(pubKey)
 	if err != nil {
-		return nil, fmt.Errorf("fail to get block metas: %w", err)
+		return nil, fmt.Errorf("fail to get UTXOs: %w", err)
 	}
-	target := 0.0
-	sort.SliceStable(blockMetas, func(i, j int) bool {
-		return blockMetas[i].Height < blockMetas[j].Height
-	})
-	for _, b := range blockMetas {
+	var utxoes []UnspentTransactionOutput
+	for _, u := range utxos {
 		// not enough confirmations, skip it
-		if b.Height > stopHeight {
+		if u.BlockHeight > stopHeight {
 			continue
 		}
-
-		// blocks that might be evicted from storage , so spent it all
-		if b.Height <= consumeAllHeight || target < total {
-			blockUtxoes := b.GetUTXOs(pubKey)
-			for _, u := range blockUtxoes {
-				target += u.Value
-			}
-			utxoes = append(utxoes, blockUtxoes...)
-			continue
+		utxoes = append(utxoes, u)
+	}
+	target, err := btcutil.NewAmount(total)
+	if err != nil {
+		return nil, fmt.Errorf("fail to parse total amount(%f): %w", total, err)
+	}
+	params := CoinSelectionParams{
+		Target:        target,
+		DustThreshold: c.getDustThreshold(),
+		MaxInputs:     c.getMaxInputs(),
+	}
+	selector := c.coinSelector
+	if feeRate > 0 && feeRate <= c.getConsolidateFeeRate() {
+		c.logger.Info().Int64("fee_rate", feeRate).Msg("fee rate is low, consolidate UTXOs")
+		selector = ConsolidationSelector{
+			Base:      selector,
+			MaxInputs: getConsolidateMaxInputs(feeRate),
 		}
+	}
+	return selector.Select(utxoes, params)
+}
 
-		if target > total {
-			return utxoes, nil
-		}
+func (c *Client) getMaxInputs() int {
+	if c.cfg.UTXO.MaxInputs > 0 {
+		return c.cfg.UTXO.MaxInputs
+	}
+	return DefaultMaxInputs
+}
+
+func (c *Client) getDustThreshold() btcutil.Amount {
+	if c.cfg.UTXO.DustThreshold > 0 {
+		return btcutil.Amount(c.cfg.UTXO.DustThreshold)
+	}
+	return DefaultDustThreshold
+}
+
+func (c *Client) getConsolidateFeeRate() int64 {
+	if c.cfg.UTXO.ConsolidateFeeRate > 0 {
+		return c.cfg.UTXO.ConsolidateFeeRate
+	}
+	return DefaultConsolidateFeeRate
+}
+
+// updateUTXOMetrics report the number of unspent UTXOs the given vault has
+func (c *Client) updateUTXOMetrics(pubKey common.PubKey) {
+	if c.m == nil {
+		return
+	}
+	gauge := c.m.GetGaugeVec(metrics.UTXOCount(c.chain))
+	if gauge == nil {
+		return
+	}
+	utxos, err := c.utxoAccessor.GetUTXOs(pubKey)
+	if err != nil {
+		c.logger.Err(err).Msg("fail to get UTXOs")
+		return
 	}
-	return utxoes, nil
+	gauge.WithLabelValues(pubKey.String()).Set(float64(len(utxos)))
 }
 
 func (c *Client) getBlockHeight() (int64, error) {
@@ -145,10 +195,10 @@ func (c *Client) getBlockHeight() (int64, error) {
 }
 
 func (c *Client) getBTCPaymentAmount(tx stypes.TxOutItem) float64 {
-	amtToPay := tx.Coins.GetCoin(common.BTCAsset).Amount.Uint64()
+	amtToPay := tx.Coins.GetCoin(c.chain.GetGasAsset()).Amount.Uint64()
 	amtToPayInBTC := btcutil.Amount(int64(amtToPay)).ToBTC()
 	if !tx.MaxGas.IsEmpty() {
-		gasAmt := tx.MaxGas.ToCoins().GetCoin(common.BTCAsset).Amount
+		gasAmt := tx.MaxGas.ToCoins().GetCoin(c.chain.GetGasAsset()).Amount
 		amtToPayInBTC += btcutil.Amount(int64(gasAmt.Uint64())).ToBTC()
 	}
 	return amtToPayInBTC
@@ -156,12 +206,12 @@ func (c *Client) getBTCPaymentAmount(tx stypes.TxOutItem) float64 {
 
 // getSourceScript retrieve pay to addr script from tx source
 func (c *Client) getSourceScript(tx stypes.TxOutItem) ([]byte, error) {
-	sourceAddr, err := tx.VaultPubKey.GetAddress(common.BTCChain)
+	sourceAddr, err := tx.VaultPubKey.GetAddress(c.chain)
 	if err != nil {
 		return nil, fmt.Errorf("fail to get source address: %w", err)
 	}
 
-	addr, err := btcutil.DecodeAddress(sourceAddr.String(), c.getChainCfg())
+	addr, err := c.utxoChain.DecodeAddress(sourceAddr.String(), c.getChainCfg())
 	if err != nil {
 		return nil, fmt.Errorf("fail to decode source address(%s): %w", sourceAddr.String(), err)
 	}
@@ -170,8 +220,8 @@ func (c *Client) getSourceScript(tx stypes.TxOutItem) ([]byte, error) {
 
 // SignTx is going to generate the outbound transaction, and also sign it
 func (c *Client) SignTx(tx stypes.TxOutItem, thorchainHeight int64) ([]byte, error) {
-	if !tx.Chain.Equals(common.BTCChain) {
-		return nil, errors.New("not BTC chain")
+	if !tx.Chain.Equals(c.chain) {
+		return nil, fmt.Errorf("not %s chain", c.chain)
 	}
 	sourceScript, err := c.getSourceScript(tx)
 	if err != nil {
@@ -181,13 +231,13 @@ func (c *Client) SignTx(tx stypes.TxOutItem, thorchainHeight int64) ([]byte, err
 	if err != nil {
 		return nil, fmt.Errorf("fail to get chain block height: %w", err)
 	}
-	txes, err := c.getAllUtxos(chainBlockHeight, tx.VaultPubKey, c.getBTCPaymentAmount(tx))
+	txes, err := c.getUtxosToSpend(chainBlockHeight, tx.VaultPubKey, c.getBTCPaymentAmount(tx), c.getMaxGasFeeRate(tx))
 	if err != nil {
-		return nil, fmt.Errorf("fail to get unspent UTXO")
+		return nil, fmt.Errorf("fail to get unspent UTXO: %w", err)
 	}
 	redeemTx := wire.NewMsgTx(wire.TxVersion)
 	totalAmt := float64(0)
-	individualAmounts := make(map[chainhash.Hash]btcutil.Amount, len(txes))
+	individualAmounts := make(map[string]btcutil.Amount, len(txes))
 	for _, item := range txes {
 		// double check that the utxo is still valid
 		outputPoint := wire.NewOutPoint(&item.TxID, item.N)
@@ -198,10 +248,10 @@ func (c *Client) SignTx(tx stypes.TxOutItem, thorchainHeight int64) ([]byte, err
 		if err != nil {
 			return nil, fmt.Errorf("fail to parse amount(%f): %w", item.Value, err)
 		}
-		individualAmounts[item.TxID] = amt
+		individualAmounts[outputPoint.String()] = amt
 	}
 
-	outputAddr, err := btcutil.DecodeAddress(tx.ToAddress.String(), c.getChainCfg())
+	outputAddr, err := c.utxoChain.DecodeAddress(tx.ToAddress.String(), c.getChainCfg())
 	if err != nil {
 		return nil, fmt.Errorf("fail to decode next address: %w", err)
 	}
@@ -220,7 +270,7 @@ func (c *Client) SignTx(tx stypes.TxOutItem, thorchainHeight int64) ([]byte, err
 	if err := c.blockMetaAccessor.UpsertTransactionFee(gasAmt.ToBTC(), int32(vSize)); err != nil {
 		c.logger.Err(err).Msg("fail to save gas info to UTXO storage")
 	}
-	coinToCustomer := tx.Coins.GetCoin(common.BTCAsset)
+	coinToCustomer := tx.Coins.GetCoin(c.chain.GetGasAsset())
 
 	// pay to customer
 	redeemTxOut := wire.NewTxOut(int64(coinToCustomer.Amount.Uint64()), buf)
@@ -240,18 +290,17 @@ func (c *Client) SignTx(tx stypes.TxOutItem, thorchainHeight int64) ([]byte, err
 	if balance < 0 {
 		return nil, errors.New("not enough balance to pay customer")
 	}
-	if balance > 0 {
+	// change less than dust threshold is not worth to spend later, give it to miners
+	if balance >= int64(c.getDustThreshold()) {
 		redeemTx.AddTxOut(wire.NewTxOut(balance, sourceScript))
 	}
 	// sort inputs and outputs
 	txsort.InPlaceSort(redeemTx)
 
 	for idx, txIn := range redeemTx.TxIn {
-		sigHashes := txscript.NewTxSigHashes(redeemTx)
 		sig := c.ksWrapper.GetSignable(tx.VaultPubKey)
-		outputAmount := int64(individualAmounts[txIn.PreviousOutPoint.Hash])
-		witness, err := txscript.WitnessSignature(redeemTx, sigHashes, idx, outputAmount, sourceScript, txscript.SigHashAll, sig, true)
-		if err != nil {
+		outputAmount := int64(individualAmounts[txIn.PreviousOutPoint.String()])
+		if err := c.utxoChain.SignInput(redeemTx, idx, outputAmount, sourceScript, sig); err != nil {
 			var keysignError tss.KeysignError
 			if errors.As(err, &keysignError) {
 				if len(keysignError.Blame.BlameNodes) == 0 {
@@ -268,17 +317,7 @@ func (c *Client) SignTx(tx stypes.TxOutItem, thorchainHeight int64) ([]byte, err
 				c.logger.Info().Str("tx_id", txID.String()).Msgf("post keysign failure to thorchain")
 				return nil, fmt.Errorf("sent keysign failure to thorchain")
 			}
-			return nil, fmt.Errorf("fail to get witness: %w", err)
-		}
-
-		redeemTx.TxIn[idx].Witness = witness
-		flag := txscript.StandardVerifyFlags
-		engine, err := txscript.NewEngine(sourceScript, redeemTx, idx, flag, nil, nil, outputAmount)
-		if err != nil {
-			return nil, fmt.Errorf("fail to create engine: %w", err)
-		}
-		if err := engine.Execute(); err != nil {
-			return nil, fmt.Errorf("fail to execute the script: %w", err)
+			return nil, fmt.Errorf("fail to sign input: %w", err)
 		}
 	}
 
@@ -303,6 +342,9 @@ func (c *Client) updateBlockMeta(txOut stypes.TxOutItem, blockMeta *BlockMeta, t
 		value := btcutil.Amount(out.Value)
 		utxo := NewUnspentTransactionOutput(tx.TxHash(), uint32(n), value.ToBTC(), blockMeta.Height, txOut.VaultPubKey)
 		blockMeta.AddUTXO(utxo)
+		if err := c.utxoAccessor.AddUTXO(utxo); err != nil {
+			return fmt.Errorf("fail to add utxo to index: %w", err)
+		}
 		break
 	}
 
@@ -313,6 +355,9 @@ func (c *Client) updateBlockMeta(txOut stypes.TxOutItem, blockMeta *BlockMeta, t
 		if err != nil {
 			return fmt.Errorf("fail to mark spent utxo: %w", err)
 		}
+		if err := c.utxoAccessor.SpendUTXO(txOut.VaultPubKey, key); err != nil {
+			return fmt.Errorf("fail to mark spent utxo in index: %w", err)
+		}
 	}
 
 	err = c.blockMetaAccessor.SaveBlockMeta(blockMeta.Height, blockMeta)
@@ -335,6 +380,9 @@ func (c *Client) revertBlockMeta(txOut stypes.TxOutItem, blockMeta *BlockMeta, t
 		}
 		key := fmt.Sprintf("%s:%d", tx.TxHash().String(), n)
 		blockMeta.RemoveUTXO(key)
+		if err := c.utxoAccessor.RemoveUTXO(txOut.VaultPubKey, key); err != nil {
+			return fmt.Errorf("fail to remove utxo from index: %w", err)
+		}
 		break
 	}
 
@@ -345,6 +393,9 @@ func (c *Client) revertBlockMeta(txOut stypes.TxOutItem, blockMeta *BlockMeta, t
 		if err != nil {
 			return fmt.Errorf("fail to mark unspent utxo: %w", err)
 		}
+		if err := c.utxoAccessor.UnspendUTXO(txOut.VaultPubKey, key); err != nil {
+			return fmt.Errorf("fail to mark unspent utxo in index: %w", err)
+		}
 	}
 
 	err = c.blockMetaAccessor.SaveBlockMeta(blockMeta.Height, blockMeta)
@@ -388,7 +439,7 @@ func (c *Client) unspendUTXO(key string) error {
 	return nil
 }
 
-// BroadcastTx will broadcast the given payload to BTC chain
+// BroadcastTx will broadcast the given payload to the chain
 func (c *Client) BroadcastTx(txOut stypes.TxOutItem, payload []byte) error {
 	redeemTx := wire.NewMsgTx(wire.TxVersion)
 	buf := bytes.NewBuffer(payload)
@@ -422,9 +473,11 @@ func (c *Client) BroadcastTx(txOut stypes.TxOutItem, payload []byte) error {
 		if err2 != nil {
 			c.logger.Err(err2).Msg("fail to revert block meta")
 		}
+		c.updateUTXOMetrics(txOut.VaultPubKey)
 		return fmt.Errorf("fail to broadcast transaction to chain: %w", err)
 	}
+	c.updateUTXOMetrics(txOut.VaultPubKey)
 	// save tx id to block meta in case we need to errata later
-	c.logger.Info().Str("hash", txHash.String()).Msg("broadcast to BTC chain successfully")
+	c.logger.Info().Str("hash", txHash.String()).Msgf("broadcast to %s chain successfully", c.chain)
 	return nil
 }
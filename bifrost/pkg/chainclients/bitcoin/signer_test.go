@@ -224,6 +224,7 @@ func (s *BitcoinSignerSuite) TestSignTxHappyPathWithPrivateKey(c *C) {
 		"0000000000000068f0710c510e94bd29aa624745da43e32a1de887387306bfda")
 	blockMeta.AddUTXO(utxo)
 	c.Assert(s.client.blockMetaAccessor.SaveBlockMeta(blockMeta.Height, blockMeta), IsNil)
+	c.Assert(s.client.utxoAccessor.AddUTXO(utxo), IsNil)
 	priKeyBuf, err := hex.DecodeString("b404c5ec58116b5f0fe13464a92e46626fc5db130e418cbce98df86ffe9317c5")
 	c.Assert(err, IsNil)
 	pkey, _ := btcec.PrivKeyFromBytes(btcec.S256(), priKeyBuf)
@@ -269,6 +270,7 @@ func (s *BitcoinSignerSuite) TestSignTxWithTSS(c *C) {
 		"0000000000000068f0710c510e94bd29aa624745da43e32a1de887387306bfda")
 	blockMeta.AddUTXO(utxo)
 	c.Assert(s.client.blockMetaAccessor.SaveBlockMeta(blockMeta.Height, blockMeta), IsNil)
+	c.Assert(s.client.utxoAccessor.AddUTXO(utxo), IsNil)
 	buf, err := s.client.SignTx(txOutItem, 1)
 	c.Assert(err, IsNil)
 	c.Assert(buf, NotNil)
@@ -307,7 +309,43 @@ func (s *BitcoinSignerSuite) TestBroadcastTx(c *C) {
 	c.Assert(s.client.BroadcastTx(txOutItem, input1), IsNil)
 }
 
-func (s *BitcoinSignerSuite) TestGetAllUTXOs(c *C) {
+func (s *BitcoinSignerSuite) TestGetGasCoin(c *C) {
+	txOutItem := stypes.TxOutItem{
+		Chain: common.BTCChain,
+		MaxGas: common.Gas{
+			common.NewCoin(common.BTCAsset, sdk.NewUint(5000)),
+		},
+	}
+	c.Assert(s.client.getGasCoin(txOutItem, 200).Amount.Uint64(), Equals, uint64(5000))
+	// without max gas, fallback to the fee rate of the last outbound tx
+	txOutItem.MaxGas = common.Gas{}
+	c.Assert(s.client.getGasCoin(txOutItem, 200).Amount.Uint64(), Equals, uint64(SatsPervBytes*200))
+	c.Assert(s.client.blockMetaAccessor.UpsertTransactionFee(0.0001, 200), IsNil)
+	c.Assert(s.client.getGasCoin(txOutItem, 200).Amount.Uint64(), Equals, uint64(10000))
+}
+
+func (s *BitcoinSignerSuite) TestGetMaxGasFeeRate(c *C) {
+	txOutItem := stypes.TxOutItem{
+		Chain: common.BTCChain,
+	}
+	c.Assert(s.client.getMaxGasFeeRate(txOutItem), Equals, int64(0))
+	txOutItem.MaxGas = common.Gas{
+		common.NewCoin(common.BTCAsset, sdk.NewUint(DefaultTransactionSize*20)),
+	}
+	c.Assert(s.client.getMaxGasFeeRate(txOutItem), Equals, int64(20))
+}
+
+func (s *BitcoinSignerSuite) TestGetConsolidateMaxInputs(c *C) {
+	c.Check(getConsolidateMaxInputs(1), Equals, 1)
+	c.Check(getConsolidateMaxInputs(DefaultConsolidateFeeRate), Equals, 15)
+	// the max gas still pays the min relay fee rate for the largest consolidation tx
+	for _, feeRate := range []int64{1, 2, DefaultConsolidateFeeRate} {
+		vSize := DefaultTransactionSize + int64(getConsolidateMaxInputs(feeRate)-1)*EstimatedInputVSize
+		c.Check(feeRate*DefaultTransactionSize >= vSize*MinRelayFeeRate, Equals, true)
+	}
+}
+
+func (s *BitcoinSignerSuite) TestGetUtxosToSpend(c *C) {
 	vaultPubKey := thorchain.GetRandomPubKey()
 	for i := 0; i < 150; i++ {
 		previousHash := thorchain.GetRandomTxHash().String()
@@ -318,12 +356,20 @@ func (s *BitcoinSignerSuite) TestGetAllUTXOs(c *C) {
 		utxo.BlockHeight = int64(i)
 		blockMeta.AddUTXO(utxo)
 		c.Assert(s.client.blockMetaAccessor.SaveBlockMeta(blockMeta.Height, blockMeta), IsNil)
+		c.Assert(s.client.utxoAccessor.AddUTXO(utxo), IsNil)
 	}
-	utxoes, err := s.client.getAllUtxos(150, vaultPubKey, 10)
+	utxoes, err := s.client.getUtxosToSpend(100, vaultPubKey, 10, 0)
 	c.Assert(err, IsNil)
 
-	// include block height 0 ~ 51
-	c.Assert(utxoes, HasLen, 52)
+	// the oldest UTXOs add up to the exact amount, no change needed
+	c.Assert(utxoes, HasLen, 10)
+	for i, utxo := range utxoes {
+		c.Check(utxo.BlockHeight, Equals, int64(i))
+	}
+
+	// more than the UTXOs a tx can spend
+	_, err = s.client.getUtxosToSpend(100, vaultPubKey, DefaultMaxInputs+1, 0)
+	c.Assert(err, NotNil)
 
 	// mark them as spent
 	for _, utxo := range utxoes {
@@ -331,13 +377,31 @@ func (s *BitcoinSignerSuite) TestGetAllUTXOs(c *C) {
 		c.Assert(err, IsNil)
 		blockMeta.SpendUTXO(utxo.GetKey())
 		c.Assert(s.client.blockMetaAccessor.SaveBlockMeta(blockMeta.Height, blockMeta), IsNil)
+		c.Assert(s.client.utxoAccessor.SpendUTXO(vaultPubKey, utxo.GetKey()), IsNil)
 	}
+	utxoes, err = s.client.getUtxosToSpend(100, vaultPubKey, 10, 0)
+	c.Assert(err, IsNil)
+	c.Assert(utxoes, HasLen, 10)
+	c.Check(utxoes[0].BlockHeight, Equals, int64(10))
+
+	// UTXOs older than the block cache are kept in the index, they don't need to be spent all at once
+	utxoes, err = s.client.getUtxosToSpend(150, vaultPubKey, 10, 0)
+	c.Assert(err, IsNil)
+	c.Assert(utxoes, HasLen, 10)
+	for i, utxo := range utxoes {
+		c.Check(utxo.BlockHeight, Equals, int64(i+10))
+	}
+
+	// a low fee rate consolidate the UTXOs, up to what the max gas can pay for
+	utxoes, err = s.client.getUtxosToSpend(100, vaultPubKey, 10, DefaultConsolidateFeeRate)
+	c.Assert(err, IsNil)
+	c.Assert(utxoes, HasLen, getConsolidateMaxInputs(DefaultConsolidateFeeRate))
 
 	// check prune is not returning them when spent
 	c.Assert(s.client.blockMetaAccessor.PruneBlockMeta(150-BlockCacheSize), IsNil)
 	allmetas, err := s.client.blockMetaAccessor.GetBlockMetas()
 	c.Assert(err, IsNil)
-	c.Assert(allmetas, HasLen, 100)
+	c.Assert(allmetas, HasLen, 140)
 
 	// make sure block will not be Pruned when there are unspend UTXO in it
 	for i := 150; i < 200; i++ {
@@ -354,5 +418,5 @@ func (s *BitcoinSignerSuite) TestGetAllUTXOs(c *C) {
 	c.Assert(s.client.blockMetaAccessor.PruneBlockMeta(200-BlockCacheSize), IsNil)
 	allmetas, err = s.client.blockMetaAccessor.GetBlockMetas()
 	c.Assert(err, IsNil)
-	c.Assert(allmetas, HasLen, 148)
+	c.Assert(allmetas, HasLen, 190)
 }
@@ -0,0 +1,15 @@
+package bitcoin
+
+import (
+	"gitlab.com/thorchain/thornode/common"
+)
+
+// UTXOAccessor define methods need to access the UTXO index, unlike block meta, UTXOs in the index are kept until they are spent
+type UTXOAccessor interface {
+	GetUTXOs(pubKey common.PubKey) ([]UnspentTransactionOutput, error)
+	GetAllUTXOs() ([]UnspentTransactionOutput, error)
+	AddUTXO(utxo UnspentTransactionOutput) error
+	SpendUTXO(pubKey common.PubKey, key string) error
+	UnspendUTXO(pubKey common.PubKey, key string) error
+	RemoveUTXO(pubKey common.PubKey, key string) error
+}
@@ -0,0 +1,67 @@
+package bitcoin
+
+import (
+	"fmt"
+
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/btcsuite/btcutil"
+	"gitlab.com/thorchain/txscript"
+
+	"gitlab.com/thorchain/thornode/common"
+)
+
+// UTXOChain describe the behaviours that are different between Bitcoin and the chains forked from it
+// thus the same UTXO engine can be used to observe and sign txs for all of them
+type UTXOChain interface {
+	// GetChain return the chain this UTXOChain represent
+	GetChain() common.Chain
+	// DecodeAddress parse the given address, the address can be in either THORChain's or the chain RPC's format
+	DecodeAddress(addr string, params *chaincfg.Params) (btcutil.Address, error)
+	// NormalizeAddress convert the address returned by the chain's RPC to the format THORChain use
+	NormalizeAddress(addr string) string
+	// SignInput sign the input at idx of the given tx, which spend amount locked by sourceScript
+	SignInput(tx *wire.MsgTx, idx int, amount int64, sourceScript []byte, signable txscript.Signable) error
+}
+
+// BitcoinChain is the UTXOChain implementation of Bitcoin
+type BitcoinChain struct{}
+
+// GetChain return BTC chain
+func (BitcoinChain) GetChain() common.Chain {
+	return common.BTCChain
+}
+
+// DecodeAddress parse the given bitcoin address
+func (BitcoinChain) DecodeAddress(addr string, params *chaincfg.Params) (btcutil.Address, error) {
+	return btcutil.DecodeAddress(addr, params)
+}
+
+// NormalizeAddress bitcoind return addresses in the same format THORChain use
+func (BitcoinChain) NormalizeAddress(addr string) string {
+	return addr
+}
+
+// SignInput sign the given input as a segwit input
+func (BitcoinChain) SignInput(tx *wire.MsgTx, idx int, amount int64, sourceScript []byte, signable txscript.Signable) error {
+	return SignWitnessInput(tx, idx, amount, sourceScript, signable)
+}
+
+// SignWitnessInput sign the input at idx of the given tx with BIP143 sighash, the signature is set to the witness of the input
+// it is used by all the chains that support segwit
+func SignWitnessInput(tx *wire.MsgTx, idx int, amount int64, sourceScript []byte, signable txscript.Signable) error {
+	sigHashes := txscript.NewTxSigHashes(tx)
+	witness, err := txscript.WitnessSignature(tx, sigHashes, idx, amount, sourceScript, txscript.SigHashAll, signable, true)
+	if err != nil {
+		return err
+	}
+	tx.TxIn[idx].Witness = witness
+	engine, err := txscript.NewEngine(sourceScript, tx, idx, txscript.StandardVerifyFlags, nil, nil, amount)
+	if err != nil {
+		return fmt.Errorf("fail to create engine: %w", err)
+	}
+	if err := engine.Execute(); err != nil {
+		return fmt.Errorf("fail to execute the script: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,235 @@
+package bitcoin
+
+import (
+	"fmt"
+
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcutil"
+
+	"gitlab.com/thorchain/thornode/common"
+)
+
+// DefaultReconcileBlocks how often(in blocks) the UTXO index get reconciled against the chain node, when it is not configured
+const DefaultReconcileBlocks = 6
+
+func (c *Client) getReconcileBlocks() int64 {
+	if c.cfg.UTXO.ReconcileBlocks > 0 {
+		return c.cfg.UTXO.ReconcileBlocks
+	}
+	return DefaultReconcileBlocks
+}
+
+// shouldReconcile return true when the UTXO index need to be reconciled after processing the block of the given height
+// the heights are the same for every node, so the UTXOs signers pick inputs from only change at a common height
+func (c *Client) shouldReconcile(height int64) bool {
+	return height > 0 && height%c.getReconcileBlocks() == 0
+}
+
+// initUTXOIndex populate an empty UTXO index, from the UTXOs in block metas , and from a rescan when RescanStartHeight is configured
+func (c *Client) initUTXOIndex() error {
+	utxos, err := c.utxoAccessor.GetAllUTXOs()
+	if err != nil {
+		return fmt.Errorf("fail to get UTXOs from index: %w", err)
+	}
+	if len(utxos) > 0 {
+		return nil
+	}
+	blockMetas, err := c.blockMetaAccessor.GetBlockMetas()
+	if err != nil {
+		return fmt.Errorf("fail to get block metas: %w", err)
+	}
+	for _, blockMeta := range blockMetas {
+		for _, utxo := range blockMeta.UnspentTransactionOutputs {
+			if err := c.utxoAccessor.AddUTXO(utxo); err != nil {
+				return fmt.Errorf("fail to add UTXO(%s) to index: %w", utxo.GetKey(), err)
+			}
+		}
+	}
+	if c.cfg.UTXO.RescanStartHeight <= 0 {
+		return nil
+	}
+	height, err := c.getBlockHeight()
+	if err != nil {
+		return fmt.Errorf("fail to get chain block height: %w", err)
+	}
+	return c.RescanUTXOs(c.cfg.UTXO.RescanStartHeight, height)
+}
+
+// getVaultPubKeys return the pubkeys of all the vaults this client need to track, asgards, the yggdrasil of this node
+// and any vault still has UTXOs in the index
+func (c *Client) getVaultPubKeys() (common.PubKeys, error) {
+	var pubKeys common.PubKeys
+	seen := make(map[string]bool)
+	add := func(pk common.PubKey) {
+		if pk.IsEmpty() || seen[pk.String()] {
+			return
+		}
+		seen[pk.String()] = true
+		pubKeys = append(pubKeys, pk)
+	}
+	asgards, err := c.bridge.GetAsgards()
+	if err != nil {
+		return nil, fmt.Errorf("fail to get asgard vaults from thorchain: %w", err)
+	}
+	for _, item := range asgards {
+		add(item.PubKey)
+	}
+	add(c.nodePubKey)
+	utxos, err := c.utxoAccessor.GetAllUTXOs()
+	if err != nil {
+		return nil, fmt.Errorf("fail to get UTXOs from index: %w", err)
+	}
+	for _, item := range utxos {
+		add(item.VaultPubKey)
+	}
+	return pubKeys, nil
+}
+
+// RescanUTXOs rebuild the UTXO index from the blocks in the given height range, outputs paid to the vaults are added to the index
+// and the ones spent by a later tx in the range are removed
+func (c *Client) RescanUTXOs(startHeight, endHeight int64) error {
+	pubKeys, err := c.getVaultPubKeys()
+	if err != nil {
+		return fmt.Errorf("fail to get vault pubkeys: %w", err)
+	}
+	vaults := make(map[string]common.PubKey, len(pubKeys))
+	for _, pk := range pubKeys {
+		addr, err := pk.GetAddress(c.chain)
+		if err != nil {
+			c.logger.Err(err).Str("pubkey", pk.String()).Msg("fail to get vault address")
+			continue
+		}
+		vaults[c.utxoChain.NormalizeAddress(addr.String())] = pk
+	}
+	utxos, err := c.utxoAccessor.GetAllUTXOs()
+	if err != nil {
+		return fmt.Errorf("fail to get UTXOs from index: %w", err)
+	}
+	owners := make(map[string]common.PubKey, len(utxos))
+	for _, item := range utxos {
+		owners[item.GetKey()] = item.VaultPubKey
+	}
+	c.logger.Info().Int64("start", startHeight).Int64("end", endHeight).Msg("rescan blocks to rebuild UTXO index")
+	for height := startHeight; height <= endHeight; height++ {
+		block, err := c.getBlock(height)
+		if err != nil {
+			return fmt.Errorf("fail to get block(%d): %w", height, err)
+		}
+		for _, tx := range block.Tx {
+			for _, vin := range tx.Vin {
+				if vin.Txid == "" {
+					continue
+				}
+				key := fmt.Sprintf("%s:%d", vin.Txid, vin.Vout)
+				pk, ok := owners[key]
+				if !ok {
+					continue
+				}
+				if err := c.utxoAccessor.RemoveUTXO(pk, key); err != nil {
+					return fmt.Errorf("fail to remove spent UTXO(%s) from index: %w", key, err)
+				}
+				delete(owners, key)
+			}
+			hash, err := chainhash.NewHashFromStr(tx.Txid)
+			if err != nil {
+				return fmt.Errorf("fail to parse tx hash(%s): %w", tx.Txid, err)
+			}
+			for _, vout := range tx.Vout {
+				if len(vout.ScriptPubKey.Addresses) != 1 {
+					continue
+				}
+				pk, ok := vaults[c.utxoChain.NormalizeAddress(vout.ScriptPubKey.Addresses[0])]
+				if !ok {
+					continue
+				}
+				utxo := NewUnspentTransactionOutput(*hash, vout.N, vout.Value, height, pk)
+				if err := c.utxoAccessor.AddUTXO(utxo); err != nil {
+					return fmt.Errorf("fail to add UTXO(%s) to index: %w", utxo.GetKey(), err)
+				}
+				owners[utxo.GetKey()] = pk
+			}
+		}
+	}
+	return nil
+}
+
+// reconcileVaults reconcile the UTXO index of all the vaults as of the given block height
+func (c *Client) reconcileVaults(height int64) {
+	pubKeys, err := c.getVaultPubKeys()
+	if err != nil {
+		c.logger.Err(err).Msg("fail to get vault pubkeys")
+		return
+	}
+	for _, pk := range pubKeys {
+		if err := c.ReconcileUTXOs(pk, height); err != nil {
+			c.logger.Err(err).Str("pubkey", pk.String()).Msg("fail to reconcile UTXOs")
+		}
+		c.updateUTXOMetrics(pk)
+	}
+}
+
+// ReconcileUTXOs compare the UTXOs of the given vault in the index with the ones reported by the chain node, only UTXOs confirmed
+// at or below the given block height are considered, so the result doesn't depend on when the node reconcile
+// UTXOs the node doesn't know are removed from the index, and the missing ones are added
+// UTXOs spent by a tx still in mempool are not reported by the node either, they are removed as the tx will not be re-signed
+func (c *Client) ReconcileUTXOs(pubKey common.PubKey, height int64) error {
+	vaultAddr, err := pubKey.GetAddress(c.chain)
+	if err != nil {
+		return fmt.Errorf("fail to get vault address: %w", err)
+	}
+	addr, err := c.utxoChain.DecodeAddress(vaultAddr.String(), c.getChainCfg())
+	if err != nil {
+		return fmt.Errorf("fail to decode vault address(%s): %w", vaultAddr, err)
+	}
+	tip, err := c.getBlockHeight()
+	if err != nil {
+		return fmt.Errorf("fail to get chain block height: %w", err)
+	}
+	if height > tip {
+		return fmt.Errorf("block height(%d) is above chain tip(%d)", height, tip)
+	}
+	results, err := c.client.ListUnspentMinMaxAddresses(int(tip-height+1), 9999999, []btcutil.Address{addr})
+	if err != nil {
+		return fmt.Errorf("fail to list unspent of address(%s): %w", vaultAddr, err)
+	}
+	// the block heights of the UTXOs are worked out from the confirmations, they will be wrong if a block come in the meantime
+	latest, err := c.getBlockHeight()
+	if err != nil {
+		return fmt.Errorf("fail to get chain block height: %w", err)
+	}
+	if latest != tip {
+		return fmt.Errorf("chain tip moved from %d to %d during reconcile", tip, latest)
+	}
+	onChain := make(map[string]bool, len(results))
+	for _, item := range results {
+		hash, err := chainhash.NewHashFromStr(item.TxID)
+		if err != nil {
+			return fmt.Errorf("fail to parse tx hash(%s): %w", item.TxID, err)
+		}
+		utxo := NewUnspentTransactionOutput(*hash, item.Vout, item.Amount, tip-item.Confirmations+1, pubKey)
+		onChain[utxo.GetKey()] = true
+		if err := c.utxoAccessor.AddUTXO(utxo); err != nil {
+			return fmt.Errorf("fail to add UTXO(%s) to index: %w", utxo.GetKey(), err)
+		}
+	}
+	// node only report UTXOs of the addresses in its wallet, when it report nothing, it might not watch the vault address
+	// so don't wipe the index
+	if len(results) == 0 {
+		c.logger.Warn().Str("address", vaultAddr.String()).Msg("chain node report no UTXO, make sure the vault address had been imported")
+		return nil
+	}
+	utxos, err := c.utxoAccessor.GetAllUTXOs()
+	if err != nil {
+		return fmt.Errorf("fail to get UTXOs from index: %w", err)
+	}
+	for _, item := range utxos {
+		if !item.VaultPubKey.Equals(pubKey) || item.BlockHeight > height || onChain[item.GetKey()] {
+			continue
+		}
+		c.logger.Info().Str("utxo", item.GetKey()).Bool("spent", item.Spent).Msg("UTXO no longer exist on chain, remove it from index")
+		if err := c.utxoAccessor.RemoveUTXO(pubKey, item.GetKey()); err != nil {
+			return fmt.Errorf("fail to remove UTXO(%s) from index: %w", item.GetKey(), err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,199 @@
+package bitcoin
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	ctypes "github.com/binance-chain/go-sdk/common/types"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/btcsuite/btcutil"
+	"github.com/cosmos/cosmos-sdk/client/keys"
+	cKeys "github.com/cosmos/cosmos-sdk/crypto/keys"
+	. "gopkg.in/check.v1"
+
+	"gitlab.com/thorchain/thornode/bifrost/config"
+	"gitlab.com/thorchain/thornode/bifrost/pkg/mockchain"
+	"gitlab.com/thorchain/thornode/bifrost/thorclient"
+	"gitlab.com/thorchain/thornode/common"
+	ttypes "gitlab.com/thorchain/thornode/x/thorchain/types"
+)
+
+type UTXOIndexSuite struct {
+	node   *mockchain.Bitcoind
+	server *httptest.Server
+	client *Client
+}
+
+var _ = Suite(&UTXOIndexSuite{})
+
+func (s *UTXOIndexSuite) SetUpTest(c *C) {
+	ttypes.SetupConfigForTest()
+	ctypes.Network = ctypes.TestNetwork
+	c.Assert(os.Setenv("NET", "testnet"), IsNil)
+	var err error
+	s.node, err = mockchain.NewBitcoind(&chaincfg.TestNet3Params)
+	c.Assert(err, IsNil)
+	s.server = httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if req.RequestURI == thorclient.AsgardVault {
+			_, err := rw.Write([]byte("[]"))
+			c.Assert(err, IsNil)
+		}
+	}))
+
+	thordir := filepath.Join(os.TempDir(), strconv.Itoa(time.Now().Nanosecond()), ".thorcli")
+	cfg := config.ClientConfiguration{
+		ChainID:         "thorchain",
+		ChainHost:       s.server.Listener.Addr().String(),
+		SignerName:      "bob",
+		SignerPasswd:    "password",
+		ChainHomeFolder: thordir,
+	}
+	kb, err := keys.NewKeyBaseFromDir(thordir)
+	c.Assert(err, IsNil)
+	_, _, err = kb.CreateMnemonic(cfg.SignerName, cKeys.English, cfg.SignerPasswd, cKeys.Secp256k1)
+	c.Assert(err, IsNil)
+	thorKeys, err := thorclient.NewKeys(cfg.ChainHomeFolder, cfg.SignerName, cfg.SignerPasswd)
+	c.Assert(err, IsNil)
+	m := GetMetricForTest(c)
+	bridge, err := thorclient.NewThorchainBridge(cfg, m)
+	c.Assert(err, IsNil)
+	s.client, err = NewClient(thorKeys, config.ChainConfiguration{
+		ChainID:     "BTC",
+		RPCHost:     s.node.Host(),
+		UserName:    "bob",
+		Password:    "password",
+		DisableTLS:  true,
+		HTTPostMode: true,
+		BlockScanner: config.BlockScannerConfiguration{
+			StartBlockHeight: 1,
+		},
+	}, nil, bridge, m)
+	c.Assert(err, IsNil)
+}
+
+func (s *UTXOIndexSuite) TearDownTest(c *C) {
+	s.node.Close()
+	s.server.Close()
+}
+
+func (s *UTXOIndexSuite) vaultAddress(c *C) string {
+	addr, err := s.client.nodePubKey.GetAddress(common.BTCChain)
+	c.Assert(err, IsNil)
+	return addr.String()
+}
+
+// spend build a tx spending the given output to a random address, signature is not needed by the mock chain
+func (s *UTXOIndexSuite) spend(c *C, txID string, amount int64) string {
+	hash, err := chainhash.NewHashFromStr(txID)
+	c.Assert(err, IsNil)
+	addr, err := ttypes.GetRandomPubKey().GetAddress(common.BTCChain)
+	c.Assert(err, IsNil)
+	to, err := btcutil.DecodeAddress(addr.String(), &chaincfg.TestNet3Params)
+	c.Assert(err, IsNil)
+	script, err := txscript.PayToAddrScript(to)
+	c.Assert(err, IsNil)
+	tx := wire.NewMsgTx(wire.TxVersion)
+	tx.AddTxIn(wire.NewTxIn(wire.NewOutPoint(hash, 0), nil, nil))
+	tx.AddTxOut(wire.NewTxOut(amount, script))
+	spendTxID, err := s.node.AddTx(tx)
+	c.Assert(err, IsNil)
+	return spendTxID
+}
+
+func (s *UTXOIndexSuite) TestRescanUTXOs(c *C) {
+	vault := s.client.nodePubKey
+	fundTxID1, err := s.node.Fund(s.vaultAddress(c), 100000)
+	c.Assert(err, IsNil)
+	fundTxID2, err := s.node.Fund(s.vaultAddress(c), 200000)
+	c.Assert(err, IsNil)
+	c.Assert(s.node.Ledger().MineBlocks(1), IsNil)
+	s.spend(c, fundTxID1, 90000)
+	c.Assert(s.node.Ledger().MineBlocks(1), IsNil)
+
+	c.Assert(s.client.RescanUTXOs(0, 2), IsNil)
+	utxos, err := s.client.utxoAccessor.GetUTXOs(vault)
+	c.Assert(err, IsNil)
+	c.Assert(utxos, HasLen, 1)
+	c.Check(utxos[0].TxID.String(), Equals, fundTxID2)
+	c.Check(utxos[0].Value, Equals, 0.002)
+	c.Check(utxos[0].BlockHeight, Equals, int64(1))
+
+	// an empty index is populated on start
+	c.Assert(s.client.utxoAccessor.RemoveUTXO(vault, utxos[0].GetKey()), IsNil)
+	s.client.cfg.UTXO.RescanStartHeight = 1
+	c.Assert(s.client.initUTXOIndex(), IsNil)
+	utxos, err = s.client.utxoAccessor.GetUTXOs(vault)
+	c.Assert(err, IsNil)
+	c.Assert(utxos, HasLen, 1)
+}
+
+func (s *UTXOIndexSuite) TestReconcileUTXOs(c *C) {
+	vault := s.client.nodePubKey
+	// node doesn't know any UTXO of the vault , index is kept as is
+	stale := GetRandomUTXO(1.0)
+	stale.VaultPubKey = vault
+	stale.BlockHeight = 1
+	c.Assert(s.client.utxoAccessor.AddUTXO(stale), IsNil)
+	c.Assert(s.client.ReconcileUTXOs(vault, 0), IsNil)
+	utxos, err := s.client.utxoAccessor.GetUTXOs(vault)
+	c.Assert(err, IsNil)
+	c.Assert(utxos, HasLen, 1)
+
+	fundTxID1, err := s.node.Fund(s.vaultAddress(c), 100000)
+	c.Assert(err, IsNil)
+	c.Assert(s.node.Ledger().MineBlocks(1), IsNil)
+	fundTxID2, err := s.node.Fund(s.vaultAddress(c), 200000)
+	c.Assert(err, IsNil)
+	c.Assert(s.node.Ledger().MineBlocks(1), IsNil)
+	fundTxID3, err := s.node.Fund(s.vaultAddress(c), 300000)
+	c.Assert(err, IsNil)
+
+	// block height above the chain tip
+	c.Assert(s.client.ReconcileUTXOs(vault, 3), NotNil)
+
+	// only UTXOs confirmed at or below the given height are added, whatever the node know about later blocks
+	c.Assert(s.client.ReconcileUTXOs(vault, 1), IsNil)
+	utxos, err = s.client.utxoAccessor.GetUTXOs(vault)
+	c.Assert(err, IsNil)
+	c.Assert(utxos, HasLen, 1)
+	c.Check(utxos[0].TxID.String(), Equals, fundTxID1)
+	c.Check(utxos[0].BlockHeight, Equals, int64(1))
+
+	c.Assert(s.client.ReconcileUTXOs(vault, 2), IsNil)
+	utxos, err = s.client.utxoAccessor.GetUTXOs(vault)
+	c.Assert(err, IsNil)
+	c.Assert(utxos, HasLen, 2)
+	heights := make(map[string]int64)
+	for _, u := range utxos {
+		heights[u.TxID.String()] = u.BlockHeight
+	}
+	c.Check(heights[fundTxID1], Equals, int64(1))
+	c.Check(heights[fundTxID2], Equals, int64(2))
+	// unconfirmed UTXO is never added
+	_, ok := heights[fundTxID3]
+	c.Check(ok, Equals, false)
+
+	// UTXO spent outside of bifrost is removed
+	s.spend(c, fundTxID1, 90000)
+	c.Assert(s.client.ReconcileUTXOs(vault, 2), IsNil)
+	utxos, err = s.client.utxoAccessor.GetUTXOs(vault)
+	c.Assert(err, IsNil)
+	c.Assert(utxos, HasLen, 1)
+	c.Check(utxos[0].TxID.String(), Equals, fundTxID2)
+}
+
+func (s *UTXOIndexSuite) TestShouldReconcile(c *C) {
+	c.Assert(s.client.shouldReconcile(0), Equals, false)
+	c.Assert(s.client.shouldReconcile(DefaultReconcileBlocks-1), Equals, false)
+	c.Assert(s.client.shouldReconcile(DefaultReconcileBlocks), Equals, true)
+	s.client.cfg.UTXO.ReconcileBlocks = 10
+	c.Assert(s.client.shouldReconcile(DefaultReconcileBlocks), Equals, false)
+	c.Assert(s.client.shouldReconcile(20), Equals, true)
+}
@@ -0,0 +1,106 @@
+package bitcoincash
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/btcsuite/btcutil"
+	tssp "gitlab.com/thorchain/tss/go-tss/tss"
+	"gitlab.com/thorchain/txscript"
+
+	"gitlab.com/thorchain/thornode/bifrost/config"
+	"gitlab.com/thorchain/thornode/bifrost/metrics"
+	"gitlab.com/thorchain/thornode/bifrost/pkg/chainclients"
+	"gitlab.com/thorchain/thornode/bifrost/pkg/chainclients/bitcoin"
+	"gitlab.com/thorchain/thornode/bifrost/thorclient"
+	"gitlab.com/thorchain/thornode/common"
+)
+
+// SigHashForkID is the flag Bitcoin Cash use to prevent signatures being replayed on Bitcoin
+const SigHashForkID = txscript.SigHashType(0x40)
+
+// BitcoinCashChain is the bitcoin.UTXOChain implementation of Bitcoin Cash
+type BitcoinCashChain struct{}
+
+func init() {
+	chainclients.Register(common.BCHChain, chainclients.Factory{
+		Capabilities: chainclients.Capabilities{UTXO: true, SupportsMemo: true},
+		New: func(thorKeys *thorclient.Keys, cfg config.ChainConfiguration, server *tssp.TssServer, thorchainBridge *thorclient.ThorchainBridge, m *metrics.Metrics) (chainclients.ChainClient, error) {
+			return NewClient(thorKeys, cfg, server, thorchainBridge, m)
+		},
+	})
+}
+
+// NewClient create a new chain client for Bitcoin Cash, it is backed by the bitcoin UTXO engine
+func NewClient(thorKeys *thorclient.Keys, cfg config.ChainConfiguration, server *tssp.TssServer, bridge *thorclient.ThorchainBridge, m *metrics.Metrics) (*bitcoin.Client, error) {
+	return bitcoin.NewUTXOClient(BitcoinCashChain{}, thorKeys, cfg, server, bridge, m)
+}
+
+// GetChain return BCH chain
+func (BitcoinCashChain) GetChain() common.Chain {
+	return common.BCHChain
+}
+
+// DecodeAddress parse the given cash address or legacy address
+func (BitcoinCashChain) DecodeAddress(addr string, params *chaincfg.Params) (btcutil.Address, error) {
+	_, addrType, hash, err := common.DecodeCashAddr(addr)
+	if err == nil {
+		if addrType == common.CashAddrP2SH {
+			return btcutil.NewAddressScriptHashFromHash(hash, params)
+		}
+		return btcutil.NewAddressPubKeyHash(hash, params)
+	}
+	legacy, legacyErr := btcutil.DecodeAddress(addr, params)
+	if legacyErr != nil {
+		return nil, fmt.Errorf("fail to decode address(%s): %w", addr, err)
+	}
+	switch legacy.(type) {
+	case *btcutil.AddressPubKeyHash, *btcutil.AddressScriptHash:
+		return legacy, nil
+	}
+	return nil, fmt.Errorf("address(%s) is not supported on BCH", addr)
+}
+
+// NormalizeAddress remove the prefix of the cash address returned by the RPC, as THORChain use ':' as memo separator
+func (BitcoinCashChain) NormalizeAddress(addr string) string {
+	if idx := strings.LastIndex(addr, ":"); idx >= 0 {
+		return addr[idx+1:]
+	}
+	return addr
+}
+
+// SignInput sign the given P2PKH input with the BIP143 sighash, with the fork id set in the hash type
+func (BitcoinCashChain) SignInput(tx *wire.MsgTx, idx int, amount int64, sourceScript []byte, signable txscript.Signable) error {
+	hashType := txscript.SigHashAll | SigHashForkID
+	sigHashes := txscript.NewTxSigHashes(tx)
+	sig, err := txscript.RawTxInWitnessSignature(tx, sigHashes, idx, amount, sourceScript, hashType, signable)
+	if err != nil {
+		return err
+	}
+	pubKey := signable.GetPubKey()
+	if pubKey == nil {
+		return errors.New("fail to get public key of the signer")
+	}
+	sigScript, err := txscript.NewScriptBuilder().AddData(sig).AddData(pubKey.SerializeCompressed()).Script()
+	if err != nil {
+		return fmt.Errorf("fail to build signature script: %w", err)
+	}
+	// txscript engine doesn't support fork id, thus verify the signature against the sighash directly
+	hash, err := txscript.CalcWitnessSigHash(sourceScript, sigHashes, hashType, tx, idx, amount)
+	if err != nil {
+		return fmt.Errorf("fail to calculate sighash: %w", err)
+	}
+	signature, err := btcec.ParseDERSignature(sig[:len(sig)-1], btcec.S256())
+	if err != nil {
+		return fmt.Errorf("fail to parse signature: %w", err)
+	}
+	if !signature.Verify(hash, pubKey) {
+		return errors.New("fail to verify signature")
+	}
+	tx.TxIn[idx].SignatureScript = sigScript
+	return nil
+}
@@ -0,0 +1,81 @@
+package bitcoincash
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/btcsuite/btcutil"
+	"gitlab.com/thorchain/txscript"
+	. "gopkg.in/check.v1"
+
+	"gitlab.com/thorchain/thornode/common"
+)
+
+func TestPackage(t *testing.T) { TestingT(t) }
+
+type BitcoinCashSuite struct{}
+
+var _ = Suite(&BitcoinCashSuite{})
+
+func (s *BitcoinCashSuite) TestDecodeAddress(c *C) {
+	chain := BitcoinCashChain{}
+	c.Check(chain.GetChain(), Equals, common.BCHChain)
+	for _, input := range []string{
+		"qpm2qsznhks23z7629mms6s4cwef74vcwvy22gdx6a",
+		"bitcoincash:qpm2qsznhks23z7629mms6s4cwef74vcwvy22gdx6a",
+		"1BpEi6DfDAUFd7GtittLSdBeYJvcoaVggu",
+	} {
+		addr, err := chain.DecodeAddress(input, &common.BCHMainNetParams)
+		c.Assert(err, IsNil, Commentf(input))
+		c.Check(hex.EncodeToString(addr.ScriptAddress()), Equals, "76a04053bda0a88bda5177b86a15c3b29f559873")
+		_, ok := addr.(*btcutil.AddressPubKeyHash)
+		c.Check(ok, Equals, true)
+	}
+	addr, err := chain.DecodeAddress("ppm2qsznhks23z7629mms6s4cwef74vcwvn0h829pq", &common.BCHMainNetParams)
+	c.Assert(err, IsNil)
+	_, ok := addr.(*btcutil.AddressScriptHash)
+	c.Check(ok, Equals, true)
+
+	// segwit is not supported on BCH
+	_, err = chain.DecodeAddress("bc1qj08ys4ct2hzzc2hcz6h2hgrvlmsjynawlht528", &common.BCHMainNetParams)
+	c.Check(err, NotNil)
+	_, err = chain.DecodeAddress("bogus", &common.BCHMainNetParams)
+	c.Check(err, NotNil)
+
+	c.Check(chain.NormalizeAddress("bitcoincash:qpm2qsznhks23z7629mms6s4cwef74vcwvy22gdx6a"), Equals, "qpm2qsznhks23z7629mms6s4cwef74vcwvy22gdx6a")
+	c.Check(chain.NormalizeAddress("qpm2qsznhks23z7629mms6s4cwef74vcwvy22gdx6a"), Equals, "qpm2qsznhks23z7629mms6s4cwef74vcwvy22gdx6a")
+}
+
+func (s *BitcoinCashSuite) TestSignInput(c *C) {
+	privKey, err := btcec.NewPrivateKey(btcec.S256())
+	c.Assert(err, IsNil)
+	pkHash := btcutil.Hash160(privKey.PubKey().SerializeCompressed())
+	source, err := btcutil.NewAddressPubKeyHash(pkHash, &common.BCHRegressionNetParams)
+	c.Assert(err, IsNil)
+	sourceScript, err := txscript.PayToAddrScript(source)
+	c.Assert(err, IsNil)
+
+	tx := wire.NewMsgTx(wire.TxVersion)
+	tx.AddTxIn(wire.NewTxIn(wire.NewOutPoint(&chainhash.Hash{1}, 0), nil, nil))
+	tx.AddTxOut(wire.NewTxOut(90000, sourceScript))
+	c.Assert(BitcoinCashChain{}.SignInput(tx, 0, 100000, sourceScript, txscript.NewPrivateKeySignable(privKey)), IsNil)
+	c.Assert(tx.TxIn[0].SignatureScript, NotNil)
+	c.Check(tx.TxIn[0].Witness, HasLen, 0)
+
+	pushes, err := txscript.PushedData(tx.TxIn[0].SignatureScript)
+	c.Assert(err, IsNil)
+	c.Assert(pushes, HasLen, 2)
+	sig := pushes[0]
+	c.Check(sig[len(sig)-1], Equals, byte(txscript.SigHashAll|SigHashForkID))
+	c.Check(pushes[1], DeepEquals, privKey.PubKey().SerializeCompressed())
+
+	// signature commit to the input amount
+	hash, err := txscript.CalcWitnessSigHash(sourceScript, txscript.NewTxSigHashes(tx), txscript.SigHashAll|SigHashForkID, tx, 0, 100001)
+	c.Assert(err, IsNil)
+	signature, err := btcec.ParseDERSignature(sig[:len(sig)-1], btcec.S256())
+	c.Assert(err, IsNil)
+	c.Check(signature.Verify(hash, privKey.PubKey()), Equals, false)
+}
@@ -0,0 +1,78 @@
+package ethereum
+
+import (
+	"sync"
+)
+
+// BlockCacheSize the number of block meta that get kept in the ring buffer, a re-org deeper than this can't be detected
+const BlockCacheSize = 100
+
+// BlockMeta is a structure to store the blocks bifrost scanned
+type BlockMeta struct {
+	PreviousHash string `json:"previous_hash"`
+	Height       int64  `json:"height"`
+	BlockHash    string `json:"block_hash"`
+	// Transactions are the hashes of the txs THORNode observed in this block
+	Transactions []string `json:"transactions"`
+}
+
+// NewBlockMeta create a new instance of BlockMeta
+func NewBlockMeta(previousHash string, height int64, blockHash string) *BlockMeta {
+	return &BlockMeta{
+		PreviousHash: previousHash,
+		Height:       height,
+		BlockHash:    blockHash,
+	}
+}
+
+// HasTransaction check whether the given tx hash had been observed in this block
+func (b *BlockMeta) HasTransaction(hash string) bool {
+	for _, tx := range b.Transactions {
+		if tx == hash {
+			return true
+		}
+	}
+	return false
+}
+
+// BlockMetaCache is a ring buffer of the most recent BlockCacheSize block metas, indexed by block height
+type BlockMetaCache struct {
+	lock   *sync.Mutex
+	blocks []*BlockMeta
+}
+
+// NewBlockMetaCache create a new instance of BlockMetaCache which hold up to size block metas
+func NewBlockMetaCache(size int) *BlockMetaCache {
+	return &BlockMetaCache{
+		lock:   &sync.Mutex{},
+		blocks: make([]*BlockMeta, size),
+	}
+}
+
+func (b *BlockMetaCache) index(height int64) int {
+	return int(height % int64(len(b.blocks)))
+}
+
+// Get return the block meta of the given height, it return nil when the block meta doesn't exist or has been overwritten by a later block
+func (b *BlockMetaCache) Get(height int64) *BlockMeta {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	if height < 0 {
+		return nil
+	}
+	meta := b.blocks[b.index(height)]
+	if meta == nil || meta.Height != height {
+		return nil
+	}
+	return meta
+}
+
+// Set save the given block meta, overwriting the oldest block meta in the buffer
+func (b *BlockMetaCache) Set(meta *BlockMeta) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	if meta == nil || meta.Height < 0 {
+		return
+	}
+	b.blocks[b.index(meta.Height)] = meta
+}
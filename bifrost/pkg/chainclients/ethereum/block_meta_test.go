@@ -0,0 +1,30 @@
+package ethereum
+
+import (
+	. "gopkg.in/check.v1"
+)
+
+type BlockMetaTestSuite struct{}
+
+var _ = Suite(&BlockMetaTestSuite{})
+
+func (s *BlockMetaTestSuite) TestBlockMetaCache(c *C) {
+	cache := NewBlockMetaCache(3)
+	c.Check(cache.Get(1), IsNil)
+	c.Check(cache.Get(-1), IsNil)
+
+	meta := NewBlockMeta("0xparent", 1, "0xhash")
+	meta.Transactions = append(meta.Transactions, "88DF016429689C079F3B2F6AD39FA052532C56795B733DA78A91EBE6A713944B")
+	cache.Set(meta)
+	result := cache.Get(1)
+	c.Assert(result, NotNil)
+	c.Check(result.BlockHash, Equals, "0xhash")
+	c.Check(result.HasTransaction("88DF016429689C079F3B2F6AD39FA052532C56795B733DA78A91EBE6A713944B"), Equals, true)
+	c.Check(result.HasTransaction("abc"), Equals, false)
+
+	// block 4 overwrite block 1 in the ring buffer
+	cache.Set(NewBlockMeta("0xparent3", 4, "0xhash4"))
+	c.Check(cache.Get(1), IsNil)
+	c.Assert(cache.Get(4), NotNil)
+	c.Check(cache.Get(4).BlockHash, Equals, "0xhash4")
+}
@@ -0,0 +1,52 @@
+// SPDX-License-Identifier: MIT
+pragma solidity 0.6.8;
+
+interface iERC20 {
+    function transfer(address, uint) external returns (bool);
+    function transferFrom(address, address, uint) external returns (bool);
+}
+
+// Router is the entry point of all the inbound and outbound txs of THORChain vaults on Ethereum.
+// Every deposit and transfer out emits an event carrying the memo, so bifrost doesn't need to
+// decode memos from the tx data field.
+contract Router {
+    // ETH is represented as the zero address
+    address private constant ETH = address(0);
+
+    // vault => asset => amount of token the vault is allowed to transfer out
+    mapping(address => mapping(address => uint)) public vaultAllowance;
+
+    event Deposit(address indexed to, address indexed asset, uint amount, string memo);
+    event TransferOut(address indexed vault, address indexed to, address asset, uint amount, string memo);
+
+    // deposit ETH or token to the given vault with a THORChain memo
+    function deposit(address payable vault, address asset, uint amount, string memory memo) public payable {
+        uint value;
+        if (asset == ETH) {
+            value = msg.value;
+            vault.transfer(value);
+        } else {
+            require(msg.value == 0, "unexpected ETH");
+            value = amount;
+            require(iERC20(asset).transferFrom(msg.sender, address(this), value), "transfer failed");
+            vaultAllowance[vault][asset] += value;
+        }
+        emit Deposit(vault, asset, value, memo);
+    }
+
+    // transferOut is called by the vault to send an outbound, the memo is the outbound memo which carries the in hash
+    function transferOut(address payable to, address asset, uint amount, string memory memo) public payable {
+        uint value;
+        if (asset == ETH) {
+            value = msg.value;
+            to.transfer(value);
+        } else {
+            require(msg.value == 0, "unexpected ETH");
+            require(vaultAllowance[msg.sender][asset] >= amount, "insufficient allowance");
+            value = amount;
+            vaultAllowance[msg.sender][asset] -= value;
+            require(iERC20(asset).transfer(to, value), "transfer failed");
+        }
+        emit TransferOut(msg.sender, to, asset, value, memo);
+    }
+}
@@ -0,0 +1,286 @@
+package ethereum
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"sync"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	ecommon "github.com/ethereum/go-ethereum/common"
+	etypes "github.com/ethereum/go-ethereum/core/types"
+
+	"gitlab.com/thorchain/thornode/common"
+)
+
+// erc20ABI is the subset of the ERC-20 interface bifrost needs to observe and send tokens
+const erc20ABI = `[
+	{"constant":true,"inputs":[],"name":"symbol","outputs":[{"name":"","type":"string"}],"payable":false,"stateMutability":"view","type":"function"},
+	{"constant":true,"inputs":[],"name":"decimals","outputs":[{"name":"","type":"uint8"}],"payable":false,"stateMutability":"view","type":"function"},
+	{"constant":true,"inputs":[{"name":"_owner","type":"address"}],"name":"balanceOf","outputs":[{"name":"balance","type":"uint256"}],"payable":false,"stateMutability":"view","type":"function"},
+	{"constant":false,"inputs":[{"name":"_to","type":"address"},{"name":"_value","type":"uint256"}],"name":"transfer","outputs":[{"name":"","type":"bool"}],"payable":false,"stateMutability":"nonpayable","type":"function"},
+	{"constant":false,"inputs":[{"name":"_from","type":"address"},{"name":"_to","type":"address"},{"name":"_value","type":"uint256"}],"name":"transferFrom","outputs":[{"name":"","type":"bool"}],"payable":false,"stateMutability":"nonpayable","type":"function"},
+	{"anonymous":false,"inputs":[{"indexed":true,"name":"from","type":"address"},{"indexed":true,"name":"to","type":"address"},{"indexed":false,"name":"value","type":"uint256"}],"name":"Transfer","type":"event"}
+]`
+
+const (
+	// ERC20TransferGas is used as gas limit when the node is not able to estimate the gas of a token transfer
+	ERC20TransferGas = uint64(100000)
+	// erc20TransferInputLength is the length of an abi encoded transfer(address,uint256) call
+	erc20TransferInputLength = 4 + 32*2
+	// thorchainDecimals is the number of decimals of all the amounts on THORChain
+	thorchainDecimals = 8
+)
+
+var erc20 abi.ABI
+
+func init() {
+	var err error
+	erc20, err = abi.JSON(strings.NewReader(erc20ABI))
+	if err != nil {
+		panic(fmt.Sprintf("fail to parse erc20 abi: %s", err))
+	}
+}
+
+// TokenMeta is the metadata of an ERC-20 token bifrost has seen
+type TokenMeta struct {
+	Address  ecommon.Address
+	Asset    common.Asset
+	Decimals uint8
+}
+
+// decimalsFactor return the factor between the token amount and THORChain amount, and whether the token has more
+// decimals than THORChain
+func (m TokenMeta) decimalsFactor() (*big.Int, bool) {
+	if m.Decimals >= thorchainDecimals {
+		return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(m.Decimals-thorchainDecimals)), nil), true
+	}
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(thorchainDecimals-m.Decimals)), nil), false
+}
+
+// toThorchainAmount convert the given token amount to THORChain amount, which has 8 decimals
+func (m TokenMeta) toThorchainAmount(amount *big.Int) sdk.Uint {
+	factor, more := m.decimalsFactor()
+	if more {
+		return sdk.NewUintFromBigInt(new(big.Int).Quo(amount, factor))
+	}
+	return sdk.NewUintFromBigInt(new(big.Int).Mul(amount, factor))
+}
+
+// fromThorchainAmount convert the given THORChain amount, which has 8 decimals, to token amount
+func (m TokenMeta) fromThorchainAmount(amount sdk.Uint) *big.Int {
+	factor, more := m.decimalsFactor()
+	if more {
+		return new(big.Int).Mul(amount.BigInt(), factor)
+	}
+	return new(big.Int).Quo(amount.BigInt(), factor)
+}
+
+// TokenMetaStore caches the token metadata keyed by contract address, so THORNode doesn't need to query the contract every time
+type TokenMetaStore struct {
+	lock   *sync.Mutex
+	tokens map[ecommon.Address]TokenMeta
+}
+
+// NewTokenMetaStore create a new instance of TokenMetaStore
+func NewTokenMetaStore() *TokenMetaStore {
+	return &TokenMetaStore{
+		lock:   &sync.Mutex{},
+		tokens: make(map[ecommon.Address]TokenMeta),
+	}
+}
+
+// Get return the token metadata of the given contract address
+func (t *TokenMetaStore) Get(addr ecommon.Address) (TokenMeta, bool) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	meta, ok := t.tokens[addr]
+	return meta, ok
+}
+
+// Set save the given token metadata
+func (t *TokenMetaStore) Set(meta TokenMeta) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	t.tokens[meta.Address] = meta
+}
+
+// getTokenAsset convert a token symbol and contract address to THORChain asset, symbol will be in the format TICKER-CONTRACTADDRESS
+func getTokenAsset(symbol string, addr ecommon.Address) (common.Asset, error) {
+	return common.NewAsset(fmt.Sprintf("%s.%s-%s", common.ETHChain, symbol, strings.ToUpper(addr.String())))
+}
+
+// getTokenAddress return the contract address of the given ERC-20 asset
+func getTokenAddress(asset common.Asset) (ecommon.Address, error) {
+	if !asset.Chain.Equals(common.ETHChain) || asset.Equals(common.ETHAsset) {
+		return ecommon.Address{}, fmt.Errorf("%s is not an ERC-20 asset", asset)
+	}
+	parts := strings.Split(asset.Symbol.String(), "-")
+	addr := parts[len(parts)-1]
+	if len(parts) < 2 || !ecommon.IsHexAddress(addr) {
+		return ecommon.Address{}, fmt.Errorf("%s doesn't have a contract address", asset)
+	}
+	return ecommon.HexToAddress(addr), nil
+}
+
+// isTokenTransfer check whether the given coins need to be sent through an ERC-20 contract
+func isTokenTransfer(coins common.Coins) bool {
+	for _, coin := range coins {
+		if _, err := getTokenAddress(coin.Asset); err == nil {
+			return true
+		}
+	}
+	return false
+}
+
+// isERC20Transfer check whether the given tx data is a call to the ERC-20 transfer or transferFrom function
+func isERC20Transfer(data []byte) bool {
+	if len(data) < 4 {
+		return false
+	}
+	return bytes.Equal(data[:4], erc20.Methods["transfer"].ID()) ||
+		bytes.Equal(data[:4], erc20.Methods["transferFrom"].ID())
+}
+
+// getERC20Memo return the memo appended to the end of an ERC-20 transfer call
+func getERC20Memo(data []byte) string {
+	if len(data) < 4 || !bytes.Equal(data[:4], erc20.Methods["transfer"].ID()) {
+		return ""
+	}
+	if len(data) <= erc20TransferInputLength {
+		return ""
+	}
+	return string(data[erc20TransferInputLength:])
+}
+
+// getTokenMeta return the metadata of the given token contract, it query the contract when it is not in the cache
+func (e *BlockScanner) getTokenMeta(addr ecommon.Address) (TokenMeta, error) {
+	if meta, ok := e.tokens.Get(addr); ok {
+		return meta, nil
+	}
+	var symbol string
+	if err := e.callToken(addr, "symbol", &symbol); err != nil {
+		return TokenMeta{}, err
+	}
+	var decimals uint8
+	if err := e.callToken(addr, "decimals", &decimals); err != nil {
+		return TokenMeta{}, err
+	}
+	asset, err := getTokenAsset(symbol, addr)
+	if err != nil {
+		return TokenMeta{}, fmt.Errorf("fail to create asset for token(%s): %w", addr, err)
+	}
+	meta := TokenMeta{
+		Address:  addr,
+		Asset:    asset,
+		Decimals: decimals,
+	}
+	e.tokens.Set(meta)
+	return meta, nil
+}
+
+// callToken call the given view function, which has no input, on the token contract and unpack the output
+func (e *BlockScanner) callToken(addr ecommon.Address, method string, out interface{}) error {
+	input, err := erc20.Pack(method)
+	if err != nil {
+		return fmt.Errorf("fail to pack %s call: %w", method, err)
+	}
+	output, err := e.client.CallContract(context.Background(), ethereum.CallMsg{
+		To:   &addr,
+		Data: input,
+	}, nil)
+	if err != nil {
+		return fmt.Errorf("fail to call %s on token(%s): %w", method, addr, err)
+	}
+	if err := erc20.Unpack(out, method, output); err != nil {
+		return fmt.Errorf("fail to unpack %s of token(%s): %w", method, addr, err)
+	}
+	return nil
+}
+
+// getTokenTransfers parse all the ERC-20 Transfer events in the given receipt
+func (e *BlockScanner) getTokenTransfers(receipt *etypes.Receipt) (from, to ecommon.Address, coins common.Coins, err error) {
+	transferEvent := erc20.Events["Transfer"].ID()
+	for _, item := range receipt.Logs {
+		if len(item.Topics) != 3 || item.Topics[0] != transferEvent {
+			continue
+		}
+		logFrom := ecommon.BytesToAddress(item.Topics[1].Bytes())
+		logTo := ecommon.BytesToAddress(item.Topics[2].Bytes())
+		// only the first sender / receiver pair in a tx will be observed
+		if len(coins) > 0 && (logFrom != from || logTo != to) {
+			continue
+		}
+		// from and to are indexed, value is the only field in the log data
+		if len(item.Data) != 32 {
+			e.logger.Error().Int("length", len(item.Data)).Str("contract", item.Address.String()).Msg("invalid transfer event data length, ignore transfer")
+			continue
+		}
+		meta, err := e.getTokenMeta(item.Address)
+		if err != nil {
+			e.logger.Error().Err(err).Str("contract", item.Address.String()).Msg("fail to get token metadata, ignore transfer")
+			continue
+		}
+		amount := new(big.Int).SetBytes(item.Data)
+		from, to = logFrom, logTo
+		coins = append(coins, common.NewCoin(meta.Asset, meta.toThorchainAmount(amount)))
+	}
+	return from, to, coins, nil
+}
+
+// getTokenBalance return the balance of the given address on the token contract
+func (c *Client) getTokenBalance(token, owner ecommon.Address) (*big.Int, error) {
+	input, err := erc20.Pack("balanceOf", owner)
+	if err != nil {
+		return nil, fmt.Errorf("fail to pack balanceOf call: %w", err)
+	}
+	output, err := c.client.CallContract(context.Background(), ethereum.CallMsg{
+		To:   &token,
+		Data: input,
+	}, nil)
+	if err != nil {
+		return nil, fmt.Errorf("fail to call balanceOf on token(%s): %w", token, err)
+	}
+	balance := new(big.Int)
+	if err := erc20.Unpack(&balance, "balanceOf", output); err != nil {
+		return nil, fmt.Errorf("fail to unpack balance: %w", err)
+	}
+	return balance, nil
+}
+
+// buildTokenTransfer create an ERC-20 transfer tx, the memo is appended to the call data, it is ignored by the token contract
+func (c *Client) buildTokenTransfer(from string, nonce uint64, gasPrice *big.Int, toAddr string, coin common.Coin, memo string) (*etypes.Transaction, error) {
+	token, err := getTokenAddress(coin.Asset)
+	if err != nil {
+		return nil, err
+	}
+	if !ecommon.IsHexAddress(toAddr) {
+		return nil, errors.New("invalid to address")
+	}
+	meta, err := c.ethScanner.getTokenMeta(token)
+	if err != nil {
+		return nil, fmt.Errorf("fail to get token metadata: %w", err)
+	}
+	data, err := erc20.Pack("transfer", ecommon.HexToAddress(toAddr), meta.fromThorchainAmount(coin.Amount))
+	if err != nil {
+		return nil, fmt.Errorf("fail to pack transfer call: %w", err)
+	}
+	data = append(data, []byte(memo)...)
+	gas, err := c.client.EstimateGas(context.Background(), ethereum.CallMsg{
+		From:     ecommon.HexToAddress(from),
+		To:       &token,
+		GasPrice: gasPrice,
+		Value:    big.NewInt(0),
+		Data:     data,
+	})
+	if err != nil {
+		c.logger.Error().Err(err).Str("token", token.String()).Msg("fail to estimate gas of token transfer, fallback to default")
+		gas = ERC20TransferGas
+	}
+	return etypes.NewTransaction(nonce, token, big.NewInt(0), gas, gasPrice, data), nil
+}
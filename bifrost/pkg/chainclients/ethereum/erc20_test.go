@@ -0,0 +1,220 @@
+package ethereum
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	ecommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	etypes "github.com/ethereum/go-ethereum/core/types"
+	ecrypto "github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient"
+	. "gopkg.in/check.v1"
+
+	"gitlab.com/thorchain/thornode/bifrost/blockscanner"
+	"gitlab.com/thorchain/thornode/bifrost/metrics"
+	"gitlab.com/thorchain/thornode/bifrost/pkg/chainclients/ethereum/types"
+	"gitlab.com/thorchain/thornode/common"
+)
+
+type ERC20TestSuite struct {
+	m *metrics.Metrics
+}
+
+var _ = Suite(&ERC20TestSuite{})
+
+func (s *ERC20TestSuite) SetUpSuite(c *C) {
+	s.m = GetMetricForTest(c)
+	c.Assert(s.m, NotNil)
+}
+
+const testTokenAddress = "0x3b7fa4dd21c6f9ba3ca375217ead7cab9d6bf483"
+
+// testTokenDecimals the test token has 18 decimals, ten more than THORChain
+const testTokenDecimals = 18
+
+// testTokenAmount return the amount of the test token that is worth the given THORChain amount
+func testTokenAmount(amount int64) *big.Int {
+	return new(big.Int).Mul(big.NewInt(amount), big.NewInt(10_000_000_000))
+}
+
+// callSelector return the function selector of the given eth_call request
+func callSelector(c *C, body []byte) []byte {
+	var rpcRequest struct {
+		Params []json.RawMessage `json:"params"`
+	}
+	c.Assert(json.Unmarshal(body, &rpcRequest), IsNil)
+	c.Assert(rpcRequest.Params, Not(HasLen), 0)
+	var callMsg struct {
+		Data hexutil.Bytes `json:"data"`
+	}
+	c.Assert(json.Unmarshal(rpcRequest.Params[0], &callMsg), IsNil)
+	c.Assert(len(callMsg.Data) >= 4, Equals, true)
+	return callMsg.Data[:4]
+}
+
+// tokenCallResult return the json rpc result of the given eth_call request on the test token
+func tokenCallResult(c *C, body []byte) string {
+	selector := callSelector(c, body)
+	var output []byte
+	var err error
+	switch {
+	case bytes.Equal(selector, erc20.Methods["symbol"].ID()):
+		output, err = erc20.Methods["symbol"].Outputs.Pack("TKN")
+	case bytes.Equal(selector, erc20.Methods["decimals"].ID()):
+		output, err = erc20.Methods["decimals"].Outputs.Pack(uint8(testTokenDecimals))
+	}
+	c.Assert(err, IsNil)
+	return fmt.Sprintf(`{"jsonrpc":"2.0","id":1,"result":"%s"}`, hexutil.Encode(output))
+}
+
+func (s *ERC20TestSuite) TestTokenAsset(c *C) {
+	addr := ecommon.HexToAddress(testTokenAddress)
+	asset, err := getTokenAsset("TKN", addr)
+	c.Assert(err, IsNil)
+	c.Check(asset.Chain.Equals(common.ETHChain), Equals, true)
+	c.Check(asset.Ticker.String(), Equals, "TKN")
+	c.Check(asset.Symbol.String(), Equals, "TKN-0X3B7FA4DD21C6F9BA3CA375217EAD7CAB9D6BF483")
+
+	tokenAddr, err := getTokenAddress(asset)
+	c.Assert(err, IsNil)
+	c.Check(tokenAddr, Equals, addr)
+
+	_, err = getTokenAddress(common.ETHAsset)
+	c.Check(err, NotNil)
+	_, err = getTokenAddress(common.BNBAsset)
+	c.Check(err, NotNil)
+	_, err = getTokenAddress(common.Asset{Chain: common.ETHChain, Symbol: "TKN-ABC", Ticker: "TKN"})
+	c.Check(err, NotNil)
+
+	_, err = getTokenAsset("T$N", addr)
+	c.Check(err, NotNil)
+
+	c.Check(isTokenTransfer(common.Coins{common.NewCoin(asset, sdk.NewUint(1))}), Equals, true)
+	c.Check(isTokenTransfer(common.Coins{common.NewCoin(common.ETHAsset, sdk.NewUint(1))}), Equals, false)
+}
+
+func (s *ERC20TestSuite) TestTransferInput(c *C) {
+	data, err := erc20.Pack("transfer", ecommon.HexToAddress(testTokenAddress), big.NewInt(100))
+	c.Assert(err, IsNil)
+	c.Check(isERC20Transfer(data), Equals, true)
+	c.Check(getERC20Memo(data), Equals, "")
+	data = append(data, []byte("SWAP:ETH.ETH")...)
+	c.Check(getERC20Memo(data), Equals, "SWAP:ETH.ETH")
+
+	c.Check(isERC20Transfer([]byte("hello!")), Equals, false)
+	c.Check(isERC20Transfer(nil), Equals, false)
+	c.Check(getERC20Memo([]byte("hello!")), Equals, "")
+}
+
+func (s *ERC20TestSuite) TestFromERC20TxToTxIn(c *C) {
+	privKey, err := ecrypto.GenerateKey()
+	c.Assert(err, IsNil)
+	sender := ecrypto.PubkeyToAddress(privKey.PublicKey)
+	token := ecommon.HexToAddress(testTokenAddress)
+	vault := ecommon.HexToAddress("0xf02c1c8e6114b1dbe8937a39260b5b0a374432bb")
+
+	data, err := erc20.Pack("transfer", vault, big.NewInt(12345))
+	c.Assert(err, IsNil)
+	data = append(data, []byte("STAKE:ETH.TKN")...)
+	tx := etypes.NewTransaction(0, token, big.NewInt(0), ERC20TransferGas, big.NewInt(2), data)
+	tx, err = etypes.SignTx(tx, etypes.NewEIP155Signer(big.NewInt(int64(types.Mainnet))), privKey)
+	c.Assert(err, IsNil)
+	encodedTx, err := tx.MarshalJSON()
+	c.Assert(err, IsNil)
+
+	receipt := &etypes.Receipt{
+		Status:            etypes.ReceiptStatusSuccessful,
+		CumulativeGasUsed: 37000,
+		GasUsed:           37000,
+		TxHash:            tx.Hash(),
+		Logs: []*etypes.Log{
+			// malformed transfer event is ignored
+			{
+				Address: token,
+				Topics: []ecommon.Hash{
+					erc20.Events["Transfer"].ID(),
+					ecommon.BytesToHash(sender.Bytes()),
+					ecommon.BytesToHash(vault.Bytes()),
+				},
+				Data:   []byte{0x01},
+				TxHash: tx.Hash(),
+			},
+			{
+				Address: token,
+				Topics: []ecommon.Hash{
+					erc20.Events["Transfer"].ID(),
+					ecommon.BytesToHash(sender.Bytes()),
+					ecommon.BytesToHash(vault.Bytes()),
+				},
+				Data:   ecommon.LeftPadBytes(testTokenAmount(12345).Bytes(), 32),
+				TxHash: tx.Hash(),
+			},
+		},
+	}
+	buf, err := json.Marshal(receipt)
+	c.Assert(err, IsNil)
+
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		body, err := ioutil.ReadAll(req.Body)
+		c.Assert(err, IsNil)
+		var rpcRequest struct {
+			Method string `json:"method"`
+		}
+		c.Assert(json.Unmarshal(body, &rpcRequest), IsNil)
+		switch rpcRequest.Method {
+		case "eth_gasPrice":
+			_, err = rw.Write([]byte(`{"jsonrpc":"2.0","id":1,"result":"0x1"}`))
+		case "eth_getTransactionReceipt":
+			_, err = rw.Write([]byte(fmt.Sprintf(`{"jsonrpc":"2.0","id":1,"result":%s}`, buf)))
+		case "eth_call":
+			_, err = rw.Write([]byte(tokenCallResult(c, body)))
+		}
+		c.Assert(err, IsNil)
+	}))
+	defer server.Close()
+	ethClient, err := ethclient.Dial(server.URL)
+	c.Assert(err, IsNil)
+	bs, err := NewBlockScanner(getConfigForTest(server.URL), blockscanner.NewMockScannerStorage(), types.Mainnet, ethClient, ecommon.Address{}, s.m)
+	c.Assert(err, IsNil)
+
+	txInItem, err := bs.fromTxToTxIn(string(encodedTx))
+	c.Assert(err, IsNil)
+	c.Assert(txInItem, NotNil)
+	c.Check(txInItem.Memo, Equals, "STAKE:ETH.TKN")
+	c.Check(txInItem.Sender, Equals, strings.ToLower(sender.String()))
+	c.Check(txInItem.To, Equals, strings.ToLower(vault.String()))
+	c.Assert(txInItem.Coins, HasLen, 1)
+	c.Check(txInItem.Coins[0].Asset.String(), Equals, "ETH.TKN-0X3B7FA4DD21C6F9BA3CA375217EAD7CAB9D6BF483")
+	c.Check(txInItem.Coins[0].Amount.Equal(sdk.NewUint(12345)), Equals, true)
+	c.Check(txInItem.Gas[0].Amount.Equal(sdk.NewUint(74000)), Equals, true)
+
+	// token metadata should be cached
+	meta, ok := bs.tokens.Get(token)
+	c.Check(ok, Equals, true)
+	c.Check(meta.Asset.Ticker.String(), Equals, "TKN")
+	c.Check(meta.Decimals, Equals, uint8(testTokenDecimals))
+}
+
+func (s *ERC20TestSuite) TestTokenAmount(c *C) {
+	meta := TokenMeta{Decimals: 18}
+	c.Check(meta.toThorchainAmount(testTokenAmount(5)).Equal(sdk.NewUint(5)), Equals, true)
+	c.Check(meta.fromThorchainAmount(sdk.NewUint(5)).Cmp(testTokenAmount(5)), Equals, 0)
+	// dust below THORChain precision is dropped
+	c.Check(meta.toThorchainAmount(big.NewInt(9_999_999_999)).IsZero(), Equals, true)
+
+	meta = TokenMeta{Decimals: 6}
+	c.Check(meta.toThorchainAmount(big.NewInt(7)).Equal(sdk.NewUint(700)), Equals, true)
+	c.Check(meta.fromThorchainAmount(sdk.NewUint(700)).Int64(), Equals, int64(7))
+
+	meta = TokenMeta{Decimals: 8}
+	c.Check(meta.toThorchainAmount(big.NewInt(7)).Equal(sdk.NewUint(7)), Equals, true)
+	c.Check(meta.fromThorchainAmount(sdk.NewUint(7)).Int64(), Equals, int64(7))
+}
@@ -7,6 +7,7 @@ import (
 	"errors"
 	"fmt"
 	"math/big"
+	"sync"
 
 	ecommon "github.com/ethereum/go-ethereum/common"
 	etypes "github.com/ethereum/go-ethereum/core/types"
@@ -18,6 +19,7 @@ import (
 	"gitlab.com/thorchain/thornode/bifrost/blockscanner"
 	"gitlab.com/thorchain/thornode/bifrost/config"
 	"gitlab.com/thorchain/thornode/bifrost/metrics"
+	"gitlab.com/thorchain/thornode/bifrost/pkg/chainclients"
 	"gitlab.com/thorchain/thornode/bifrost/pkg/chainclients/ethereum/types"
 	"gitlab.com/thorchain/thornode/bifrost/thorclient"
 	stypes "gitlab.com/thorchain/thornode/bifrost/thorclient/types"
@@ -37,6 +39,18 @@ type Client struct {
 	accts           *EthereumMetaDataStore
 	thorchainBridge *thorclient.ThorchainBridge
 	blockScanner    *blockscanner.BlockScanner
+	nonces          *NonceManager
+	wg              *sync.WaitGroup
+	stopchan        chan struct{}
+}
+
+func init() {
+	chainclients.Register(common.ETHChain, chainclients.Factory{
+		Capabilities: chainclients.Capabilities{SupportsMemo: true},
+		New: func(thorKeys *thorclient.Keys, cfg config.ChainConfiguration, server *tssp.TssServer, thorchainBridge *thorclient.ThorchainBridge, m *metrics.Metrics) (chainclients.ChainClient, error) {
+			return NewClient(thorKeys, cfg, server, thorchainBridge, m)
+		},
+	})
 }
 
 // NewClient create new instance of Ethereum client
@@ -83,6 +97,8 @@ func NewClient(thorKeys *thorclient.Keys, cfg config.ChainConfiguration, server
 		accts:           NewEthereumMetaDataStore(),
 		kw:              keysignWrapper,
 		thorchainBridge: thorchainBridge,
+		wg:              &sync.WaitGroup{},
+		stopchan:        make(chan struct{}),
 	}
 	c.InitChainID()
 
@@ -94,8 +110,9 @@ func NewClient(thorKeys *thorclient.Keys, cfg config.ChainConfiguration, server
 	if err != nil {
 		return c, fmt.Errorf("fail to create blockscanner storage: %w", err)
 	}
+	c.nonces = NewNonceManager(storage.GetInternalDb())
 
-	c.ethScanner, err = NewBlockScanner(c.cfg.BlockScanner, storage, c.chainID, c.client, m)
+	c.ethScanner, err = NewBlockScanner(c.cfg.BlockScanner, storage, c.chainID, c.client, ecommon.HexToAddress(c.cfg.RouterAddress), m)
 	if err != nil {
 		return c, fmt.Errorf("fail to create eth block scanner: %w", err)
 	}
@@ -109,11 +126,17 @@ func NewClient(thorKeys *thorclient.Keys, cfg config.ChainConfiguration, server
 }
 
 func (c *Client) Start(globalTxsQueue chan stypes.TxIn, globalErrataQueue chan stypes.ErrataBlock) {
+	c.ethScanner.globalTxsQueue = globalTxsQueue
+	c.ethScanner.globalErrataQueue = globalErrataQueue
 	c.blockScanner.Start(globalTxsQueue)
+	c.wg.Add(1)
+	go c.processPendingTxs()
 }
 
 func (c *Client) Stop() {
 	c.blockScanner.Stop()
+	close(c.stopchan)
+	c.wg.Wait()
 	c.client.Close()
 }
 
@@ -203,12 +226,20 @@ func (c *Client) SignTx(tx stypes.TxOutItem, height int64) ([]byte, error) {
 		})
 	}
 	meta = c.accts.Get(tx.VaultPubKey)
-	c.logger.Info().Uint64("nonce", meta.Nonce).Msg("account info")
+	// the nonce in storage is ahead of the chain when there are pending txs in mempool
+	nonce, err := c.nonces.GetNonce(fromAddr)
+	if err != nil {
+		return nil, fmt.Errorf("fail to get nonce from storage: %w", err)
+	}
+	if meta.Nonce > nonce {
+		nonce = meta.Nonce
+	}
+	c.logger.Info().Uint64("nonce", nonce).Msg("account info")
 
-	gasPrice := c.ethScanner.GetGasPrice()
-	encodedData := []byte(hex.EncodeToString([]byte(tx.Memo)))
-	gasFee := common.GetETHGasFee(big.NewInt(1), uint64(len(tx.Memo)))[0].Amount.Uint64()
-	createdTx := etypes.NewTransaction(meta.Nonce, ecommon.HexToAddress(toAddr), value, gasFee, gasPrice, encodedData)
+	createdTx, err := c.buildTx(tx, fromAddr, nonce, c.ethScanner.GetGasPrice())
+	if err != nil {
+		return nil, err
+	}
 
 	rawTx, err := c.sign(createdTx, fromAddr, tx.VaultPubKey, currentHeight, tx)
 	if err != nil || len(rawTx) == 0 {
@@ -217,6 +248,38 @@ func (c *Client) SignTx(tx stypes.TxOutItem, height int64) ([]byte, error) {
 	return rawTx, nil
 }
 
+// buildTx create the outbound tx of the given TxOutItem with the given nonce and gas price
+func (c *Client) buildTx(tx stypes.TxOutItem, fromAddr string, nonce uint64, gasPrice *big.Int) (*etypes.Transaction, error) {
+	toAddr := tx.ToAddress.String()
+	if c.ethScanner.router != (ecommon.Address{}) {
+		if len(tx.Coins) != 1 {
+			return nil, errors.New("only one coin can be sent per router tx")
+		}
+		createdTx, err := c.buildRouterTransferOut(fromAddr, nonce, gasPrice, toAddr, tx.Coins[0], tx.Memo)
+		if err != nil {
+			return nil, fmt.Errorf("fail to build router transfer out tx: %w", err)
+		}
+		return createdTx, nil
+	}
+	if isTokenTransfer(tx.Coins) {
+		if len(tx.Coins) != 1 {
+			return nil, errors.New("only one token can be sent per tx")
+		}
+		createdTx, err := c.buildTokenTransfer(fromAddr, nonce, gasPrice, toAddr, tx.Coins[0], tx.Memo)
+		if err != nil {
+			return nil, fmt.Errorf("fail to build token transfer tx: %w", err)
+		}
+		return createdTx, nil
+	}
+	value := big.NewInt(0)
+	for _, coin := range tx.Coins {
+		value.Add(value, coin.Amount.BigInt())
+	}
+	encodedData := []byte(hex.EncodeToString([]byte(tx.Memo)))
+	gasFee := common.GetETHGasFee(big.NewInt(1), uint64(len(tx.Memo)))[0].Amount.Uint64()
+	return etypes.NewTransaction(nonce, ecommon.HexToAddress(toAddr), value, gasFee, gasPrice, encodedData), nil
+}
+
 // sign is design to sign a given message with keysign party and keysign wrapper
 func (c *Client) sign(tx *etypes.Transaction, from string, poolPubKey common.PubKey, height int64, txOutItem stypes.TxOutItem) ([]byte, error) {
 	keySignParty, err := c.thorchainBridge.GetKeysignParty(poolPubKey)
@@ -260,10 +323,57 @@ func (c *Client) GetAccount(pkey common.PubKey) (common.Account, error) {
 	if err != nil {
 		return common.Account{}, fmt.Errorf("fail to get account nonce: %w", err)
 	}
-	account := common.NewAccount(int64(nonce), 0, common.AccountCoins{common.AccountCoin{Amount: balance.Uint64(), Denom: "ETH.ETH"}})
+	coins := common.AccountCoins{common.AccountCoin{Amount: balance.Uint64(), Denom: "ETH.ETH"}}
+	tokens, err := c.getPoolTokens()
+	if err != nil {
+		return common.Account{}, fmt.Errorf("fail to get pool tokens: %w", err)
+	}
+	for _, token := range tokens {
+		var tokenBalance *big.Int
+		if c.ethScanner.router != (ecommon.Address{}) {
+			// the router holds the tokens, the vault can only spend its allowance
+			tokenBalance, err = c.getVaultAllowance(ecommon.HexToAddress(addr), token.Address)
+		} else {
+			tokenBalance, err = c.getTokenBalance(token.Address, ecommon.HexToAddress(addr))
+		}
+		if err != nil {
+			c.logger.Error().Err(err).Str("token", token.Asset.String()).Msg("fail to get token balance, ignore token")
+			continue
+		}
+		amount := token.toThorchainAmount(tokenBalance)
+		if !amount.BigInt().IsUint64() {
+			c.logger.Error().Str("token", token.Asset.String()).Str("balance", amount.String()).Msg("token balance is too large, ignore token")
+			continue
+		}
+		coins = append(coins, common.AccountCoin{Amount: amount.Uint64(), Denom: token.Asset.String()})
+	}
+	account := common.NewAccount(int64(nonce), 0, coins)
 	return account, nil
 }
 
+// getPoolTokens return the metadata of the ERC-20 tokens that have a pool on THORChain, those are the only tokens a vault
+// is supposed to hold
+func (c *Client) getPoolTokens() ([]TokenMeta, error) {
+	pools, err := c.thorchainBridge.GetPools()
+	if err != nil {
+		return nil, err
+	}
+	tokens := make([]TokenMeta, 0, len(pools))
+	for _, pool := range pools {
+		addr, err := getTokenAddress(pool.Asset)
+		if err != nil {
+			continue
+		}
+		meta, err := c.ethScanner.getTokenMeta(addr)
+		if err != nil {
+			c.logger.Error().Err(err).Str("asset", pool.Asset.String()).Msg("fail to get token metadata, ignore token")
+			continue
+		}
+		tokens = append(tokens, meta)
+	}
+	return tokens, nil
+}
+
 // BroadcastTx decodes tx using rlp and broadcasts too Ethereum chain
 func (c *Client) BroadcastTx(stx stypes.TxOutItem, hexTx []byte) error {
 	var tx *etypes.Transaction = &etypes.Transaction{}
@@ -274,5 +384,9 @@ func (c *Client) BroadcastTx(stx stypes.TxOutItem, hexTx []byte) error {
 		return err
 	}
 	c.accts.NonceInc(stx.VaultPubKey)
+	if err := c.addPendingTx(stx, tx); err != nil {
+		// the tx has been broadcast already, it will not be replaced when it get stuck, but it is not worth to fail the outbound
+		c.logger.Error().Err(err).Str("hash", tx.Hash().Hex()).Msg("fail to track pending tx")
+	}
 	return nil
 }
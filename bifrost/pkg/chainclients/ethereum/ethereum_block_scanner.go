@@ -11,6 +11,7 @@ import (
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/ethereum/go-ethereum"
+	ecommon "github.com/ethereum/go-ethereum/common"
 	etypes "github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/prometheus/client_golang/prometheus"
@@ -43,10 +44,16 @@ type BlockScanner struct {
 	errCounter *prometheus.CounterVec
 	gasPrice   *big.Int
 	client     *ethclient.Client
+	tokens     *TokenMetaStore
+	router     ecommon.Address
+	blockMetas *BlockMetaCache
+
+	globalTxsQueue    chan<- stypes.TxIn
+	globalErrataQueue chan<- stypes.ErrataBlock
 }
 
 // NewBlockScanner create a new instance of BlockScan
-func NewBlockScanner(cfg config.BlockScannerConfiguration, scanStorage blockscanner.ScannerStorage, chainID types.ChainID, client *ethclient.Client, m *metrics.Metrics) (*BlockScanner, error) {
+func NewBlockScanner(cfg config.BlockScannerConfiguration, scanStorage blockscanner.ScannerStorage, chainID types.ChainID, client *ethclient.Client, router ecommon.Address, m *metrics.Metrics) (*BlockScanner, error) {
 	if scanStorage == nil {
 		return nil, errors.New("scanStorage is nil")
 	}
@@ -62,9 +69,13 @@ func NewBlockScanner(cfg config.BlockScannerConfiguration, scanStorage blockscan
 		cfg:        cfg,
 		logger:     log.Logger.With().Str("module", "blockscanner").Str("chain", common.ETHChain.String()).Logger(),
 		db:         scanStorage,
+		m:          m,
 		errCounter: m.GetCounterVec(metrics.BlockScanError(common.ETHChain)),
 		client:     client,
 		gasPrice:   gasPrice,
+		tokens:     NewTokenMetaStore(),
+		router:     router,
+		blockMetas: NewBlockMetaCache(BlockCacheSize),
 		httpClient: &http.Client{
 			Timeout: cfg.HttpRequestTimeout,
 		},
@@ -145,8 +156,18 @@ func (e *BlockScanner) processBlock(block blockscanner.Block) (stypes.TxIn, erro
 }
 
 func (e *BlockScanner) FetchTxs(height int64) (stypes.TxIn, error) {
-	rawTxs, err := e.getRPCBlock(height)
+	rpcBlock, err := e.getRPCBlock(height)
+	if err != nil {
+		return stypes.TxIn{}, err
+	}
+	// the block meta of this height will be overwritten once the block is processed, thus a failed re-org has to be retried now
+	if err := e.processReorg(rpcBlock); err != nil {
+		e.logger.Error().Err(err).Int64("height", height).Msg("fail to process ethereum re-org")
+		return stypes.TxIn{}, fmt.Errorf("fail to process ethereum re-org: %w", err)
+	}
+	rawTxs, err := e.getTransactionsFromBlock(rpcBlock)
 	if err != nil {
+		e.errCounter.WithLabelValues("fail_to_get_txs", e.cfg.RPCHost).Inc()
 		return stypes.TxIn{}, err
 	}
 
@@ -162,6 +183,7 @@ func (e *BlockScanner) FetchTxs(height int64) (stypes.TxIn, error) {
 		// THORNode will have a retry go routine to check it.
 		return txIn, err
 	}
+	e.blockMetas.Set(newBlockMetaFromTxIn(rpcBlock, txIn))
 	// set a block as success
 	if err := e.db.RemoveBlockStatus(block.Height); err != nil {
 		e.errCounter.WithLabelValues("fail_remove_block_status", "").Inc()
@@ -170,7 +192,7 @@ func (e *BlockScanner) FetchTxs(height int64) (stypes.TxIn, error) {
 	return txIn, nil
 }
 
-func (e *BlockScanner) getRPCBlock(height int64) ([]string, error) {
+func (e *BlockScanner) getRPCBlock(height int64) (*etypes.Block, error) {
 	block, err := e.client.BlockByNumber(context.Background(), big.NewInt(height))
 	if err == ethereum.NotFound {
 		return nil, btypes.UnavailableBlock
@@ -179,11 +201,107 @@ func (e *BlockScanner) getRPCBlock(height int64) ([]string, error) {
 		e.logger.Error().Err(err).Int64("block", height).Msg("fail to fetch block")
 		return nil, err
 	}
+	return block, nil
+}
+
+// newBlockMetaFromTxIn create a block meta of the given block, which record all the txs THORNode observed in it
+func newBlockMetaFromTxIn(block *etypes.Block, txIn stypes.TxIn) *BlockMeta {
+	blockMeta := NewBlockMeta(block.ParentHash().Hex(), block.Number().Int64(), block.Hash().Hex())
+	for _, item := range txIn.TxArray {
+		blockMeta.Transactions = append(blockMeta.Transactions, item.Tx)
+	}
+	return blockMeta
+}
+
+// processReorg compare the parent hash of the given block with the block hash THORNode recorded at the previous height
+// when they are different, a re-org happened, THORNode walk back to find all the orphaned blocks and re-scan them
+func (e *BlockScanner) processReorg(block *etypes.Block) error {
+	previousHeight := block.Number().Int64() - 1
+	prevBlockMeta := e.blockMetas.Get(previousHeight)
+	if prevBlockMeta == nil {
+		return nil
+	}
+	// blockMetas[PreviousHeight].BlockHash == Block.ParentHash
+	if strings.EqualFold(prevBlockMeta.BlockHash, block.ParentHash().Hex()) {
+		return nil
+	}
+	e.logger.Info().Msgf("re-org detected, current block height:%d ,previous block hash is : %s , however block meta at height: %d, block hash is %s", block.Number().Int64(), block.ParentHash().Hex(), prevBlockMeta.Height, prevBlockMeta.BlockHash)
+
+	// walk back until THORNode find a block that is still on the canonical chain, or run out of block metas
+	var orphaned []*BlockMeta
+	var canonical []*etypes.Block
+	for height := previousHeight; height >= 0; height-- {
+		blockMeta := e.blockMetas.Get(height)
+		if blockMeta == nil {
+			break
+		}
+		rpcBlock, err := e.getRPCBlock(height)
+		if err != nil {
+			return fmt.Errorf("fail to get block at height(%d): %w", height, err)
+		}
+		if strings.EqualFold(blockMeta.BlockHash, rpcBlock.Hash().Hex()) {
+			break
+		}
+		orphaned = append(orphaned, blockMeta)
+		canonical = append(canonical, rpcBlock)
+	}
+	// re-scan from the oldest orphaned block, thus txs will be observed in the same order as they are on chain
+	for i := len(orphaned) - 1; i >= 0; i-- {
+		if err := e.reScanBlock(orphaned[i], canonical[i]); err != nil {
+			return fmt.Errorf("fail to re-scan block at height(%d): %w", orphaned[i].Height, err)
+		}
+	}
+	return nil
+}
+
+// reScanBlock replace the given orphaned block with the block currently on chain at the same height
+// txs THORNode observed in the orphaned block but don't exist in the new block will be sent to THORChain as errata,
+// txs only exist in the new block will be observed
+func (e *BlockScanner) reScanBlock(orphaned *BlockMeta, block *etypes.Block) error {
 	rawTxs, err := e.getTransactionsFromBlock(block)
 	if err != nil {
-		e.errCounter.WithLabelValues("fail_to_get_txs", e.cfg.RPCHost).Inc()
+		return err
+	}
+	txIn, err := e.processBlock(blockscanner.Block{Height: orphaned.Height, Txs: rawTxs})
+	if err != nil {
+		return fmt.Errorf("fail to process block: %w", err)
+	}
+	if err := e.db.RemoveBlockStatus(orphaned.Height); err != nil {
+		e.logger.Error().Err(err).Int64("block", orphaned.Height).Msg("fail to remove block status from data store")
+	}
+	blockMeta := newBlockMetaFromTxIn(block, txIn)
+
+	var errataTxs []stypes.ErrataTx
+	for _, tx := range orphaned.Transactions {
+		if blockMeta.HasTransaction(tx) {
+			continue
+		}
+		e.logger.Info().Int64("height", orphaned.Height).Str("tx", tx).Msg("tx doesn't exist on chain anymore")
+		errataTxs = append(errataTxs, stypes.ErrataTx{
+			TxID:  common.TxID(tx),
+			Chain: common.ETHChain,
+		})
 	}
-	return rawTxs, err
+	if len(errataTxs) > 0 {
+		e.globalErrataQueue <- stypes.ErrataBlock{
+			Height: orphaned.Height,
+			Txs:    errataTxs,
+		}
+	}
+
+	var newTxs []stypes.TxInItem
+	for _, item := range txIn.TxArray {
+		if !orphaned.HasTransaction(item.Tx) {
+			newTxs = append(newTxs, item)
+		}
+	}
+	if len(newTxs) > 0 {
+		txIn.TxArray = newTxs
+		txIn.Count = strconv.Itoa(len(newTxs))
+		e.globalTxsQueue <- txIn
+	}
+	e.blockMetas.Set(blockMeta)
+	return nil
 }
 
 func (e *BlockScanner) getTransactionsFromBlock(block *etypes.Block) ([]string, error) {
@@ -207,16 +325,27 @@ func (e *BlockScanner) fromTxToTxIn(encodedTx string) (*stypes.TxInItem, error)
 		return nil, err
 	}
 
+	sender, err := eipSigner.Sender(tx)
+	if err != nil {
+		return nil, err
+	}
+	if e.isRouter(tx.To()) {
+		return e.fromRouterTxToTxIn(tx, sender)
+	}
+	if tx.To() != nil && isERC20Transfer(tx.Data()) {
+		// with a router, vaults can only spend the tokens deposited through it, tokens sent straight to a vault can't
+		// be sent out again, so they are not observed
+		if e.router != (ecommon.Address{}) {
+			return nil, nil
+		}
+		return e.fromERC20TxToTxIn(tx)
+	}
+
 	txInItem := &stypes.TxInItem{
 		Tx: tx.Hash().Hex()[2:],
 	}
 	// tx data field bytes should be hex encoded byres string as outboud or yggradsil- or migrate or yggdrasil+, etc
 	txInItem.Memo = string(tx.Data())
-
-	sender, err := eipSigner.Sender(tx)
-	if err != nil {
-		return nil, err
-	}
 	txInItem.Sender = strings.ToLower(sender.String())
 	if tx.To() == nil {
 		return nil, err
@@ -234,3 +363,33 @@ func (e *BlockScanner) fromTxToTxIn(encodedTx string) (*stypes.TxInItem, error)
 
 	return txInItem, nil
 }
+
+// fromERC20TxToTxIn parse the ERC-20 Transfer events of the given tx from its receipt
+func (e *BlockScanner) fromERC20TxToTxIn(tx *etypes.Transaction) (*stypes.TxInItem, error) {
+	receipt, err := e.client.TransactionReceipt(context.Background(), tx.Hash())
+	if err != nil {
+		return nil, fmt.Errorf("fail to get receipt of tx(%s): %w", tx.Hash().Hex(), err)
+	}
+	// a failed tx didn't transfer any token
+	if receipt.Status != etypes.ReceiptStatusSuccessful {
+		return nil, nil
+	}
+	from, to, coins, err := e.getTokenTransfers(receipt)
+	if err != nil {
+		return nil, fmt.Errorf("fail to get token transfers of tx(%s): %w", tx.Hash().Hex(), err)
+	}
+	if len(coins) == 0 {
+		return nil, nil
+	}
+	gasFee := new(big.Int).Mul(tx.GasPrice(), new(big.Int).SetUint64(receipt.GasUsed))
+	return &stypes.TxInItem{
+		Tx:     tx.Hash().Hex()[2:],
+		Memo:   getERC20Memo(tx.Data()),
+		Sender: strings.ToLower(from.String()),
+		To:     strings.ToLower(to.String()),
+		Coins:  coins,
+		Gas: common.Gas{
+			common.NewCoin(common.ETHAsset, sdk.NewUintFromBigInt(gasFee)),
+		},
+	}, nil
+}
@@ -2,20 +2,31 @@ package ethereum
 
 import (
 	"encoding/json"
+	"fmt"
 	"io/ioutil"
+	"math/big"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	ecommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	etypes "github.com/ethereum/go-ethereum/core/types"
+	ecrypto "github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/ethclient"
 	. "gopkg.in/check.v1"
 
 	"gitlab.com/thorchain/thornode/bifrost/blockscanner"
+	btypes "gitlab.com/thorchain/thornode/bifrost/blockscanner/types"
 	"gitlab.com/thorchain/thornode/bifrost/config"
 	"gitlab.com/thorchain/thornode/bifrost/metrics"
 	"gitlab.com/thorchain/thornode/bifrost/pkg/chainclients/ethereum/types"
+	"gitlab.com/thorchain/thornode/bifrost/pkg/mockchain"
+	stypes "gitlab.com/thorchain/thornode/bifrost/thorclient/types"
+	"gitlab.com/thorchain/thornode/common"
 )
 
 func Test(t *testing.T) { TestingT(t) }
@@ -52,19 +63,19 @@ func (s *BlockScannerTestSuite) TestNewBlockScanner(c *C) {
 	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {}))
 	ethClient, err := ethclient.Dial(server.URL)
 	c.Assert(err, IsNil)
-	bs, err := NewBlockScanner(getConfigForTest(""), storage, types.Mainnet, ethClient, s.m)
+	bs, err := NewBlockScanner(getConfigForTest(""), storage, types.Mainnet, ethClient, ecommon.Address{}, s.m)
 	c.Assert(err, NotNil)
 	c.Assert(bs, IsNil)
-	bs, err = NewBlockScanner(getConfigForTest("127.0.0.1"), storage, types.Mainnet, ethClient, s.m)
+	bs, err = NewBlockScanner(getConfigForTest("127.0.0.1"), storage, types.Mainnet, ethClient, ecommon.Address{}, s.m)
 	c.Assert(err, NotNil)
 	c.Assert(bs, IsNil)
-	bs, err = NewBlockScanner(getConfigForTest("127.0.0.1"), storage, types.Mainnet, nil, s.m)
+	bs, err = NewBlockScanner(getConfigForTest("127.0.0.1"), storage, types.Mainnet, nil, ecommon.Address{}, s.m)
 	c.Assert(err, NotNil)
 	c.Assert(bs, IsNil)
-	bs, err = NewBlockScanner(getConfigForTest("127.0.0.1"), storage, types.Mainnet, ethClient, s.m)
+	bs, err = NewBlockScanner(getConfigForTest("127.0.0.1"), storage, types.Mainnet, ethClient, ecommon.Address{}, s.m)
 	c.Assert(err, NotNil)
 	c.Assert(bs, IsNil)
-	bs, err = NewBlockScanner(getConfigForTest("127.0.0.1"), storage, types.Mainnet, ethClient, s.m)
+	bs, err = NewBlockScanner(getConfigForTest("127.0.0.1"), storage, types.Mainnet, ethClient, ecommon.Address{}, s.m)
 	c.Assert(err, IsNil)
 	c.Assert(bs, NotNil)
 }
@@ -133,7 +144,7 @@ func (s *BlockScannerTestSuite) TestProcessBlock(c *C) {
 	ethClient, err := ethclient.Dial(server.URL)
 	c.Assert(err, IsNil)
 	c.Assert(ethClient, NotNil)
-	bs, err := NewBlockScanner(getConfigForTest(server.URL), blockscanner.NewMockScannerStorage(), types.Mainnet, ethClient, s.m)
+	bs, err := NewBlockScanner(getConfigForTest(server.URL), blockscanner.NewMockScannerStorage(), types.Mainnet, ethClient, ecommon.Address{}, s.m)
 	c.Assert(err, IsNil)
 	c.Assert(bs, NotNil)
 	txIn, err := bs.FetchTxs(int64(1))
@@ -181,7 +192,7 @@ func (s *BlockScannerTestSuite) TestFromTxToTxIn(c *C) {
 	ethClient, err := ethclient.Dial(server.URL)
 	c.Assert(err, IsNil)
 	c.Assert(ethClient, NotNil)
-	bs, err := NewBlockScanner(getConfigForTest(server.URL), blockscanner.NewMockScannerStorage(), types.Mainnet, ethClient, s.m)
+	bs, err := NewBlockScanner(getConfigForTest(server.URL), blockscanner.NewMockScannerStorage(), types.Mainnet, ethClient, ecommon.Address{}, s.m)
 	c.Assert(err, IsNil)
 	c.Assert(bs, NotNil)
 	encodedTx := `{
@@ -219,3 +230,145 @@ func (s *BlockScannerTestSuite) TestFromTxToTxIn(c *C) {
 		true,
 	)
 }
+
+func (s *BlockScannerTestSuite) TestProcessReorg(c *C) {
+	newHeader := func(parent ecommon.Hash, number int64, extra string) *etypes.Header {
+		return &etypes.Header{
+			ParentHash: parent,
+			UncleHash:  etypes.EmptyUncleHash,
+			TxHash:     etypes.EmptyRootHash,
+			Number:     big.NewInt(number),
+			Difficulty: big.NewInt(1),
+			Extra:      []byte(extra),
+		}
+	}
+	genesis := newHeader(ecommon.Hash{}, 0, "")
+	orphaned := newHeader(genesis.Hash(), 1, "orphaned")
+	canonical := newHeader(genesis.Hash(), 1, "canonical")
+	headers := []*etypes.Header{
+		genesis,
+		canonical,
+		newHeader(canonical.Hash(), 2, "canonical"),
+	}
+	rescanFailed := true
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		body, err := ioutil.ReadAll(req.Body)
+		c.Assert(err, IsNil)
+		var rpcRequest struct {
+			Method string        `json:"method"`
+			Params []interface{} `json:"params"`
+		}
+		c.Assert(json.Unmarshal(body, &rpcRequest), IsNil)
+		switch rpcRequest.Method {
+		case "eth_gasPrice":
+			_, err = rw.Write([]byte(`{"jsonrpc":"2.0","id":1,"result":"0x1"}`))
+		case "eth_getBlockByNumber":
+			height, err := hexutil.DecodeUint64(rpcRequest.Params[0].(string))
+			c.Assert(err, IsNil)
+			if height == 1 && rescanFailed {
+				rescanFailed = false
+				_, err = rw.Write([]byte(`{"jsonrpc":"2.0","id":1,"error":{"code":-32000,"message":"unavailable"}}`))
+				c.Assert(err, IsNil)
+				return
+			}
+			buf, err := json.Marshal(headers[height])
+			c.Assert(err, IsNil)
+			var block map[string]interface{}
+			c.Assert(json.Unmarshal(buf, &block), IsNil)
+			block["transactions"] = []interface{}{}
+			block["uncles"] = []interface{}{}
+			buf, err = json.Marshal(block)
+			c.Assert(err, IsNil)
+			_, err = rw.Write([]byte(fmt.Sprintf(`{"jsonrpc":"2.0","id":1,"result":%s}`, buf)))
+		}
+		c.Assert(err, IsNil)
+	}))
+	defer server.Close()
+	ethClient, err := ethclient.Dial(server.URL)
+	c.Assert(err, IsNil)
+	bs, err := NewBlockScanner(getConfigForTest(server.URL), blockscanner.NewMockScannerStorage(), types.Mainnet, ethClient, ecommon.Address{}, s.m)
+	c.Assert(err, IsNil)
+	errataQueue := make(chan stypes.ErrataBlock, 1)
+	bs.globalErrataQueue = errataQueue
+
+	// THORNode observed a tx in block 1, but block 1 then got replaced by another block without the tx
+	txID := "88df016429689c079f3b2f6ad39fa052532c56795b733da78a91ebe6a713944b"
+	bs.blockMetas.Set(NewBlockMeta(genesis.Hash().Hex(), 0, genesis.Hash().Hex()))
+	blockMeta := NewBlockMeta(genesis.Hash().Hex(), 1, orphaned.Hash().Hex())
+	blockMeta.Transactions = []string{txID}
+	bs.blockMetas.Set(blockMeta)
+
+	// the orphaned block can't be re-scanned, the block should be retried rather than recorded
+	_, err = bs.FetchTxs(2)
+	c.Assert(err, NotNil)
+	c.Check(errataQueue, HasLen, 0)
+	c.Check(bs.blockMetas.Get(2), IsNil)
+
+	_, err = bs.FetchTxs(2)
+	c.Assert(err, IsNil)
+	c.Assert(errataQueue, HasLen, 1)
+	errataBlock := <-errataQueue
+	c.Check(errataBlock.Height, Equals, int64(1))
+	c.Assert(errataBlock.Txs, HasLen, 1)
+	c.Check(errataBlock.Txs[0].TxID.String(), Equals, txID)
+	c.Check(errataBlock.Txs[0].Chain, Equals, common.ETHChain)
+
+	// block meta should be updated to the canonical block
+	blockMeta = bs.blockMetas.Get(1)
+	c.Assert(blockMeta, NotNil)
+	c.Check(blockMeta.BlockHash, Equals, canonical.Hash().Hex())
+	c.Check(blockMeta.Transactions, HasLen, 0)
+	c.Assert(bs.blockMetas.Get(2), NotNil)
+
+	// no re-org, nothing should be sent to errata queue
+	_, err = bs.FetchTxs(2)
+	c.Assert(err, IsNil)
+	c.Check(errataQueue, HasLen, 0)
+}
+
+func (s *BlockScannerTestSuite) TestFetchTxsFromMockChain(c *C) {
+	chain, err := mockchain.NewEthereum(big.NewInt(int64(types.Mainnet)))
+	c.Assert(err, IsNil)
+	defer chain.Close()
+
+	privKey, err := ecrypto.GenerateKey()
+	c.Assert(err, IsNil)
+	sender := ecrypto.PubkeyToAddress(privKey.PublicKey)
+	vault := ecommon.HexToAddress("0x3fd2d4ce97b082d4bce3f9fee2a3d60668d2f473")
+	tx, err := etypes.SignTx(etypes.NewTransaction(0, vault, big.NewInt(1000), 21000, big.NewInt(1), []byte("SWAP:BNB.BNB")), etypes.NewEIP155Signer(big.NewInt(int64(types.Mainnet))), privKey)
+	c.Assert(err, IsNil)
+	c.Assert(chain.AddTx(tx), IsNil)
+	c.Assert(chain.Ledger().MineBlocks(2), IsNil)
+
+	ethClient, err := ethclient.Dial(chain.URL())
+	c.Assert(err, IsNil)
+	bs, err := NewBlockScanner(getConfigForTest(chain.URL()), blockscanner.NewMockScannerStorage(), types.Mainnet, ethClient, ecommon.Address{}, s.m)
+	c.Assert(err, IsNil)
+	errataQueue := make(chan stypes.ErrataBlock, 1)
+	bs.globalErrataQueue = errataQueue
+
+	txIn, err := bs.FetchTxs(1)
+	c.Assert(err, IsNil)
+	c.Assert(txIn.TxArray, HasLen, 1)
+	c.Check(txIn.TxArray[0].Tx, Equals, tx.Hash().Hex()[2:])
+	c.Check(txIn.TxArray[0].Sender, Equals, strings.ToLower(sender.Hex()))
+	c.Check(txIn.TxArray[0].To, Equals, strings.ToLower(vault.Hex()))
+	c.Check(txIn.TxArray[0].Memo, Equals, "SWAP:BNB.BNB")
+	c.Check(txIn.TxArray[0].Coins[0].Amount.Uint64(), Equals, uint64(1000))
+	_, err = bs.FetchTxs(2)
+	c.Assert(err, IsNil)
+	_, err = bs.FetchTxs(3)
+	c.Check(err, Equals, btypes.UnavailableBlock)
+
+	// the tx get dropped by a re-org, THORNode should send errata when it scan the new fork
+	c.Assert(chain.Ledger().Reorg(2, tx.Hash().Hex()), IsNil)
+	c.Assert(chain.Ledger().MineBlocks(1), IsNil)
+	txIn, err = bs.FetchTxs(3)
+	c.Assert(err, IsNil)
+	c.Check(txIn.TxArray, HasLen, 0)
+	c.Assert(errataQueue, HasLen, 1)
+	errataBlock := <-errataQueue
+	c.Check(errataBlock.Height, Equals, int64(1))
+	c.Assert(errataBlock.Txs, HasLen, 1)
+	c.Check(errataBlock.Txs[0].TxID.String(), Equals, tx.Hash().Hex()[2:])
+}
@@ -88,6 +88,9 @@ func (s *EthereumSuite) SetUpSuite(c *C) {
 			}
 			`))
 			c.Assert(err, IsNil)
+		} else if strings.HasPrefix(req.RequestURI, thorclient.PoolsEndpoint) {
+			_, err := rw.Write([]byte(`[{"balance_rune":"1000000000","balance_asset":"1000000000","asset":"ETH.ETH","pool_units":"1000000000","status":"Enabled"}]`))
+			c.Assert(err, IsNil)
 		} else if strings.HasSuffix(req.RequestURI, "/signers") {
 			_, err := rw.Write([]byte(`[
 				"thorpub1addwnpepqflvfv08t6qt95lmttd6wpf3ss8wx63e9vf6fvyuj2yy6nnyna5763e2kck",
@@ -0,0 +1,154 @@
+package ethereum
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strings"
+	"sync"
+
+	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/util"
+
+	stypes "gitlab.com/thorchain/thornode/bifrost/thorclient/types"
+)
+
+// prefix used in leveldb to avoid conflicts with the block scanner
+const (
+	PrefixNonce     = `nonce-`
+	PrefixPendingTx = `pendingtx-`
+)
+
+// PendingTx is an outbound tx that has been broadcast, but THORNode haven't seen it get mined yet
+type PendingTx struct {
+	Hash            string           `json:"hash"`
+	From            string           `json:"from"`
+	Nonce           uint64           `json:"nonce"`
+	GasPrice        *big.Int         `json:"gas_price"`
+	BroadcastHeight int64            `json:"broadcast_height"`
+	TxOutItem       stypes.TxOutItem `json:"tx_out_item"`
+	// Replacements are the hashes of all the txs that replaced the original one, the latest one is at the end
+	Replacements []string `json:"replacements"`
+}
+
+// Hashes return the hash of the original tx and all its replacements, any of them could be the one that get mined
+func (tx PendingTx) Hashes() []string {
+	return append([]string{tx.Hash}, tx.Replacements...)
+}
+
+// NonceManager keeps track of the next nonce of each vault address, and the outbound txs that are waiting to be mined
+// it is backed by leveldb, so the state survives bifrost restart
+type NonceManager struct {
+	lock *sync.Mutex
+	db   *leveldb.DB
+}
+
+// NewNonceManager create a new instance of NonceManager
+func NewNonceManager(db *leveldb.DB) *NonceManager {
+	return &NonceManager{
+		lock: &sync.Mutex{},
+		db:   db,
+	}
+}
+
+func (n *NonceManager) getNonceKey(addr string) string {
+	return PrefixNonce + strings.ToLower(addr)
+}
+
+func (n *NonceManager) getPendingTxKey(addr string, nonce uint64) string {
+	// nonce is zero padded, thus pending txs are iterated in nonce order
+	return fmt.Sprintf("%s%s-%020d", PrefixPendingTx, strings.ToLower(addr), nonce)
+}
+
+// GetNonce return the next nonce THORNode should use for the given address, it will return 0 when the address doesn't have any record
+func (n *NonceManager) GetNonce(addr string) (uint64, error) {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	return n.getNonce(addr)
+}
+
+func (n *NonceManager) getNonce(addr string) (uint64, error) {
+	buf, err := n.db.Get([]byte(n.getNonceKey(addr)), nil)
+	if err == leveldb.ErrNotFound {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("fail to get nonce of %s from storage: %w", addr, err)
+	}
+	var nonce uint64
+	if err := json.Unmarshal(buf, &nonce); err != nil {
+		return 0, fmt.Errorf("fail to unmarshal nonce: %w", err)
+	}
+	return nonce, nil
+}
+
+// SetNonce save the next nonce of the given address, a nonce lower than the one in storage will be ignored
+func (n *NonceManager) SetNonce(addr string, nonce uint64) error {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	current, err := n.getNonce(addr)
+	if err != nil {
+		return err
+	}
+	if nonce <= current {
+		return nil
+	}
+	buf, err := json.Marshal(nonce)
+	if err != nil {
+		return fmt.Errorf("fail to marshal nonce: %w", err)
+	}
+	return n.db.Put([]byte(n.getNonceKey(addr)), buf, nil)
+}
+
+// SetPendingTx save the given pending tx, it will overwrite the existing record with the same address and nonce
+func (n *NonceManager) SetPendingTx(tx PendingTx) error {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	buf, err := json.Marshal(tx)
+	if err != nil {
+		return fmt.Errorf("fail to marshal pending tx: %w", err)
+	}
+	return n.db.Put([]byte(n.getPendingTxKey(tx.From, tx.Nonce)), buf, nil)
+}
+
+// GetPendingTx return the pending tx of the given address and nonce, it return nil when it doesn't exist
+func (n *NonceManager) GetPendingTx(addr string, nonce uint64) (*PendingTx, error) {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	buf, err := n.db.Get([]byte(n.getPendingTxKey(addr, nonce)), nil)
+	if err == leveldb.ErrNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("fail to get pending tx from storage: %w", err)
+	}
+	var tx PendingTx
+	if err := json.Unmarshal(buf, &tx); err != nil {
+		return nil, fmt.Errorf("fail to unmarshal pending tx: %w", err)
+	}
+	return &tx, nil
+}
+
+// GetPendingTxs return all the pending txs in storage
+func (n *NonceManager) GetPendingTxs() ([]PendingTx, error) {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	txs := make([]PendingTx, 0)
+	iterator := n.db.NewIterator(util.BytesPrefix([]byte(PrefixPendingTx)), nil)
+	defer iterator.Release()
+	for iterator.Next() {
+		var tx PendingTx
+		if err := json.Unmarshal(iterator.Value(), &tx); err != nil {
+			return nil, fmt.Errorf("fail to unmarshal pending tx: %w", err)
+		}
+		txs = append(txs, tx)
+	}
+	return txs, nil
+}
+
+// RemovePendingTx delete the pending tx of the given address and nonce
+func (n *NonceManager) RemovePendingTx(addr string, nonce uint64) error {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	return n.db.Delete([]byte(n.getPendingTxKey(addr, nonce)), nil)
+}
@@ -0,0 +1,95 @@
+package ethereum
+
+import (
+	"math/big"
+
+	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/storage"
+	. "gopkg.in/check.v1"
+
+	stypes "gitlab.com/thorchain/thornode/bifrost/thorclient/types"
+	"gitlab.com/thorchain/thornode/common"
+)
+
+type NonceManagerSuite struct{}
+
+var _ = Suite(&NonceManagerSuite{})
+
+func (s *NonceManagerSuite) TestNonce(c *C) {
+	db, err := leveldb.Open(storage.NewMemStorage(), nil)
+	c.Assert(err, IsNil)
+	nonces := NewNonceManager(db)
+	addr := "0xF02C1C8E6114B1DBE8937A39260B5B0A374432BB"
+
+	nonce, err := nonces.GetNonce(addr)
+	c.Assert(err, IsNil)
+	c.Check(nonce, Equals, uint64(0))
+
+	c.Assert(nonces.SetNonce(addr, 5), IsNil)
+	nonce, err = nonces.GetNonce(addr)
+	c.Assert(err, IsNil)
+	c.Check(nonce, Equals, uint64(5))
+
+	// address is case insensitive
+	nonce, err = nonces.GetNonce("0xf02c1c8e6114b1dbe8937a39260b5b0a374432bb")
+	c.Assert(err, IsNil)
+	c.Check(nonce, Equals, uint64(5))
+
+	// nonce should never go backward
+	c.Assert(nonces.SetNonce(addr, 3), IsNil)
+	nonce, err = nonces.GetNonce(addr)
+	c.Assert(err, IsNil)
+	c.Check(nonce, Equals, uint64(5))
+}
+
+func (s *NonceManagerSuite) TestPendingTx(c *C) {
+	db, err := leveldb.Open(storage.NewMemStorage(), nil)
+	c.Assert(err, IsNil)
+	nonces := NewNonceManager(db)
+	addr := "0xf02c1c8e6114b1dbe8937a39260b5b0a374432bb"
+
+	pendingTx, err := nonces.GetPendingTx(addr, 1)
+	c.Assert(err, IsNil)
+	c.Check(pendingTx, IsNil)
+
+	item := stypes.TxOutItem{
+		Chain:       common.ETHChain,
+		ToAddress:   common.Address("0xde0b295669a9fd93d5f28d9ec85e40f4cb697bae"),
+		VaultPubKey: common.PubKey("thorpub1addwnpepq2jgpsw2lalzuk7sgtmyakj7l6890f5cfpwjyfp8k4y4t7cw2vk8vcglsjy"),
+		Memo:        "OUTBOUND:88DF016429689C079F3B2F6AD39FA052532C56795B733DA78A91EBE6A713944B",
+	}
+	for _, nonce := range []uint64{10, 2, 1} {
+		c.Assert(nonces.SetPendingTx(PendingTx{
+			Hash:            "0x88df016429689c079f3b2f6ad39fa052532c56795b733da78a91ebe6a713944b",
+			From:            addr,
+			Nonce:           nonce,
+			GasPrice:        big.NewInt(100),
+			BroadcastHeight: 1024,
+			TxOutItem:       item,
+		}), IsNil)
+	}
+	pendingTxs, err := nonces.GetPendingTxs()
+	c.Assert(err, IsNil)
+	c.Assert(pendingTxs, HasLen, 3)
+	c.Check(pendingTxs[0].Nonce, Equals, uint64(1))
+	c.Check(pendingTxs[1].Nonce, Equals, uint64(2))
+	c.Check(pendingTxs[2].Nonce, Equals, uint64(10))
+
+	pendingTx, err = nonces.GetPendingTx(addr, 2)
+	c.Assert(err, IsNil)
+	c.Assert(pendingTx, NotNil)
+	c.Check(pendingTx.GasPrice.Int64(), Equals, int64(100))
+	c.Check(pendingTx.TxOutItem.Equals(item), Equals, true)
+
+	pendingTx.Replacements = append(pendingTx.Replacements, "0x78bfef68fccd4507f9f4804ba5c65eb2f928ea45b3383ade88aaa720f1209cba")
+	c.Assert(nonces.SetPendingTx(*pendingTx), IsNil)
+	pendingTx, err = nonces.GetPendingTx(addr, 2)
+	c.Assert(err, IsNil)
+	c.Check(pendingTx.Replacements, HasLen, 1)
+	c.Check(pendingTx.Hashes(), DeepEquals, []string{pendingTx.Hash, "0x78bfef68fccd4507f9f4804ba5c65eb2f928ea45b3383ade88aaa720f1209cba"})
+
+	c.Assert(nonces.RemovePendingTx(addr, 2), IsNil)
+	pendingTxs, err = nonces.GetPendingTxs()
+	c.Assert(err, IsNil)
+	c.Assert(pendingTxs, HasLen, 2)
+}
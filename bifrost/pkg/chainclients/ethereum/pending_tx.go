@@ -0,0 +1,178 @@
+package ethereum
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	ecommon "github.com/ethereum/go-ethereum/common"
+	etypes "github.com/ethereum/go-ethereum/core/types"
+
+	stypes "gitlab.com/thorchain/thornode/bifrost/thorclient/types"
+)
+
+const (
+	// DefaultStuckTxBlocks is the number of blocks an outbound can stay in mempool before it get replaced, when it is not configured
+	DefaultStuckTxBlocks = 20
+	// GasPriceBumpPercent is how much gas price will be increased every time a stuck tx get replaced, Ethereum nodes require at least 10% to accept a replacement
+	GasPriceBumpPercent = 10
+	// PendingTxCheckInterval is how often THORNode check whether pending txs get stuck
+	PendingTxCheckInterval = 15 * time.Second
+)
+
+// bumpGasPrice increase the given gas price by GasPriceBumpPercent
+// it only depends on the gas price of the previous tx, thus all the members of a vault will come up with the same replacement tx
+func bumpGasPrice(gasPrice *big.Int) *big.Int {
+	bumped := new(big.Int).Mul(gasPrice, big.NewInt(100+GasPriceBumpPercent))
+	bumped.Div(bumped, big.NewInt(100))
+	if bumped.Cmp(gasPrice) <= 0 {
+		bumped = new(big.Int).Add(gasPrice, big.NewInt(1))
+	}
+	return bumped
+}
+
+func (c *Client) getStuckTxBlocks() int64 {
+	if c.cfg.StuckTxBlocks > 0 {
+		return c.cfg.StuckTxBlocks
+	}
+	return DefaultStuckTxBlocks
+}
+
+// addPendingTx record the given outbound tx which has just been broadcast, and move the nonce of the vault forward
+func (c *Client) addPendingTx(item stypes.TxOutItem, tx *etypes.Transaction) error {
+	from, err := eipSigner.Sender(tx)
+	if err != nil {
+		return fmt.Errorf("fail to get sender of tx: %w", err)
+	}
+	height, err := c.GetHeight()
+	if err != nil {
+		return fmt.Errorf("fail to get current Ethereum block height: %w", err)
+	}
+	addr := strings.ToLower(from.String())
+	if err := c.nonces.SetNonce(addr, tx.Nonce()+1); err != nil {
+		return fmt.Errorf("fail to save nonce: %w", err)
+	}
+	return c.nonces.SetPendingTx(PendingTx{
+		Hash:            tx.Hash().Hex(),
+		From:            addr,
+		Nonce:           tx.Nonce(),
+		GasPrice:        tx.GasPrice(),
+		BroadcastHeight: height,
+		TxOutItem:       item,
+	})
+}
+
+// processPendingTxs check pending txs periodically until the client get stopped
+func (c *Client) processPendingTxs() {
+	c.logger.Info().Msg("start to process pending txs")
+	defer c.logger.Info().Msg("stop to process pending txs")
+	defer c.wg.Done()
+	for {
+		select {
+		case <-c.stopchan:
+			return
+		case <-time.After(PendingTxCheckInterval):
+			if err := c.checkPendingTxs(); err != nil {
+				c.logger.Error().Err(err).Msg("fail to check pending txs")
+			}
+		}
+	}
+}
+
+// checkPendingTxs remove the pending txs that have been mined, and replace those that have been stuck for too long
+func (c *Client) checkPendingTxs() error {
+	pendingTxs, err := c.nonces.GetPendingTxs()
+	if err != nil {
+		return fmt.Errorf("fail to get pending txs: %w", err)
+	}
+	if len(pendingTxs) == 0 {
+		return nil
+	}
+	height, err := c.GetHeight()
+	if err != nil {
+		return fmt.Errorf("fail to get current Ethereum block height: %w", err)
+	}
+	for _, pendingTx := range pendingTxs {
+		// nonce of the latest block only count the mined txs, thus either the original tx or one of the replacements has been mined
+		nonce, err := c.GetNonce(pendingTx.From)
+		if err != nil {
+			return err
+		}
+		if nonce > pendingTx.Nonce {
+			c.matchMinedTx(pendingTx)
+			if err := c.nonces.RemovePendingTx(pendingTx.From, pendingTx.Nonce); err != nil {
+				return fmt.Errorf("fail to remove pending tx: %w", err)
+			}
+			continue
+		}
+		if height-pendingTx.BroadcastHeight < c.getStuckTxBlocks() {
+			continue
+		}
+		if err := c.replaceTx(pendingTx, height); err != nil {
+			c.logger.Error().Err(err).Str("hash", pendingTx.Hash).Uint64("nonce", pendingTx.Nonce).Msg("fail to replace stuck tx")
+		}
+	}
+	return nil
+}
+
+// matchMinedTx find which one of the original tx and its replacements has been mined, and match it to the tx out item they
+// were all signed for, THORChain will observe it with the same memo whichever one it is
+func (c *Client) matchMinedTx(pendingTx PendingTx) {
+	for _, hash := range pendingTx.Hashes() {
+		receipt, err := c.client.TransactionReceipt(context.Background(), ecommon.HexToHash(hash))
+		if err != nil || receipt == nil {
+			continue
+		}
+		c.logger.Info().
+			Str("hash", hash).
+			Str("original", pendingTx.Hash).
+			Str("in_hash", pendingTx.TxOutItem.InHash.String()).
+			Uint64("nonce", pendingTx.Nonce).
+			Msg("outbound mined")
+		return
+	}
+	// the nonce has been taken by a tx this vault didn't sign for the outbound
+	c.logger.Error().
+		Strs("hashes", pendingTx.Hashes()).
+		Str("in_hash", pendingTx.TxOutItem.InHash.String()).
+		Uint64("nonce", pendingTx.Nonce).
+		Msg("none of the outbound txs got mined")
+}
+
+// replaceTx re-sign the given pending tx with the same nonce and a higher gas price, the replacement is recorded against the original
+// tx out item, thus whichever tx get mined, it will be matched to the same outbound
+func (c *Client) replaceTx(pendingTx PendingTx, height int64) error {
+	gasPrice := bumpGasPrice(pendingTx.GasPrice)
+	tx, err := c.buildTx(pendingTx.TxOutItem, pendingTx.From, pendingTx.Nonce, gasPrice)
+	if err != nil {
+		return fmt.Errorf("fail to build replacement tx: %w", err)
+	}
+	thorchainHeight, err := c.thorchainBridge.GetBlockHeight()
+	if err != nil {
+		return fmt.Errorf("fail to get THORChain block height: %w", err)
+	}
+	rawTx, err := c.sign(tx, pendingTx.From, pendingTx.TxOutItem.VaultPubKey, thorchainHeight, pendingTx.TxOutItem)
+	if err != nil || len(rawTx) == 0 {
+		return fmt.Errorf("fail to sign replacement tx: %w", err)
+	}
+	signedTx := &etypes.Transaction{}
+	if err := json.Unmarshal(rawTx, signedTx); err != nil {
+		return fmt.Errorf("fail to unmarshal replacement tx: %w", err)
+	}
+	if err := c.client.SendTransaction(context.Background(), signedTx); err != nil {
+		return fmt.Errorf("fail to broadcast replacement tx: %w", err)
+	}
+	c.logger.Info().
+		Str("original", pendingTx.Hash).
+		Str("replacement", signedTx.Hash().Hex()).
+		Uint64("nonce", pendingTx.Nonce).
+		Str("gas_price", gasPrice.String()).
+		Msg("replaced stuck tx")
+	pendingTx.Replacements = append(pendingTx.Replacements, signedTx.Hash().Hex())
+	pendingTx.GasPrice = gasPrice
+	pendingTx.BroadcastHeight = height
+	return c.nonces.SetPendingTx(pendingTx)
+}
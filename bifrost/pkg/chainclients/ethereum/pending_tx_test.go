@@ -0,0 +1,117 @@
+package ethereum
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/rs/zerolog/log"
+	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/storage"
+	. "gopkg.in/check.v1"
+
+	"gitlab.com/thorchain/thornode/bifrost/config"
+)
+
+type PendingTxSuite struct{}
+
+var _ = Suite(&PendingTxSuite{})
+
+// newPendingTxTestServer start a json rpc server which report the given block height and account nonce
+func newPendingTxTestServer(c *C, height, nonce uint64) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		body, err := ioutil.ReadAll(req.Body)
+		c.Assert(err, IsNil)
+		var rpcRequest struct {
+			Method string `json:"method"`
+		}
+		c.Assert(json.Unmarshal(body, &rpcRequest), IsNil)
+		switch rpcRequest.Method {
+		case "eth_getTransactionCount":
+			_, err = rw.Write([]byte(fmt.Sprintf(`{"jsonrpc":"2.0","id":1,"result":"0x%x"}`, nonce)))
+		case "eth_getTransactionReceipt":
+			_, err = rw.Write([]byte(`{"jsonrpc":"2.0","id":1,"result":null}`))
+		case "eth_getBlockByNumber":
+			_, err = rw.Write([]byte(fmt.Sprintf(`{"jsonrpc":"2.0","id":1,"result":{
+				"difficulty": "0x31962a3fc82b",
+				"extraData": "0x4477617266506f6f6c",
+				"gasLimit": "0x47c3d8",
+				"gasUsed": "0x0",
+				"hash": "0x78bfef68fccd4507f9f4804ba5c65eb2f928ea45b3383ade88aaa720f1209cba",
+				"logsBloom": "0x00000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000",
+				"miner": "0x2a65aca4d5fc5b5c859090a6c34d164135398226",
+				"nonce": "0xa5e8fb780cc2cd5e",
+				"number": "0x%x",
+				"parentHash": "0x8b535592eb3192017a527bbf8e3596da86b3abea51d6257898b2ced9d3a83826",
+				"receiptsRoot": "0x56e81f171bcc55a6ff8345e692c0f86e5b48e01b996cadc001622fb5e363b421",
+				"sha3Uncles": "0x1dcc4de8dec75d7aab85b567b6ccd41ad312451b948a7413f0a142fd40d49347",
+				"size": "0x20e",
+				"stateRoot": "0xdc6ed0a382e50edfedb6bd296892690eb97eb3fc88fd55088d5ea753c48253dc",
+				"timestamp": "0x579f4981",
+				"totalDifficulty": "0x25cff06a0d96f4bee",
+				"transactions": [],
+				"transactionsRoot": "0x56e81f171bcc55a6ff8345e692c0f86e5b48e01b996cadc001622fb5e363b421",
+				"uncles": []
+			}}`, height)))
+		}
+		c.Assert(err, IsNil)
+	}))
+}
+
+func (s *PendingTxSuite) newClient(c *C, url string) *Client {
+	ethClient, err := ethclient.Dial(url)
+	c.Assert(err, IsNil)
+	db, err := leveldb.Open(storage.NewMemStorage(), nil)
+	c.Assert(err, IsNil)
+	return &Client{
+		logger: log.Logger,
+		cfg:    config.ChainConfiguration{StuckTxBlocks: 10},
+		client: ethClient,
+		nonces: NewNonceManager(db),
+	}
+}
+
+func (s *PendingTxSuite) TestBumpGasPrice(c *C) {
+	c.Check(bumpGasPrice(big.NewInt(100)).Int64(), Equals, int64(110))
+	c.Check(bumpGasPrice(big.NewInt(25)).Int64(), Equals, int64(27))
+	// gas price should always go up
+	c.Check(bumpGasPrice(big.NewInt(1)).Int64(), Equals, int64(2))
+	c.Check(bumpGasPrice(big.NewInt(0)).Int64(), Equals, int64(1))
+}
+
+func (s *PendingTxSuite) TestCheckPendingTxs(c *C) {
+	addr := "0xf02c1c8e6114b1dbe8937a39260b5b0a374432bb"
+	pendingTx := PendingTx{
+		Hash:            "0x88df016429689c079f3b2f6ad39fa052532c56795b733da78a91ebe6a713944b",
+		From:            addr,
+		Nonce:           4,
+		GasPrice:        big.NewInt(20),
+		BroadcastHeight: 25,
+	}
+
+	// tx is not mined yet, but it hasn't been stuck long enough to be replaced
+	server := newPendingTxTestServer(c, 30, 4)
+	defer server.Close()
+	client := s.newClient(c, server.URL)
+	c.Check(client.getStuckTxBlocks(), Equals, int64(10))
+	c.Assert(client.nonces.SetPendingTx(pendingTx), IsNil)
+	c.Assert(client.checkPendingTxs(), IsNil)
+	tx, err := client.nonces.GetPendingTx(addr, 4)
+	c.Assert(err, IsNil)
+	c.Assert(tx, NotNil)
+	c.Check(tx.Replacements, HasLen, 0)
+
+	// account nonce moved past the pending tx, thus it has been mined
+	minedServer := newPendingTxTestServer(c, 30, 5)
+	defer minedServer.Close()
+	client = s.newClient(c, minedServer.URL)
+	c.Assert(client.nonces.SetPendingTx(pendingTx), IsNil)
+	c.Assert(client.checkPendingTxs(), IsNil)
+	tx, err = client.nonces.GetPendingTx(addr, 4)
+	c.Assert(err, IsNil)
+	c.Check(tx, IsNil)
+}
@@ -0,0 +1,194 @@
+package ethereum
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	ecommon "github.com/ethereum/go-ethereum/common"
+	etypes "github.com/ethereum/go-ethereum/core/types"
+
+	stypes "gitlab.com/thorchain/thornode/bifrost/thorclient/types"
+	"gitlab.com/thorchain/thornode/common"
+)
+
+// routerABI is the abi of the vault router contract, source can be found in contracts/router.sol
+const routerABI = `[
+	{"inputs":[{"name":"vault","type":"address"},{"name":"asset","type":"address"},{"name":"amount","type":"uint256"},{"name":"memo","type":"string"}],"name":"deposit","outputs":[],"stateMutability":"payable","type":"function"},
+	{"inputs":[{"name":"to","type":"address"},{"name":"asset","type":"address"},{"name":"amount","type":"uint256"},{"name":"memo","type":"string"}],"name":"transferOut","outputs":[],"stateMutability":"payable","type":"function"},
+	{"inputs":[{"name":"","type":"address"},{"name":"","type":"address"}],"name":"vaultAllowance","outputs":[{"name":"","type":"uint256"}],"stateMutability":"view","type":"function"},
+	{"anonymous":false,"inputs":[{"indexed":true,"name":"to","type":"address"},{"indexed":true,"name":"asset","type":"address"},{"indexed":false,"name":"amount","type":"uint256"},{"indexed":false,"name":"memo","type":"string"}],"name":"Deposit","type":"event"},
+	{"anonymous":false,"inputs":[{"indexed":true,"name":"vault","type":"address"},{"indexed":true,"name":"to","type":"address"},{"indexed":false,"name":"asset","type":"address"},{"indexed":false,"name":"amount","type":"uint256"},{"indexed":false,"name":"memo","type":"string"}],"name":"TransferOut","type":"event"}
+]`
+
+// RouterTransferOutGas is used as gas limit when the node is not able to estimate the gas of a router transfer out
+const RouterTransferOutGas = uint64(120000)
+
+var router abi.ABI
+
+func init() {
+	var err error
+	router, err = abi.JSON(strings.NewReader(routerABI))
+	if err != nil {
+		panic(fmt.Sprintf("fail to parse router abi: %s", err))
+	}
+}
+
+// routerDepositEvent is the non indexed fields of the router Deposit event
+type routerDepositEvent struct {
+	Amount *big.Int
+	Memo   string
+}
+
+// routerTransferOutEvent is the non indexed fields of the router TransferOut event
+type routerTransferOutEvent struct {
+	Asset  ecommon.Address
+	Amount *big.Int
+	Memo   string
+}
+
+// isRouter check whether the given address is the configured router contract
+func (e *BlockScanner) isRouter(addr *ecommon.Address) bool {
+	if addr == nil || e.router == (ecommon.Address{}) {
+		return false
+	}
+	return *addr == e.router
+}
+
+// getRouterCoin convert the asset address used in router events to THORChain coin, zero address is ETH
+func (e *BlockScanner) getRouterCoin(asset ecommon.Address, amount *big.Int) (common.Coin, error) {
+	if asset == (ecommon.Address{}) {
+		return common.NewCoin(common.ETHAsset, sdk.NewUintFromBigInt(amount)), nil
+	}
+	meta, err := e.getTokenMeta(asset)
+	if err != nil {
+		return common.NoCoin, err
+	}
+	return common.NewCoin(meta.Asset, meta.toThorchainAmount(amount)), nil
+}
+
+// fromRouterTxToTxIn parse the Deposit / TransferOut event emitted by the router contract from the receipt of the given tx
+func (e *BlockScanner) fromRouterTxToTxIn(tx *etypes.Transaction, sender ecommon.Address) (*stypes.TxInItem, error) {
+	receipt, err := e.client.TransactionReceipt(context.Background(), tx.Hash())
+	if err != nil {
+		return nil, fmt.Errorf("fail to get receipt of tx(%s): %w", tx.Hash().Hex(), err)
+	}
+	if receipt.Status != etypes.ReceiptStatusSuccessful {
+		return nil, nil
+	}
+	gasFee := new(big.Int).Mul(tx.GasPrice(), new(big.Int).SetUint64(receipt.GasUsed))
+	txInItem := &stypes.TxInItem{
+		Tx: tx.Hash().Hex()[2:],
+		Gas: common.Gas{
+			common.NewCoin(common.ETHAsset, sdk.NewUintFromBigInt(gasFee)),
+		},
+	}
+	for _, item := range receipt.Logs {
+		if item.Address != e.router || len(item.Topics) != 3 {
+			continue
+		}
+		switch item.Topics[0] {
+		case router.Events["Deposit"].ID():
+			var evt routerDepositEvent
+			if err := router.Unpack(&evt, "Deposit", item.Data); err != nil {
+				e.logger.Error().Err(err).Str("hash", tx.Hash().Hex()).Msg("fail to unpack deposit event, ignore tx")
+				return nil, nil
+			}
+			coin, err := e.getRouterCoin(ecommon.BytesToAddress(item.Topics[2].Bytes()), evt.Amount)
+			if err != nil {
+				e.logger.Error().Err(err).Str("hash", tx.Hash().Hex()).Msg("fail to get deposit coin, ignore tx")
+				return nil, nil
+			}
+			txInItem.Sender = strings.ToLower(sender.String())
+			txInItem.To = strings.ToLower(ecommon.BytesToAddress(item.Topics[1].Bytes()).String())
+			txInItem.Memo = evt.Memo
+			txInItem.Coins = common.Coins{coin}
+			return txInItem, nil
+		case router.Events["TransferOut"].ID():
+			var evt routerTransferOutEvent
+			if err := router.Unpack(&evt, "TransferOut", item.Data); err != nil {
+				e.logger.Error().Err(err).Str("hash", tx.Hash().Hex()).Msg("fail to unpack transfer out event, ignore tx")
+				return nil, nil
+			}
+			coin, err := e.getRouterCoin(evt.Asset, evt.Amount)
+			if err != nil {
+				e.logger.Error().Err(err).Str("hash", tx.Hash().Hex()).Msg("fail to get transfer out coin, ignore tx")
+				return nil, nil
+			}
+			txInItem.Sender = strings.ToLower(ecommon.BytesToAddress(item.Topics[1].Bytes()).String())
+			txInItem.To = strings.ToLower(ecommon.BytesToAddress(item.Topics[2].Bytes()).String())
+			txInItem.Memo = evt.Memo
+			txInItem.Coins = common.Coins{coin}
+			return txInItem, nil
+		}
+	}
+	// router tx without deposit or transfer out event, nothing to observe
+	return nil, nil
+}
+
+// buildRouterTransferOut create a tx which send the given coin through the router contract, the router emit the memo in the TransferOut event
+func (c *Client) buildRouterTransferOut(from string, nonce uint64, gasPrice *big.Int, toAddr string, coin common.Coin, memo string) (*etypes.Transaction, error) {
+	if !ecommon.IsHexAddress(toAddr) {
+		return nil, errors.New("invalid to address")
+	}
+	value := big.NewInt(0)
+	asset := ecommon.Address{}
+	amount := coin.Amount.BigInt()
+	if coin.Asset.Equals(common.ETHAsset) {
+		value = amount
+	} else {
+		var err error
+		asset, err = getTokenAddress(coin.Asset)
+		if err != nil {
+			return nil, err
+		}
+		meta, err := c.ethScanner.getTokenMeta(asset)
+		if err != nil {
+			return nil, fmt.Errorf("fail to get token metadata: %w", err)
+		}
+		amount = meta.fromThorchainAmount(coin.Amount)
+	}
+	data, err := router.Pack("transferOut", ecommon.HexToAddress(toAddr), asset, amount, memo)
+	if err != nil {
+		return nil, fmt.Errorf("fail to pack transfer out call: %w", err)
+	}
+	routerAddr := c.ethScanner.router
+	gas, err := c.client.EstimateGas(context.Background(), ethereum.CallMsg{
+		From:     ecommon.HexToAddress(from),
+		To:       &routerAddr,
+		GasPrice: gasPrice,
+		Value:    value,
+		Data:     data,
+	})
+	if err != nil {
+		c.logger.Error().Err(err).Msg("fail to estimate gas of router transfer out, fallback to default")
+		gas = RouterTransferOutGas
+	}
+	return etypes.NewTransaction(nonce, routerAddr, value, gas, gasPrice, data), nil
+}
+
+// getVaultAllowance return the amount of the given token the vault can send out through the router
+func (c *Client) getVaultAllowance(vault, token ecommon.Address) (*big.Int, error) {
+	input, err := router.Pack("vaultAllowance", vault, token)
+	if err != nil {
+		return nil, fmt.Errorf("fail to pack vaultAllowance call: %w", err)
+	}
+	routerAddr := c.ethScanner.router
+	output, err := c.client.CallContract(context.Background(), ethereum.CallMsg{
+		To:   &routerAddr,
+		Data: input,
+	}, nil)
+	if err != nil {
+		return nil, fmt.Errorf("fail to call vaultAllowance on router: %w", err)
+	}
+	allowance := new(big.Int)
+	if err := router.Unpack(&allowance, "vaultAllowance", output); err != nil {
+		return nil, fmt.Errorf("fail to unpack vault allowance: %w", err)
+	}
+	return allowance, nil
+}
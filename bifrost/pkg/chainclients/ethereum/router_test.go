@@ -0,0 +1,303 @@
+package ethereum
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	ecommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	etypes "github.com/ethereum/go-ethereum/core/types"
+	ecrypto "github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/rs/zerolog/log"
+	. "gopkg.in/check.v1"
+
+	"gitlab.com/thorchain/thornode/bifrost/blockscanner"
+	"gitlab.com/thorchain/thornode/bifrost/metrics"
+	"gitlab.com/thorchain/thornode/bifrost/pkg/chainclients/ethereum/types"
+	"gitlab.com/thorchain/thornode/common"
+)
+
+type RouterTestSuite struct {
+	m *metrics.Metrics
+}
+
+var _ = Suite(&RouterTestSuite{})
+
+func (s *RouterTestSuite) SetUpSuite(c *C) {
+	s.m = GetMetricForTest(c)
+	c.Assert(s.m, NotNil)
+}
+
+var (
+	testRouter = ecommon.HexToAddress("0xe65e9d372f8cacc7b6dfcd4af6507851ed31bb44")
+	testVault  = ecommon.HexToAddress("0xf02c1c8e6114b1dbe8937a39260b5b0a374432bb")
+)
+
+// newRouterTestServer start a json rpc server which return the given receipt
+func newRouterTestServer(c *C, receipt *etypes.Receipt) *httptest.Server {
+	buf, err := json.Marshal(receipt)
+	c.Assert(err, IsNil)
+	return httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		body, err := ioutil.ReadAll(req.Body)
+		c.Assert(err, IsNil)
+		var rpcRequest struct {
+			Method string `json:"method"`
+		}
+		c.Assert(json.Unmarshal(body, &rpcRequest), IsNil)
+		switch rpcRequest.Method {
+		case "eth_gasPrice":
+			_, err = rw.Write([]byte(`{"jsonrpc":"2.0","id":1,"result":"0x1"}`))
+		case "eth_getTransactionReceipt":
+			_, err = rw.Write([]byte(fmt.Sprintf(`{"jsonrpc":"2.0","id":1,"result":%s}`, buf)))
+		case "eth_call":
+			if bytes.Equal(callSelector(c, body), router.Methods["vaultAllowance"].ID()) {
+				var allowance []byte
+				allowance, err = router.Methods["vaultAllowance"].Outputs.Pack(testTokenAmount(42))
+				c.Assert(err, IsNil)
+				_, err = rw.Write([]byte(fmt.Sprintf(`{"jsonrpc":"2.0","id":1,"result":"%s"}`, hexutil.Encode(allowance))))
+				break
+			}
+			_, err = rw.Write([]byte(tokenCallResult(c, body)))
+		case "eth_estimateGas":
+			_, err = rw.Write([]byte(`{"jsonrpc":"2.0","id":1,"result":"0xea60"}`))
+		}
+		c.Assert(err, IsNil)
+	}))
+}
+
+func (s *RouterTestSuite) signRouterTx(c *C, input []byte, value *big.Int) (ecommon.Address, *etypes.Transaction) {
+	privKey, err := ecrypto.GenerateKey()
+	c.Assert(err, IsNil)
+	tx := etypes.NewTransaction(0, testRouter, value, RouterTransferOutGas, big.NewInt(2), input)
+	tx, err = etypes.SignTx(tx, etypes.NewEIP155Signer(big.NewInt(int64(types.Mainnet))), privKey)
+	c.Assert(err, IsNil)
+	return ecrypto.PubkeyToAddress(privKey.PublicKey), tx
+}
+
+func (s *RouterTestSuite) TestDeposit(c *C) {
+	input, err := router.Pack("deposit", testVault, ecommon.Address{}, big.NewInt(0), "SWAP:BNB.BNB")
+	c.Assert(err, IsNil)
+	sender, tx := s.signRouterTx(c, input, big.NewInt(5000))
+	data, err := router.Events["Deposit"].Inputs.NonIndexed().Pack(big.NewInt(5000), "SWAP:BNB.BNB")
+	c.Assert(err, IsNil)
+	server := newRouterTestServer(c, &etypes.Receipt{
+		Status:  etypes.ReceiptStatusSuccessful,
+		GasUsed: 30000,
+		TxHash:  tx.Hash(),
+		Logs: []*etypes.Log{
+			{
+				Address: testRouter,
+				Topics: []ecommon.Hash{
+					router.Events["Deposit"].ID(),
+					ecommon.BytesToHash(testVault.Bytes()),
+					{},
+				},
+				Data:   data,
+				TxHash: tx.Hash(),
+			},
+		},
+	})
+	defer server.Close()
+	ethClient, err := ethclient.Dial(server.URL)
+	c.Assert(err, IsNil)
+	bs, err := NewBlockScanner(getConfigForTest(server.URL), blockscanner.NewMockScannerStorage(), types.Mainnet, ethClient, testRouter, s.m)
+	c.Assert(err, IsNil)
+
+	encodedTx, err := tx.MarshalJSON()
+	c.Assert(err, IsNil)
+	txInItem, err := bs.fromTxToTxIn(string(encodedTx))
+	c.Assert(err, IsNil)
+	c.Assert(txInItem, NotNil)
+	c.Check(txInItem.Memo, Equals, "SWAP:BNB.BNB")
+	c.Check(txInItem.Sender, Equals, strings.ToLower(sender.String()))
+	c.Check(txInItem.To, Equals, strings.ToLower(testVault.String()))
+	c.Assert(txInItem.Coins, HasLen, 1)
+	c.Check(txInItem.Coins[0].Asset.Equals(common.ETHAsset), Equals, true)
+	c.Check(txInItem.Coins[0].Amount.Equal(sdk.NewUint(5000)), Equals, true)
+	c.Check(txInItem.Gas[0].Amount.Equal(sdk.NewUint(60000)), Equals, true)
+}
+
+func (s *RouterTestSuite) TestInvalidDepositIgnored(c *C) {
+	input, err := router.Pack("deposit", testVault, ecommon.Address{}, big.NewInt(0), "SWAP:BNB.BNB")
+	c.Assert(err, IsNil)
+	_, tx := s.signRouterTx(c, input, big.NewInt(5000))
+	server := newRouterTestServer(c, &etypes.Receipt{
+		Status:  etypes.ReceiptStatusSuccessful,
+		GasUsed: 30000,
+		TxHash:  tx.Hash(),
+		Logs: []*etypes.Log{
+			{
+				Address: testRouter,
+				Topics: []ecommon.Hash{
+					router.Events["Deposit"].ID(),
+					ecommon.BytesToHash(testVault.Bytes()),
+					{},
+				},
+				Data:   []byte{0x01},
+				TxHash: tx.Hash(),
+			},
+		},
+	})
+	defer server.Close()
+	ethClient, err := ethclient.Dial(server.URL)
+	c.Assert(err, IsNil)
+	bs, err := NewBlockScanner(getConfigForTest(server.URL), blockscanner.NewMockScannerStorage(), types.Mainnet, ethClient, testRouter, s.m)
+	c.Assert(err, IsNil)
+
+	// an event that can't be parsed should not stall the block scanner
+	encodedTx, err := tx.MarshalJSON()
+	c.Assert(err, IsNil)
+	txInItem, err := bs.fromTxToTxIn(string(encodedTx))
+	c.Assert(err, IsNil)
+	c.Check(txInItem, IsNil)
+}
+
+func (s *RouterTestSuite) TestTransferOut(c *C) {
+	token := ecommon.HexToAddress(testTokenAddress)
+	to := ecommon.HexToAddress("0xde0b295669a9fd93d5f28d9ec85e40f4cb697bae")
+	memo := "OUTBOUND:88DF016429689C079F3B2F6AD39FA052532C56795B733DA78A91EBE6A713944B"
+	input, err := router.Pack("transferOut", to, token, testTokenAmount(700), memo)
+	c.Assert(err, IsNil)
+	vault, tx := s.signRouterTx(c, input, big.NewInt(0))
+	data, err := router.Events["TransferOut"].Inputs.NonIndexed().Pack(token, testTokenAmount(700), memo)
+	c.Assert(err, IsNil)
+	server := newRouterTestServer(c, &etypes.Receipt{
+		Status:  etypes.ReceiptStatusSuccessful,
+		GasUsed: 40000,
+		TxHash:  tx.Hash(),
+		Logs: []*etypes.Log{
+			{
+				Address: testRouter,
+				Topics: []ecommon.Hash{
+					router.Events["TransferOut"].ID(),
+					ecommon.BytesToHash(vault.Bytes()),
+					ecommon.BytesToHash(to.Bytes()),
+				},
+				Data:   data,
+				TxHash: tx.Hash(),
+			},
+		},
+	})
+	defer server.Close()
+	ethClient, err := ethclient.Dial(server.URL)
+	c.Assert(err, IsNil)
+	bs, err := NewBlockScanner(getConfigForTest(server.URL), blockscanner.NewMockScannerStorage(), types.Mainnet, ethClient, testRouter, s.m)
+	c.Assert(err, IsNil)
+
+	encodedTx, err := tx.MarshalJSON()
+	c.Assert(err, IsNil)
+	txInItem, err := bs.fromTxToTxIn(string(encodedTx))
+	c.Assert(err, IsNil)
+	c.Assert(txInItem, NotNil)
+	c.Check(txInItem.Memo, Equals, memo)
+	c.Check(txInItem.Sender, Equals, strings.ToLower(vault.String()))
+	c.Check(txInItem.To, Equals, strings.ToLower(to.String()))
+	c.Assert(txInItem.Coins, HasLen, 1)
+	c.Check(txInItem.Coins[0].Asset.String(), Equals, "ETH.TKN-0X3B7FA4DD21C6F9BA3CA375217EAD7CAB9D6BF483")
+	c.Check(txInItem.Coins[0].Amount.Equal(sdk.NewUint(700)), Equals, true)
+
+	// failed tx should be ignored
+	failedServer := newRouterTestServer(c, &etypes.Receipt{
+		Status: etypes.ReceiptStatusFailed,
+		TxHash: tx.Hash(),
+		Logs:   []*etypes.Log{},
+	})
+	defer failedServer.Close()
+	ethClient, err = ethclient.Dial(failedServer.URL)
+	c.Assert(err, IsNil)
+	bs, err = NewBlockScanner(getConfigForTest(failedServer.URL), blockscanner.NewMockScannerStorage(), types.Mainnet, ethClient, testRouter, s.m)
+	c.Assert(err, IsNil)
+	txInItem, err = bs.fromTxToTxIn(string(encodedTx))
+	c.Assert(err, IsNil)
+	c.Assert(txInItem, IsNil)
+}
+
+func (s *RouterTestSuite) TestBuildRouterTransferOut(c *C) {
+	server := newRouterTestServer(c, &etypes.Receipt{})
+	defer server.Close()
+	ethClient, err := ethclient.Dial(server.URL)
+	c.Assert(err, IsNil)
+	bs, err := NewBlockScanner(getConfigForTest(server.URL), blockscanner.NewMockScannerStorage(), types.Mainnet, ethClient, testRouter, s.m)
+	c.Assert(err, IsNil)
+	client := &Client{
+		logger:     log.Logger,
+		client:     ethClient,
+		ethScanner: bs,
+	}
+	memo := "OUTBOUND:88DF016429689C079F3B2F6AD39FA052532C56795B733DA78A91EBE6A713944B"
+	to := "0xde0b295669a9fd93d5f28d9ec85e40f4cb697bae"
+
+	tx, err := client.buildRouterTransferOut(testVault.String(), 3, big.NewInt(5), to, common.NewCoin(common.ETHAsset, sdk.NewUint(1000)), memo)
+	c.Assert(err, IsNil)
+	c.Check(*tx.To(), Equals, testRouter)
+	c.Check(tx.Value().Int64(), Equals, int64(1000))
+	c.Check(tx.Nonce(), Equals, uint64(3))
+	c.Check(tx.Gas(), Equals, uint64(60000))
+	args, err := router.Methods["transferOut"].Inputs.UnpackValues(tx.Data()[4:])
+	c.Assert(err, IsNil)
+	c.Check(args[0].(ecommon.Address), Equals, ecommon.HexToAddress(to))
+	c.Check(args[1].(ecommon.Address), Equals, ecommon.Address{})
+	c.Check(args[3].(string), Equals, memo)
+
+	asset, err := getTokenAsset("TKN", ecommon.HexToAddress(testTokenAddress))
+	c.Assert(err, IsNil)
+	tx, err = client.buildRouterTransferOut(testVault.String(), 3, big.NewInt(5), to, common.NewCoin(asset, sdk.NewUint(1000)), memo)
+	c.Assert(err, IsNil)
+	c.Check(tx.Value().Int64(), Equals, int64(0))
+	args, err = router.Methods["transferOut"].Inputs.UnpackValues(tx.Data()[4:])
+	c.Assert(err, IsNil)
+	c.Check(args[1].(ecommon.Address), Equals, ecommon.HexToAddress(testTokenAddress))
+	c.Check(args[2].(*big.Int).Cmp(testTokenAmount(1000)), Equals, 0)
+
+	_, err = client.buildRouterTransferOut(testVault.String(), 3, big.NewInt(5), "bad", common.NewCoin(common.ETHAsset, sdk.NewUint(1000)), memo)
+	c.Check(err, NotNil)
+}
+
+func (s *RouterTestSuite) TestDirectTokenTransferIgnored(c *C) {
+	privKey, err := ecrypto.GenerateKey()
+	c.Assert(err, IsNil)
+	data, err := erc20.Pack("transfer", testVault, testTokenAmount(10))
+	c.Assert(err, IsNil)
+	data = append(data, []byte("SWAP:BNB.BNB")...)
+	tx := etypes.NewTransaction(0, ecommon.HexToAddress(testTokenAddress), big.NewInt(0), ERC20TransferGas, big.NewInt(2), data)
+	tx, err = etypes.SignTx(tx, etypes.NewEIP155Signer(big.NewInt(int64(types.Mainnet))), privKey)
+	c.Assert(err, IsNil)
+	server := newRouterTestServer(c, &etypes.Receipt{})
+	defer server.Close()
+	ethClient, err := ethclient.Dial(server.URL)
+	c.Assert(err, IsNil)
+	bs, err := NewBlockScanner(getConfigForTest(server.URL), blockscanner.NewMockScannerStorage(), types.Mainnet, ethClient, testRouter, s.m)
+	c.Assert(err, IsNil)
+
+	// the router can't spend tokens sent straight to the vault, so they are not observed
+	encodedTx, err := tx.MarshalJSON()
+	c.Assert(err, IsNil)
+	txInItem, err := bs.fromTxToTxIn(string(encodedTx))
+	c.Assert(err, IsNil)
+	c.Check(txInItem, IsNil)
+}
+
+func (s *RouterTestSuite) TestGetVaultAllowance(c *C) {
+	server := newRouterTestServer(c, &etypes.Receipt{})
+	defer server.Close()
+	ethClient, err := ethclient.Dial(server.URL)
+	c.Assert(err, IsNil)
+	bs, err := NewBlockScanner(getConfigForTest(server.URL), blockscanner.NewMockScannerStorage(), types.Mainnet, ethClient, testRouter, s.m)
+	c.Assert(err, IsNil)
+	client := &Client{
+		logger:     log.Logger,
+		client:     ethClient,
+		ethScanner: bs,
+	}
+	allowance, err := client.getVaultAllowance(testVault, ecommon.HexToAddress(testTokenAddress))
+	c.Assert(err, IsNil)
+	c.Check(allowance.Cmp(testTokenAmount(42)), Equals, 0)
+}
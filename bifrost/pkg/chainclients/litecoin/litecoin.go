@@ -0,0 +1,78 @@
+package litecoin
+
+import (
+	"fmt"
+
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/btcsuite/btcutil"
+	"github.com/btcsuite/btcutil/bech32"
+	tssp "gitlab.com/thorchain/tss/go-tss/tss"
+	"gitlab.com/thorchain/txscript"
+
+	"gitlab.com/thorchain/thornode/bifrost/config"
+	"gitlab.com/thorchain/thornode/bifrost/metrics"
+	"gitlab.com/thorchain/thornode/bifrost/pkg/chainclients"
+	"gitlab.com/thorchain/thornode/bifrost/pkg/chainclients/bitcoin"
+	"gitlab.com/thorchain/thornode/bifrost/thorclient"
+	"gitlab.com/thorchain/thornode/common"
+)
+
+// LitecoinChain is the bitcoin.UTXOChain implementation of Litecoin
+type LitecoinChain struct{}
+
+func init() {
+	chainclients.Register(common.LTCChain, chainclients.Factory{
+		Capabilities: chainclients.Capabilities{UTXO: true, SupportsMemo: true},
+		New: func(thorKeys *thorclient.Keys, cfg config.ChainConfiguration, server *tssp.TssServer, thorchainBridge *thorclient.ThorchainBridge, m *metrics.Metrics) (chainclients.ChainClient, error) {
+			return NewClient(thorKeys, cfg, server, thorchainBridge, m)
+		},
+	})
+}
+
+// NewClient create a new chain client for Litecoin, it is backed by the bitcoin UTXO engine
+func NewClient(thorKeys *thorclient.Keys, cfg config.ChainConfiguration, server *tssp.TssServer, bridge *thorclient.ThorchainBridge, m *metrics.Metrics) (*bitcoin.Client, error) {
+	return bitcoin.NewUTXOClient(LitecoinChain{}, thorKeys, cfg, server, bridge, m)
+}
+
+// GetChain return LTC chain
+func (LitecoinChain) GetChain() common.Chain {
+	return common.LTCChain
+}
+
+// DecodeAddress parse the given litecoin address
+// btcutil only decode segwit addresses of the registered networks, and litecoin regtest network can't be registered as its
+// network magic is the same as bitcoin regtest, thus segwit addresses are decoded here
+func (LitecoinChain) DecodeAddress(addr string, params *chaincfg.Params) (btcutil.Address, error) {
+	hrp, data, err := bech32.Decode(addr)
+	if err != nil {
+		return btcutil.DecodeAddress(addr, params)
+	}
+	if hrp != params.Bech32HRPSegwit {
+		return nil, fmt.Errorf("address(%s) is not for network %s", addr, params.Name)
+	}
+	if len(data) == 0 || data[0] != 0 {
+		return nil, fmt.Errorf("unsupported witness version of address(%s)", addr)
+	}
+	program, err := bech32.ConvertBits(data[1:], 5, 8, false)
+	if err != nil {
+		return nil, fmt.Errorf("fail to convert witness program: %w", err)
+	}
+	switch len(program) {
+	case 20:
+		return btcutil.NewAddressWitnessPubKeyHash(program, params)
+	case 32:
+		return btcutil.NewAddressWitnessScriptHash(program, params)
+	}
+	return nil, fmt.Errorf("invalid witness program length(%d)", len(program))
+}
+
+// NormalizeAddress litecoind return addresses in the same format THORChain use
+func (LitecoinChain) NormalizeAddress(addr string) string {
+	return addr
+}
+
+// SignInput litecoin support segwit, the input is signed the same way as bitcoin
+func (LitecoinChain) SignInput(tx *wire.MsgTx, idx int, amount int64, sourceScript []byte, signable txscript.Signable) error {
+	return bitcoin.SignWitnessInput(tx, idx, amount, sourceScript, signable)
+}
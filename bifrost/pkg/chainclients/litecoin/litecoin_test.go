@@ -0,0 +1,62 @@
+package litecoin
+
+import (
+	"testing"
+
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/btcsuite/btcutil"
+	"gitlab.com/thorchain/txscript"
+	. "gopkg.in/check.v1"
+
+	"gitlab.com/thorchain/thornode/common"
+)
+
+func TestPackage(t *testing.T) { TestingT(t) }
+
+type LitecoinSuite struct{}
+
+var _ = Suite(&LitecoinSuite{})
+
+func (s *LitecoinSuite) TestDecodeAddress(c *C) {
+	chain := LitecoinChain{}
+	c.Check(chain.GetChain(), Equals, common.LTCChain)
+
+	addr, err := chain.DecodeAddress("ltc1qj08ys4ct2hzzc2hcz6h2hgrvlmsjynawmt3sjh", &common.LTCMainNetParams)
+	c.Assert(err, IsNil)
+	c.Check(addr.String(), Equals, "ltc1qj08ys4ct2hzzc2hcz6h2hgrvlmsjynawmt3sjh")
+	_, ok := addr.(*btcutil.AddressWitnessPubKeyHash)
+	c.Check(ok, Equals, true)
+
+	addr, err = chain.DecodeAddress("rltc1qj08ys4ct2hzzc2hcz6h2hgrvlmsjynawf4nr3r", &common.LTCRegressionNetParams)
+	c.Assert(err, IsNil)
+	c.Check(addr.String(), Equals, "rltc1qj08ys4ct2hzzc2hcz6h2hgrvlmsjynawf4nr3r")
+
+	addr, err = chain.DecodeAddress("LVg2kJoFNg45Nbpy53h7Fe1wKyeXVRhMH9", &common.LTCMainNetParams)
+	c.Assert(err, IsNil)
+	_, ok = addr.(*btcutil.AddressPubKeyHash)
+	c.Check(ok, Equals, true)
+
+	// address of another network
+	_, err = chain.DecodeAddress("ltc1qj08ys4ct2hzzc2hcz6h2hgrvlmsjynawmt3sjh", &common.LTCTestNetParams)
+	c.Check(err, NotNil)
+	_, err = chain.DecodeAddress("bc1qj08ys4ct2hzzc2hcz6h2hgrvlmsjynawlht528", &common.LTCMainNetParams)
+	c.Check(err, NotNil)
+}
+
+func (s *LitecoinSuite) TestSignInput(c *C) {
+	privKey, err := btcec.NewPrivateKey(btcec.S256())
+	c.Assert(err, IsNil)
+	pkHash := btcutil.Hash160(privKey.PubKey().SerializeCompressed())
+	source, err := btcutil.NewAddressWitnessPubKeyHash(pkHash, &common.LTCRegressionNetParams)
+	c.Assert(err, IsNil)
+	sourceScript, err := txscript.PayToAddrScript(source)
+	c.Assert(err, IsNil)
+
+	tx := wire.NewMsgTx(wire.TxVersion)
+	tx.AddTxIn(wire.NewTxIn(wire.NewOutPoint(&chainhash.Hash{1}, 0), nil, nil))
+	tx.AddTxOut(wire.NewTxOut(90000, sourceScript))
+	c.Assert(LitecoinChain{}.SignInput(tx, 0, 100000, sourceScript, txscript.NewPrivateKeySignable(privKey)), IsNil)
+	c.Check(tx.TxIn[0].Witness, HasLen, 2)
+}
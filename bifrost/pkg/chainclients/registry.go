@@ -0,0 +1,79 @@
+package chainclients
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"gitlab.com/thorchain/tss/go-tss/tss"
+
+	"gitlab.com/thorchain/thornode/bifrost/config"
+	"gitlab.com/thorchain/thornode/bifrost/metrics"
+	"gitlab.com/thorchain/thornode/bifrost/thorclient"
+	"gitlab.com/thorchain/thornode/common"
+)
+
+// Capabilities describe what a chain is able to do
+type Capabilities struct {
+	// UTXO is true for chains using unspent transaction outputs, false for account based chains
+	UTXO bool
+	// SupportsMemo whether a tx on the chain can carry a THORChain memo
+	SupportsMemo bool
+	// SupportsMultiSend whether a single tx can pay multiple recipients
+	SupportsMultiSend bool
+}
+
+// Factory knows how to create the chain client of a chain
+type Factory struct {
+	Capabilities Capabilities
+	New          func(thorKeys *thorclient.Keys, cfg config.ChainConfiguration, server *tss.TssServer, thorchainBridge *thorclient.ThorchainBridge, m *metrics.Metrics) (ChainClient, error)
+}
+
+var (
+	registryLock = &sync.RWMutex{}
+	registry     = make(map[common.Chain]Factory)
+)
+
+// Register make the chain client factory available to LoadChains, it is meant to be called from the init function of the chain
+// client package, thus importing the package is enough to enable the chain via config
+// it panics when the same chain is registered twice, or the factory is nil
+func Register(chain common.Chain, factory Factory) {
+	registryLock.Lock()
+	defer registryLock.Unlock()
+	if factory.New == nil {
+		panic(fmt.Sprintf("chain client factory of %s is nil", chain))
+	}
+	if _, ok := registry[chain]; ok {
+		panic(fmt.Sprintf("chain client of %s has been registered already", chain))
+	}
+	registry[chain] = factory
+}
+
+// GetCapabilities return the capabilities of the given chain, the bool is false when the chain is not registered
+func GetCapabilities(chain common.Chain) (Capabilities, bool) {
+	registryLock.RLock()
+	defer registryLock.RUnlock()
+	factory, ok := registry[chain]
+	return factory.Capabilities, ok
+}
+
+// RegisteredChains return all the chains that have been registered, sorted by name
+func RegisteredChains() common.Chains {
+	registryLock.RLock()
+	defer registryLock.RUnlock()
+	chains := make(common.Chains, 0, len(registry))
+	for chain := range registry {
+		chains = append(chains, chain)
+	}
+	sort.SliceStable(chains, func(i, j int) bool {
+		return chains[i].String() < chains[j].String()
+	})
+	return chains
+}
+
+func getFactory(chain common.Chain) (Factory, bool) {
+	registryLock.RLock()
+	defer registryLock.RUnlock()
+	factory, ok := registry[chain]
+	return factory, ok
+}
@@ -0,0 +1,74 @@
+package chainclients
+
+import (
+	"errors"
+	"testing"
+
+	"gitlab.com/thorchain/tss/go-tss/tss"
+	. "gopkg.in/check.v1"
+
+	"gitlab.com/thorchain/thornode/bifrost/config"
+	"gitlab.com/thorchain/thornode/bifrost/metrics"
+	"gitlab.com/thorchain/thornode/bifrost/thorclient"
+	"gitlab.com/thorchain/thornode/common"
+)
+
+func TestPackage(t *testing.T) { TestingT(t) }
+
+type RegistrySuite struct{}
+
+var _ = Suite(&RegistrySuite{})
+
+func (s *RegistrySuite) TearDownTest(c *C) {
+	registryLock.Lock()
+	defer registryLock.Unlock()
+	registry = make(map[common.Chain]Factory)
+}
+
+func (s *RegistrySuite) TestRegister(c *C) {
+	c.Check(func() { Register(common.BTCChain, Factory{}) }, PanicMatches, ".*factory of BTC is nil")
+
+	factory := Factory{
+		Capabilities: Capabilities{UTXO: true, SupportsMemo: true},
+		New: func(_ *thorclient.Keys, _ config.ChainConfiguration, _ *tss.TssServer, _ *thorclient.ThorchainBridge, _ *metrics.Metrics) (ChainClient, error) {
+			return nil, nil
+		},
+	}
+	Register(common.BTCChain, factory)
+	c.Check(func() { Register(common.BTCChain, factory) }, PanicMatches, ".*BTC has been registered already")
+	Register(common.BNBChain, Factory{
+		Capabilities: Capabilities{SupportsMemo: true, SupportsMultiSend: true},
+		New:          factory.New,
+	})
+
+	capabilities, ok := GetCapabilities(common.BTCChain)
+	c.Check(ok, Equals, true)
+	c.Check(capabilities.UTXO, Equals, true)
+	c.Check(capabilities.SupportsMultiSend, Equals, false)
+	capabilities, ok = GetCapabilities(common.BNBChain)
+	c.Check(ok, Equals, true)
+	c.Check(capabilities.UTXO, Equals, false)
+	c.Check(capabilities.SupportsMultiSend, Equals, true)
+	_, ok = GetCapabilities(common.ETHChain)
+	c.Check(ok, Equals, false)
+
+	c.Check(RegisteredChains(), DeepEquals, common.Chains{common.BNBChain, common.BTCChain})
+}
+
+func (s *RegistrySuite) TestLoadChains(c *C) {
+	Register(common.BNBChain, Factory{
+		New: func(_ *thorclient.Keys, _ config.ChainConfiguration, _ *tss.TssServer, _ *thorclient.ThorchainBridge, _ *metrics.Metrics) (ChainClient, error) {
+			return nil, errors.New("kaboom")
+		},
+	})
+
+	// construction failure is logged and the chain is skipped
+	chains, err := LoadChains(nil, []config.ChainConfiguration{{ChainID: common.BNBChain}}, nil, nil, nil)
+	c.Assert(err, IsNil)
+	c.Check(chains, HasLen, 0)
+
+	// a configured chain without registered factory fail loudly
+	chains, err = LoadChains(nil, []config.ChainConfiguration{{ChainID: common.BNBChain}, {ChainID: common.ETHChain}}, nil, nil, nil)
+	c.Assert(err, NotNil)
+	c.Check(chains, IsNil)
+}
@@ -0,0 +1,356 @@
+package mockchain
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/binance-chain/go-sdk/common/bech32"
+	"github.com/binance-chain/go-sdk/common/types"
+	ttypes "github.com/binance-chain/go-sdk/types"
+	"github.com/binance-chain/go-sdk/types/tx"
+	"github.com/tendermint/go-amino"
+)
+
+// BinanceTestNet is the network name of binance chain testnet
+const BinanceTestNet = "Binance-Chain-Nile"
+
+// binance chain abci codes the bifrost binance client care about
+const (
+	binanceCodeInternal     = 1
+	binanceCodeUnauthorized = 4
+)
+
+// Binance simulate the tendermint rpc interface of a binance chain node
+type Binance struct {
+	ledger           *Ledger
+	network          string
+	server           *httptest.Server
+	lock             *sync.Mutex
+	accountNumbers   map[string]int64
+	sequences        map[string]int64
+	singleFee        int64
+	multiTransferFee int64
+}
+
+// NewBinance create a new simulated binance chain node of the given network, and start serving rpc requests
+func NewBinance(network string) (*Binance, error) {
+	b := &Binance{
+		network:          network,
+		lock:             &sync.Mutex{},
+		accountNumbers:   make(map[string]int64),
+		sequences:        make(map[string]int64),
+		singleFee:        37500,
+		multiTransferFee: 30000,
+	}
+	ledger, err := NewLedger(func(block *Block) (string, error) {
+		hash, err := defaultBlockHasher(block)
+		return strings.ToUpper(hash), err
+	})
+	if err != nil {
+		return nil, err
+	}
+	b.ledger = ledger
+	mux := http.NewServeMux()
+	mux.HandleFunc("/status", b.status)
+	mux.HandleFunc("/abci_info", b.abciInfo)
+	mux.HandleFunc("/abci_query", b.abciQuery)
+	mux.HandleFunc("/block", b.block)
+	mux.HandleFunc("/broadcast_tx_commit", b.broadcastTxCommit)
+	b.server = httptest.NewServer(mux)
+	return b, nil
+}
+
+// Ledger return the ledger backing the simulated node
+func (b *Binance) Ledger() *Ledger {
+	return b.ledger
+}
+
+// URL return the url of the simulated node
+func (b *Binance) URL() string {
+	return b.server.URL
+}
+
+// Close stop serving requests
+func (b *Binance) Close() {
+	b.server.Close()
+}
+
+// hrp return the bech32 prefix of the addresses on the simulated network
+func (b *Binance) hrp() string {
+	if b.network == BinanceTestNet {
+		return types.TestNetwork.Bech32Prefixes()
+	}
+	return types.ProdNetwork.Bech32Prefixes()
+}
+
+// AddTx add the given amino encoded tx to mempool, bypassing the broadcast error, it returns the hash of the tx
+func (b *Binance) AddTx(raw []byte) string {
+	hash := binanceTxHash(raw)
+	b.ledger.AddTx(Tx{Hash: hash, Raw: raw})
+	return hash
+}
+
+func binanceTxHash(raw []byte) string {
+	return fmt.Sprintf("%X", sha256.Sum256(raw))
+}
+
+// SetAccount set the account number and the sequence of the given address
+func (b *Binance) SetAccount(addr string, accountNumber, sequence int64) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	b.accountNumbers[addr] = accountNumber
+	b.sequences[addr] = sequence
+}
+
+// SetFees set the fee of single transfer and the fee per coin of multi transfer
+func (b *Binance) SetFees(singleFee, multiTransferFee int64) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	b.singleFee = singleFee
+	b.multiTransferFee = multiTransferFee
+}
+
+func (b *Binance) writeResult(w http.ResponseWriter, result interface{}) {
+	writeJSON(w, map[string]interface{}{
+		"jsonrpc": "2.0",
+		"id":      "",
+		"result":  result,
+	})
+}
+
+func (b *Binance) writeError(w http.ResponseWriter, message, data string) {
+	writeJSON(w, map[string]interface{}{
+		"jsonrpc": "2.0",
+		"id":      "",
+		"error": map[string]interface{}{
+			"code":    rpcErrInternal,
+			"message": message,
+			"data":    data,
+		},
+	})
+}
+
+func (b *Binance) status(w http.ResponseWriter, _ *http.Request) {
+	tip := b.ledger.Tip()
+	b.writeResult(w, map[string]interface{}{
+		"node_info": map[string]interface{}{
+			"network": b.network,
+		},
+		"sync_info": map[string]interface{}{
+			"latest_block_hash":   tip.Hash,
+			"latest_block_height": strconv.FormatInt(tip.Height, 10),
+			"latest_block_time":   tip.Time,
+			"catching_up":         false,
+		},
+	})
+}
+
+func (b *Binance) abciInfo(w http.ResponseWriter, _ *http.Request) {
+	b.writeResult(w, map[string]interface{}{
+		"response": map[string]interface{}{
+			"data":              "BNBChain",
+			"last_block_height": strconv.FormatInt(b.ledger.Height(), 10),
+		},
+	})
+}
+
+func (b *Binance) abciQuery(w http.ResponseWriter, r *http.Request) {
+	path := strings.Trim(r.URL.Query().Get("path"), "\"")
+	var value []byte
+	var err error
+	switch {
+	case path == "/param/fees":
+		value, err = b.fees()
+	case strings.HasPrefix(path, "/account/"):
+		value, err = b.account(strings.TrimPrefix(path, "/account/"))
+	default:
+		err = fmt.Errorf("unknown query path: %s", path)
+	}
+	if err != nil {
+		b.writeError(w, "Internal error", err.Error())
+		return
+	}
+	b.writeResult(w, map[string]interface{}{
+		"response": map[string]interface{}{
+			"value":  base64.StdEncoding.EncodeToString(value),
+			"height": strconv.FormatInt(b.ledger.Height(), 10),
+		},
+	})
+}
+
+func (b *Binance) fees() ([]byte, error) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	cdc := amino.NewCodec()
+	types.RegisterWire(cdc)
+	fees := []types.FeeParam{
+		&types.TransferFeeParam{
+			FixedFeeParams: types.FixedFeeParams{
+				MsgType: "send",
+				Fee:     b.singleFee,
+				FeeFor:  types.FeeForProposer,
+			},
+			MultiTransferFee:  b.multiTransferFee,
+			LowerLimitAsMulti: 2,
+		},
+	}
+	return cdc.MarshalBinaryLengthPrefixed(fees)
+}
+
+func (b *Binance) account(addr string) ([]byte, error) {
+	_, accAddr, err := bech32.DecodeAndConvert(addr)
+	if err != nil {
+		return nil, fmt.Errorf("fail to decode address(%s): %w", addr, err)
+	}
+	var coins types.Coins
+	for denom, amount := range b.ledger.GetBalances(addr) {
+		coins = append(coins, types.Coin{Denom: denom, Amount: amount.Int64()})
+	}
+	sort.SliceStable(coins, func(i, j int) bool {
+		return coins[i].Denom < coins[j].Denom
+	})
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	acc := types.AppAccount{
+		BaseAccount: types.BaseAccount{
+			Address:       types.AccAddress(accAddr),
+			Coins:         coins,
+			AccountNumber: b.accountNumbers[addr],
+			Sequence:      b.sequences[addr],
+		},
+	}
+	return ttypes.NewCodec().MarshalBinaryBare(acc)
+}
+
+func (b *Binance) block(w http.ResponseWriter, r *http.Request) {
+	height := b.ledger.Height()
+	if value := r.URL.Query().Get("height"); value != "" {
+		var err error
+		height, err = strconv.ParseInt(strings.Trim(value, "\""), 10, 64)
+		if err != nil {
+			b.writeError(w, "Invalid params", err.Error())
+			return
+		}
+	}
+	block := b.ledger.GetBlock(height)
+	if block == nil {
+		b.writeError(w, "Internal error", fmt.Sprintf("Height must be less than or equal to the current blockchain height, height: %d", height))
+		return
+	}
+	txs := make([]string, 0, len(block.Txs))
+	for _, tx := range block.Txs {
+		txs = append(txs, base64.StdEncoding.EncodeToString(tx.Raw))
+	}
+	header := map[string]interface{}{
+		"chain_id": b.network,
+		"height":   strconv.FormatInt(block.Height, 10),
+		"time":     block.Time,
+		"num_txs":  strconv.Itoa(len(txs)),
+		"last_block_id": map[string]interface{}{
+			"hash": block.PreviousHash,
+		},
+	}
+	b.writeResult(w, map[string]interface{}{
+		"block_meta": map[string]interface{}{
+			"block_id": map[string]interface{}{
+				"hash": block.Hash,
+			},
+			"header": header,
+		},
+		"block": map[string]interface{}{
+			"header": header,
+			"data": map[string]interface{}{
+				"txs": txs,
+			},
+		},
+	})
+}
+
+// broadcastTxCommit add the tx to mempool when the sequence of the signer match, and reply in the format bifrost expect
+func (b *Binance) broadcastTxCommit(w http.ResponseWriter, r *http.Request) {
+	raw, err := hex.DecodeString(strings.TrimPrefix(strings.Trim(r.URL.Query().Get("tx"), "\""), "0x"))
+	if err != nil {
+		b.writeBadCommit(w, "", binanceCodeInternal, fmt.Sprintf("fail to decode tx: %s", err))
+		return
+	}
+	hash := binanceTxHash(raw)
+	var stdTx tx.StdTx
+	if err := tx.Cdc.UnmarshalBinaryLengthPrefixed(raw, &stdTx); err != nil {
+		b.writeBadCommit(w, hash, binanceCodeInternal, fmt.Sprintf("fail to unmarshal tx: %s", err))
+		return
+	}
+	if code, log := b.checkSequence(stdTx); code != 0 {
+		b.writeBadCommit(w, hash, code, log)
+		return
+	}
+	if err := b.ledger.Broadcast(Tx{Hash: hash, Raw: raw}); err != nil {
+		b.writeBadCommit(w, hash, binanceCodeInternal, err.Error())
+		return
+	}
+	b.increaseSequence(stdTx)
+	writeJSON(w, map[string]interface{}{
+		"height": "0",
+		"txhash": hash,
+		"logs": []map[string]interface{}{
+			{
+				"msg_index": 0,
+				"success":   true,
+				"log":       "Msg 0: ",
+			},
+		},
+	})
+}
+
+func (b *Binance) writeBadCommit(w http.ResponseWriter, hash string, code int, log string) {
+	writeJSON(w, map[string]interface{}{
+		"height":  "0",
+		"txhash":  hash,
+		"code":    code,
+		"raw_log": log,
+	})
+}
+
+// signerAddress return the address of the given signature, the bool is false when the signature has no public key
+func (b *Binance) signerAddress(sig tx.StdSignature) (string, bool) {
+	if sig.PubKey == nil {
+		return "", false
+	}
+	addr, err := bech32.ConvertAndEncode(b.hrp(), sig.PubKey.Address().Bytes())
+	if err != nil {
+		return "", false
+	}
+	return addr, true
+}
+
+func (b *Binance) checkSequence(stdTx tx.StdTx) (int, string) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	for _, sig := range stdTx.Signatures {
+		addr, ok := b.signerAddress(sig)
+		if !ok {
+			continue
+		}
+		if sig.Sequence != b.sequences[addr] {
+			return binanceCodeUnauthorized, fmt.Sprintf("signature verification failed; verify correct account sequence(%d) and chain-id", b.sequences[addr])
+		}
+	}
+	return 0, ""
+}
+
+func (b *Binance) increaseSequence(stdTx tx.StdTx) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	for _, sig := range stdTx.Signatures {
+		if addr, ok := b.signerAddress(sig); ok {
+			b.sequences[addr]++
+		}
+	}
+}
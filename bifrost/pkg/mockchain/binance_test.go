@@ -0,0 +1,185 @@
+package mockchain
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"net/url"
+
+	"github.com/binance-chain/go-sdk/common/types"
+	"github.com/binance-chain/go-sdk/keys"
+	ttypes "github.com/binance-chain/go-sdk/types"
+	"github.com/binance-chain/go-sdk/types/msg"
+	"github.com/binance-chain/go-sdk/types/tx"
+	"github.com/tendermint/go-amino"
+	. "gopkg.in/check.v1"
+)
+
+type BinanceSuite struct {
+	node *Binance
+}
+
+var _ = Suite(&BinanceSuite{})
+
+func (s *BinanceSuite) SetUpTest(c *C) {
+	types.Network = types.TestNetwork
+	var err error
+	s.node, err = NewBinance(BinanceTestNet)
+	c.Assert(err, IsNil)
+}
+
+func (s *BinanceSuite) TearDownTest(c *C) {
+	s.node.Close()
+}
+
+func (s *BinanceSuite) get(c *C, path string, query url.Values, result interface{}) {
+	u, err := url.Parse(s.node.URL())
+	c.Assert(err, IsNil)
+	u.Path = path
+	u.RawQuery = query.Encode()
+	resp, err := http.Get(u.String())
+	c.Assert(err, IsNil)
+	defer resp.Body.Close()
+	c.Assert(resp.StatusCode, Equals, http.StatusOK)
+	buf, err := ioutil.ReadAll(resp.Body)
+	c.Assert(err, IsNil)
+	c.Assert(json.Unmarshal(buf, result), IsNil)
+}
+
+type binanceQueryResult struct {
+	Result struct {
+		Response struct {
+			Value string `json:"value"`
+		} `json:"response"`
+	} `json:"result"`
+}
+
+func (s *BinanceSuite) getAccount(c *C, addr string) types.AppAccount {
+	var result binanceQueryResult
+	s.get(c, "/abci_query", url.Values{"path": []string{fmt.Sprintf("\"/account/%s\"", addr)}}, &result)
+	buf, err := base64.StdEncoding.DecodeString(result.Result.Response.Value)
+	c.Assert(err, IsNil)
+	var acc types.AppAccount
+	c.Assert(ttypes.NewCodec().UnmarshalBinaryBare(buf, &acc), IsNil)
+	return acc
+}
+
+func (s *BinanceSuite) broadcast(c *C, raw []byte) map[string]interface{} {
+	u, err := url.Parse(s.node.URL())
+	c.Assert(err, IsNil)
+	u.Path = "broadcast_tx_commit"
+	u.RawQuery = url.Values{"tx": []string{"0x" + hex.EncodeToString(raw)}}.Encode()
+	resp, err := http.Post(u.String(), "", nil)
+	c.Assert(err, IsNil)
+	defer resp.Body.Close()
+	var result map[string]interface{}
+	c.Assert(json.NewDecoder(resp.Body).Decode(&result), IsNil)
+	return result
+}
+
+func (s *BinanceSuite) TestStatus(c *C) {
+	c.Assert(s.node.Ledger().MineBlocks(2), IsNil)
+	var status struct {
+		Result struct {
+			NodeInfo struct {
+				Network string `json:"network"`
+			} `json:"node_info"`
+		} `json:"result"`
+	}
+	s.get(c, "/status", nil, &status)
+	c.Check(status.Result.NodeInfo.Network, Equals, BinanceTestNet)
+	var info struct {
+		Result struct {
+			Response struct {
+				BlockHeight string `json:"last_block_height"`
+			} `json:"response"`
+		} `json:"result"`
+	}
+	s.get(c, "/abci_info", nil, &info)
+	c.Check(info.Result.Response.BlockHeight, Equals, "2")
+
+	var result binanceQueryResult
+	s.get(c, "/abci_query", url.Values{"path": []string{"\"/param/fees\""}}, &result)
+	buf, err := base64.StdEncoding.DecodeString(result.Result.Response.Value)
+	c.Assert(err, IsNil)
+	var fees []types.FeeParam
+	cdc := amino.NewCodec()
+	types.RegisterWire(cdc)
+	c.Assert(cdc.UnmarshalBinaryLengthPrefixed(buf, &fees), IsNil)
+	c.Assert(fees, HasLen, 1)
+	c.Assert(fees[0].Check(), IsNil)
+	c.Check(fees[0].(*types.TransferFeeParam).Fee, Equals, int64(37500))
+}
+
+func (s *BinanceSuite) TestBroadcast(c *C) {
+	keyManager, err := keys.NewKeyManager()
+	c.Assert(err, IsNil)
+	from := keyManager.GetAddr().String()
+	s.node.Ledger().SetBalance(from, "BNB", big.NewInt(100000000))
+	s.node.SetAccount(from, 3, 5)
+	acc := s.getAccount(c, from)
+	c.Check(acc.AccountNumber, Equals, int64(3))
+	c.Check(acc.Sequence, Equals, int64(5))
+	c.Check(acc.Coins.AmountOf("BNB"), Equals, int64(100000000))
+
+	coins := types.Coins{types.Coin{Denom: "BNB", Amount: 1000}}
+	sign := func(sequence int64) []byte {
+		raw, err := keyManager.Sign(tx.StdSignMsg{
+			ChainID:       BinanceTestNet,
+			AccountNumber: 3,
+			Sequence:      sequence,
+			Memo:          "SWAP:BNB.RUNE-A1F",
+			Msgs: []msg.Msg{
+				msg.CreateSendMsg(keyManager.GetAddr(), coins, []msg.Transfer{{ToAddr: keyManager.GetAddr(), Coins: coins}}),
+			},
+		})
+		c.Assert(err, IsNil)
+		return raw
+	}
+
+	// wrong sequence is rejected with code 4
+	result := s.broadcast(c, sign(4))
+	c.Check(result["code"], Equals, float64(binanceCodeUnauthorized))
+	s.node.Ledger().SetBroadcastError(errors.New("kaboom"))
+	result = s.broadcast(c, sign(5))
+	c.Check(result["code"], Equals, float64(binanceCodeInternal))
+	c.Check(result["raw_log"], Equals, "kaboom")
+	s.node.Ledger().SetBroadcastError(nil)
+	raw := sign(5)
+	result = s.broadcast(c, raw)
+	c.Check(result["code"], IsNil)
+	c.Check(result["txhash"], Equals, binanceTxHash(raw))
+	c.Check(s.getAccount(c, from).Sequence, Equals, int64(6))
+
+	_, err = s.node.Ledger().Mine()
+	c.Assert(err, IsNil)
+	var block struct {
+		Result struct {
+			Block struct {
+				Header struct {
+					Height string `json:"height"`
+				} `json:"header"`
+				Data struct {
+					Txs []string `json:"txs"`
+				} `json:"data"`
+			} `json:"block"`
+		} `json:"result"`
+	}
+	s.get(c, "/block", url.Values{"height": []string{"1"}}, &block)
+	c.Check(block.Result.Block.Header.Height, Equals, "1")
+	c.Assert(block.Result.Block.Data.Txs, HasLen, 1)
+	c.Check(block.Result.Block.Data.Txs[0], Equals, base64.StdEncoding.EncodeToString(raw))
+
+	var blockErr struct {
+		Error struct {
+			Data string `json:"data"`
+		} `json:"error"`
+	}
+	s.get(c, "/block", url.Values{"height": []string{"2"}}, &blockErr)
+	c.Check(blockErr.Error.Data, Matches, "Height must be less than or equal to the current blockchain height.*")
+}
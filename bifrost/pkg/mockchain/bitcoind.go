@@ -0,0 +1,466 @@
+package mockchain
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http/httptest"
+	"strings"
+
+	"github.com/btcsuite/btcd/btcjson"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/btcsuite/btcutil"
+)
+
+// Bitcoind simulate the json rpc interface of bitcoind, it can be used for the chains forked from bitcoin as well
+type Bitcoind struct {
+	ledger        *Ledger
+	params        *chaincfg.Params
+	server        *httptest.Server
+	encodeAddress func(addr btcutil.Address) string
+}
+
+// NewBitcoind create a new simulated bitcoind and start serving json rpc requests
+// addresses in the responses are encoded with the given network params
+func NewBitcoind(params *chaincfg.Params) (*Bitcoind, error) {
+	b := &Bitcoind{
+		params: params,
+		encodeAddress: func(addr btcutil.Address) string {
+			return addr.EncodeAddress()
+		},
+	}
+	ledger, err := NewLedger(bitcoinBlockHasher)
+	if err != nil {
+		return nil, err
+	}
+	b.ledger = ledger
+	b.server = httptest.NewServer(serveJSONRPC(map[string]rpcHandler{
+		"getnetworkinfo":     b.getNetworkInfo,
+		"getblockcount":      b.getBlockCount,
+		"getblockhash":       b.getBlockHash,
+		"getbestblockhash":   b.getBestBlockHash,
+		"getblock":           b.getBlock,
+		"getrawtransaction":  b.getRawTransaction,
+		"gettransaction":     b.getTransaction,
+		"getmempoolentry":    b.getMempoolEntry,
+		"getrawmempool":      b.getRawMempool,
+		"sendrawtransaction": b.sendRawTransaction,
+		"listunspent":        b.listUnspent,
+	}))
+	return b, nil
+}
+
+// bitcoinBlockHasher hash the block header the same way bitcoin does, the fork is used as header nonce
+func bitcoinBlockHasher(block *Block) (string, error) {
+	header := wire.BlockHeader{
+		Version:   1,
+		Timestamp: block.Time,
+		Nonce:     uint32(block.Fork),
+	}
+	if block.PreviousHash != "" {
+		prevHash, err := chainhash.NewHashFromStr(block.PreviousHash)
+		if err != nil {
+			return "", fmt.Errorf("fail to parse previous hash: %w", err)
+		}
+		header.PrevBlock = *prevHash
+	}
+	var buf bytes.Buffer
+	for _, tx := range block.Txs {
+		buf.WriteString(tx.Hash)
+	}
+	header.MerkleRoot = chainhash.DoubleHashH(buf.Bytes())
+	return header.BlockHash().String(), nil
+}
+
+// Ledger return the ledger backing the simulated node
+func (b *Bitcoind) Ledger() *Ledger {
+	return b.ledger
+}
+
+// Host return the host:port the simulated node is listening on
+func (b *Bitcoind) Host() string {
+	return b.server.Listener.Addr().String()
+}
+
+// Close stop serving requests
+func (b *Bitcoind) Close() {
+	b.server.Close()
+}
+
+// SetAddressEncoder override how addresses are encoded in the responses, for example Bitcoin Cash node return cash addresses
+func (b *Bitcoind) SetAddressEncoder(encoder func(addr btcutil.Address) string) {
+	b.encodeAddress = encoder
+}
+
+// AddTx add the given tx to mempool, bypassing the broadcast error
+func (b *Bitcoind) AddTx(tx *wire.MsgTx) (string, error) {
+	var buf bytes.Buffer
+	if err := tx.Serialize(&buf); err != nil {
+		return "", fmt.Errorf("fail to serialize tx: %w", err)
+	}
+	hash := tx.TxHash().String()
+	b.ledger.AddTx(Tx{Hash: hash, Raw: buf.Bytes()})
+	return hash, nil
+}
+
+// Fund add a coinbase tx paying the given amount to the address into mempool, it return the hash of the tx
+func (b *Bitcoind) Fund(addr string, amount int64) (string, error) {
+	address, err := btcutil.DecodeAddress(addr, b.params)
+	if err != nil {
+		return "", fmt.Errorf("fail to decode address(%s): %w", addr, err)
+	}
+	pkScript, err := txscript.PayToAddrScript(address)
+	if err != nil {
+		return "", fmt.Errorf("fail to create pay to address script: %w", err)
+	}
+	tx := wire.NewMsgTx(wire.TxVersion)
+	// the height and the size of the mempool make sure all coinbase txs are unique
+	sigScript := []byte(fmt.Sprintf("%d-%d", b.ledger.Height(), len(b.ledger.Mempool())))
+	tx.AddTxIn(wire.NewTxIn(wire.NewOutPoint(&chainhash.Hash{}, wire.MaxPrevOutIndex), sigScript, nil))
+	tx.AddTxOut(wire.NewTxOut(amount, pkScript))
+	return b.AddTx(tx)
+}
+
+func (b *Bitcoind) decodeTx(raw []byte) (*wire.MsgTx, error) {
+	tx := wire.NewMsgTx(wire.TxVersion)
+	if err := tx.Deserialize(bytes.NewReader(raw)); err != nil {
+		return nil, fmt.Errorf("fail to deserialize tx: %w", err)
+	}
+	return tx, nil
+}
+
+func (b *Bitcoind) toTxRawResult(tx Tx, block *Block) (btcjson.TxRawResult, error) {
+	msgTx, err := b.decodeTx(tx.Raw)
+	if err != nil {
+		return btcjson.TxRawResult{}, err
+	}
+	result := btcjson.TxRawResult{
+		Hex:      hex.EncodeToString(tx.Raw),
+		Txid:     msgTx.TxHash().String(),
+		Hash:     msgTx.WitnessHash().String(),
+		Size:     int32(msgTx.SerializeSize()),
+		Vsize:    int32(msgTx.SerializeSizeStripped()),
+		Version:  msgTx.Version,
+		LockTime: msgTx.LockTime,
+	}
+	if block != nil {
+		result.BlockHash = block.Hash
+		result.Confirmations = uint64(b.ledger.Height() - block.Height + 1)
+		result.Time = block.Time.Unix()
+		result.Blocktime = block.Time.Unix()
+	}
+	for _, txIn := range msgTx.TxIn {
+		vin := btcjson.Vin{
+			Sequence: txIn.Sequence,
+		}
+		if txIn.PreviousOutPoint.Index == wire.MaxPrevOutIndex && txIn.PreviousOutPoint.Hash == (chainhash.Hash{}) {
+			vin.Coinbase = hex.EncodeToString(txIn.SignatureScript)
+		} else {
+			disasm, _ := txscript.DisasmString(txIn.SignatureScript)
+			vin.Txid = txIn.PreviousOutPoint.Hash.String()
+			vin.Vout = txIn.PreviousOutPoint.Index
+			vin.ScriptSig = &btcjson.ScriptSig{
+				Asm: disasm,
+				Hex: hex.EncodeToString(txIn.SignatureScript),
+			}
+			for _, item := range txIn.Witness {
+				vin.Witness = append(vin.Witness, hex.EncodeToString(item))
+			}
+		}
+		result.Vin = append(result.Vin, vin)
+	}
+	for idx, txOut := range msgTx.TxOut {
+		disasm, _ := txscript.DisasmString(txOut.PkScript)
+		class, addresses, reqSigs, _ := txscript.ExtractPkScriptAddrs(txOut.PkScript, b.params)
+		vout := btcjson.Vout{
+			Value: btcutil.Amount(txOut.Value).ToBTC(),
+			N:     uint32(idx),
+			ScriptPubKey: btcjson.ScriptPubKeyResult{
+				Asm:     disasm,
+				Hex:     hex.EncodeToString(txOut.PkScript),
+				ReqSigs: int32(reqSigs),
+				Type:    class.String(),
+			},
+		}
+		for _, addr := range addresses {
+			vout.ScriptPubKey.Addresses = append(vout.ScriptPubKey.Addresses, b.encodeAddress(addr))
+		}
+		result.Vout = append(result.Vout, vout)
+	}
+	return result, nil
+}
+
+// getNetworkInfo report the node as bitcoind 0.19, rpcclient use it to detect the backend version
+func (b *Bitcoind) getNetworkInfo(_ []json.RawMessage) (interface{}, *rpcError) {
+	return btcjson.GetNetworkInfoResult{
+		Version:         190000,
+		SubVersion:      "/Satoshi:0.19.0/",
+		ProtocolVersion: 70015,
+	}, nil
+}
+
+func (b *Bitcoind) getBlockCount(_ []json.RawMessage) (interface{}, *rpcError) {
+	return b.ledger.Height(), nil
+}
+
+func (b *Bitcoind) getBlockHash(params []json.RawMessage) (interface{}, *rpcError) {
+	var height int64
+	if err := decodeParam(params, 0, &height, true); err != nil {
+		return nil, err
+	}
+	block := b.ledger.GetBlock(height)
+	if block == nil {
+		return nil, newRPCError(int(btcjson.ErrRPCInvalidParameter), "Block height out of range")
+	}
+	return block.Hash, nil
+}
+
+func (b *Bitcoind) getBestBlockHash(_ []json.RawMessage) (interface{}, *rpcError) {
+	return b.ledger.Tip().Hash, nil
+}
+
+func (b *Bitcoind) getBlock(params []json.RawMessage) (interface{}, *rpcError) {
+	var hash string
+	verbosity := 1
+	if err := decodeParam(params, 0, &hash, true); err != nil {
+		return nil, err
+	}
+	if err := decodeParam(params, 1, &verbosity, false); err != nil {
+		return nil, err
+	}
+	block := b.ledger.GetBlockByHash(hash)
+	if block == nil {
+		return nil, newRPCError(int(btcjson.ErrRPCBlockNotFound), "Block not found")
+	}
+	result := btcjson.GetBlockVerboseTxResult{
+		Hash:          block.Hash,
+		Confirmations: b.ledger.Height() - block.Height + 1,
+		Height:        block.Height,
+		Version:       1,
+		Time:          block.Time.Unix(),
+		Nonce:         uint32(block.Fork),
+		PreviousHash:  block.PreviousHash,
+	}
+	if next := b.ledger.GetBlock(block.Height + 1); next != nil {
+		result.NextHash = next.Hash
+	}
+	var txids []string
+	for _, tx := range block.Txs {
+		txids = append(txids, tx.Hash)
+		rawTx, err := b.toTxRawResult(tx, block)
+		if err != nil {
+			return nil, newRPCError(rpcErrInternal, "%s", err)
+		}
+		result.Tx = append(result.Tx, rawTx)
+	}
+	switch verbosity {
+	case 0:
+		return nil, newRPCError(int(btcjson.ErrRPCInvalidParameter), "raw block is not supported")
+	case 1:
+		return btcjson.GetBlockVerboseResult{
+			Hash:          result.Hash,
+			Confirmations: result.Confirmations,
+			Height:        result.Height,
+			Version:       result.Version,
+			Tx:            txids,
+			Time:          result.Time,
+			Nonce:         result.Nonce,
+			PreviousHash:  result.PreviousHash,
+			NextHash:      result.NextHash,
+		}, nil
+	}
+	return result, nil
+}
+
+func (b *Bitcoind) getRawTransaction(params []json.RawMessage) (interface{}, *rpcError) {
+	var hash string
+	verbose := 0
+	if err := decodeParam(params, 0, &hash, true); err != nil {
+		return nil, err
+	}
+	if err := decodeParam(params, 1, &verbose, false); err != nil {
+		return nil, err
+	}
+	tx, block, err := b.ledger.GetTx(hash)
+	if err != nil {
+		return nil, newRPCError(int(btcjson.ErrRPCNoTxInfo), "No such mempool or blockchain transaction")
+	}
+	if verbose == 0 {
+		return hex.EncodeToString(tx.Raw), nil
+	}
+	result, err := b.toTxRawResult(tx, block)
+	if err != nil {
+		return nil, newRPCError(rpcErrInternal, "%s", err)
+	}
+	return result, nil
+}
+
+func (b *Bitcoind) getTransaction(params []json.RawMessage) (interface{}, *rpcError) {
+	var hash string
+	if err := decodeParam(params, 0, &hash, true); err != nil {
+		return nil, err
+	}
+	tx, block, err := b.ledger.GetTx(hash)
+	if err != nil {
+		return nil, newRPCError(int(btcjson.ErrRPCNoTxInfo), "Invalid or non-wallet transaction id")
+	}
+	result := btcjson.GetTransactionResult{
+		TxID: tx.Hash,
+		Hex:  hex.EncodeToString(tx.Raw),
+	}
+	if block != nil {
+		result.Confirmations = b.ledger.Height() - block.Height + 1
+		result.BlockHash = block.Hash
+		result.BlockTime = block.Time.Unix()
+		result.Time = block.Time.Unix()
+	}
+	return result, nil
+}
+
+func (b *Bitcoind) getMempoolEntry(params []json.RawMessage) (interface{}, *rpcError) {
+	var hash string
+	if err := decodeParam(params, 0, &hash, true); err != nil {
+		return nil, err
+	}
+	for _, tx := range b.ledger.Mempool() {
+		if !strings.EqualFold(tx.Hash, hash) {
+			continue
+		}
+		return btcjson.GetMempoolEntryResult{
+			Size:   int32(len(tx.Raw)),
+			VSize:  int32(len(tx.Raw)),
+			Height: b.ledger.Height(),
+			Time:   b.ledger.Tip().Time.Unix(),
+		}, nil
+	}
+	return nil, newRPCError(int(btcjson.ErrRPCInvalidAddressOrKey), "Transaction not in mempool")
+}
+
+func (b *Bitcoind) getRawMempool(_ []json.RawMessage) (interface{}, *rpcError) {
+	hashes := []string{}
+	for _, tx := range b.ledger.Mempool() {
+		hashes = append(hashes, tx.Hash)
+	}
+	return hashes, nil
+}
+
+func (b *Bitcoind) sendRawTransaction(params []json.RawMessage) (interface{}, *rpcError) {
+	var rawHex string
+	if err := decodeParam(params, 0, &rawHex, true); err != nil {
+		return nil, err
+	}
+	raw, err := hex.DecodeString(rawHex)
+	if err != nil {
+		return nil, newRPCError(int(btcjson.ErrRPCDecodeHexString), "TX decode failed")
+	}
+	tx, err := b.decodeTx(raw)
+	if err != nil {
+		return nil, newRPCError(int(btcjson.ErrRPCDeserialization), "TX decode failed")
+	}
+	hash := tx.TxHash().String()
+	if err := b.ledger.Broadcast(Tx{Hash: hash, Raw: raw}); err != nil {
+		return nil, newRPCError(int(btcjson.ErrRPCTxRejected), "%s", err)
+	}
+	return hash, nil
+}
+
+// listUnspent return the outputs that have not been spent by any tx in the chain or mempool
+func (b *Bitcoind) listUnspent(params []json.RawMessage) (interface{}, *rpcError) {
+	minConf := int64(1)
+	maxConf := int64(9999999)
+	var addresses []string
+	if err := decodeParam(params, 0, &minConf, false); err != nil {
+		return nil, err
+	}
+	if err := decodeParam(params, 1, &maxConf, false); err != nil {
+		return nil, err
+	}
+	if err := decodeParam(params, 2, &addresses, false); err != nil {
+		return nil, err
+	}
+	type output struct {
+		result btcjson.ListUnspentResult
+		spent  bool
+	}
+	outputs := make(map[wire.OutPoint]*output)
+	var outPoints []wire.OutPoint
+	process := func(tx Tx, confirmations int64) *rpcError {
+		rawTx, err := b.toTxRawResult(tx, nil)
+		if err != nil {
+			return newRPCError(rpcErrInternal, "%s", err)
+		}
+		for _, vin := range rawTx.Vin {
+			if vin.Coinbase != "" {
+				continue
+			}
+			hash, err := chainhash.NewHashFromStr(vin.Txid)
+			if err != nil {
+				return newRPCError(rpcErrInternal, "%s", err)
+			}
+			if item, ok := outputs[*wire.NewOutPoint(hash, vin.Vout)]; ok {
+				item.spent = true
+			}
+		}
+		hash, err := chainhash.NewHashFromStr(rawTx.Txid)
+		if err != nil {
+			return newRPCError(rpcErrInternal, "%s", err)
+		}
+		for _, vout := range rawTx.Vout {
+			if len(vout.ScriptPubKey.Addresses) != 1 {
+				continue
+			}
+			outPoint := *wire.NewOutPoint(hash, vout.N)
+			outPoints = append(outPoints, outPoint)
+			outputs[outPoint] = &output{
+				result: btcjson.ListUnspentResult{
+					TxID:          rawTx.Txid,
+					Vout:          vout.N,
+					Address:       vout.ScriptPubKey.Addresses[0],
+					ScriptPubKey:  vout.ScriptPubKey.Hex,
+					Amount:        vout.Value,
+					Confirmations: confirmations,
+					Spendable:     true,
+				},
+			}
+		}
+		return nil
+	}
+	height := b.ledger.Height()
+	for h := int64(0); h <= height; h++ {
+		for _, tx := range b.ledger.GetBlock(h).Txs {
+			if err := process(tx, height-h+1); err != nil {
+				return nil, err
+			}
+		}
+	}
+	for _, tx := range b.ledger.Mempool() {
+		if err := process(tx, 0); err != nil {
+			return nil, err
+		}
+	}
+	result := []btcjson.ListUnspentResult{}
+	for _, outPoint := range outPoints {
+		item := outputs[outPoint]
+		if item.spent || item.result.Confirmations < minConf || item.result.Confirmations > maxConf {
+			continue
+		}
+		if len(addresses) > 0 && !containsAddress(addresses, item.result.Address) {
+			continue
+		}
+		result = append(result, item.result)
+	}
+	return result, nil
+}
+
+func containsAddress(addresses []string, addr string) bool {
+	for _, item := range addresses {
+		if item == addr {
+			return true
+		}
+	}
+	return false
+}
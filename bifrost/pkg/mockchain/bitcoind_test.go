@@ -0,0 +1,132 @@
+package mockchain
+
+import (
+	"errors"
+
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/btcsuite/btcd/btcjson"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/rpcclient"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/btcsuite/btcutil"
+	. "gopkg.in/check.v1"
+)
+
+type BitcoindSuite struct {
+	node   *Bitcoind
+	client *rpcclient.Client
+}
+
+var _ = Suite(&BitcoindSuite{})
+
+func (s *BitcoindSuite) SetUpTest(c *C) {
+	var err error
+	s.node, err = NewBitcoind(&chaincfg.RegressionNetParams)
+	c.Assert(err, IsNil)
+	s.client, err = rpcclient.New(&rpcclient.ConnConfig{
+		Host:         s.node.Host(),
+		User:         "bob",
+		Pass:         "password",
+		DisableTLS:   true,
+		HTTPPostMode: true,
+	}, nil)
+	c.Assert(err, IsNil)
+}
+
+func (s *BitcoindSuite) TearDownTest(c *C) {
+	s.client.Shutdown()
+	s.node.Close()
+}
+
+func newTestAddress(c *C) btcutil.Address {
+	privKey, err := btcec.NewPrivateKey(btcec.S256())
+	c.Assert(err, IsNil)
+	addr, err := btcutil.NewAddressWitnessPubKeyHash(btcutil.Hash160(privKey.PubKey().SerializeCompressed()), &chaincfg.RegressionNetParams)
+	c.Assert(err, IsNil)
+	return addr
+}
+
+func (s *BitcoindSuite) TestScanBlocks(c *C) {
+	sender := newTestAddress(c)
+	vault := newTestAddress(c)
+	fundTxID, err := s.node.Fund(sender.String(), 100000)
+	c.Assert(err, IsNil)
+	c.Assert(s.node.Ledger().MineBlocks(1), IsNil)
+
+	height, err := s.client.GetBlockCount()
+	c.Assert(err, IsNil)
+	c.Check(height, Equals, int64(1))
+	_, err = s.client.GetBlockHash(2)
+	c.Assert(err, NotNil)
+	rpcErr, ok := err.(*btcjson.RPCError)
+	c.Assert(ok, Equals, true)
+	c.Check(rpcErr.Code, Equals, btcjson.ErrRPCInvalidParameter)
+
+	// spend the funded output with a memo
+	fundHash, err := chainhash.NewHashFromStr(fundTxID)
+	c.Assert(err, IsNil)
+	tx := wire.NewMsgTx(wire.TxVersion)
+	tx.AddTxIn(wire.NewTxIn(wire.NewOutPoint(fundHash, 0), nil, nil))
+	vaultScript, err := txscript.PayToAddrScript(vault)
+	c.Assert(err, IsNil)
+	tx.AddTxOut(wire.NewTxOut(90000, vaultScript))
+	memoScript, err := txscript.NullDataScript([]byte("SWAP:BNB.BNB"))
+	c.Assert(err, IsNil)
+	tx.AddTxOut(wire.NewTxOut(0, memoScript))
+
+	s.node.Ledger().SetBroadcastError(errors.New("kaboom"))
+	_, err = s.client.SendRawTransaction(tx, true)
+	c.Check(err, NotNil)
+	s.node.Ledger().SetBroadcastError(nil)
+	txHash, err := s.client.SendRawTransaction(tx, true)
+	c.Assert(err, IsNil)
+	c.Check(txHash.String(), Equals, tx.TxHash().String())
+	_, err = s.client.GetMempoolEntry(txHash.String())
+	c.Assert(err, IsNil)
+	c.Assert(s.node.Ledger().MineBlocks(1), IsNil)
+	_, err = s.client.GetMempoolEntry(txHash.String())
+	c.Assert(err, NotNil)
+
+	bestHash, err := s.client.GetBestBlockHash()
+	c.Assert(err, IsNil)
+	blockInfo, err := s.client.GetBlockVerbose(bestHash)
+	c.Assert(err, IsNil)
+	c.Check(blockInfo.Height, Equals, int64(2))
+	block, err := s.client.GetBlockVerboseTx(bestHash)
+	c.Assert(err, IsNil)
+	c.Assert(block.Tx, HasLen, 1)
+	rawTx := block.Tx[0]
+	c.Check(rawTx.Txid, Equals, txHash.String())
+	c.Check(rawTx.Vin[0].Txid, Equals, fundTxID)
+	c.Check(rawTx.Vout[0].Value, Equals, 0.0009)
+	c.Check(rawTx.Vout[0].ScriptPubKey.Addresses, DeepEquals, []string{vault.String()})
+	c.Check(rawTx.Vout[1].ScriptPubKey.Asm, Equals, "OP_RETURN 535741503a424e422e424e42")
+
+	fundTx, err := s.client.GetRawTransactionVerbose(fundHash)
+	c.Assert(err, IsNil)
+	c.Check(fundTx.Vin[0].Coinbase, Not(Equals), "")
+	c.Check(fundTx.Confirmations, Equals, uint64(2))
+	c.Check(fundTx.Vout[0].ScriptPubKey.Addresses, DeepEquals, []string{sender.String()})
+
+	result, err := s.client.GetTransaction(txHash)
+	c.Assert(err, IsNil)
+	c.Check(result.Confirmations, Equals, int64(1))
+
+	unspent, err := s.client.ListUnspentMinMaxAddresses(1, 9999999, []btcutil.Address{sender, vault})
+	c.Assert(err, IsNil)
+	c.Assert(unspent, HasLen, 1)
+	c.Check(unspent[0].TxID, Equals, txHash.String())
+	c.Check(unspent[0].Address, Equals, vault.String())
+	c.Check(unspent[0].Amount, Equals, 0.0009)
+
+	// the tx get dropped by a re-org
+	c.Assert(s.node.Ledger().Reorg(1, txHash.String()), IsNil)
+	_, err = s.client.GetTransaction(txHash)
+	c.Assert(err, NotNil)
+	unspent, err = s.client.ListUnspentMinMaxAddresses(1, 9999999, []btcutil.Address{sender, vault})
+	c.Assert(err, IsNil)
+	c.Assert(unspent, HasLen, 1)
+	c.Check(unspent[0].TxID, Equals, fundTxID)
+}
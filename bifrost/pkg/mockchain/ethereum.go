@@ -0,0 +1,476 @@
+package mockchain
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http/httptest"
+	"strings"
+	"sync"
+
+	ecommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	etypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+// EthereumGasLimit is the gas limit of all the simulated ethereum blocks
+const EthereumGasLimit = 8000000
+
+// Ethereum simulate the json rpc interface of an ethereum node
+// contracts are not executed, the result of eth_call and the logs of the receipts are programmed by the tests
+type Ethereum struct {
+	ledger      *Ledger
+	chainID     *big.Int
+	signer      etypes.Signer
+	server      *httptest.Server
+	lock        *sync.Mutex
+	gasPrice    *big.Int
+	estimateGas uint64
+	callResults map[string][]byte
+	logs        map[string][]*etypes.Log
+	failedTxs   map[string]bool
+}
+
+// NewEthereum create a new simulated ethereum node of the given chain id, and start serving json rpc requests
+func NewEthereum(chainID *big.Int) (*Ethereum, error) {
+	e := &Ethereum{
+		chainID:     chainID,
+		signer:      etypes.NewEIP155Signer(chainID),
+		lock:        &sync.Mutex{},
+		gasPrice:    big.NewInt(1),
+		estimateGas: 21000,
+		callResults: make(map[string][]byte),
+		logs:        make(map[string][]*etypes.Log),
+		failedTxs:   make(map[string]bool),
+	}
+	ledger, err := NewLedger(func(block *Block) (string, error) {
+		header, _, err := ethereumHeader(block)
+		if err != nil {
+			return "", err
+		}
+		return header.Hash().Hex(), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	e.ledger = ledger
+	e.server = httptest.NewServer(serveJSONRPC(map[string]rpcHandler{
+		"eth_chainId":               e.getChainID,
+		"net_version":               e.getNetVersion,
+		"eth_blockNumber":           e.getBlockNumber,
+		"eth_getBlockByNumber":      e.getBlockByNumber,
+		"eth_getBlockByHash":        e.getBlockByHash,
+		"eth_getTransactionByHash":  e.getTransactionByHash,
+		"eth_getTransactionReceipt": e.getTransactionReceipt,
+		"eth_getTransactionCount":   e.getTransactionCount,
+		"eth_getBalance":            e.getBalance,
+		"eth_gasPrice":              e.getGasPrice,
+		"eth_estimateGas":           e.getEstimateGas,
+		"eth_call":                  e.call,
+		"eth_sendRawTransaction":    e.sendRawTransaction,
+	}))
+	return e, nil
+}
+
+// ethereumHeader build the header of the given block, the fork is written into the extra data
+func ethereumHeader(block *Block) (*etypes.Header, etypes.Transactions, error) {
+	txs := make(etypes.Transactions, 0, len(block.Txs))
+	gasUsed := uint64(0)
+	for _, item := range block.Txs {
+		tx, err := decodeEthereumTx(item.Raw)
+		if err != nil {
+			return nil, nil, err
+		}
+		gasUsed += tx.Gas()
+		txs = append(txs, tx)
+	}
+	extra := make([]byte, 8)
+	binary.BigEndian.PutUint64(extra, block.Fork)
+	header := &etypes.Header{
+		ParentHash:  ecommon.HexToHash(block.PreviousHash),
+		UncleHash:   etypes.EmptyUncleHash,
+		Root:        etypes.EmptyRootHash,
+		TxHash:      etypes.DeriveSha(txs),
+		ReceiptHash: etypes.EmptyRootHash,
+		Difficulty:  big.NewInt(1),
+		Number:      big.NewInt(block.Height),
+		GasLimit:    EthereumGasLimit,
+		GasUsed:     gasUsed,
+		Time:        uint64(block.Time.Unix()),
+		Extra:       extra,
+	}
+	return header, txs, nil
+}
+
+func decodeEthereumTx(raw []byte) (*etypes.Transaction, error) {
+	tx := &etypes.Transaction{}
+	if err := rlp.DecodeBytes(raw, tx); err != nil {
+		return nil, fmt.Errorf("fail to decode tx: %w", err)
+	}
+	return tx, nil
+}
+
+// Ledger return the ledger backing the simulated node
+func (e *Ethereum) Ledger() *Ledger {
+	return e.ledger
+}
+
+// URL return the url of the simulated node
+func (e *Ethereum) URL() string {
+	return e.server.URL
+}
+
+// Close stop serving requests
+func (e *Ethereum) Close() {
+	e.server.Close()
+}
+
+// AddTx add the given signed tx to mempool, bypassing the broadcast error
+func (e *Ethereum) AddTx(tx *etypes.Transaction) error {
+	raw, err := rlp.EncodeToBytes(tx)
+	if err != nil {
+		return fmt.Errorf("fail to encode tx: %w", err)
+	}
+	e.ledger.AddTx(Tx{Hash: tx.Hash().Hex(), Raw: raw})
+	return nil
+}
+
+// SetBalance set the ETH balance of the given address in wei
+func (e *Ethereum) SetBalance(addr ecommon.Address, amount *big.Int) {
+	e.ledger.SetBalance(addr.Hex(), "ETH", amount)
+}
+
+// SetGasPrice set the gas price returned by eth_gasPrice
+func (e *Ethereum) SetGasPrice(gasPrice *big.Int) {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	e.gasPrice = new(big.Int).Set(gasPrice)
+}
+
+// SetEstimateGas set the gas returned by eth_estimateGas
+func (e *Ethereum) SetEstimateGas(gas uint64) {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	e.estimateGas = gas
+}
+
+// SetCallResult set the result of eth_call of the given contract method, the method is identified by its 4 bytes selector
+func (e *Ethereum) SetCallResult(contract ecommon.Address, selector, result []byte) {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	e.callResults[callKey(contract, selector)] = result
+}
+
+func callKey(contract ecommon.Address, data []byte) string {
+	if len(data) > 4 {
+		data = data[:4]
+	}
+	return strings.ToLower(contract.Hex()) + hex.EncodeToString(data)
+}
+
+// SetLogs set the logs in the receipt of the given tx
+func (e *Ethereum) SetLogs(txHash ecommon.Hash, logs []*etypes.Log) {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	e.logs[strings.ToLower(txHash.Hex())] = logs
+}
+
+// SetTxFailed make the receipt of the given tx report a failed execution
+func (e *Ethereum) SetTxFailed(txHash ecommon.Hash) {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	e.failedTxs[strings.ToLower(txHash.Hex())] = true
+}
+
+// getBlockByTag return the block of the given tag, nil when the block doesn't exist
+func (e *Ethereum) getBlockByTag(tag string) (*Block, *rpcError) {
+	switch tag {
+	case "latest", "pending":
+		return e.ledger.Tip(), nil
+	case "earliest":
+		return e.ledger.GetBlock(0), nil
+	}
+	height, err := hexutil.DecodeUint64(tag)
+	if err != nil {
+		return nil, newRPCError(rpcErrInvalidParams, "invalid block number(%s): %s", tag, err)
+	}
+	return e.ledger.GetBlock(int64(height)), nil
+}
+
+func (e *Ethereum) marshalTx(tx *etypes.Transaction, block *Block, idx int) (map[string]interface{}, error) {
+	buf, err := json.Marshal(tx)
+	if err != nil {
+		return nil, fmt.Errorf("fail to marshal tx: %w", err)
+	}
+	result := make(map[string]interface{})
+	if err := json.Unmarshal(buf, &result); err != nil {
+		return nil, fmt.Errorf("fail to unmarshal tx: %w", err)
+	}
+	from, err := etypes.Sender(e.signer, tx)
+	if err != nil {
+		return nil, fmt.Errorf("fail to get sender: %w", err)
+	}
+	result["from"] = from
+	result["blockHash"] = nil
+	result["blockNumber"] = nil
+	result["transactionIndex"] = nil
+	if block != nil {
+		result["blockHash"] = block.Hash
+		result["blockNumber"] = hexutil.EncodeUint64(uint64(block.Height))
+		result["transactionIndex"] = hexutil.EncodeUint64(uint64(idx))
+	}
+	return result, nil
+}
+
+func (e *Ethereum) marshalBlock(block *Block, fullTx bool) (interface{}, *rpcError) {
+	if block == nil {
+		return nil, nil
+	}
+	header, txs, err := ethereumHeader(block)
+	if err != nil {
+		return nil, newRPCError(rpcErrInternal, "%s", err)
+	}
+	buf, err := json.Marshal(header)
+	if err != nil {
+		return nil, newRPCError(rpcErrInternal, "fail to marshal header: %s", err)
+	}
+	result := make(map[string]interface{})
+	if err := json.Unmarshal(buf, &result); err != nil {
+		return nil, newRPCError(rpcErrInternal, "fail to unmarshal header: %s", err)
+	}
+	items := make([]interface{}, 0, len(txs))
+	for idx, tx := range txs {
+		if !fullTx {
+			items = append(items, tx.Hash())
+			continue
+		}
+		item, err := e.marshalTx(tx, block, idx)
+		if err != nil {
+			return nil, newRPCError(rpcErrInternal, "%s", err)
+		}
+		items = append(items, item)
+	}
+	result["transactions"] = items
+	result["uncles"] = []ecommon.Hash{}
+	result["totalDifficulty"] = hexutil.EncodeUint64(uint64(block.Height + 1))
+	result["size"] = hexutil.EncodeUint64(uint64(header.Size()))
+	return result, nil
+}
+
+func (e *Ethereum) getChainID(_ []json.RawMessage) (interface{}, *rpcError) {
+	return (*hexutil.Big)(e.chainID), nil
+}
+
+func (e *Ethereum) getNetVersion(_ []json.RawMessage) (interface{}, *rpcError) {
+	return e.chainID.String(), nil
+}
+
+func (e *Ethereum) getBlockNumber(_ []json.RawMessage) (interface{}, *rpcError) {
+	return hexutil.Uint64(e.ledger.Height()), nil
+}
+
+func (e *Ethereum) getBlockByNumber(params []json.RawMessage) (interface{}, *rpcError) {
+	var tag string
+	var fullTx bool
+	if err := decodeParam(params, 0, &tag, true); err != nil {
+		return nil, err
+	}
+	if err := decodeParam(params, 1, &fullTx, false); err != nil {
+		return nil, err
+	}
+	block, err := e.getBlockByTag(tag)
+	if err != nil {
+		return nil, err
+	}
+	return e.marshalBlock(block, fullTx)
+}
+
+func (e *Ethereum) getBlockByHash(params []json.RawMessage) (interface{}, *rpcError) {
+	var hash string
+	var fullTx bool
+	if err := decodeParam(params, 0, &hash, true); err != nil {
+		return nil, err
+	}
+	if err := decodeParam(params, 1, &fullTx, false); err != nil {
+		return nil, err
+	}
+	return e.marshalBlock(e.ledger.GetBlockByHash(hash), fullTx)
+}
+
+// getTx return the tx of the given hash, the block it has been mined in, and its index in the block
+func (e *Ethereum) getTx(params []json.RawMessage) (*etypes.Transaction, *Block, int, *rpcError) {
+	var hash string
+	if err := decodeParam(params, 0, &hash, true); err != nil {
+		return nil, nil, 0, err
+	}
+	item, block, err := e.ledger.GetTx(hash)
+	if err != nil {
+		return nil, nil, 0, nil
+	}
+	tx, err := decodeEthereumTx(item.Raw)
+	if err != nil {
+		return nil, nil, 0, newRPCError(rpcErrInternal, "%s", err)
+	}
+	idx := 0
+	if block != nil {
+		for i, blockTx := range block.Txs {
+			if blockTx.Hash == item.Hash {
+				idx = i
+			}
+		}
+	}
+	return tx, block, idx, nil
+}
+
+func (e *Ethereum) getTransactionByHash(params []json.RawMessage) (interface{}, *rpcError) {
+	tx, block, idx, rpcErr := e.getTx(params)
+	if rpcErr != nil || tx == nil {
+		return nil, rpcErr
+	}
+	result, err := e.marshalTx(tx, block, idx)
+	if err != nil {
+		return nil, newRPCError(rpcErrInternal, "%s", err)
+	}
+	return result, nil
+}
+
+func (e *Ethereum) getTransactionReceipt(params []json.RawMessage) (interface{}, *rpcError) {
+	tx, block, idx, rpcErr := e.getTx(params)
+	if rpcErr != nil || tx == nil || block == nil {
+		return nil, rpcErr
+	}
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	key := strings.ToLower(tx.Hash().Hex())
+	receipt := &etypes.Receipt{
+		Status:            etypes.ReceiptStatusSuccessful,
+		CumulativeGasUsed: tx.Gas(),
+		Logs:              []*etypes.Log{},
+		TxHash:            tx.Hash(),
+		GasUsed:           tx.Gas(),
+		BlockHash:         ecommon.HexToHash(block.Hash),
+		BlockNumber:       big.NewInt(block.Height),
+		TransactionIndex:  uint(idx),
+	}
+	if e.failedTxs[key] {
+		receipt.Status = etypes.ReceiptStatusFailed
+	}
+	for i, log := range e.logs[key] {
+		item := *log
+		if item.Topics == nil {
+			item.Topics = []ecommon.Hash{}
+		}
+		if item.Data == nil {
+			item.Data = []byte{}
+		}
+		item.TxHash = tx.Hash()
+		item.TxIndex = uint(idx)
+		item.BlockHash = receipt.BlockHash
+		item.BlockNumber = uint64(block.Height)
+		item.Index = uint(i)
+		receipt.Logs = append(receipt.Logs, &item)
+	}
+	receipt.Bloom = etypes.CreateBloom(etypes.Receipts{receipt})
+	return receipt, nil
+}
+
+func (e *Ethereum) getTransactionCount(params []json.RawMessage) (interface{}, *rpcError) {
+	var addr ecommon.Address
+	tag := "latest"
+	if err := decodeParam(params, 0, &addr, true); err != nil {
+		return nil, err
+	}
+	if err := decodeParam(params, 1, &tag, false); err != nil {
+		return nil, err
+	}
+	block, rpcErr := e.getBlockByTag(tag)
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+	if block == nil {
+		return nil, newRPCError(rpcErrInvalidParams, "block %s not found", tag)
+	}
+	var txs []Tx
+	for h := int64(0); h <= block.Height; h++ {
+		txs = append(txs, e.ledger.GetBlock(h).Txs...)
+	}
+	if tag == "pending" {
+		txs = append(txs, e.ledger.Mempool()...)
+	}
+	nonce := uint64(0)
+	for _, item := range txs {
+		tx, err := decodeEthereumTx(item.Raw)
+		if err != nil {
+			return nil, newRPCError(rpcErrInternal, "%s", err)
+		}
+		from, err := etypes.Sender(e.signer, tx)
+		if err != nil {
+			return nil, newRPCError(rpcErrInternal, "fail to get sender: %s", err)
+		}
+		if from == addr && tx.Nonce() >= nonce {
+			nonce = tx.Nonce() + 1
+		}
+	}
+	return hexutil.Uint64(nonce), nil
+}
+
+func (e *Ethereum) getBalance(params []json.RawMessage) (interface{}, *rpcError) {
+	var addr ecommon.Address
+	if err := decodeParam(params, 0, &addr, true); err != nil {
+		return nil, err
+	}
+	return (*hexutil.Big)(e.ledger.GetBalance(addr.Hex(), "ETH")), nil
+}
+
+func (e *Ethereum) getGasPrice(_ []json.RawMessage) (interface{}, *rpcError) {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	return (*hexutil.Big)(e.gasPrice), nil
+}
+
+func (e *Ethereum) getEstimateGas(_ []json.RawMessage) (interface{}, *rpcError) {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	return hexutil.Uint64(e.estimateGas), nil
+}
+
+func (e *Ethereum) call(params []json.RawMessage) (interface{}, *rpcError) {
+	var msg struct {
+		To   *ecommon.Address `json:"to"`
+		Data hexutil.Bytes    `json:"data"`
+	}
+	if err := decodeParam(params, 0, &msg, true); err != nil {
+		return nil, err
+	}
+	if msg.To == nil {
+		return hexutil.Bytes{}, nil
+	}
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	result, ok := e.callResults[callKey(*msg.To, msg.Data)]
+	if !ok {
+		return hexutil.Bytes{}, nil
+	}
+	return hexutil.Bytes(result), nil
+}
+
+func (e *Ethereum) sendRawTransaction(params []json.RawMessage) (interface{}, *rpcError) {
+	var raw hexutil.Bytes
+	if err := decodeParam(params, 0, &raw, true); err != nil {
+		return nil, err
+	}
+	tx, err := decodeEthereumTx(raw)
+	if err != nil {
+		return nil, newRPCError(rpcErrInvalidParams, "%s", err)
+	}
+	if _, err := etypes.Sender(e.signer, tx); err != nil {
+		return nil, newRPCError(rpcErrInvalidParams, "invalid sender: %s", err)
+	}
+	if err := e.ledger.Broadcast(Tx{Hash: tx.Hash().Hex(), Raw: raw}); err != nil {
+		return nil, newRPCError(rpcErrInternal, "%s", err)
+	}
+	return tx.Hash(), nil
+}
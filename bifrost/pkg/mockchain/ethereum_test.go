@@ -0,0 +1,115 @@
+package mockchain
+
+import (
+	"context"
+	"errors"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum"
+	ecommon "github.com/ethereum/go-ethereum/common"
+	etypes "github.com/ethereum/go-ethereum/core/types"
+	ecrypto "github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient"
+	. "gopkg.in/check.v1"
+)
+
+type EthereumSuite struct {
+	node   *Ethereum
+	client *ethclient.Client
+}
+
+var _ = Suite(&EthereumSuite{})
+
+func (s *EthereumSuite) SetUpTest(c *C) {
+	var err error
+	s.node, err = NewEthereum(big.NewInt(15))
+	c.Assert(err, IsNil)
+	s.client, err = ethclient.Dial(s.node.URL())
+	c.Assert(err, IsNil)
+}
+
+func (s *EthereumSuite) TearDownTest(c *C) {
+	s.client.Close()
+	s.node.Close()
+}
+
+func (s *EthereumSuite) TestChain(c *C) {
+	ctx := context.Background()
+	privKey, err := ecrypto.GenerateKey()
+	c.Assert(err, IsNil)
+	sender := ecrypto.PubkeyToAddress(privKey.PublicKey)
+	to := ecommon.HexToAddress("0x3fd2d4ce97b082d4bce3f9fee2a3d60668d2f473")
+	s.node.SetBalance(sender, big.NewInt(1000000))
+	s.node.SetGasPrice(big.NewInt(20))
+
+	chainID, err := s.client.ChainID(ctx)
+	c.Assert(err, IsNil)
+	c.Check(chainID.Int64(), Equals, int64(15))
+	balance, err := s.client.BalanceAt(ctx, sender, nil)
+	c.Assert(err, IsNil)
+	c.Check(balance.Int64(), Equals, int64(1000000))
+	gasPrice, err := s.client.SuggestGasPrice(ctx)
+	c.Assert(err, IsNil)
+	c.Check(gasPrice.Int64(), Equals, int64(20))
+
+	signer := etypes.NewEIP155Signer(chainID)
+	tx, err := etypes.SignTx(etypes.NewTransaction(0, to, big.NewInt(100), 21000, gasPrice, []byte("memo")), signer, privKey)
+	c.Assert(err, IsNil)
+	s.node.Ledger().SetBroadcastError(errors.New("kaboom"))
+	c.Check(s.client.SendTransaction(ctx, tx), NotNil)
+	s.node.Ledger().SetBroadcastError(nil)
+	c.Assert(s.client.SendTransaction(ctx, tx), IsNil)
+	nonce, err := s.client.PendingNonceAt(ctx, sender)
+	c.Assert(err, IsNil)
+	c.Check(nonce, Equals, uint64(1))
+	nonce, err = s.client.NonceAt(ctx, sender, nil)
+	c.Assert(err, IsNil)
+	c.Check(nonce, Equals, uint64(0))
+	_, err = s.client.TransactionReceipt(ctx, tx.Hash())
+	c.Check(err, Equals, ethereum.NotFound)
+
+	s.node.SetLogs(tx.Hash(), []*etypes.Log{
+		{
+			Address: to,
+			Topics:  []ecommon.Hash{ecommon.HexToHash("0x01")},
+		},
+	})
+	c.Assert(s.node.Ledger().MineBlocks(1), IsNil)
+	block, err := s.client.BlockByNumber(ctx, nil)
+	c.Assert(err, IsNil)
+	c.Check(block.NumberU64(), Equals, uint64(1))
+	c.Check(block.Hash().Hex(), Equals, s.node.Ledger().Tip().Hash)
+	c.Assert(block.Transactions(), HasLen, 1)
+	c.Check(block.Transactions()[0].Hash(), Equals, tx.Hash())
+	_, err = s.client.BlockByNumber(ctx, big.NewInt(2))
+	c.Check(err, Equals, ethereum.NotFound)
+
+	receipt, err := s.client.TransactionReceipt(ctx, tx.Hash())
+	c.Assert(err, IsNil)
+	c.Check(receipt.Status, Equals, etypes.ReceiptStatusSuccessful)
+	c.Check(receipt.BlockNumber.Int64(), Equals, int64(1))
+	c.Assert(receipt.Logs, HasLen, 1)
+	c.Check(receipt.Logs[0].Address, Equals, to)
+	c.Check(receipt.Logs[0].TxHash, Equals, tx.Hash())
+	nonce, err = s.client.NonceAt(ctx, sender, nil)
+	c.Assert(err, IsNil)
+	c.Check(nonce, Equals, uint64(1))
+
+	selector := []byte{0x70, 0xa0, 0x82, 0x31}
+	s.node.SetCallResult(to, selector, ecommon.LeftPadBytes([]byte{0x10}, 32))
+	output, err := s.client.CallContract(ctx, ethereum.CallMsg{To: &to, Data: append(selector, ecommon.LeftPadBytes(sender.Bytes(), 32)...)}, nil)
+	c.Assert(err, IsNil)
+	c.Check(new(big.Int).SetBytes(output).Int64(), Equals, int64(16))
+
+	// re-org the tx away, and make it fail in the new fork
+	s.node.SetTxFailed(tx.Hash())
+	c.Assert(s.node.Ledger().Reorg(1), IsNil)
+	newBlock, err := s.client.BlockByNumber(ctx, nil)
+	c.Assert(err, IsNil)
+	c.Check(newBlock.Hash(), Not(Equals), block.Hash())
+	c.Check(newBlock.ParentHash(), Equals, block.ParentHash())
+	receipt, err = s.client.TransactionReceipt(ctx, tx.Hash())
+	c.Assert(err, IsNil)
+	c.Check(receipt.Status, Equals, etypes.ReceiptStatusFailed)
+	c.Check(receipt.BlockHash, Equals, newBlock.Hash())
+}
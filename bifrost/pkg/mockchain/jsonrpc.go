@@ -0,0 +1,89 @@
+package mockchain
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/rs/zerolog/log"
+)
+
+// json rpc error codes used by the simulated nodes
+const (
+	rpcErrMethodNotFound = -32601
+	rpcErrInvalidParams  = -32602
+	rpcErrInternal       = -32603
+)
+
+type rpcRequest struct {
+	ID     json.RawMessage   `json:"id"`
+	Method string            `json:"method"`
+	Params []json.RawMessage `json:"params"`
+}
+
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+type rpcResponse struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Result  interface{}     `json:"result"`
+	Error   *rpcError       `json:"error"`
+}
+
+// rpcHandler handle the params of a json rpc method, and return the result
+type rpcHandler func(params []json.RawMessage) (interface{}, *rpcError)
+
+func newRPCError(code int, format string, args ...interface{}) *rpcError {
+	return &rpcError{
+		Code:    code,
+		Message: fmt.Sprintf(format, args...),
+	}
+}
+
+// serveJSONRPC dispatch json rpc requests to the handler of the method
+func serveJSONRPC(handlers map[string]rpcHandler) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req rpcRequest
+		resp := rpcResponse{
+			JSONRPC: "2.0",
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			resp.Error = newRPCError(rpcErrInvalidParams, "fail to decode request: %s", err)
+			writeJSON(w, resp)
+			return
+		}
+		resp.ID = req.ID
+		handler, ok := handlers[req.Method]
+		if !ok {
+			resp.Error = newRPCError(rpcErrMethodNotFound, "method %s not found", req.Method)
+			writeJSON(w, resp)
+			return
+		}
+		resp.Result, resp.Error = handler(req.Params)
+		writeJSON(w, resp)
+	}
+}
+
+// decodeParam unmarshal the param at idx into v, missing optional params are left untouched
+func decodeParam(params []json.RawMessage, idx int, v interface{}, required bool) *rpcError {
+	if idx >= len(params) {
+		if required {
+			return newRPCError(rpcErrInvalidParams, "missing param %d", idx)
+		}
+		return nil
+	}
+	if err := json.Unmarshal(params[idx], v); err != nil {
+		return newRPCError(rpcErrInvalidParams, "invalid param %d: %s", idx, err)
+	}
+	return nil
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		log.Error().Err(err).Msg("fail to write response")
+	}
+}
@@ -0,0 +1,305 @@
+package mockchain
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrTxNotFound is returned when the tx is neither in the mempool nor in any block
+var ErrTxNotFound = errors.New("tx not found")
+
+// Tx is a chain encoded transaction
+type Tx struct {
+	Hash string
+	Raw  []byte
+}
+
+// Block is a block of the simulated chain
+type Block struct {
+	Height       int64
+	Hash         string
+	PreviousHash string
+	Time         time.Time
+	// Fork is increased every time the chain is re-organised, so the blocks of a new fork never have the same hash as the orphaned ones
+	Fork uint64
+	Txs  []Tx
+}
+
+// BlockHasher calculate the hash of the given block in the format of the chain
+type BlockHasher func(block *Block) (string, error)
+
+// Ledger is a programmable chain, it keeps the blocks, the mempool and the balances of the accounts
+// Ledger only store the txs, it doesn't validate nor execute them, tests set the state they need explicitly
+type Ledger struct {
+	lock         *sync.RWMutex
+	blocks       []*Block
+	mempool      []Tx
+	balances     map[string]map[string]*big.Int
+	broadcastErr error
+	fork         uint64
+	clock        time.Time
+	blockTime    time.Duration
+	hasher       BlockHasher
+}
+
+// NewLedger create a new Ledger with a genesis block at height 0
+func NewLedger(hasher BlockHasher) (*Ledger, error) {
+	if hasher == nil {
+		hasher = defaultBlockHasher
+	}
+	l := &Ledger{
+		lock:      &sync.RWMutex{},
+		balances:  make(map[string]map[string]*big.Int),
+		clock:     time.Unix(1590000000, 0).UTC(),
+		blockTime: 10 * time.Second,
+		hasher:    hasher,
+	}
+	if _, err := l.mine(nil); err != nil {
+		return nil, fmt.Errorf("fail to create genesis block: %w", err)
+	}
+	return l, nil
+}
+
+// defaultBlockHasher hash the block height, fork, previous hash and all the tx hashes with sha256
+func defaultBlockHasher(block *Block) (string, error) {
+	h := sha256.New()
+	buf := make([]byte, 16)
+	binary.BigEndian.PutUint64(buf[:8], uint64(block.Height))
+	binary.BigEndian.PutUint64(buf[8:], block.Fork)
+	h.Write(buf)
+	h.Write([]byte(block.PreviousHash))
+	for _, tx := range block.Txs {
+		h.Write([]byte(tx.Hash))
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// mine add a new block with the given txs on top of the chain, caller need to hold the lock
+func (l *Ledger) mine(txs []Tx) (*Block, error) {
+	block := &Block{
+		Height: int64(len(l.blocks)),
+		Time:   l.clock.Add(l.blockTime * time.Duration(len(l.blocks))),
+		Fork:   l.fork,
+		Txs:    txs,
+	}
+	if len(l.blocks) > 0 {
+		block.PreviousHash = l.blocks[len(l.blocks)-1].Hash
+	}
+	hash, err := l.hasher(block)
+	if err != nil {
+		return nil, fmt.Errorf("fail to hash block(%d): %w", block.Height, err)
+	}
+	block.Hash = hash
+	l.blocks = append(l.blocks, block)
+	return block, nil
+}
+
+// Mine add a new block including all the txs in the mempool
+func (l *Ledger) Mine() (*Block, error) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	block, err := l.mine(l.mempool)
+	if err != nil {
+		return nil, err
+	}
+	l.mempool = nil
+	return block, nil
+}
+
+// MineBlocks mine the given number of blocks, the first block include all the txs in the mempool
+func (l *Ledger) MineBlocks(count int) error {
+	for i := 0; i < count; i++ {
+		if _, err := l.Mine(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Reorg orphan the last depth blocks, and replace them with the same number of blocks of a new fork
+// txs in the orphaned blocks are included in the first block of the new fork, except the dropped ones
+func (l *Ledger) Reorg(depth int, dropped ...string) error {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	if depth <= 0 || depth >= len(l.blocks) {
+		return fmt.Errorf("invalid reorg depth(%d), chain height is %d", depth, len(l.blocks)-1)
+	}
+	var txs []Tx
+	for _, block := range l.blocks[len(l.blocks)-depth:] {
+		for _, tx := range block.Txs {
+			if !containsHash(dropped, tx.Hash) {
+				txs = append(txs, tx)
+			}
+		}
+	}
+	l.blocks = l.blocks[:len(l.blocks)-depth]
+	l.fork++
+	for i := 0; i < depth; i++ {
+		if _, err := l.mine(txs); err != nil {
+			return err
+		}
+		txs = nil
+	}
+	return nil
+}
+
+func containsHash(hashes []string, hash string) bool {
+	for _, item := range hashes {
+		if strings.EqualFold(item, hash) {
+			return true
+		}
+	}
+	return false
+}
+
+// AddTx add the given tx to mempool regardless of the broadcast error
+func (l *Ledger) AddTx(tx Tx) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	l.mempool = append(l.mempool, tx)
+}
+
+// Broadcast add the given tx to mempool, it fails when a broadcast error has been set, or the tx is known already
+func (l *Ledger) Broadcast(tx Tx) error {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	if l.broadcastErr != nil {
+		return l.broadcastErr
+	}
+	for _, item := range l.mempool {
+		if strings.EqualFold(item.Hash, tx.Hash) {
+			return fmt.Errorf("tx(%s) is in mempool already", tx.Hash)
+		}
+	}
+	if _, _, err := l.getTx(tx.Hash); err == nil {
+		return fmt.Errorf("tx(%s) has been committed already", tx.Hash)
+	}
+	l.mempool = append(l.mempool, tx)
+	return nil
+}
+
+// SetBroadcastError make all the following broadcast fail with the given error, nil make broadcast work again
+func (l *Ledger) SetBroadcastError(err error) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	l.broadcastErr = err
+}
+
+// Height return the height of the tip of the chain
+func (l *Ledger) Height() int64 {
+	l.lock.RLock()
+	defer l.lock.RUnlock()
+	return int64(len(l.blocks) - 1)
+}
+
+// Tip return the latest block
+func (l *Ledger) Tip() *Block {
+	l.lock.RLock()
+	defer l.lock.RUnlock()
+	return l.blocks[len(l.blocks)-1]
+}
+
+// GetBlock return the block at the given height, nil when the chain is not that high yet
+func (l *Ledger) GetBlock(height int64) *Block {
+	l.lock.RLock()
+	defer l.lock.RUnlock()
+	if height < 0 || height >= int64(len(l.blocks)) {
+		return nil
+	}
+	return l.blocks[height]
+}
+
+// GetBlockByHash return the block of the given hash in the current fork, nil when it doesn't exist
+func (l *Ledger) GetBlockByHash(hash string) *Block {
+	l.lock.RLock()
+	defer l.lock.RUnlock()
+	for _, block := range l.blocks {
+		if strings.EqualFold(block.Hash, hash) {
+			return block
+		}
+	}
+	return nil
+}
+
+// Mempool return the txs that have not been mined yet
+func (l *Ledger) Mempool() []Tx {
+	l.lock.RLock()
+	defer l.lock.RUnlock()
+	return append([]Tx{}, l.mempool...)
+}
+
+// InMempool return true when the tx of the given hash is in mempool
+func (l *Ledger) InMempool(hash string) bool {
+	l.lock.RLock()
+	defer l.lock.RUnlock()
+	for _, tx := range l.mempool {
+		if strings.EqualFold(tx.Hash, hash) {
+			return true
+		}
+	}
+	return false
+}
+
+// GetTx return the tx of the given hash, and the block it has been included in
+// the block is nil when the tx is still in mempool
+func (l *Ledger) GetTx(hash string) (Tx, *Block, error) {
+	l.lock.RLock()
+	defer l.lock.RUnlock()
+	for _, tx := range l.mempool {
+		if strings.EqualFold(tx.Hash, hash) {
+			return tx, nil, nil
+		}
+	}
+	return l.getTx(hash)
+}
+
+func (l *Ledger) getTx(hash string) (Tx, *Block, error) {
+	for _, block := range l.blocks {
+		for _, tx := range block.Txs {
+			if strings.EqualFold(tx.Hash, hash) {
+				return tx, block, nil
+			}
+		}
+	}
+	return Tx{}, nil, ErrTxNotFound
+}
+
+// SetBalance set the balance of the given denom of an address
+func (l *Ledger) SetBalance(addr, denom string, amount *big.Int) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	addr = strings.ToLower(addr)
+	if _, ok := l.balances[addr]; !ok {
+		l.balances[addr] = make(map[string]*big.Int)
+	}
+	l.balances[addr][denom] = new(big.Int).Set(amount)
+}
+
+// GetBalance return the balance of the given denom of an address, zero when it has not been set
+func (l *Ledger) GetBalance(addr, denom string) *big.Int {
+	l.lock.RLock()
+	defer l.lock.RUnlock()
+	amount, ok := l.balances[strings.ToLower(addr)][denom]
+	if !ok {
+		return big.NewInt(0)
+	}
+	return new(big.Int).Set(amount)
+}
+
+// GetBalances return all the balances of an address, keyed by denom
+func (l *Ledger) GetBalances(addr string) map[string]*big.Int {
+	l.lock.RLock()
+	defer l.lock.RUnlock()
+	result := make(map[string]*big.Int)
+	for denom, amount := range l.balances[strings.ToLower(addr)] {
+		result[denom] = new(big.Int).Set(amount)
+	}
+	return result
+}
@@ -0,0 +1,104 @@
+package mockchain
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	. "gopkg.in/check.v1"
+)
+
+func TestPackage(t *testing.T) { TestingT(t) }
+
+type LedgerSuite struct{}
+
+var _ = Suite(&LedgerSuite{})
+
+func (s *LedgerSuite) TestMine(c *C) {
+	ledger, err := NewLedger(nil)
+	c.Assert(err, IsNil)
+	c.Check(ledger.Height(), Equals, int64(0))
+	genesis := ledger.Tip()
+	c.Check(genesis.PreviousHash, Equals, "")
+
+	ledger.AddTx(Tx{Hash: "tx1", Raw: []byte("tx1")})
+	c.Check(ledger.InMempool("tx1"), Equals, true)
+	tx, block, err := ledger.GetTx("tx1")
+	c.Assert(err, IsNil)
+	c.Check(tx.Hash, Equals, "tx1")
+	c.Check(block, IsNil)
+
+	block, err = ledger.Mine()
+	c.Assert(err, IsNil)
+	c.Check(block.Height, Equals, int64(1))
+	c.Check(block.PreviousHash, Equals, genesis.Hash)
+	c.Check(block.Txs, HasLen, 1)
+	c.Check(ledger.Mempool(), HasLen, 0)
+	c.Check(ledger.GetBlockByHash(block.Hash), Equals, block)
+	_, txBlock, err := ledger.GetTx("TX1")
+	c.Assert(err, IsNil)
+	c.Check(txBlock, Equals, block)
+	_, _, err = ledger.GetTx("tx2")
+	c.Check(err, Equals, ErrTxNotFound)
+
+	c.Assert(ledger.MineBlocks(2), IsNil)
+	c.Check(ledger.Height(), Equals, int64(3))
+	c.Check(ledger.GetBlock(4), IsNil)
+	c.Check(ledger.GetBlock(-1), IsNil)
+}
+
+func (s *LedgerSuite) TestBroadcast(c *C) {
+	ledger, err := NewLedger(nil)
+	c.Assert(err, IsNil)
+	c.Assert(ledger.Broadcast(Tx{Hash: "tx1"}), IsNil)
+	c.Check(ledger.Broadcast(Tx{Hash: "tx1"}), NotNil)
+	_, err = ledger.Mine()
+	c.Assert(err, IsNil)
+	c.Check(ledger.Broadcast(Tx{Hash: "tx1"}), NotNil)
+
+	ledger.SetBroadcastError(errors.New("kaboom"))
+	c.Check(ledger.Broadcast(Tx{Hash: "tx2"}), ErrorMatches, "kaboom")
+	// AddTx bypass the broadcast error
+	ledger.AddTx(Tx{Hash: "tx3"})
+	c.Check(ledger.InMempool("tx3"), Equals, true)
+	ledger.SetBroadcastError(nil)
+	c.Assert(ledger.Broadcast(Tx{Hash: "tx2"}), IsNil)
+}
+
+func (s *LedgerSuite) TestReorg(c *C) {
+	ledger, err := NewLedger(nil)
+	c.Assert(err, IsNil)
+	ledger.AddTx(Tx{Hash: "tx1"})
+	ledger.AddTx(Tx{Hash: "tx2"})
+	c.Assert(ledger.MineBlocks(3), IsNil)
+	orphaned := ledger.GetBlock(1)
+
+	c.Check(ledger.Reorg(0), NotNil)
+	c.Check(ledger.Reorg(4), NotNil)
+	c.Assert(ledger.Reorg(3, "tx2"), IsNil)
+	c.Check(ledger.Height(), Equals, int64(3))
+	block := ledger.GetBlock(1)
+	c.Check(block.Hash, Not(Equals), orphaned.Hash)
+	c.Check(block.PreviousHash, Equals, orphaned.PreviousHash)
+	c.Check(block.Fork, Equals, uint64(1))
+	c.Assert(block.Txs, HasLen, 1)
+	c.Check(block.Txs[0].Hash, Equals, "tx1")
+	c.Check(ledger.GetBlockByHash(orphaned.Hash), IsNil)
+	_, _, err = ledger.GetTx("tx2")
+	c.Check(err, Equals, ErrTxNotFound)
+	c.Check(ledger.GetBlock(2).PreviousHash, Equals, block.Hash)
+}
+
+func (s *LedgerSuite) TestBalance(c *C) {
+	ledger, err := NewLedger(nil)
+	c.Assert(err, IsNil)
+	c.Check(ledger.GetBalance("addr", "BNB").Int64(), Equals, int64(0))
+	amount := big.NewInt(100)
+	ledger.SetBalance("ADDR", "BNB", amount)
+	amount.SetInt64(1)
+	c.Check(ledger.GetBalance("addr", "BNB").Int64(), Equals, int64(100))
+	ledger.SetBalance("addr", "RUNE-A1F", big.NewInt(5))
+	balances := ledger.GetBalances("addr")
+	c.Assert(balances, HasLen, 2)
+	c.Check(balances["RUNE-A1F"].Int64(), Equals, int64(5))
+}
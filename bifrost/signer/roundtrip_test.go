@@ -0,0 +1,395 @@
+package signer
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/btcsuite/btcutil"
+	"github.com/cosmos/cosmos-sdk/client/keys"
+	"github.com/cosmos/cosmos-sdk/codec"
+	cKeys "github.com/cosmos/cosmos-sdk/crypto/keys"
+	"github.com/rs/zerolog/log"
+	. "gopkg.in/check.v1"
+
+	"gitlab.com/thorchain/thornode/bifrost/blockscanner"
+	"gitlab.com/thorchain/thornode/bifrost/config"
+	"gitlab.com/thorchain/thornode/bifrost/metrics"
+	"gitlab.com/thorchain/thornode/bifrost/observer"
+	"gitlab.com/thorchain/thornode/bifrost/pkg/chainclients"
+	"gitlab.com/thorchain/thornode/bifrost/pkg/chainclients/bitcoin"
+	"gitlab.com/thorchain/thornode/bifrost/pkg/mockchain"
+	pubkeymanager "gitlab.com/thorchain/thornode/bifrost/pubkeymanager"
+	"gitlab.com/thorchain/thornode/bifrost/thorclient"
+	stypes "gitlab.com/thorchain/thornode/bifrost/thorclient/types"
+	"gitlab.com/thorchain/thornode/common"
+	"gitlab.com/thorchain/thornode/x/thorchain"
+	types2 "gitlab.com/thorchain/thornode/x/thorchain/types"
+)
+
+// vaultValidator is a pubkey manager that only knows the yggdrasil vault of this node
+type vaultValidator struct {
+	*pubkeymanager.MockPoolAddressValidator
+	vault common.PubKey
+}
+
+func (v vaultValidator) GetSignPubKeys() common.PubKeys    { return common.PubKeys{v.vault} }
+func (v vaultValidator) GetNodePubKey() common.PubKey      { return v.vault }
+func (v vaultValidator) HasPubKey(pk common.PubKey) bool   { return pk.Equals(v.vault) }
+func (v vaultValidator) Start() error                      { return nil }
+func (v vaultValidator) Stop() error                       { return nil }
+func (v vaultValidator) AddPubKey(_ common.PubKey, _ bool) {}
+
+func (v vaultValidator) IsValidPoolAddress(addr string, chain common.Chain) (bool, common.ChainPoolInfo) {
+	vaultAddr, err := v.vault.GetAddress(chain)
+	if err != nil || !strings.EqualFold(vaultAddr.String(), addr) {
+		return false, common.EmptyChainPoolInfo
+	}
+	cpi, err := common.NewChainPoolInfo(chain, v.vault)
+	if err != nil {
+		return false, common.EmptyChainPoolInfo
+	}
+	return true, cpi
+}
+
+// mockThorchain serve the thorchain endpoints bifrost use, an outbound refunding the sender is scheduled for every inbound observed
+type mockThorchain struct {
+	lock     sync.Mutex
+	server   *httptest.Server
+	cdc      *codec.Codec
+	height   int64
+	observed []types2.ObservedTx
+	keysign  map[int64]stypes.TxOut
+}
+
+func newMockThorchain(c *C) *mockThorchain {
+	t := &mockThorchain{
+		cdc:     thorclient.MakeCodec(),
+		height:  1,
+		keysign: make(map[int64]stypes.TxOut),
+	}
+	t.server = httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		t.lock.Lock()
+		defer t.lock.Unlock()
+		var result interface{}
+		switch {
+		case strings.HasPrefix(req.RequestURI, thorclient.LastBlockEndpoint):
+			result = types2.QueryResHeights{
+				Chain:      common.BTCChain,
+				Statechain: t.height,
+			}
+		case strings.HasPrefix(req.RequestURI, thorclient.NodeAccountEndpoint):
+			result = types2.GetRandomNodeAccount(types2.Active)
+		case strings.HasPrefix(req.RequestURI, thorclient.AsgardVault):
+			result = types2.Vaults{}
+		case strings.HasPrefix(req.RequestURI, thorclient.AuthAccountEndpoint):
+			_, err := rw.Write([]byte(`{ "height": "0", "result": { "value": { "account_number": "0", "sequence": "0" } } }`))
+			c.Assert(err, IsNil)
+			return
+		case strings.HasPrefix(req.RequestURI, thorclient.BroadcastTxsEndpoint):
+			buf, err := ioutil.ReadAll(req.Body)
+			c.Assert(err, IsNil)
+			var setTx stypes.SetTx
+			c.Assert(t.cdc.UnmarshalJSON(buf, &setTx), IsNil)
+			for _, msg := range setTx.Tx.Msg {
+				if m, ok := msg.(types2.MsgObservedTxIn); ok {
+					t.observeTxIns(m.Txs)
+				}
+			}
+			_, err = rw.Write([]byte(`{ "height": "1", "txhash": "AAAA000000000000000000000000000000000000000000000000000000000000", "logs": [{"success": true, "log": ""}] }`))
+			c.Assert(err, IsNil)
+			return
+		case strings.HasPrefix(req.RequestURI, thorclient.KeysignEndpoint):
+			height := t.parseHeight(c, req.RequestURI)
+			if height > t.height {
+				rw.WriteHeader(http.StatusNotFound)
+				return
+			}
+			chains := stypes.ChainsTxOut{
+				Chains: make(map[common.Chain]stypes.TxOut),
+			}
+			if txOut, ok := t.keysign[height]; ok {
+				chains.Chains[txOut.Chain] = txOut
+			}
+			buf, err := json.Marshal(chains)
+			c.Assert(err, IsNil)
+			_, err = rw.Write(buf)
+			c.Assert(err, IsNil)
+			return
+		case strings.HasPrefix(req.RequestURI, thorclient.KeygenEndpoint):
+			height := t.parseHeight(c, req.RequestURI)
+			if height > t.height {
+				rw.WriteHeader(http.StatusNotFound)
+				return
+			}
+			result = types2.KeygenBlock{Height: height}
+		default:
+			rw.WriteHeader(http.StatusNotFound)
+			return
+		}
+		buf, err := t.cdc.MarshalJSON(result)
+		c.Assert(err, IsNil)
+		_, err = rw.Write(buf)
+		c.Assert(err, IsNil)
+	}))
+	return t
+}
+
+// parseHeight return the block height of the keysign and keygen endpoints, /{endpoint}/{height}/{pubkey}
+func (t *mockThorchain) parseHeight(c *C, uri string) int64 {
+	parts := strings.Split(strings.Trim(uri, "/"), "/")
+	c.Assert(len(parts) >= 3, Equals, true)
+	height, err := strconv.ParseInt(parts[2], 10, 64)
+	c.Assert(err, IsNil)
+	return height
+}
+
+// observeTxIns record the observed txs, and schedule an outbound at the next block to refund the inbound ones
+func (t *mockThorchain) observeTxIns(txs types2.ObservedTxs) {
+	for _, tx := range txs {
+		t.observed = append(t.observed, tx)
+		vaultAddr, err := tx.ObservedPubKey.GetAddress(common.BTCChain)
+		if err != nil || !tx.Tx.ToAddress.Equals(vaultAddr) {
+			continue
+		}
+		t.height++
+		coin := tx.Tx.Coins.GetCoin(common.BTCAsset)
+		maxGas := common.NewCoin(common.BTCAsset, coin.Amount.QuoUint64(10))
+		t.keysign[t.height] = stypes.TxOut{
+			Height: t.height,
+			Chain:  common.BTCChain,
+			TxArray: []stypes.TxArrayItem{
+				{
+					Chain:       common.BTCChain,
+					ToAddress:   tx.Tx.FromAddress,
+					VaultPubKey: tx.ObservedPubKey,
+					Coin:        common.NewCoin(common.BTCAsset, coin.Amount.Sub(maxGas.Amount.MulUint64(2))),
+					Memo:        thorchain.NewRefundMemo(tx.Tx.ID).String(),
+					MaxGas:      common.Gas{maxGas},
+					InHash:      tx.Tx.ID,
+				},
+			},
+		}
+	}
+}
+
+func (t *mockThorchain) getObserved() []types2.ObservedTx {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	return append([]types2.ObservedTx{}, t.observed...)
+}
+
+func (t *mockThorchain) getHeight() int64 {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	return t.height
+}
+
+type RoundTripSuite struct {
+	thordir   string
+	thorKeys  *thorclient.Keys
+	bridge    *thorclient.ThorchainBridge
+	m         *metrics.Metrics
+	thorchain *mockThorchain
+	node      *mockchain.Bitcoind
+}
+
+var _ = Suite(&RoundTripSuite{})
+
+func (s *RoundTripSuite) SetUpTest(c *C) {
+	thorchain.SetupConfigForTest()
+	s.m = GetMetricForTest(c)
+	c.Assert(os.Setenv("NET", "testnet"), IsNil)
+	var err error
+	s.node, err = mockchain.NewBitcoind(&chaincfg.TestNet3Params)
+	c.Assert(err, IsNil)
+	s.thorchain = newMockThorchain(c)
+
+	s.thordir = filepath.Join(os.TempDir(), strconv.Itoa(time.Now().Nanosecond()), ".thorcli")
+	cfg := config.ClientConfiguration{
+		ChainID:         "thorchain",
+		ChainHost:       s.thorchain.server.Listener.Addr().String(),
+		SignerName:      "bob",
+		SignerPasswd:    "password",
+		ChainHomeFolder: s.thordir,
+	}
+	kb, err := keys.NewKeyBaseFromDir(s.thordir)
+	c.Assert(err, IsNil)
+	_, _, err = kb.CreateMnemonic(cfg.SignerName, cKeys.English, cfg.SignerPasswd, cKeys.Secp256k1)
+	c.Assert(err, IsNil)
+	s.thorKeys, err = thorclient.NewKeys(cfg.ChainHomeFolder, cfg.SignerName, cfg.SignerPasswd)
+	c.Assert(err, IsNil)
+	s.bridge, err = thorclient.NewThorchainBridge(cfg, s.m)
+	c.Assert(err, IsNil)
+}
+
+func (s *RoundTripSuite) TearDownTest(c *C) {
+	s.node.Close()
+	s.thorchain.server.Close()
+	c.Assert(os.Unsetenv("NET"), IsNil)
+	if err := os.RemoveAll(s.thordir); err != nil {
+		c.Error(err)
+	}
+}
+
+// waitFor poll the given condition until it is met, or fail the test after a while
+func waitFor(c *C, what string, cond func() bool) {
+	for i := 0; i < 200; i++ {
+		if cond() {
+			return
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+	c.Fatalf("timeout waiting for %s", what)
+}
+
+func (s *RoundTripSuite) TestObserveAndRefund(c *C) {
+	vault, err := common.NewPubKeyFromCrypto(s.thorKeys.GetSignerInfo().GetPubKey())
+	c.Assert(err, IsNil)
+	vaultAddr, err := vault.GetAddress(common.BTCChain)
+	c.Assert(err, IsNil)
+	pubkeyMgr := vaultValidator{
+		MockPoolAddressValidator: pubkeymanager.NewMockPoolAddressValidator(),
+		vault:                    vault,
+	}
+
+	client, err := bitcoin.NewClient(s.thorKeys, config.ChainConfiguration{
+		ChainID:     common.BTCChain,
+		RPCHost:     s.node.Host(),
+		UserName:    "bob",
+		Password:    "password",
+		DisableTLS:  true,
+		HTTPostMode: true,
+		BlockScanner: config.BlockScannerConfiguration{
+			ChainID:                    common.BTCChain,
+			StartBlockHeight:           1,
+			BlockHeightDiscoverBackoff: 100 * time.Millisecond,
+		},
+	}, nil, s.bridge, s.m)
+	c.Assert(err, IsNil)
+	chains := map[common.Chain]chainclients.ChainClient{
+		common.BTCChain: client,
+	}
+
+	// a user send BTC to the vault with a memo, in block 2
+	user := types2.GetRandomPubKey()
+	userAddr, err := user.GetAddress(common.BTCChain)
+	c.Assert(err, IsNil)
+	fundTxID, err := s.node.Fund(userAddr.String(), 1100000)
+	c.Assert(err, IsNil)
+	c.Assert(s.node.Ledger().MineBlocks(1), IsNil)
+	fundHash, err := chainhash.NewHashFromStr(fundTxID)
+	c.Assert(err, IsNil)
+	toScript, err := txscript.PayToAddrScript(mustDecodeAddress(c, vaultAddr.String()))
+	c.Assert(err, IsNil)
+	memoScript, err := txscript.NullDataScript([]byte("SWAP:BNB.BNB"))
+	c.Assert(err, IsNil)
+	inbound := wire.NewMsgTx(wire.TxVersion)
+	inbound.AddTxIn(wire.NewTxIn(wire.NewOutPoint(fundHash, 0), nil, nil))
+	inbound.AddTxOut(wire.NewTxOut(1000000, toScript))
+	inbound.AddTxOut(wire.NewTxOut(0, memoScript))
+	inboundTxID, err := s.node.AddTx(inbound)
+	c.Assert(err, IsNil)
+	c.Assert(s.node.Ledger().MineBlocks(1), IsNil)
+
+	// observer report the inbound to thorchain, and the chain client keep the UTXO to spend it later
+	obs, err := observer.NewObserver(pubkeyMgr, chains, s.bridge, s.m)
+	c.Assert(err, IsNil)
+	c.Assert(obs.Start(), IsNil)
+	waitFor(c, "inbound observation", func() bool {
+		return len(s.thorchain.getObserved()) > 0
+	})
+	observed := s.thorchain.getObserved()
+	c.Assert(observed, HasLen, 1)
+	c.Check(observed[0].Tx.ID.String(), Equals, strings.ToUpper(inboundTxID))
+	c.Check(observed[0].Tx.FromAddress.String(), Equals, userAddr.String())
+	c.Check(observed[0].Tx.Memo, Equals, "SWAP:BNB.BNB")
+	c.Check(observed[0].BlockHeight, Equals, int64(2))
+	c.Check(observed[0].ObservedPubKey.Equals(vault), Equals, true)
+	c.Check(observed[0].Tx.Coins.GetCoin(common.BTCAsset).Amount.Uint64(), Equals, uint64(1000000))
+	waitFor(c, "vault UTXO", func() bool {
+		acct, err := client.GetAccount(vault)
+		return err == nil && len(acct.Coins) > 0 && acct.Coins[0].Amount > 0
+	})
+	c.Assert(s.thorchain.getHeight(), Equals, int64(2))
+
+	// signer pick up the refund thorchain scheduled, sign it with the vault key and broadcast it to the chain
+	signerCfg := config.BlockScannerConfiguration{
+		ChainID:                    common.THORChain,
+		StartBlockHeight:           1,
+		BlockHeightDiscoverBackoff: 100 * time.Millisecond,
+	}
+	storage, err := NewSignerStore("", "")
+	c.Assert(err, IsNil)
+	blockScan, err := NewThorchainBlockScan(signerCfg, storage, s.bridge, s.m, pubkeyMgr)
+	c.Assert(err, IsNil)
+	blockScanner, err := blockscanner.NewBlockScanner(signerCfg, storage, s.m, s.bridge, blockScan)
+	c.Assert(err, IsNil)
+	sign := &Signer{
+		logger:                log.With().Str("module", "signer").Logger(),
+		cfg:                   config.SignerConfiguration{BlockScanner: signerCfg},
+		wg:                    &sync.WaitGroup{},
+		stopChan:              make(chan struct{}),
+		blockScanner:          blockScanner,
+		thorchainBlockScanner: blockScan,
+		chains:                chains,
+		m:                     s.m,
+		storage:               storage,
+		errCounter:            s.m.GetCounterVec(metrics.SignerError),
+		pubkeyMgr:             pubkeyMgr,
+		thorchainBridge:       s.bridge,
+	}
+	c.Assert(sign.Start(), IsNil)
+	waitFor(c, "outbound broadcast", func() bool {
+		return len(s.node.Ledger().Mempool()) > 0
+	})
+	c.Assert(obs.Stop(), IsNil)
+	c.Assert(sign.Stop(), IsNil)
+
+	mempool := s.node.Ledger().Mempool()
+	c.Assert(mempool, HasLen, 1)
+	outbound := wire.NewMsgTx(wire.TxVersion)
+	c.Assert(outbound.Deserialize(bytes.NewReader(mempool[0].Raw)), IsNil)
+	// spend the UTXO of the inbound
+	c.Assert(outbound.TxIn, HasLen, 1)
+	c.Check(outbound.TxIn[0].PreviousOutPoint.String(), Equals, fmt.Sprintf("%s:0", inboundTxID))
+	userScript, err := txscript.PayToAddrScript(mustDecodeAddress(c, userAddr.String()))
+	c.Assert(err, IsNil)
+	refundMemo, err := txscript.NullDataScript([]byte(thorchain.NewRefundMemo(observed[0].Tx.ID).String()))
+	c.Assert(err, IsNil)
+	var paid, changed int64
+	var hasMemo bool
+	for _, out := range outbound.TxOut {
+		switch {
+		case bytes.Equal(out.PkScript, userScript):
+			paid += out.Value
+		case bytes.Equal(out.PkScript, toScript):
+			changed += out.Value
+		case bytes.Equal(out.PkScript, refundMemo):
+			hasMemo = true
+		}
+	}
+	// 1000000 inbound, 100000 max gas, 800000 refund
+	c.Check(paid, Equals, int64(800000))
+	c.Check(changed, Equals, int64(100000))
+	c.Check(hasMemo, Equals, true)
+}
+
+func mustDecodeAddress(c *C, addr string) btcutil.Address {
+	address, err := btcutil.DecodeAddress(addr, &chaincfg.TestNet3Params)
+	c.Assert(err, IsNil)
+	return address
+}
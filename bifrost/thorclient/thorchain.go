@@ -40,6 +40,7 @@ const (
 	SignerMembershipEndpoint = "/thorchain/vaults/%s/signers"
 	StatusEndpoint           = "/status"
 	AsgardVault              = "/thorchain/vaults/asgard"
+	PoolsEndpoint            = "/thorchain/pools"
 )
 
 // ThorchainBridge will be used to send tx to thorchain
@@ -222,6 +223,22 @@ func (b *ThorchainBridge) PostKeysignFailure(blame blame.Blame, height int64, me
 	return b.Broadcast(stdTx, types.TxSync)
 }
 
+// PostNetworkFee send the network fee rate of the given chain to thorchain, so it can work out the max gas of outbound txs
+func (b *ThorchainBridge) PostNetworkFee(height int64, chain common.Chain, transactionSize, transactionRate uint64) (common.TxID, error) {
+	start := time.Now()
+	defer func() {
+		b.m.GetHistograms(metrics.SignToThorchainDuration).Observe(time.Since(start).Seconds())
+	}()
+	msg := stypes.NewMsgNetworkFee(height, chain, transactionSize, transactionRate, b.keys.GetSignerInfo().GetAddress())
+	stdTx := authtypes.NewStdTx(
+		[]sdk.Msg{msg},
+		authtypes.NewStdFee(100000000, nil), // fee
+		nil,                                 // signatures
+		"",                                  // memo
+	)
+	return b.Broadcast(stdTx, types.TxSync)
+}
+
 // GetErrataStdTx get errata tx from params
 func (b *ThorchainBridge) GetErrataStdTx(txID common.TxID, chain common.Chain) (*authtypes.StdTx, error) {
 	start := time.Now()
@@ -404,3 +421,19 @@ func (b *ThorchainBridge) GetAsgards() (stypes.Vaults, error) {
 	}
 	return vaults, nil
 }
+
+// GetPools retrieve all the pools from thorchain
+func (b *ThorchainBridge) GetPools() (stypes.Pools, error) {
+	buf, s, err := b.getWithPath(PoolsEndpoint)
+	if err != nil {
+		return nil, fmt.Errorf("fail to get pools: %w", err)
+	}
+	if s != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code %d", s)
+	}
+	var pools stypes.Pools
+	if err := b.cdc.UnmarshalJSON(buf, &pools); err != nil {
+		return nil, fmt.Errorf("fail to unmarshal pools from json: %w", err)
+	}
+	return pools, nil
+}
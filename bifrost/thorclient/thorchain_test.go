@@ -49,6 +49,8 @@ func (s *ThorchainSuite) SetUpSuite(c *C) {
 			httpTestHandler(c, rw, "../../test/fixtures/endpoints/tss/keysign_party.json")
 		case strings.HasPrefix(req.RequestURI, AsgardVault):
 			httpTestHandler(c, rw, "../../test/fixtures/endpoints/vaults/asgard.json")
+		case strings.HasPrefix(req.RequestURI, PoolsEndpoint):
+			httpTestHandler(c, rw, "../../test/fixtures/endpoints/pools/pools.json")
 		}
 	}))
 	s.cfg.ChainHost = s.server.Listener.Addr().String()
@@ -246,3 +248,10 @@ func (s *ThorchainSuite) TestGetAsgards(c *C) {
 	c.Assert(err, IsNil)
 	c.Assert(vaults, NotNil)
 }
+
+func (s *ThorchainSuite) TestGetPools(c *C) {
+	pools, err := s.bridge.GetPools()
+	c.Assert(err, IsNil)
+	c.Assert(pools, HasLen, 2)
+	c.Check(pools[1].Asset.Symbol.String(), Equals, "TKN-0X40BCD4DB8889A8BF0B1391D0C819DCD9627F9D0A")
+}
@@ -4,6 +4,7 @@ CHAIN_ID="${CHAIN_ID:=thorchain}"
 BINANCE_HOST="${BINANCE_HOST:=https://data-seed-pre-0-s3.binance.org}"
 BTC_HOST="${BTC_HOST:=127.0.0.1:18443}"
 ETH_HOST="${ETH_HOST:=http://ethereum-localnet:8545}"
+ETH_ROUTER="${ETH_ROUTER:=}"
 DB_PATH="${DB_PATH:=/var/data}"
 CHAIN_API="${CHAIN_API:=127.0.0.1:1317}"
 CHAIN_RPC="${CHAIN_RPC:=127.0.0.1:26657}"
@@ -78,6 +79,7 @@ if [ ! -f /etc/bifrost/config.json ]; then
         {
           \"chain_id\": \"ETH\",
           \"rpc_host\": \"$ETH_HOST\",
+          \"router_address\": \"$ETH_ROUTER\",
           \"username\": \"$SIGNER_NAME\",
           \"password\": \"$SIGNER_PASSWD\",
           \"http_post_mode\": 1,
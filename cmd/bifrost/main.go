@@ -27,6 +27,12 @@ import (
 	"gitlab.com/thorchain/thornode/bifrost/metrics"
 	"gitlab.com/thorchain/thornode/bifrost/observer"
 	"gitlab.com/thorchain/thornode/bifrost/pkg/chainclients"
+	// chain clients register themselves to chainclients when imported
+	_ "gitlab.com/thorchain/thornode/bifrost/pkg/chainclients/binance"
+	_ "gitlab.com/thorchain/thornode/bifrost/pkg/chainclients/bitcoin"
+	_ "gitlab.com/thorchain/thornode/bifrost/pkg/chainclients/bitcoincash"
+	_ "gitlab.com/thorchain/thornode/bifrost/pkg/chainclients/ethereum"
+	_ "gitlab.com/thorchain/thornode/bifrost/pkg/chainclients/litecoin"
 	"gitlab.com/thorchain/thornode/bifrost/pubkeymanager"
 	"gitlab.com/thorchain/thornode/bifrost/signer"
 	"gitlab.com/thorchain/thornode/bifrost/thorclient"
@@ -161,7 +167,10 @@ func main() {
 		}
 	}
 
-	chains := chainclients.LoadChains(thorKeys, cfg.Chains, tssIns, thorchainBridge, m)
+	chains, err := chainclients.LoadChains(thorKeys, cfg.Chains, tssIns, thorchainBridge, m)
+	if err != nil {
+		log.Fatal().Err(err).Msg("fail to load chains")
+	}
 
 	// start observer
 	obs, err := observer.NewObserver(pubkeyMgr, chains, thorchainBridge, m)
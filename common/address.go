@@ -47,6 +47,22 @@ func NewAddress(address string) (Address, error) {
 		return Address(address), nil
 	}
 
+	// Check LTC legacy address formats with mainnet and testnet
+	_, err = btcutil.DecodeAddress(address, &LTCMainNetParams)
+	if err == nil {
+		return Address(address), nil
+	}
+	_, err = btcutil.DecodeAddress(address, &LTCTestNetParams)
+	if err == nil {
+		return Address(address), nil
+	}
+
+	// Check BCH cash address
+	_, _, _, err = DecodeCashAddr(address)
+	if err == nil {
+		return Address(address), nil
+	}
+
 	return NoAddress, fmt.Errorf("address format not supported: %s", address)
 }
 
@@ -76,6 +92,37 @@ func (addr Address) IsChain(chain Chain) bool {
 			return true
 		}
 		return false
+	case LTCChain:
+		prefix, _, err := bech32.Decode(addr.String())
+		if err == nil && (prefix == "ltc" || prefix == "tltc" || prefix == "rltc") {
+			return true
+		}
+		// Check mainnet other formats
+		_, err = btcutil.DecodeAddress(addr.String(), &LTCMainNetParams)
+		if err == nil {
+			return true
+		}
+		// Check testnet other formats
+		_, err = btcutil.DecodeAddress(addr.String(), &LTCTestNetParams)
+		if err == nil {
+			return true
+		}
+		return false
+	case BCHChain:
+		_, _, _, err := DecodeCashAddr(addr.String())
+		if err == nil {
+			return true
+		}
+		// BCH legacy addresses are the same as BTC's, but BCH doesn't support segwit
+		_, err = btcutil.DecodeAddress(addr.String(), &BCHMainNetParams)
+		if err == nil {
+			return true
+		}
+		_, err = btcutil.DecodeAddress(addr.String(), &BCHTestNetParams)
+		if err == nil {
+			return true
+		}
+		return false
 	default:
 		return true // if THORNode don't specifically check a chain yet, assume its ok.
 	}
@@ -223,4 +223,36 @@ func (s *AddressSuite) TestAddress(c *C) {
 	c.Check(addr.IsChain(ETHChain), Equals, false)
 	c.Check(addr.IsChain(BNBChain), Equals, false)
 	c.Check(addr.IsChain(THORChain), Equals, false)
+
+	// ltc tests
+	addr, err = NewAddress("ltc1qj08ys4ct2hzzc2hcz6h2hgrvlmsjynawmt3sjh")
+	c.Assert(err, IsNil)
+	c.Check(addr.IsChain(LTCChain), Equals, true)
+	c.Check(addr.IsChain(BTCChain), Equals, false)
+	c.Check(addr.IsChain(BCHChain), Equals, false)
+	addr, err = NewAddress("tltc1qj08ys4ct2hzzc2hcz6h2hgrvlmsjynawvejepa")
+	c.Assert(err, IsNil)
+	c.Check(addr.IsChain(LTCChain), Equals, true)
+	c.Check(addr.IsChain(BTCChain), Equals, false)
+	// legacy P2PKH address
+	addr, err = NewAddress("LVg2kJoFNg45Nbpy53h7Fe1wKyeXVRhMH9")
+	c.Assert(err, IsNil)
+	c.Check(addr.IsChain(LTCChain), Equals, true)
+	c.Check(addr.IsChain(BTCChain), Equals, false)
+
+	// bch tests
+	addr, err = NewAddress("qpm2qsznhks23z7629mms6s4cwef74vcwvy22gdx6a")
+	c.Assert(err, IsNil)
+	c.Check(addr.IsChain(BCHChain), Equals, true)
+	c.Check(addr.IsChain(BTCChain), Equals, false)
+	c.Check(addr.IsChain(LTCChain), Equals, false)
+	addr, err = NewAddress("bitcoincash:qpm2qsznhks23z7629mms6s4cwef74vcwvy22gdx6a")
+	c.Assert(err, IsNil)
+	c.Check(addr.IsChain(BCHChain), Equals, true)
+	// legacy address
+	addr, err = NewAddress("1BpEi6DfDAUFd7GtittLSdBeYJvcoaVggu")
+	c.Assert(err, IsNil)
+	c.Check(addr.IsChain(BCHChain), Equals, true)
+	_, err = NewAddress("qpm2qsznhks23z7629mms6s4cwef74vcwvy22gdx6b")
+	c.Check(err, NotNil)
 }
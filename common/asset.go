@@ -10,6 +10,8 @@ import (
 var (
 	BNBAsset     = Asset{Chain: BNBChain, Symbol: "BNB", Ticker: "BNB"}
 	BTCAsset     = Asset{Chain: BTCChain, Symbol: "BTC", Ticker: "BTC"}
+	BCHAsset     = Asset{Chain: BCHChain, Symbol: "BCH", Ticker: "BCH"}
+	LTCAsset     = Asset{Chain: LTCChain, Symbol: "LTC", Ticker: "LTC"}
 	ETHAsset     = Asset{Chain: ETHChain, Symbol: "ETH", Ticker: "ETH"}
 	RuneA1FAsset = Asset{Chain: BNBChain, Symbol: "RUNE-A1F", Ticker: "RUNE"} // testnet
 	RuneB1AAsset = Asset{Chain: BNBChain, Symbol: "RUNE-B1A", Ticker: "RUNE"} // mainnet
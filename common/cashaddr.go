@@ -0,0 +1,138 @@
+package common
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// cash address types, they are the type bits of the version byte
+const (
+	CashAddrP2PKH = byte(0)
+	CashAddrP2SH  = byte(8)
+)
+
+// cash address prefixes of each network
+const (
+	CashAddrMainNetPrefix = "bitcoincash"
+	CashAddrTestNetPrefix = "bchtest"
+	CashAddrRegTestPrefix = "bchreg"
+)
+
+const cashAddrCharset = "qpzry9x8gf2tvdw0s3jn54khce6mua7l"
+
+var cashAddrGenerator = []uint64{0x98f2bc8e61, 0x79b76d99e2, 0xf33e5fb3c4, 0xae2eabe2a8, 0x1e4f43e470}
+
+func cashAddrPolyMod(values []byte) uint64 {
+	c := uint64(1)
+	for _, d := range values {
+		c0 := byte(c >> 35)
+		c = ((c & 0x07ffffffff) << 5) ^ uint64(d)
+		for i, g := range cashAddrGenerator {
+			if (c0>>uint(i))&1 == 1 {
+				c ^= g
+			}
+		}
+	}
+	return c ^ 1
+}
+
+// cashAddrChecksumInput is the lower 5 bits of each prefix character, followed by a zero separator and the payload
+func cashAddrChecksumInput(prefix string, payload []byte) []byte {
+	input := make([]byte, 0, len(prefix)+1+len(payload)+8)
+	for _, ch := range prefix {
+		input = append(input, byte(ch)&0x1f)
+	}
+	input = append(input, 0)
+	return append(input, payload...)
+}
+
+// convertBits regroup the given data from fromBits per element to toBits per element
+func convertBits(data []byte, fromBits, toBits uint, pad bool) ([]byte, error) {
+	acc := uint32(0)
+	bits := uint(0)
+	maxv := uint32(1<<toBits) - 1
+	result := make([]byte, 0, len(data)*int(fromBits)/int(toBits)+1)
+	for _, value := range data {
+		if uint32(value)>>fromBits != 0 {
+			return nil, errors.New("invalid data range")
+		}
+		acc = (acc << fromBits) | uint32(value)
+		bits += fromBits
+		for bits >= toBits {
+			bits -= toBits
+			result = append(result, byte((acc>>bits)&maxv))
+		}
+	}
+	if pad {
+		if bits > 0 {
+			result = append(result, byte((acc<<(toBits-bits))&maxv))
+		}
+	} else if bits >= fromBits || (acc<<(toBits-bits))&maxv != 0 {
+		return nil, errors.New("invalid padding")
+	}
+	return result, nil
+}
+
+// EncodeCashAddr encode the given hash160 as a Bitcoin Cash address
+// the prefix is not included in the result, as THORChain use ':' as memo separator
+func EncodeCashAddr(prefix string, addrType byte, hash []byte) (string, error) {
+	if len(hash) != 20 {
+		return "", fmt.Errorf("hash length(%d) is not 20", len(hash))
+	}
+	// the lower 3 bits of version byte is the size of the hash, 0 means 160 bits
+	payload, err := convertBits(append([]byte{addrType}, hash...), 8, 5, true)
+	if err != nil {
+		return "", err
+	}
+	checksum := cashAddrPolyMod(append(cashAddrChecksumInput(prefix, payload), make([]byte, 8)...))
+	for i := 0; i < 8; i++ {
+		payload = append(payload, byte((checksum>>uint(5*(7-i)))&0x1f))
+	}
+	var sb strings.Builder
+	for _, v := range payload {
+		sb.WriteByte(cashAddrCharset[v])
+	}
+	return sb.String(), nil
+}
+
+// DecodeCashAddr decode the given Bitcoin Cash address, it returns the prefix, address type and hash160
+// the address can be either with or without prefix, when the prefix is absent, all known prefixes will be tried
+func DecodeCashAddr(addr string) (string, byte, []byte, error) {
+	if strings.ToLower(addr) != addr && strings.ToUpper(addr) != addr {
+		return "", 0, nil, errors.New("mixed case cash address")
+	}
+	addr = strings.ToLower(addr)
+	prefixes := []string{CashAddrMainNetPrefix, CashAddrTestNetPrefix, CashAddrRegTestPrefix}
+	if idx := strings.LastIndex(addr, ":"); idx >= 0 {
+		prefixes = []string{addr[:idx]}
+		addr = addr[idx+1:]
+	}
+	// 34 characters of hash160 with version byte, and 8 characters of checksum
+	if len(addr) != 42 {
+		return "", 0, nil, fmt.Errorf("invalid cash address length(%d)", len(addr))
+	}
+	values := make([]byte, len(addr))
+	for i, ch := range addr {
+		idx := strings.IndexRune(cashAddrCharset, ch)
+		if idx < 0 {
+			return "", 0, nil, fmt.Errorf("invalid character(%c) in cash address", ch)
+		}
+		values[i] = byte(idx)
+	}
+	for _, prefix := range prefixes {
+		if cashAddrPolyMod(cashAddrChecksumInput(prefix, values)) != 0 {
+			continue
+		}
+		data, err := convertBits(values[:len(values)-8], 5, 8, false)
+		if err != nil {
+			return "", 0, nil, fmt.Errorf("fail to convert cash address payload: %w", err)
+		}
+		addrType := data[0]
+		if addrType != CashAddrP2PKH && addrType != CashAddrP2SH {
+			return "", 0, nil, fmt.Errorf("unsupported cash address type(%d)", addrType)
+		}
+		return prefix, addrType, data[1:], nil
+	}
+	return "", 0, nil, errors.New("invalid cash address checksum")
+}
@@ -0,0 +1,57 @@
+package common
+
+import (
+	"encoding/hex"
+
+	. "gopkg.in/check.v1"
+)
+
+type CashAddrSuite struct{}
+
+var _ = Suite(&CashAddrSuite{})
+
+func (s *CashAddrSuite) TestCashAddr(c *C) {
+	hash, err := hex.DecodeString("76a04053bda0a88bda5177b86a15c3b29f559873")
+	c.Assert(err, IsNil)
+
+	addr, err := EncodeCashAddr(CashAddrMainNetPrefix, CashAddrP2PKH, hash)
+	c.Assert(err, IsNil)
+	c.Check(addr, Equals, "qpm2qsznhks23z7629mms6s4cwef74vcwvy22gdx6a")
+	addr, err = EncodeCashAddr(CashAddrMainNetPrefix, CashAddrP2SH, hash)
+	c.Assert(err, IsNil)
+	c.Check(addr, Equals, "ppm2qsznhks23z7629mms6s4cwef74vcwvn0h829pq")
+	_, err = EncodeCashAddr(CashAddrMainNetPrefix, CashAddrP2PKH, hash[1:])
+	c.Check(err, NotNil)
+
+	// with or without prefix
+	for _, input := range []string{
+		"bitcoincash:qpm2qsznhks23z7629mms6s4cwef74vcwvy22gdx6a",
+		"qpm2qsznhks23z7629mms6s4cwef74vcwvy22gdx6a",
+		"BITCOINCASH:QPM2QSZNHKS23Z7629MMS6S4CWEF74VCWVY22GDX6A",
+	} {
+		prefix, addrType, result, err := DecodeCashAddr(input)
+		c.Assert(err, IsNil)
+		c.Check(prefix, Equals, CashAddrMainNetPrefix)
+		c.Check(addrType, Equals, CashAddrP2PKH)
+		c.Check(hex.EncodeToString(result), Equals, "76a04053bda0a88bda5177b86a15c3b29f559873")
+	}
+
+	// other networks
+	addr, err = EncodeCashAddr(CashAddrTestNetPrefix, CashAddrP2PKH, hash)
+	c.Assert(err, IsNil)
+	prefix, _, _, err := DecodeCashAddr(addr)
+	c.Assert(err, IsNil)
+	c.Check(prefix, Equals, CashAddrTestNetPrefix)
+
+	// bad addresses
+	for _, input := range []string{
+		"qpm2qsznhks23z7629mms6s4cwef74vcwvy22gdx6b",
+		"bchtest:qpm2qsznhks23z7629mms6s4cwef74vcwvy22gdx6a",
+		"qpm2qsznhks23z7629mms6s4cwef74vcwvy22gdx6",
+		"Qpm2qsznhks23z7629mms6s4cwef74vcwvy22gdx6a",
+		"bpm2qsznhks23z7629mms6s4cwef74vcwvy22gdx6a",
+	} {
+		_, _, _, err = DecodeCashAddr(input)
+		c.Check(err, NotNil, Commentf(input))
+	}
+}
@@ -14,6 +14,8 @@ var (
 	BNBChain   = Chain("BNB")
 	ETHChain   = Chain("ETH")
 	BTCChain   = Chain("BTC")
+	BCHChain   = Chain("BCH")
+	LTCChain   = Chain("LTC")
 	THORChain  = Chain("THOR")
 	EmptyChain = Chain("")
 )
@@ -76,7 +78,7 @@ func (c Chain) IsBNB() bool {
 // GetSigningAlgo get the signing algorithm for the given chain
 func (c Chain) GetSigningAlgo() keys.SigningAlgo {
 	switch c {
-	case BNBChain, ETHChain, BTCChain, THORChain:
+	case BNBChain, ETHChain, BTCChain, BCHChain, LTCChain, THORChain:
 		return keys.Secp256k1
 	}
 	return keys.Secp256k1
@@ -89,6 +91,10 @@ func (c Chain) GetGasAsset() Asset {
 		return BNBAsset
 	case BTCChain:
 		return BTCAsset
+	case BCHChain:
+		return BCHAsset
+	case LTCChain:
+		return LTCAsset
 	case ETHChain:
 		return ETHAsset
 	default:
@@ -110,6 +116,10 @@ func (c Chain) AddressPrefix(cn ChainNetwork) string {
 			return types.GetConfig().GetBech32AccountAddrPrefix()
 		case BTCChain:
 			return chaincfg.RegressionNetParams.Bech32HRPSegwit
+		case LTCChain:
+			return LTCRegressionNetParams.Bech32HRPSegwit
+		case BCHChain:
+			return CashAddrRegTestPrefix
 		}
 	case TestNet:
 		switch c {
@@ -122,6 +132,10 @@ func (c Chain) AddressPrefix(cn ChainNetwork) string {
 			return types.GetConfig().GetBech32AccountAddrPrefix()
 		case BTCChain:
 			return chaincfg.TestNet3Params.Bech32HRPSegwit
+		case LTCChain:
+			return LTCTestNetParams.Bech32HRPSegwit
+		case BCHChain:
+			return CashAddrTestNetPrefix
 		}
 	case MainNet:
 		switch c {
@@ -133,6 +147,10 @@ func (c Chain) AddressPrefix(cn ChainNetwork) string {
 			return types.GetConfig().GetBech32AccountAddrPrefix()
 		case BTCChain:
 			return chaincfg.MainNetParams.Bech32HRPSegwit
+		case LTCChain:
+			return LTCMainNetParams.Bech32HRPSegwit
+		case BCHChain:
+			return CashAddrMainNetPrefix
 		}
 	}
 	return ""
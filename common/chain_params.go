@@ -0,0 +1,61 @@
+package common
+
+import (
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/wire"
+)
+
+// Network params of the chains forked from Bitcoin, only the fields used for address encoding are different from Bitcoin's
+var (
+	LTCMainNetParams       = newNetParams(chaincfg.MainNetParams, "mainnet", 0xdbb6c0fb, "ltc", 0x30, 0x32, 0xb0)
+	LTCTestNetParams       = newNetParams(chaincfg.TestNet3Params, "testnet4", 0xf1c8d2fd, "tltc", 0x6f, 0x3a, 0xef)
+	LTCRegressionNetParams = newNetParams(chaincfg.RegressionNetParams, "regtest", 0xdab5bffa, "rltc", 0x6f, 0x3a, 0xef)
+	// Bitcoin Cash doesn't support segwit, and its legacy addresses are the same as Bitcoin's
+	BCHMainNetParams       = newNetParams(chaincfg.MainNetParams, "mainnet", 0xe8f3e1e3, "", 0x00, 0x05, 0x80)
+	BCHTestNetParams       = newNetParams(chaincfg.TestNet3Params, "testnet3", 0xf4f3e5f4, "", 0x6f, 0xc4, 0xef)
+	BCHRegressionNetParams = newNetParams(chaincfg.RegressionNetParams, "regtest", 0xfabfb5da, "", 0x6f, 0xc4, 0xef)
+)
+
+func newNetParams(base chaincfg.Params, name string, net uint32, hrp string, pubKeyHashAddrID, scriptHashAddrID, privateKeyID byte) chaincfg.Params {
+	base.Name = name
+	base.Net = wire.BitcoinNet(net)
+	base.Bech32HRPSegwit = hrp
+	base.PubKeyHashAddrID = pubKeyHashAddrID
+	base.ScriptHashAddrID = scriptHashAddrID
+	base.PrivateKeyID = privateKeyID
+	return base
+}
+
+// GetNetParams return the network params of the given UTXO chain, it returns nil for chains not forked from Bitcoin
+func (c Chain) GetNetParams(cn ChainNetwork) *chaincfg.Params {
+	switch c {
+	case BTCChain:
+		switch cn {
+		case MockNet:
+			return &chaincfg.RegressionNetParams
+		case TestNet:
+			return &chaincfg.TestNet3Params
+		case MainNet:
+			return &chaincfg.MainNetParams
+		}
+	case LTCChain:
+		switch cn {
+		case MockNet:
+			return &LTCRegressionNetParams
+		case TestNet:
+			return &LTCTestNetParams
+		case MainNet:
+			return &LTCMainNetParams
+		}
+	case BCHChain:
+		switch cn {
+		case MockNet:
+			return &BCHRegressionNetParams
+		case TestNet:
+			return &BCHTestNetParams
+		case MainNet:
+			return &BCHMainNetParams
+		}
+	}
+	return nil
+}
@@ -34,6 +34,8 @@ func (s ChainSuite) TestChain(c *C) {
 	c.Assert(BNBChain.GetGasAsset(), Equals, BNBAsset)
 	c.Assert(BTCChain.GetGasAsset(), Equals, BTCAsset)
 	c.Assert(ETHChain.GetGasAsset(), Equals, ETHAsset)
+	c.Assert(BCHChain.GetGasAsset(), Equals, BCHAsset)
+	c.Assert(LTCChain.GetGasAsset(), Equals, LTCAsset)
 	c.Assert(EmptyChain.GetGasAsset(), Equals, EmptyAsset)
 
 	c.Assert(BNBChain.AddressPrefix(MockNet), Equals, btypes.TestNetwork.Bech32Prefixes())
@@ -43,4 +45,11 @@ func (s ChainSuite) TestChain(c *C) {
 	c.Assert(BTCChain.AddressPrefix(MockNet), Equals, chaincfg.RegressionNetParams.Bech32HRPSegwit)
 	c.Assert(BTCChain.AddressPrefix(TestNet), Equals, chaincfg.TestNet3Params.Bech32HRPSegwit)
 	c.Assert(BTCChain.AddressPrefix(MainNet), Equals, chaincfg.MainNetParams.Bech32HRPSegwit)
+	c.Assert(LTCChain.AddressPrefix(MainNet), Equals, "ltc")
+	c.Assert(BCHChain.AddressPrefix(MainNet), Equals, CashAddrMainNetPrefix)
+
+	c.Assert(BTCChain.GetNetParams(MainNet), Equals, &chaincfg.MainNetParams)
+	c.Assert(LTCChain.GetNetParams(TestNet), Equals, &LTCTestNetParams)
+	c.Assert(BCHChain.GetNetParams(MockNet), Equals, &BCHRegressionNetParams)
+	c.Assert(ETHChain.GetNetParams(MainNet), IsNil)
 }
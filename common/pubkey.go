@@ -134,6 +134,26 @@ func (pubKey PubKey) GetAddress(chain Chain) (Address, error) {
 			return NoAddress, fmt.Errorf("fail to bech32 encode the address, err:%w", err)
 		}
 		return NewAddress(addr.String())
+	case LTCChain:
+		pk, err := sdk.GetAccPubKeyBech32(string(pubKey))
+		if err != nil {
+			return NoAddress, err
+		}
+		addr, err := btcutil.NewAddressWitnessPubKeyHash(pk.Address().Bytes(), chain.GetNetParams(chainNetwork))
+		if err != nil {
+			return NoAddress, fmt.Errorf("fail to bech32 encode the address, err:%w", err)
+		}
+		return NewAddress(addr.String())
+	case BCHChain:
+		pk, err := sdk.GetAccPubKeyBech32(string(pubKey))
+		if err != nil {
+			return NoAddress, err
+		}
+		str, err := EncodeCashAddr(chain.AddressPrefix(chainNetwork), CashAddrP2PKH, pk.Address().Bytes())
+		if err != nil {
+			return NoAddress, fmt.Errorf("fail to encode the cash address, err:%w", err)
+		}
+		return NewAddress(str)
 	}
 
 	return NoAddress, nil
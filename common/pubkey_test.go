@@ -240,3 +240,48 @@ func (s *PubKeyTestSuite) TestPubKeyGetAddress(c *C) {
 
 	}
 }
+
+func (s *PubKeyTestSuite) TestPubKeyGetUTXOAddress(c *C) {
+	original := os.Getenv("NET")
+	defer func() {
+		c.Assert(os.Setenv("NET", original), IsNil)
+	}()
+	pubB, err := hex.DecodeString("02b4632d08485ff1df2db55b9dafd23347d1c47a457072a1e87be26896549a8737")
+	c.Assert(err, IsNil)
+	var pubKey secp256k1.PubKeySecp256k1
+	copy(pubKey[:], pubB)
+	pubBech32, err := sdk.Bech32ifyAccPub(pubKey)
+	c.Assert(err, IsNil)
+	pk, err := NewPubKey(pubBech32)
+	c.Assert(err, IsNil)
+
+	addrLTC := KeyDataAddr{
+		mainnet: "ltc1qj08ys4ct2hzzc2hcz6h2hgrvlmsjynawmt3sjh",
+		testnet: "tltc1qj08ys4ct2hzzc2hcz6h2hgrvlmsjynawvejepa",
+		mocknet: "rltc1qj08ys4ct2hzzc2hcz6h2hgrvlmsjynawf4nr3r",
+	}
+	addrBCH := KeyDataAddr{
+		mainnet: "qzfuujzhpd2ugtp2lqt2a2aqdnlwzgj04cswjhml4x",
+		testnet: "qzfuujzhpd2ugtp2lqt2a2aqdnlwzgj04c5uksegj6",
+		mocknet: "qzfuujzhpd2ugtp2lqt2a2aqdnlwzgj04cwqq36m3u",
+	}
+	for _, item := range []struct {
+		net     string
+		ltcAddr string
+		bchAddr string
+	}{
+		{"mainnet", addrLTC.mainnet, addrBCH.mainnet},
+		{"testnet", addrLTC.testnet, addrBCH.testnet},
+		{"mocknet", addrLTC.mocknet, addrBCH.mocknet},
+	} {
+		c.Assert(os.Setenv("NET", item.net), IsNil)
+		addr, err := pk.GetAddress(LTCChain)
+		c.Assert(err, IsNil)
+		c.Check(addr.String(), Equals, item.ltcAddr)
+		c.Check(addr.IsChain(LTCChain), Equals, true)
+		addr, err = pk.GetAddress(BCHChain)
+		c.Assert(err, IsNil)
+		c.Check(addr.String(), Equals, item.bchAddr)
+		c.Check(addr.IsChain(BCHChain), Equals, true)
+	}
+}
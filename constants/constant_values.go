@@ -39,38 +39,66 @@ const (
 	FailKeygenSlashPoints
 	FailKeySignSlashPoints
 	StakeLockUpBlocks
+	PendingRuneTimeout
+	AutoCommitPendingRune
+	LimitOrderTTL
+	MaxStreamingSwapQuantity
+	MaxAffiliateFeeBasisPoints
+	TWAPWindow
+	TWAPMaxWindow
+	SlipFeeMultiplierBasisPoints
+	MinSlipFeeBasisPoints
+	OutboundFeeMultiplierBasisPoints
+	FullImpLossProtectionBlocks
+	SlashLedgerBlocks
+	SlashPointsDecayBlocks
+	SlashPointsDecayBasisPoints
 )
 
 var nameToString = map[ConstantName]string{
-	EmissionCurve:                   "EmissionCurve",
-	BlocksPerYear:                   "BlockPerYear",
-	TransactionFee:                  "TransactionFee",
-	NewPoolCycle:                    "NewPoolCycle",
-	MinimumNodesForYggdrasil:        "MinimumNodesForYggdrasil",
-	MinimumNodesForBFT:              "MinimumNodesForBFT",
-	ValidatorRotateInNumBeforeFull:  "ValidatorRotateInNumBeforeFull",
-	ValidatorRotateOutNumBeforeFull: "ValidatorRotateOutNumBeforeFull",
-	ValidatorRotateNumAfterFull:     "ValidatorRotateNumAfterFull",
-	DesireValidatorSet:              "DesireValidatorSet",
-	RotatePerBlockHeight:            "RotatePerBlockHeight",
-	RotateRetryBlocks:               "RotateRetryBlocks",
-	ValidatorsChangeWindow:          "ValidatorsChangeWindow",
-	LeaveProcessPerBlockHeight:      "LeaveProcessPerBlockHeight",
-	BadValidatorRate:                "BadValidatorRate",
-	OldValidatorRate:                "OldValidatorRate",
-	LackOfObservationPenalty:        "LackOfObservationPenalty",
-	SigningTransactionPeriod:        "SigningTransactionPeriod",
-	DoubleSignMaxAge:                "DoubleSignMaxAge",
-	MinimumBondInRune:               "MinimumBondInRune",
-	FundMigrationInterval:           "FundMigrationInterval",
-	WhiteListGasAsset:               "WhiteListGasAsset",
-	ArtificialRagnarokBlockHeight:   "ArtificialRagnarokBlockHeight",
-	MaximumStakeRune:                "MaximumStakeRune",
-	StrictBondStakeRatio:            "StrictBondStakeRatio",
-	DefaultPoolStatus:               "DefaultPoolStatus",
-	FailKeygenSlashPoints:           "FailKeygenSlashPoints",
-	FailKeySignSlashPoints:          "FailKeySignSlashPoints",
-	StakeLockUpBlocks:               "StakeLockUpBlocks",
+	EmissionCurve:                    "EmissionCurve",
+	BlocksPerYear:                    "BlockPerYear",
+	TransactionFee:                   "TransactionFee",
+	NewPoolCycle:                     "NewPoolCycle",
+	MinimumNodesForYggdrasil:         "MinimumNodesForYggdrasil",
+	MinimumNodesForBFT:               "MinimumNodesForBFT",
+	ValidatorRotateInNumBeforeFull:   "ValidatorRotateInNumBeforeFull",
+	ValidatorRotateOutNumBeforeFull:  "ValidatorRotateOutNumBeforeFull",
+	ValidatorRotateNumAfterFull:      "ValidatorRotateNumAfterFull",
+	DesireValidatorSet:               "DesireValidatorSet",
+	RotatePerBlockHeight:             "RotatePerBlockHeight",
+	RotateRetryBlocks:                "RotateRetryBlocks",
+	ValidatorsChangeWindow:           "ValidatorsChangeWindow",
+	LeaveProcessPerBlockHeight:       "LeaveProcessPerBlockHeight",
+	BadValidatorRate:                 "BadValidatorRate",
+	OldValidatorRate:                 "OldValidatorRate",
+	LackOfObservationPenalty:         "LackOfObservationPenalty",
+	SigningTransactionPeriod:         "SigningTransactionPeriod",
+	DoubleSignMaxAge:                 "DoubleSignMaxAge",
+	MinimumBondInRune:                "MinimumBondInRune",
+	FundMigrationInterval:            "FundMigrationInterval",
+	WhiteListGasAsset:                "WhiteListGasAsset",
+	ArtificialRagnarokBlockHeight:    "ArtificialRagnarokBlockHeight",
+	MaximumStakeRune:                 "MaximumStakeRune",
+	StrictBondStakeRatio:             "StrictBondStakeRatio",
+	DefaultPoolStatus:                "DefaultPoolStatus",
+	FailKeygenSlashPoints:            "FailKeygenSlashPoints",
+	FailKeySignSlashPoints:           "FailKeySignSlashPoints",
+	StakeLockUpBlocks:                "StakeLockUpBlocks",
+	PendingRuneTimeout:               "PendingRuneTimeout",
+	AutoCommitPendingRune:            "AutoCommitPendingRune",
+	LimitOrderTTL:                    "LimitOrderTTL",
+	MaxStreamingSwapQuantity:         "MaxStreamingSwapQuantity",
+	MaxAffiliateFeeBasisPoints:       "MaxAffiliateFeeBasisPoints",
+	TWAPWindow:                       "TWAPWindow",
+	TWAPMaxWindow:                    "TWAPMaxWindow",
+	SlipFeeMultiplierBasisPoints:     "SlipFeeMultiplierBasisPoints",
+	MinSlipFeeBasisPoints:            "MinSlipFeeBasisPoints",
+	OutboundFeeMultiplierBasisPoints: "OutboundFeeMultiplierBasisPoints",
+	FullImpLossProtectionBlocks:      "FullImpLossProtectionBlocks",
+	SlashLedgerBlocks:                "SlashLedgerBlocks",
+	SlashPointsDecayBlocks:           "SlashPointsDecayBlocks",
+	SlashPointsDecayBasisPoints:      "SlashPointsDecayBasisPoints",
 }
 
 // String implement fmt.stringer
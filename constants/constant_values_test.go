@@ -30,6 +30,20 @@ func (ConstantsTestSuite) TestConstantName_String(c *C) {
 		SigningTransactionPeriod,
 		DoubleSignMaxAge,
 		MinimumBondInRune,
+		PendingRuneTimeout,
+		AutoCommitPendingRune,
+		LimitOrderTTL,
+		MaxStreamingSwapQuantity,
+		MaxAffiliateFeeBasisPoints,
+		TWAPWindow,
+		TWAPMaxWindow,
+		SlipFeeMultiplierBasisPoints,
+		MinSlipFeeBasisPoints,
+		OutboundFeeMultiplierBasisPoints,
+		FullImpLossProtectionBlocks,
+		SlashLedgerBlocks,
+		SlashPointsDecayBlocks,
+		SlashPointsDecayBasisPoints,
 	}
 	for _, item := range constantNames {
 		c.Assert(item.String(), Not(Equals), "NA")
@@ -30,32 +30,46 @@ type ConstantValue010 struct {
 func NewConstantValue010() *ConstantValue010 {
 	return &ConstantValue010{
 		int64values: map[ConstantName]int64{
-			EmissionCurve:                   6,
-			BlocksPerYear:                   6311390,
-			TransactionFee:                  100_000_000,         // A 1.0 Rune fee on all swaps and withdrawals
-			NewPoolCycle:                    50000,               // Enable a pool every 50,000 blocks (~3 days)
-			MinimumNodesForYggdrasil:        6,                   // No yggdrasil pools if THORNode have less than 6 active nodes
-			MinimumNodesForBFT:              4,                   // Minimum node count to keep network running. Below this, Ragnarök is performed.
-			ValidatorRotateInNumBeforeFull:  2,                   // How many validators should THORNode nominate before THORNode reach the desire validator set
-			ValidatorRotateOutNumBeforeFull: 1,                   // How many validators should THORNode queued to be rotate out before THORNode reach the desire validator set)
-			ValidatorRotateNumAfterFull:     1,                   // How many validators should THORNode nominate after THORNode reach the desire validator set
-			DesireValidatorSet:              33,                  // desire validator set
-			FundMigrationInterval:           360,                 // number of blocks THORNode will attempt to move funds from a retiring vault to an active one
-			RotatePerBlockHeight:            51840,               // How many blocks THORNode try to rotate validators
-			RotateRetryBlocks:               720,                 // How many blocks until we retry a churn (only if we haven't had a successful churn in RotatePerBlockHeight blocks
-			BadValidatorRate:                51840,               // rate to mark a validator to be rotated out for bad behavior
-			OldValidatorRate:                51840,               // rate to mark a validator to be rotated out for age
-			LackOfObservationPenalty:        2,                   // add two slash point for each block where a node does not observe
-			SigningTransactionPeriod:        300,                 // how many blocks before a request to sign a tx by yggdrasil pool, is counted as delinquent.
-			DoubleSignMaxAge:                24,                  // number of blocks to limit double signing a block
-			MinimumBondInRune:               100_000_000_000_000, // 1 million rune
-			WhiteListGasAsset:               1000,                // thor coins we will be given to the validator
-			FailKeygenSlashPoints:           720,                 // slash for 720 blocks , which equals 1 hour
-			FailKeySignSlashPoints:          2,                   // slash for 2 blocks
-			StakeLockUpBlocks:               17280,               // the number of blocks staker can unstake after their stake
+			EmissionCurve:                    6,
+			BlocksPerYear:                    6311390,
+			TransactionFee:                   100_000_000,         // A 1.0 Rune fee on all swaps and withdrawals
+			NewPoolCycle:                     50000,               // Enable a pool every 50,000 blocks (~3 days)
+			MinimumNodesForYggdrasil:         6,                   // No yggdrasil pools if THORNode have less than 6 active nodes
+			MinimumNodesForBFT:               4,                   // Minimum node count to keep network running. Below this, Ragnarök is performed.
+			ValidatorRotateInNumBeforeFull:   2,                   // How many validators should THORNode nominate before THORNode reach the desire validator set
+			ValidatorRotateOutNumBeforeFull:  1,                   // How many validators should THORNode queued to be rotate out before THORNode reach the desire validator set)
+			ValidatorRotateNumAfterFull:      1,                   // How many validators should THORNode nominate after THORNode reach the desire validator set
+			DesireValidatorSet:               33,                  // desire validator set
+			FundMigrationInterval:            360,                 // number of blocks THORNode will attempt to move funds from a retiring vault to an active one
+			RotatePerBlockHeight:             51840,               // How many blocks THORNode try to rotate validators
+			RotateRetryBlocks:                720,                 // How many blocks until we retry a churn (only if we haven't had a successful churn in RotatePerBlockHeight blocks
+			BadValidatorRate:                 51840,               // rate to mark a validator to be rotated out for bad behavior
+			OldValidatorRate:                 51840,               // rate to mark a validator to be rotated out for age
+			LackOfObservationPenalty:         2,                   // add two slash point for each block where a node does not observe
+			SigningTransactionPeriod:         300,                 // how many blocks before a request to sign a tx by yggdrasil pool, is counted as delinquent.
+			DoubleSignMaxAge:                 24,                  // number of blocks to limit double signing a block
+			MinimumBondInRune:                100_000_000_000_000, // 1 million rune
+			WhiteListGasAsset:                1000,                // thor coins we will be given to the validator
+			FailKeygenSlashPoints:            720,                 // slash for 720 blocks , which equals 1 hour
+			FailKeySignSlashPoints:           2,                   // slash for 2 blocks
+			StakeLockUpBlocks:                17280,               // the number of blocks staker can unstake after their stake
+			PendingRuneTimeout:               17280,               // the number of blocks RUNE can wait for the asset leg of a stake, before it is refunded or committed
+			LimitOrderTTL:                    17280,               // the number of blocks a limit order can stay in the swap queue before it is refunded
+			MaxStreamingSwapQuantity:         100,                 // the maximum number of sub-swaps a streaming swap can be split into
+			MaxAffiliateFeeBasisPoints:       500,                 // the maximum affiliate fee a swap or stake memo can ask for, in basis points
+			TWAPWindow:                       100,                 // the number of blocks the time weighted average price swap scoring and yggdrasil funding use, 0 uses the spot price
+			TWAPMaxWindow:                    17280,               // the number of blocks of pool price history kept for time weighted average prices
+			SlipFeeMultiplierBasisPoints:     10_000,              // how steep the slip based liquidity fee curve is, 10000 is the plain slip fee
+			MinSlipFeeBasisPoints:            0,                   // the minimum liquidity fee of a swap, in basis points of what the swap would emit without any fee
+			OutboundFeeMultiplierBasisPoints: 0,                   // the outbound fee as a multiple of the chain's network fee in gas asset, 0 uses the flat transaction fee
+			FullImpLossProtectionBlocks:      1728000,             // number of blocks after the last stake before a staker is fully protected against impermanent loss, 0 turns the protection off
+			SlashLedgerBlocks:                17280,               // the number of blocks of slash history kept for each node account
+			SlashPointsDecayBlocks:           720,                 // how often the slash points of active node accounts decay, in blocks
+			SlashPointsDecayBasisPoints:      0,                   // the share of slash points that decays every SlashPointsDecayBlocks, 0 turns decay off
 		},
 		boolValues: map[ConstantName]bool{
-			StrictBondStakeRatio: true,
+			StrictBondStakeRatio:  true,
+			AutoCommitPendingRune: false, // when pending RUNE timed out, commit it to the pool as an asymmetric stake instead of refunding it
 		},
 		stringValues: map[ConstantName]string{
 			DefaultPoolStatus: "Bootstrap",
@@ -12,6 +12,8 @@ func init() {
 		MinimumBondInRune:     100_000_000, // 1 rune
 		FundMigrationInterval: 10,
 		StakeLockUpBlocks:     0,
+		PendingRuneTimeout:    60, // 5 min
+		LimitOrderTTL:         60, // 5 min
 	}
 	boolOverrides = map[ConstantName]bool{
 		StrictBondStakeRatio: false,
@@ -0,0 +1,143 @@
+package thorchain
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"gitlab.com/thorchain/thornode/common"
+	"gitlab.com/thorchain/thornode/constants"
+	"gitlab.com/thorchain/thornode/x/thorchain/keep"
+)
+
+// getMaxAffiliateFeeBasisPoints return the cap of affiliate fee, mimir takes precedence over the constant
+func getMaxAffiliateFeeBasisPoints(ctx sdk.Context, keeper keep.Keeper, constAccessor constants.ConstantValues) sdk.Uint {
+	maxBps := constAccessor.GetInt64Value(constants.MaxAffiliateFeeBasisPoints)
+	if mimirBps, err := keeper.GetMimir(ctx, constants.MaxAffiliateFeeBasisPoints.String()); err == nil && mimirBps >= 0 {
+		maxBps = mimirBps
+	}
+	if maxBps < 0 {
+		maxBps = 0
+	}
+	return sdk.NewUint(uint64(maxBps))
+}
+
+// skimAffiliateFees take the affiliate fee out of every coin of the given tx, and return the tx with what is left
+func skimAffiliateFees(ctx sdk.Context, keeper keep.Keeper, txStore TxOutStore, eventMgr EventManager, constAccessor constants.ConstantValues, tx common.Tx, affiliate common.Address, bps sdk.Uint) common.Tx {
+	if affiliate.IsEmpty() || bps.IsZero() {
+		return tx
+	}
+	coins := make(common.Coins, 0, len(tx.Coins))
+	for _, coin := range tx.Coins {
+		fee, err := skimAffiliateFee(ctx, keeper, txStore, eventMgr, constAccessor, tx, coin, affiliate, bps)
+		if err != nil {
+			ctx.Logger().Error("fail to skim affiliate fee", "tx", tx.ID, "affiliate", affiliate, "error", err)
+		}
+		coins = append(coins, common.NewCoin(coin.Asset, common.SafeSub(coin.Amount, fee)))
+	}
+	tx.Coins = coins
+	return tx
+}
+
+// skimAffiliateFee take the affiliate share out of the given coin, swap it to RUNE, or to the gas asset of the affiliate's chain,
+// and send it to the affiliate. It returns the amount taken from the coin, nothing is taken when the fee can't be paid out
+func skimAffiliateFee(ctx sdk.Context, keeper keep.Keeper, txStore TxOutStore, eventMgr EventManager, constAccessor constants.ConstantValues, tx common.Tx, coin common.Coin, affiliate common.Address, bps sdk.Uint) (sdk.Uint, error) {
+	if maxBps := getMaxAffiliateFeeBasisPoints(ctx, keeper, constAccessor); bps.GT(maxBps) {
+		bps = maxBps
+	}
+	feeAmt := common.GetShare(bps, sdk.NewUint(MaxAffiliateBasisPoints), coin.Amount)
+	if feeAmt.IsZero() {
+		return sdk.ZeroUint(), nil
+	}
+	target, err := getAffiliatePayoutAsset(ctx, keeper, affiliate)
+	if err != nil {
+		return sdk.ZeroUint(), err
+	}
+	if target.IsEmpty() {
+		ctx.Logger().Info("no pool to pay affiliate fee through", "tx", tx.ID, "affiliate", affiliate)
+		return sdk.ZeroUint(), nil
+	}
+	feeInRune := feeAmt
+	if !coin.Asset.IsRune() {
+		pool, err := keeper.GetPool(ctx, coin.Asset)
+		if err != nil {
+			return sdk.ZeroUint(), fmt.Errorf("fail to get pool(%s): %w", coin.Asset, err)
+		}
+		feeInRune = pool.AssetValueInRune(feeAmt)
+	}
+
+	// an affiliate fee that can't pay for its own outbound is left in the swap or stake
+	if outboundFee := NewFeeModel(keeper, constAccessor).GetOutboundFee(ctx, target.Chain); feeInRune.LTE(outboundFee) {
+		ctx.Logger().Info("affiliate fee can't cover the outbound fee", "tx", tx.ID, "affiliate", affiliate, "fee", feeInRune, "outbound fee", outboundFee)
+		return sdk.ZeroUint(), nil
+	}
+
+	// the fee is swapped and sent out in a cache context, so nothing is changed when the outbound can't be added
+	cacheCtx, commit := ctx.CacheContext()
+	payout := feeAmt
+	if !coin.Asset.Equals(target) {
+		feeTx := tx
+		feeTx.Coins = common.Coins{common.NewCoin(coin.Asset, feeAmt)}
+		transactionFee := constAccessor.GetInt64Value(constants.TransactionFee)
+		var events []EventSwap
+		var swapErr sdk.Error
+		payout, events, swapErr = swap(cacheCtx, keeper, feeTx, target, affiliate, sdk.ZeroUint(), sdk.NewUint(uint64(transactionFee)), constAccessor)
+		if swapErr != nil {
+			// the fee is left in the swap or stake when it can't be swapped
+			ctx.Logger().Error("fail to swap affiliate fee", "tx", tx.ID, "error", swapErr)
+			return sdk.ZeroUint(), nil
+		}
+		for _, evt := range events {
+			if err := eventMgr.EmitSwapEvent(cacheCtx, keeper, evt); err != nil {
+				ctx.Logger().Error("fail to emit swap event", "error", err)
+			}
+			if err := keeper.AddToLiquidityFees(cacheCtx, evt.Pool, evt.LiquidityFeeInRune); err != nil {
+				return sdk.ZeroUint(), fmt.Errorf("fail to add liquidity fees: %w", err)
+			}
+		}
+	}
+
+	toi := &TxOutItem{
+		Chain:     target.Chain,
+		InHash:    tx.ID,
+		ToAddress: affiliate,
+		Coin:      common.NewCoin(target, payout),
+	}
+	ok, err := txStore.TryAddTxOutItem(cacheCtx, toi)
+	if err != nil {
+		return sdk.ZeroUint(), fmt.Errorf("fail to add outbound tx: %w", err)
+	}
+	if !ok {
+		ctx.Logger().Info("affiliate fee outbound can't be added", "tx", tx.ID, "affiliate", affiliate)
+		return sdk.ZeroUint(), nil
+	}
+	commit()
+	ctx.EventManager().EmitEvents(cacheCtx.EventManager().Events())
+
+	evt := NewEventAffiliateFee(tx.ID, tx.Memo, affiliate, bps, coin.Asset, feeAmt, feeInRune, toi.Coin)
+	if err := eventMgr.EmitAffiliateFeeEvent(ctx, evt); err != nil {
+		return feeAmt, fmt.Errorf("fail to emit affiliate fee event: %w", err)
+	}
+	return feeAmt, nil
+}
+
+// getAffiliatePayoutAsset return the asset an affiliate is paid in, which is RUNE when the affiliate address is on the chain
+// RUNE lives on, otherwise the gas asset of the affiliate's chain, as long as it has an enabled pool
+func getAffiliatePayoutAsset(ctx sdk.Context, keeper keep.Keeper, affiliate common.Address) (common.Asset, error) {
+	if affiliate.IsChain(common.RuneAsset().Chain) {
+		return common.RuneAsset(), nil
+	}
+	pools, err := keeper.GetPools(ctx)
+	if err != nil {
+		return common.EmptyAsset, fmt.Errorf("fail to get pools: %w", err)
+	}
+	for _, pool := range pools {
+		if !pool.IsEnabled() || !pool.Asset.Equals(pool.Asset.Chain.GetGasAsset()) {
+			continue
+		}
+		if affiliate.IsChain(pool.Asset.Chain) {
+			return pool.Asset, nil
+		}
+	}
+	return common.EmptyAsset, nil
+}
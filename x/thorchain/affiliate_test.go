@@ -0,0 +1,130 @@
+package thorchain
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	. "gopkg.in/check.v1"
+
+	"gitlab.com/thorchain/thornode/common"
+	"gitlab.com/thorchain/thornode/constants"
+	"gitlab.com/thorchain/thornode/x/thorchain/keep"
+	"gitlab.com/thorchain/thornode/x/thorchain/types"
+)
+
+type AffiliateSuite struct{}
+
+var _ = Suite(&AffiliateSuite{})
+
+func (s *AffiliateSuite) setupPool(c *C, ctx sdk.Context, k keep.Keeper, asset common.Asset) {
+	pool := NewPool()
+	pool.Asset = asset
+	pool.BalanceRune = sdk.NewUint(1000 * common.One)
+	pool.BalanceAsset = sdk.NewUint(1000 * common.One)
+	pool.PoolUnits = sdk.NewUint(1000 * common.One)
+	pool.Status = PoolEnabled
+	c.Assert(k.SetPool(ctx, pool), IsNil)
+}
+
+func (s *AffiliateSuite) newTx(coins common.Coins) common.Tx {
+	return common.NewTx(GetRandomTxHash(), GetRandomBNBAddress(), GetRandomBNBAddress(), coins, BNBGasFeeSingleton, "SWAP:BNB.BNB")
+}
+
+func (s *AffiliateSuite) TestSkimAffiliateFeesInRune(c *C) {
+	ctx, k := setupKeeperForTest(c)
+	constAccessor := constants.GetConstantValues(constants.SWVersion)
+	s.setupPool(c, ctx, k, common.BNBAsset)
+	txOutStore := NewTxStoreDummy()
+	affiliate := GetRandomBNBAddress()
+
+	// no affiliate, nothing is skimmed
+	tx := s.newTx(common.Coins{common.NewCoin(common.RuneAsset(), sdk.NewUint(1000*common.One))})
+	result := skimAffiliateFees(ctx, k, txOutStore, NewEventMgr(), constAccessor, tx, common.NoAddress, sdk.NewUint(100))
+	c.Check(result.Coins[0].Amount.Equal(sdk.NewUint(1000*common.One)), Equals, true)
+
+	result = skimAffiliateFees(ctx, k, txOutStore, NewEventMgr(), constAccessor, tx, affiliate, sdk.NewUint(100))
+	c.Check(result.Coins[0].Amount.Equal(sdk.NewUint(990*common.One)), Equals, true)
+	c.Check(tx.Coins[0].Amount.Equal(sdk.NewUint(1000*common.One)), Equals, true)
+	items, err := txOutStore.GetOutboundItems(ctx)
+	c.Assert(err, IsNil)
+	c.Assert(items, HasLen, 1)
+	c.Check(items[0].ToAddress.Equals(affiliate), Equals, true)
+	c.Check(items[0].Coin.Equals(common.NewCoin(common.RuneAsset(), sdk.NewUint(10*common.One))), Equals, true)
+	found := false
+	for _, evt := range ctx.EventManager().Events() {
+		if evt.Type == types.AffiliateFeeEventType {
+			found = true
+		}
+	}
+	c.Check(found, Equals, true)
+
+	// the fee is capped
+	tx = s.newTx(common.Coins{common.NewCoin(common.RuneAsset(), sdk.NewUint(100*common.One))})
+	result = skimAffiliateFees(ctx, k, txOutStore, NewEventMgr(), constAccessor, tx, affiliate, sdk.NewUint(MaxAffiliateBasisPoints))
+	maxBps := constAccessor.GetInt64Value(constants.MaxAffiliateFeeBasisPoints)
+	c.Check(result.Coins[0].Amount.Equal(sdk.NewUint(uint64(100*common.One-maxBps*common.One/100))), Equals, true, Commentf("%s", result.Coins[0].Amount))
+}
+
+func (s *AffiliateSuite) TestSkimAffiliateFeesThroughPool(c *C) {
+	ctx, k := setupKeeperForTest(c)
+	constAccessor := constants.GetConstantValues(constants.SWVersion)
+	s.setupPool(c, ctx, k, common.BNBAsset)
+	txOutStore := NewTxStoreDummy()
+
+	// asset is swapped to RUNE, the fee must be worth more than the transaction fee to be paid out
+	tx := s.newTx(common.Coins{common.NewCoin(common.BNBAsset, sdk.NewUint(1000*common.One))})
+	result := skimAffiliateFees(ctx, k, txOutStore, NewEventMgr(), constAccessor, tx, GetRandomBNBAddress(), sdk.NewUint(100))
+	c.Check(result.Coins[0].Amount.Equal(sdk.NewUint(990*common.One)), Equals, true)
+	items, err := txOutStore.GetOutboundItems(ctx)
+	c.Assert(err, IsNil)
+	c.Assert(items, HasLen, 1)
+	c.Check(items[0].Coin.Asset.IsRune(), Equals, true)
+	pool, err := k.GetPool(ctx, common.BNBAsset)
+	c.Assert(err, IsNil)
+	c.Check(pool.BalanceAsset.Equal(sdk.NewUint(1010*common.One)), Equals, true)
+
+	// affiliate on a chain without a pool don't get paid, and nothing is skimmed
+	btcAffiliate := types.GetRandomBTCAddress()
+	result = skimAffiliateFees(ctx, k, txOutStore, NewEventMgr(), constAccessor, tx, btcAffiliate, sdk.NewUint(100))
+	c.Check(result.Coins[0].Amount.Equal(sdk.NewUint(1000*common.One)), Equals, true)
+
+	// RUNE is swapped to the gas asset of the affiliate's chain
+	s.setupPool(c, ctx, k, common.BTCAsset)
+	tx = s.newTx(common.Coins{common.NewCoin(common.RuneAsset(), sdk.NewUint(1000*common.One))})
+	result = skimAffiliateFees(ctx, k, txOutStore, NewEventMgr(), constAccessor, tx, btcAffiliate, sdk.NewUint(100))
+	c.Check(result.Coins[0].Amount.Equal(sdk.NewUint(990*common.One)), Equals, true)
+	items, err = txOutStore.GetOutboundItems(ctx)
+	c.Assert(err, IsNil)
+	c.Assert(items, HasLen, 2)
+	c.Check(items[1].ToAddress.Equals(btcAffiliate), Equals, true)
+	c.Check(items[1].Coin.Asset.Equals(common.BTCAsset), Equals, true)
+}
+
+func (s *AffiliateSuite) TestSkimAffiliateFeesNotPaidOut(c *C) {
+	ctx, k := setupKeeperForTest(c)
+	constAccessor := constants.GetConstantValues(constants.SWVersion)
+	s.setupPool(c, ctx, k, common.BNBAsset)
+	txOutStore := NewTxStoreDummy()
+	affiliate := GetRandomBNBAddress()
+
+	// a fee that can't cover the outbound fee is not skimmed
+	transactionFee := constAccessor.GetInt64Value(constants.TransactionFee)
+	tx := s.newTx(common.Coins{common.NewCoin(common.BNBAsset, sdk.NewUint(uint64(transactionFee*100)))})
+	result := skimAffiliateFees(ctx, k, txOutStore, NewEventMgr(), constAccessor, tx, affiliate, sdk.NewUint(100))
+	c.Check(result.Coins[0].Amount.Equal(tx.Coins[0].Amount), Equals, true)
+	items, err := txOutStore.GetOutboundItems(ctx)
+	c.Assert(err, IsNil)
+	c.Check(items, HasLen, 0)
+
+	// the outbound can't be added, the fee is not swapped, and nothing is skimmed
+	tx = s.newTx(common.Coins{common.NewCoin(common.BNBAsset, sdk.NewUint(1000*common.One))})
+	notAdded := &TxOutStoreNotAddedDummy{TxOutStoreDummy: txOutStore}
+	result = skimAffiliateFees(ctx, k, notAdded, NewEventMgr(), constAccessor, tx, affiliate, sdk.NewUint(100))
+	c.Check(result.Coins[0].Amount.Equal(sdk.NewUint(1000*common.One)), Equals, true)
+	pool, err := k.GetPool(ctx, common.BNBAsset)
+	c.Assert(err, IsNil)
+	c.Check(pool.BalanceAsset.Equal(sdk.NewUint(1000*common.One)), Equals, true)
+	c.Check(pool.BalanceRune.Equal(sdk.NewUint(1000*common.One)), Equals, true)
+	for _, evt := range ctx.EventManager().Events() {
+		c.Check(evt.Type, Not(Equals), types.AffiliateFeeEventType)
+		c.Check(evt.Type, Not(Equals), types.SwapEventType)
+	}
+}
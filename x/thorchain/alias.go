@@ -26,8 +26,22 @@ const (
 	EventFail    = types.Failed
 	RefundStatus = types.Refund
 
+	// event types
+	StakeEventType   = types.StakeEventType
+	UnstakeEventType = types.UnstakeEventType
+
+	// slash reasons
+	SlashReasonLackObserving = types.SlashReasonLackObserving
+	SlashReasonLackSigning   = types.SlashReasonLackSigning
+	SlashReasonFailKeygen    = types.SlashReasonFailKeygen
+	SlashReasonFailKeysign   = types.SlashReasonFailKeysign
+	SlashReasonDoubleSign    = types.SlashReasonDoubleSign
+	SlashReasonDecay         = types.SlashReasonDecay
+
 	// Admin config keys
-	MaxUnstakeBasisPoints = types.MaxUnstakeBasisPoints
+	MaxUnstakeBasisPoints     = types.MaxUnstakeBasisPoints
+	MaxAffiliateBasisPoints   = types.MaxAffiliateBasisPoints
+	MaxOperatorFeeBasisPoints = types.MaxOperatorFeeBasisPoints
 
 	// Vaults
 	AsgardVault    = types.AsgardVault
@@ -56,10 +70,14 @@ var (
 	NewVaultData                   = types.NewVaultData
 	NewObservedTx                  = types.NewObservedTx
 	NewTssVoter                    = types.NewTssVoter
+	NewTssKeysignFailVoter         = types.NewTssKeysignFailVoter
 	NewBanVoter                    = types.NewBanVoter
 	NewErrataTxVoter               = types.NewErrataTxVoter
 	NewObservedTxVoter             = types.NewObservedTxVoter
 	NewMsgMimir                    = types.NewMsgMimir
+	NewMsgNetworkFee               = types.NewMsgNetworkFee
+	NewNetworkFee                  = types.NewNetworkFee
+	NewNetworkFeeVoter             = types.NewNetworkFeeVoter
 	NewMsgNativeTx                 = types.NewMsgNativeTx
 	NewMsgTssPool                  = types.NewMsgTssPool
 	NewMsgTssKeysignFail           = types.NewMsgTssKeysignFail
@@ -89,12 +107,18 @@ var (
 	NewEventErrata                 = types.NewEventErrata
 	NewEventFee                    = types.NewEventFee
 	NewEventOutbound               = types.NewEventOutbound
+	NewEventPendingRuneCommit      = types.NewEventPendingRuneCommit
+	NewEventPendingRuneRefund      = types.NewEventPendingRuneRefund
+	NewEventAffiliateFee           = types.NewEventAffiliateFee
 	NewPoolMod                     = types.NewPoolMod
 	NewMsgRefundTx                 = types.NewMsgRefundTx
 	NewMsgOutboundTx               = types.NewMsgOutboundTx
 	NewMsgMigrate                  = types.NewMsgMigrate
 	NewMsgRagnarok                 = types.NewMsgRagnarok
 	NewQueryNodeAccount            = types.NewQueryNodeAccount
+	NewQueryResStakerPosition      = types.NewQueryResStakerPosition
+	NewQueryResNodeSlashes         = types.NewQueryResNodeSlashes
+	NewQueryResNodeScorecard       = types.NewQueryResNodeScorecard
 	HasSuperMajority               = types.HasSuperMajority
 	ChooseSignerParty              = types.ChooseSignerParty
 	GetThreshold                   = types.GetThreshold
@@ -106,9 +130,14 @@ var (
 	NewMsgYggdrasil                = types.NewMsgYggdrasil
 	NewMsgReserveContributor       = types.NewMsgReserveContributor
 	NewMsgBond                     = types.NewMsgBond
+	NewMsgUnBond                   = types.NewMsgUnBond
+	NewBondProvider                = types.NewBondProvider
+	NewNodeSlashes                 = types.NewNodeSlashes
+	NewSlashRecord                 = types.NewSlashRecord
 	NewMsgErrataTx                 = types.NewMsgErrataTx
 	NewMsgBan                      = types.NewMsgBan
 	NewMsgSwitch                   = types.NewMsgSwitch
+	NewMsgCancelSwap               = types.NewMsgCancelSwap
 	NewMsgLeave                    = types.NewMsgLeave
 	NewMsgSetVersion               = types.NewMsgSetVersion
 	NewMsgSetIPAddress             = types.NewMsgSetIPAddress
@@ -131,88 +160,113 @@ var (
 )
 
 type (
-	MsgSend               = bank.MsgSend
-	MsgNativeTx           = types.MsgNativeTx
-	MsgSwitch             = types.MsgSwitch
-	MsgBond               = types.MsgBond
-	MsgNoOp               = types.MsgNoOp
-	MsgAdd                = types.MsgAdd
-	MsgSetUnStake         = types.MsgSetUnStake
-	MsgSetStakeData       = types.MsgSetStakeData
-	MsgOutboundTx         = types.MsgOutboundTx
-	MsgMimir              = types.MsgMimir
-	MsgMigrate            = types.MsgMigrate
-	MsgRagnarok           = types.MsgRagnarok
-	MsgRefundTx           = types.MsgRefundTx
-	MsgErrataTx           = types.MsgErrataTx
-	MsgBan                = types.MsgBan
-	MsgSwap               = types.MsgSwap
-	MsgSetVersion         = types.MsgSetVersion
-	MsgSetIPAddress       = types.MsgSetIPAddress
-	MsgSetNodeKeys        = types.MsgSetNodeKeys
-	MsgLeave              = types.MsgLeave
-	MsgReserveContributor = types.MsgReserveContributor
-	MsgYggdrasil          = types.MsgYggdrasil
-	MsgObservedTxIn       = types.MsgObservedTxIn
-	MsgObservedTxOut      = types.MsgObservedTxOut
-	MsgTssPool            = types.MsgTssPool
-	MsgTssKeysignFail     = types.MsgTssKeysignFail
-	QueryResPools         = types.QueryResPools
-	QueryResHeights       = types.QueryResHeights
-	QueryResTxOut         = types.QueryResTxOut
-	QueryYggdrasilVaults  = types.QueryYggdrasilVaults
-	QueryNodeAccount      = types.QueryNodeAccount
-	ResTxOut              = types.ResTxOut
-	NodeKeys              = types.NodeKeys
-	NodesKeys             = types.NodesKeys
-	PoolStatus            = types.PoolStatus
-	Pool                  = types.Pool
-	Pools                 = types.Pools
-	Staker                = types.Staker
-	ObservedTxs           = types.ObservedTxs
-	ObservedTx            = types.ObservedTx
-	ObservedTxVoter       = types.ObservedTxVoter
-	ObservedTxVoters      = types.ObservedTxVoters
-	ObservedTxIndex       = types.ObservedTxIndex
-	BanVoter              = types.BanVoter
-	ErrataTxVoter         = types.ErrataTxVoter
-	TssVoter              = types.TssVoter
-	TssKeysignFailVoter   = types.TssKeysignFailVoter
-	TxOutItem             = types.TxOutItem
-	TxOut                 = types.TxOut
-	Keygen                = types.Keygen
-	KeygenBlock           = types.KeygenBlock
-	Event                 = types.Event
-	Events                = types.Events
-	EventSwap             = types.EventSwap
-	EventStake            = types.EventStake
-	EventUnstake          = types.EventUnstake
-	EventStatus           = types.EventStatus
-	EventAdd              = types.EventAdd
-	EventRewards          = types.EventRewards
-	EventErrata           = types.EventErrata
-	EventReserve          = types.EventReserve
-	PoolAmt               = types.PoolAmt
-	PoolMod               = types.PoolMod
-	PoolMods              = types.PoolMods
-	ReserveContributor    = types.ReserveContributor
-	ReserveContributors   = types.ReserveContributors
-	Vault                 = types.Vault
-	Vaults                = types.Vaults
-	NodeAccount           = types.NodeAccount
-	NodeAccounts          = types.NodeAccounts
-	NodeStatus            = types.NodeStatus
-	VaultData             = types.VaultData
-	VaultStatus           = types.VaultStatus
-	EventStatuses         = types.EventStatuses
-	GasPool               = types.GasPool
-	EventGas              = types.EventGas
-	TxMarker              = types.TxMarker
-	TxMarkers             = types.TxMarkers
-	EventPool             = types.EventPool
-	EventRefund           = types.EventRefund
-	EventBond             = types.EventBond
-	EventFee              = types.EventFee
-	EventSlash            = types.EventSlash
-	EventOutbound         = types.EventOutbound
+	MsgSend                = bank.MsgSend
+	MsgNativeTx            = types.MsgNativeTx
+	MsgSwitch              = types.MsgSwitch
+	MsgCancelSwap          = types.MsgCancelSwap
+	MsgUnBond              = types.MsgUnBond
+	MsgBond                = types.MsgBond
+	MsgNoOp                = types.MsgNoOp
+	MsgAdd                 = types.MsgAdd
+	MsgSetUnStake          = types.MsgSetUnStake
+	MsgSetStakeData        = types.MsgSetStakeData
+	MsgOutboundTx          = types.MsgOutboundTx
+	MsgMimir               = types.MsgMimir
+	MsgNetworkFee          = types.MsgNetworkFee
+	MsgMigrate             = types.MsgMigrate
+	MsgRagnarok            = types.MsgRagnarok
+	MsgRefundTx            = types.MsgRefundTx
+	MsgErrataTx            = types.MsgErrataTx
+	MsgBan                 = types.MsgBan
+	MsgSwap                = types.MsgSwap
+	MsgSetVersion          = types.MsgSetVersion
+	MsgSetIPAddress        = types.MsgSetIPAddress
+	MsgSetNodeKeys         = types.MsgSetNodeKeys
+	MsgLeave               = types.MsgLeave
+	MsgReserveContributor  = types.MsgReserveContributor
+	MsgYggdrasil           = types.MsgYggdrasil
+	MsgObservedTxIn        = types.MsgObservedTxIn
+	MsgObservedTxOut       = types.MsgObservedTxOut
+	MsgTssPool             = types.MsgTssPool
+	MsgTssKeysignFail      = types.MsgTssKeysignFail
+	QueryResPools          = types.QueryResPools
+	QueryResHeights        = types.QueryResHeights
+	QueryResTxOut          = types.QueryResTxOut
+	QueryResQuoteSwap      = types.QueryResQuoteSwap
+	QueryResQuoteStake     = types.QueryResQuoteStake
+	QueryResQuoteUnstake   = types.QueryResQuoteUnstake
+	QueryResPoolTWAP       = types.QueryResPoolTWAP
+	QueryResStakerPosition = types.QueryResStakerPosition
+	QueryResNodeSlashes    = types.QueryResNodeSlashes
+	QueryResNodeScorecard  = types.QueryResNodeScorecard
+	QueryResChurnPreview   = types.QueryResChurnPreview
+	QueryYggdrasilVaults   = types.QueryYggdrasilVaults
+	QueryNodeAccount       = types.QueryNodeAccount
+	ResTxOut               = types.ResTxOut
+	NodeKeys               = types.NodeKeys
+	NodesKeys              = types.NodesKeys
+	PoolStatus             = types.PoolStatus
+	Pool                   = types.Pool
+	Pools                  = types.Pools
+	PoolPriceCumulative    = types.PoolPriceCumulative
+	Staker                 = types.Staker
+	ObservedTxs            = types.ObservedTxs
+	ObservedTx             = types.ObservedTx
+	ObservedTxVoter        = types.ObservedTxVoter
+	ObservedTxVoters       = types.ObservedTxVoters
+	ObservedTxIndex        = types.ObservedTxIndex
+	BanVoter               = types.BanVoter
+	ErrataTxVoter          = types.ErrataTxVoter
+	TssVoter               = types.TssVoter
+	TssKeysignFailVoter    = types.TssKeysignFailVoter
+	TxOutItem              = types.TxOutItem
+	TxOut                  = types.TxOut
+	Keygen                 = types.Keygen
+	KeygenBlock            = types.KeygenBlock
+	Event                  = types.Event
+	Events                 = types.Events
+	EventSwap              = types.EventSwap
+	EventStake             = types.EventStake
+	EventUnstake           = types.EventUnstake
+	EventStatus            = types.EventStatus
+	EventAdd               = types.EventAdd
+	EventRewards           = types.EventRewards
+	EventErrata            = types.EventErrata
+	EventReserve           = types.EventReserve
+	PoolAmt                = types.PoolAmt
+	PoolMod                = types.PoolMod
+	PoolMods               = types.PoolMods
+	ReserveContributor     = types.ReserveContributor
+	ReserveContributors    = types.ReserveContributors
+	NetworkFee             = types.NetworkFee
+	NetworkFeeReport       = types.NetworkFeeReport
+	NetworkFeeVoter        = types.NetworkFeeVoter
+	Vault                  = types.Vault
+	Vaults                 = types.Vaults
+	NodeAccount            = types.NodeAccount
+	NodeAccounts           = types.NodeAccounts
+	BondProvider           = types.BondProvider
+	BondProviders          = types.BondProviders
+	NodeSlashes            = types.NodeSlashes
+	SlashReason            = types.SlashReason
+	SlashRecord            = types.SlashRecord
+	SlashRecords           = types.SlashRecords
+	SlashCounter           = types.SlashCounter
+	NodeStatus             = types.NodeStatus
+	VaultData              = types.VaultData
+	VaultStatus            = types.VaultStatus
+	EventStatuses          = types.EventStatuses
+	GasPool                = types.GasPool
+	EventGas               = types.EventGas
+	TxMarker               = types.TxMarker
+	TxMarkers              = types.TxMarkers
+	EventPool              = types.EventPool
+	EventRefund            = types.EventRefund
+	EventBond              = types.EventBond
+	EventFee               = types.EventFee
+	EventSlash             = types.EventSlash
+	EventOutbound          = types.EventOutbound
+	EventPendingRuneCommit = types.EventPendingRuneCommit
+	EventPendingRuneRefund = types.EventPendingRuneRefund
+	EventAffiliateFee      = types.EventAffiliateFee
 )
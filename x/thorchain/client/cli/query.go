@@ -1,12 +1,15 @@
 package cli
 
 import (
+	"fmt"
+
 	"github.com/cosmos/cosmos-sdk/client"
 	"github.com/cosmos/cosmos-sdk/client/context"
 	"github.com/cosmos/cosmos-sdk/codec"
 	"github.com/spf13/cobra"
 
 	"gitlab.com/thorchain/thornode/constants"
+	q "gitlab.com/thorchain/thornode/x/thorchain/query"
 	"gitlab.com/thorchain/thornode/x/thorchain/types"
 )
 
@@ -28,6 +31,7 @@ func GetQueryCmd(storeKey string, cdc *codec.Codec) *cobra.Command {
 	}
 	thorchainQueryCmd.AddCommand(client.GetCommands(
 		GetCmdGetVersion(storeKey, cdc),
+		GetCmdChurnPreview(storeKey, cdc),
 	)...)
 	return thorchainQueryCmd
 }
@@ -46,3 +50,26 @@ func GetCmdGetVersion(queryRoute string, cdc *codec.Codec) *cobra.Command {
 		},
 	}
 }
+
+// GetCmdChurnPreview queries who would leave and join if the node accounts churned now
+func GetCmdChurnPreview(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "churn-preview",
+		Short: "Previews the outcome of a churn against the current state",
+		Args:  cobra.ExactArgs(0),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			res, _, err := cliCtx.QueryWithData(q.QueryChurnPreview.Path(queryRoute), nil)
+			if err != nil {
+				return fmt.Errorf("fail to preview churn: %w", err)
+			}
+
+			var out types.QueryResChurnPreview
+			if err := cdc.UnmarshalJSON(res, &out); err != nil {
+				return fmt.Errorf("fail to unmarshal churn preview: %w", err)
+			}
+			return cliCtx.PrintOutput(out)
+		},
+	}
+}
@@ -75,6 +75,18 @@ func (m *DummyEventMgr) EmitOutboundEvent(ctx sdk.Context, outbound EventOutboun
 	return nil
 }
 
+func (m *DummyEventMgr) EmitPendingRuneCommitEvent(ctx sdk.Context, commitEvt EventPendingRuneCommit) error {
+	return nil
+}
+
+func (m *DummyEventMgr) EmitPendingRuneRefundEvent(ctx sdk.Context, refundEvt EventPendingRuneRefund) error {
+	return nil
+}
+
+func (m *DummyEventMgr) EmitAffiliateFeeEvent(ctx sdk.Context, feeEvt EventAffiliateFee) error {
+	return nil
+}
+
 type DummyVersionedEventMgr struct{}
 
 func NewDummyVersionedEventMgr() *DummyVersionedEventMgr {
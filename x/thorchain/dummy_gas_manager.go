@@ -20,6 +20,9 @@ func (m *DummyGasManager) EndBlock(ctx sdk.Context, keeper keep.Keeper, eventMan
 func (m *DummyGasManager) AddGasAsset(gas common.Gas)                                              {}
 func (m *DummyGasManager) GetGas() common.Gas                                                      { return nil }
 func (m *DummyGasManager) ProcessGas(ctx sdk.Context, keeper keep.Keeper)                          {}
+func (m *DummyGasManager) GetFee(ctx sdk.Context, keeper keep.Keeper, chain common.Chain, transactionFee int64) (common.Gas, error) {
+	return nil, nil
+}
 
 type DummyVersionedGasMgr struct {
 }
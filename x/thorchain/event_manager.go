@@ -27,6 +27,9 @@ type EventManager interface {
 	EmitFeeEvent(ctx sdk.Context, keeper keep.Keeper, feeEvent EventFee) error
 	EmitSlashEvent(ctx sdk.Context, keeper keep.Keeper, slashEvt EventSlash) error
 	EmitOutboundEvent(ctx sdk.Context, outbound EventOutbound) error
+	EmitPendingRuneCommitEvent(ctx sdk.Context, commitEvt EventPendingRuneCommit) error
+	EmitPendingRuneRefundEvent(ctx sdk.Context, refundEvt EventPendingRuneRefund) error
+	EmitAffiliateFeeEvent(ctx sdk.Context, feeEvt EventAffiliateFee) error
 }
 
 // EventMgr implement EventManager interface
@@ -341,3 +344,33 @@ func (m *EventMgr) EmitOutboundEvent(ctx sdk.Context, outbound EventOutbound) er
 	ctx.EventManager().EmitEvents(events)
 	return nil
 }
+
+// EmitPendingRuneCommitEvent emit an event when pending RUNE timed out, and had been committed to the pool
+func (m *EventMgr) EmitPendingRuneCommitEvent(ctx sdk.Context, commitEvt EventPendingRuneCommit) error {
+	events, err := commitEvt.Events()
+	if err != nil {
+		return fmt.Errorf("fail to emit pending rune commit event: %w", err)
+	}
+	ctx.EventManager().EmitEvents(events)
+	return nil
+}
+
+// EmitPendingRuneRefundEvent emit an event when pending RUNE timed out, and had been refunded to the staker
+func (m *EventMgr) EmitPendingRuneRefundEvent(ctx sdk.Context, refundEvt EventPendingRuneRefund) error {
+	events, err := refundEvt.Events()
+	if err != nil {
+		return fmt.Errorf("fail to emit pending rune refund event: %w", err)
+	}
+	ctx.EventManager().EmitEvents(events)
+	return nil
+}
+
+// EmitAffiliateFeeEvent emit an event when an affiliate fee had been skimmed from a swap or stake
+func (m *EventMgr) EmitAffiliateFeeEvent(ctx sdk.Context, feeEvt EventAffiliateFee) error {
+	events, err := feeEvt.Events()
+	if err != nil {
+		return fmt.Errorf("fail to emit affiliate fee event: %w", err)
+	}
+	ctx.EventManager().EmitEvents(events)
+	return nil
+}
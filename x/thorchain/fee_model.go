@@ -0,0 +1,114 @@
+package thorchain
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"gitlab.com/thorchain/thornode/common"
+	"gitlab.com/thorchain/thornode/constants"
+	"gitlab.com/thorchain/thornode/x/thorchain/keep"
+)
+
+// FeeModel work out the liquidity fee a swap pays to a pool, and the fee an outbound pays to the reserve
+// every parameter comes from the constants, mimir can override it network wide, or for a single pool with a key like
+// SlipFeeMultiplierBasisPoints-BNB.BNB
+type FeeModel struct {
+	keeper        keep.Keeper
+	constAccessor constants.ConstantValues
+}
+
+// NewFeeModel create a new instance of FeeModel
+func NewFeeModel(keeper keep.Keeper, constAccessor constants.ConstantValues) FeeModel {
+	return FeeModel{
+		keeper:        keeper,
+		constAccessor: constAccessor,
+	}
+}
+
+// getPoolMimirKey return the mimir key that override the given constant for a single pool
+func getPoolMimirKey(name constants.ConstantName, asset common.Asset) string {
+	return fmt.Sprintf("%s-%s", name, asset)
+}
+
+// getValue return the value of the given constant for the given pool, a pool override takes precedence over a network
+// wide mimir, which takes precedence over the constant
+func (fm FeeModel) getValue(ctx sdk.Context, name constants.ConstantName, asset common.Asset) int64 {
+	if !asset.IsEmpty() {
+		if value, err := fm.keeper.GetMimir(ctx, getPoolMimirKey(name, asset)); err == nil && value >= 0 {
+			return value
+		}
+	}
+	if value, err := fm.keeper.GetMimir(ctx, name.String()); err == nil && value >= 0 {
+		return value
+	}
+	return fm.constAccessor.GetInt64Value(name)
+}
+
+// GetSlipFeeMultiplier return how steep the liquidity fee curve of the given pool is, in basis points of the slip fee
+func (fm FeeModel) GetSlipFeeMultiplier(ctx sdk.Context, asset common.Asset) sdk.Uint {
+	return sdk.NewUint(uint64(fm.getValue(ctx, constants.SlipFeeMultiplierBasisPoints, asset)))
+}
+
+// GetMinSlipFeeBasisPoints return the minimum liquidity fee of the given pool, in basis points of the swap output
+func (fm FeeModel) GetMinSlipFeeBasisPoints(ctx sdk.Context, asset common.Asset) sdk.Uint {
+	bps := fm.getValue(ctx, constants.MinSlipFeeBasisPoints, asset)
+	if bps > 10_000 {
+		bps = 10_000
+	}
+	return sdk.NewUint(uint64(bps))
+}
+
+// CalcSwapEmission return what a swap of x into a pool of X and Y emits, and the liquidity fee it leaves in the pool
+// the fee is the slip fee scaled by the pool's curve multiplier, and it is never less than the pool's minimum fee
+func (fm FeeModel) CalcSwapEmission(ctx sdk.Context, asset common.Asset, X, x, Y sdk.Uint) (sdk.Uint, sdk.Uint) {
+	emitAssets := calcAssetEmission(X, x, Y)
+	liquidityFee := calcLiquidityFee(X, x, Y)
+
+	fee := liquidityFee
+	if multiplier := fm.GetSlipFeeMultiplier(ctx, asset); !multiplier.Equal(sdk.NewUint(10_000)) {
+		fee = common.GetShare(multiplier, sdk.NewUint(10_000), liquidityFee)
+	}
+	// what the swap would emit if there is no fee at all, ( x * Y ) / ( x + X )
+	gross := x.Mul(Y).Quo(x.Add(X))
+	if minFee := common.GetShare(fm.GetMinSlipFeeBasisPoints(ctx, asset), sdk.NewUint(10_000), gross); fee.LT(minFee) {
+		fee = minFee
+	}
+	if fee.GT(gross) {
+		fee = gross
+	}
+	if fee.Equal(liquidityFee) {
+		return emitAssets, liquidityFee
+	}
+	return common.SafeSub(gross, fee), fee
+}
+
+// GetOutboundFee return the fee an outbound on the given chain pays, in RUNE. The fee is the network fee observers reported
+// for the chain scaled by the outbound fee multiplier, it is denominated in the gas asset, and valued in RUNE at the depth
+// of the gas asset pool. The flat transaction fee is used when the multiplier isn't set, or the gas asset can't be priced
+func (fm FeeModel) GetOutboundFee(ctx sdk.Context, chain common.Chain) sdk.Uint {
+	transactionFee := sdk.NewUint(uint64(fm.constAccessor.GetInt64Value(constants.TransactionFee)))
+	gasAsset := chain.GetGasAsset()
+	multiplier := fm.getValue(ctx, constants.OutboundFeeMultiplierBasisPoints, gasAsset)
+	if multiplier <= 0 {
+		return transactionFee
+	}
+	networkFee, err := fm.keeper.GetNetworkFee(ctx, chain)
+	if err != nil || networkFee.IsEmpty() {
+		return transactionFee
+	}
+	gasFee := common.GetShare(sdk.NewUint(uint64(multiplier)), sdk.NewUint(10_000), networkFee.GetFee())
+	if gasAsset.IsRune() {
+		return gasFee
+	}
+	pool, err := fm.keeper.GetPool(ctx, gasAsset)
+	if err != nil {
+		ctx.Logger().Error("fail to get gas asset pool", "asset", gasAsset, "error", err)
+		return transactionFee
+	}
+	fee := pool.AssetValueInRune(gasFee)
+	if fee.IsZero() {
+		return transactionFee
+	}
+	return fee
+}
@@ -0,0 +1,62 @@
+package thorchain
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	. "gopkg.in/check.v1"
+
+	"gitlab.com/thorchain/thornode/common"
+	"gitlab.com/thorchain/thornode/constants"
+)
+
+type FeeModelSuite struct{}
+
+var _ = Suite(&FeeModelSuite{})
+
+func (s *FeeModelSuite) TestCalcSwapEmission(c *C) {
+	ctx, k := setupKeeperForTest(c)
+	fm := NewFeeModel(k, constants.GetConstantValues(constants.SWVersion))
+	X := sdk.NewUint(100 * common.One)
+	x := sdk.NewUint(10 * common.One)
+	Y := sdk.NewUint(100 * common.One)
+
+	// the plain slip fee by default
+	emit, fee := fm.CalcSwapEmission(ctx, common.BNBAsset, X, x, Y)
+	c.Check(emit.Equal(calcAssetEmission(X, x, Y)), Equals, true)
+	c.Check(fee.Equal(calcLiquidityFee(X, x, Y)), Equals, true)
+
+	// steeper curve for a single pool
+	k.SetMimir(ctx, getPoolMimirKey(constants.SlipFeeMultiplierBasisPoints, common.BNBAsset), 20_000)
+	emit, fee = fm.CalcSwapEmission(ctx, common.BNBAsset, X, x, Y)
+	c.Check(fee.Equal(sdk.NewUint(165289256)), Equals, true, Commentf("%d", fee.Uint64()))
+	c.Check(emit.Equal(sdk.NewUint(743801653)), Equals, true, Commentf("%d", emit.Uint64()))
+	emit, _ = fm.CalcSwapEmission(ctx, common.BTCAsset, X, x, Y)
+	c.Check(emit.Equal(calcAssetEmission(X, x, Y)), Equals, true)
+
+	// minimum fee of 10%
+	k.SetMimir(ctx, constants.MinSlipFeeBasisPoints.String(), 1000)
+	emit, fee = fm.CalcSwapEmission(ctx, common.BTCAsset, X, x, Y)
+	c.Check(fee.Equal(sdk.NewUint(90909091)), Equals, true, Commentf("%d", fee.Uint64()))
+	c.Check(emit.Equal(sdk.NewUint(818181818)), Equals, true, Commentf("%d", emit.Uint64()))
+}
+
+func (s *FeeModelSuite) TestGetOutboundFee(c *C) {
+	ctx, k := setupKeeperForTest(c)
+	constAccessor := constants.GetConstantValues(constants.SWVersion)
+	transactionFee := sdk.NewUint(uint64(constAccessor.GetInt64Value(constants.TransactionFee)))
+	fm := NewFeeModel(k, constAccessor)
+	c.Check(fm.GetOutboundFee(ctx, common.BNBChain).Equal(transactionFee), Equals, true)
+
+	k.SetMimir(ctx, constants.OutboundFeeMultiplierBasisPoints.String(), 15_000)
+	// no network fee yet
+	c.Check(fm.GetOutboundFee(ctx, common.BNBChain).Equal(transactionFee), Equals, true)
+
+	c.Assert(k.SaveNetworkFee(ctx, common.BNBChain, NewNetworkFee(common.BNBChain, 1, 1, 37500)), IsNil)
+	pool := NewPool()
+	pool.Asset = common.BNBAsset
+	pool.BalanceRune = sdk.NewUint(200 * common.One)
+	pool.BalanceAsset = sdk.NewUint(100 * common.One)
+	pool.Status = PoolEnabled
+	c.Assert(k.SetPool(ctx, pool), IsNil)
+	// 1.5 times 37500 BNB, valued at 2 RUNE a BNB
+	c.Check(fm.GetOutboundFee(ctx, common.BNBChain).Equal(sdk.NewUint(112500)), Equals, true)
+}
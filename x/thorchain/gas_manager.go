@@ -1,6 +1,8 @@
 package thorchain
 
 import (
+	"fmt"
+
 	sdk "github.com/cosmos/cosmos-sdk/types"
 
 	"gitlab.com/thorchain/thornode/common"
@@ -13,6 +15,7 @@ type GasManager interface {
 	AddGasAsset(gas common.Gas)
 	ProcessGas(ctx sdk.Context, keeper keep.Keeper)
 	GetGas() common.Gas
+	GetFee(ctx sdk.Context, keeper keep.Keeper, chain common.Chain, transactionFee int64) (common.Gas, error)
 }
 
 // GasManangerImp implement a GasManager which will store the gas related events happened in thorchain in memory
@@ -51,6 +54,33 @@ func (gm *GasMgr) GetGas() common.Gas {
 	return gm.gas
 }
 
+// GetFee return the max gas an outbound tx on the given chain is allowed to spend
+func (gm *GasMgr) GetFee(ctx sdk.Context, keeper keep.Keeper, chain common.Chain, transactionFee int64) (common.Gas, error) {
+	return calcOutboundGas(ctx, keeper, chain, transactionFee)
+}
+
+// calcOutboundGas return the max gas of an outbound tx on the given chain, it is the fee of a tx at the network fee rate observers
+// reported, when the chain has no network fee yet, it is the transaction fee divided by two, in gas asset
+func calcOutboundGas(ctx sdk.Context, keeper keep.Keeper, chain common.Chain, transactionFee int64) (common.Gas, error) {
+	gasAsset := chain.GetGasAsset()
+	networkFee, err := keeper.GetNetworkFee(ctx, chain)
+	if err != nil {
+		ctx.Logger().Error("fail to get network fee", "chain", chain, "error", err)
+	}
+	if err == nil && !networkFee.IsEmpty() {
+		return common.Gas{
+			common.NewCoin(gasAsset, networkFee.GetFee()),
+		}, nil
+	}
+	pool, err := keeper.GetPool(ctx, gasAsset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get gas asset pool: %w", err)
+	}
+	return common.Gas{
+		common.NewCoin(gasAsset, pool.RuneValueInAsset(sdk.NewUint(uint64(transactionFee/2)))),
+	}, nil
+}
+
 // EndBlock emit the events
 func (gm *GasMgr) EndBlock(ctx sdk.Context, keeper keep.Keeper, eventManager EventManager) {
 	gm.ProcessGas(ctx, keeper)
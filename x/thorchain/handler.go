@@ -30,6 +30,8 @@ const (
 	CodeSwapFailInvalidAmount    sdk.CodeType = 113
 	CodeSwapFailInvalidBalance   sdk.CodeType = 114
 	CodeSwapFailNotEnoughBalance sdk.CodeType = 115
+	CodeSwapLimitOrderExpired    sdk.CodeType = 116
+	CodeSwapLimitOrderCancelled  sdk.CodeType = 117
 
 	CodeStakeFailValidation    sdk.CodeType = 120
 	CodeFailGetStaker          sdk.CodeType = 122
@@ -108,6 +110,7 @@ func getHandlerMapping(keeper keep.Keeper,
 	m[MsgErrataTx{}.Type()] = NewErrataTxHandler(keeper, versionedEventManager)
 	m[MsgSend{}.Type()] = NewSendHandler(keeper)
 	m[MsgMimir{}.Type()] = NewMimirHandler(keeper)
+	m[MsgNetworkFee{}.Type()] = NewNetworkFeeHandler(keeper)
 	return m
 }
 
@@ -158,6 +161,8 @@ func getInternalHandlerMapping(keeper keep.Keeper,
 	m[MsgMigrate{}.Type()] = NewMigrateHandler(keeper, versionedEventManager)
 	m[MsgRagnarok{}.Type()] = NewRagnarokHandler(keeper, versionedEventManager)
 	m[MsgSwitch{}.Type()] = NewSwitchHandler(keeper, versionedTxOutStore)
+	m[MsgCancelSwap{}.Type()] = NewCancelSwapHandler(keeper, versionedTxOutStore, versionedEventManager)
+	m[MsgUnBond{}.Type()] = NewUnBondHandler(keeper, versionedTxOutStore, versionedEventManager)
 	return m
 }
 
@@ -250,6 +255,8 @@ func processOneTxIn(ctx sdk.Context, keeper keep.Keeper, tx ObservedTx, signer s
 		if err != nil {
 			return nil, sdk.NewError(DefaultCodespace, CodeInvalidMemo, "invalid bond memo:%s", err.Error())
 		}
+	case UnbondMemo:
+		newMsg = NewMsgUnBond(tx.Tx, m.GetAccAddress(), m.Amount, tx.Tx.FromAddress, signer)
 	case RagnarokMemo:
 		newMsg, err = getMsgRagnarokFromMemo(m, tx, signer)
 		if err != nil {
@@ -266,6 +273,8 @@ func processOneTxIn(ctx sdk.Context, keeper keep.Keeper, tx ObservedTx, signer s
 		newMsg = NewMsgReserveContributor(tx.Tx, res, signer)
 	case SwitchMemo:
 		newMsg = NewMsgSwitch(tx.Tx, memo.GetDestination(), signer)
+	case CancelMemo:
+		newMsg = NewMsgCancelSwap(tx.Tx, m.GetTxID(), signer)
 
 	default:
 		return nil, sdk.NewError(DefaultCodespace, CodeInvalidMemo, "invalid memo")
@@ -300,7 +309,12 @@ func getMsgSwapFromMemo(memo SwapMemo, tx ObservedTx, signer sdk.AccAddress) (sd
 	}
 
 	// Looks like at the moment THORNode can only process ont ty
-	return NewMsgSwap(tx.Tx, memo.GetAsset(), memo.Destination, memo.SlipLimit, signer), nil
+	msg := NewMsgSwap(tx.Tx, memo.GetAsset(), memo.Destination, memo.SlipLimit, signer)
+	msg.LimitOrder = memo.LimitOrder
+	msg.StreamingQuantity = memo.StreamingQuantity
+	msg.AffiliateAddress = memo.AffiliateAddress
+	msg.AffiliateBasisPoints = memo.AffiliateBasisPoints
+	return msg, nil
 }
 
 func getMsgUnstakeFromMemo(memo UnstakeMemo, tx ObservedTx, signer sdk.AccAddress) (sdk.Msg, error) {
@@ -364,7 +378,7 @@ func getMsgStakeFromMemo(ctx sdk.Context, memo StakeMemo, tx ObservedTx, signer
 		}
 	}
 
-	return NewMsgSetStakeData(
+	msg := NewMsgSetStakeData(
 		tx.Tx,
 		asset,
 		runeAmount,
@@ -372,7 +386,11 @@ func getMsgStakeFromMemo(ctx sdk.Context, memo StakeMemo, tx ObservedTx, signer
 		runeAddr,
 		assetAddr,
 		signer,
-	), nil
+	)
+	msg.Asymmetric = memo.Asymmetric
+	msg.AffiliateAddress = memo.AffiliateAddress
+	msg.AffiliateBasisPoints = memo.AffiliateBasisPoints
+	return msg, nil
 }
 
 func getMsgAddFromMemo(memo AddMemo, tx ObservedTx, signer sdk.AccAddress) (sdk.Msg, error) {
@@ -428,5 +446,7 @@ func getMsgBondFromMemo(memo BondMemo, tx ObservedTx, signer sdk.AccAddress) (sd
 	if runeAmount.IsZero() {
 		return nil, errors.New("RUNE amount is 0")
 	}
-	return NewMsgBond(tx.Tx, memo.GetAccAddress(), runeAmount, tx.Tx.FromAddress, signer), nil
+	msg := NewMsgBond(tx.Tx, memo.GetAccAddress(), runeAmount, tx.Tx.FromAddress, signer)
+	msg.OperatorFeeBasisPoints = memo.OperatorFeeBasisPoints
+	return msg, nil
 }
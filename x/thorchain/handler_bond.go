@@ -51,6 +51,11 @@ func (h BondHandler) validateV1(ctx sdk.Context, version semver.Version, msg Msg
 		return sdk.ErrInternal(fmt.Sprintf("fail to get node account(%s): %s", msg.NodeAddress, err))
 	}
 
+	// only the operator, the address that whitelisted the node, can set the operator fee
+	if msg.OperatorFeeBasisPoints >= 0 && nodeAccount.Status != NodeUnknown && !msg.BondAddress.Equals(nodeAccount.BondAddress) {
+		return sdk.ErrUnauthorized(fmt.Sprintf("only the node operator(%s) can set the operator fee", nodeAccount.BondAddress))
+	}
+
 	bond := msg.Bond.Add(nodeAccount.Bond)
 	if (bond).LT(minValidatorBond) {
 		return sdk.ErrUnknownRequest(fmt.Sprintf("not enough rune to be whitelisted , minimum validator bond (%s) , bond(%s)", minValidatorBond.String(), bond))
@@ -123,7 +128,11 @@ func (h BondHandler) handle(ctx sdk.Context, msg MsgBond, version semver.Version
 			))
 	}
 
-	nodeAccount.Bond = nodeAccount.Bond.Add(msg.Bond)
+	// anyone can bond to a node, each address is tracked as a bond provider
+	nodeAccount.AddProviderBond(msg.BondAddress, msg.Bond)
+	if msg.OperatorFeeBasisPoints >= 0 && msg.BondAddress.Equals(nodeAccount.BondAddress) {
+		nodeAccount.OperatorFeeBasisPoints = msg.OperatorFeeBasisPoints
+	}
 
 	if err := h.keeper.SetNodeAccount(ctx, nodeAccount); err != nil {
 		return sdk.ErrInternal(fmt.Errorf("fail to save node account(%s): %w", nodeAccount, err).Error())
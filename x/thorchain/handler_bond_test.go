@@ -59,10 +59,31 @@ func (HandlerBondSuite) TestBondHandler_Run(c *C) {
 		common.Gas{},
 		"apply",
 	)
-	msg := NewMsgBond(txIn, GetRandomNodeAccount(NodeStandby).NodeAddress, sdk.NewUint(uint64(minimumBondInRune)), GetRandomBNBAddress(), activeNodeAccount.NodeAddress)
+	operator := GetRandomBNBAddress()
+	msg := NewMsgBond(txIn, GetRandomNodeAccount(NodeStandby).NodeAddress, sdk.NewUint(uint64(minimumBondInRune)), operator, activeNodeAccount.NodeAddress)
+	msg.OperatorFeeBasisPoints = 500
 	result := handler.Run(ctx, msg, ver, constAccessor)
 	c.Assert(result.IsOK(), Equals, true)
 
+	// another address bond to the same node
+	provider := GetRandomBNBAddress()
+	providerMsg := NewMsgBond(txIn, msg.NodeAddress, sdk.NewUint(common.One), provider, activeNodeAccount.NodeAddress)
+	result = handler.Run(ctx, providerMsg, ver, constAccessor)
+	c.Assert(result.IsOK(), Equals, true)
+	na, err := k1.GetNodeAccount(ctx, msg.NodeAddress)
+	c.Assert(err, IsNil)
+	c.Check(na.BondAddress.Equals(operator), Equals, true)
+	c.Check(na.OperatorFeeBasisPoints, Equals, int64(500))
+	c.Check(na.Bond.Equal(sdk.NewUint(uint64(minimumBondInRune)+common.One)), Equals, true)
+	bp, ok := na.GetBondProviders().Get(provider)
+	c.Assert(ok, Equals, true)
+	c.Check(bp.Bond.Equal(sdk.NewUint(common.One)), Equals, true)
+
+	// only the operator can set the operator fee
+	providerMsg.OperatorFeeBasisPoints = 0
+	result = handler.Run(ctx, providerMsg, ver, constAccessor)
+	c.Assert(result.Code, Equals, sdk.CodeUnauthorized)
+
 	// invalid version
 	handler = NewBondHandler(k, NewVersionedEventMgr())
 	ver = semver.Version{}
@@ -0,0 +1,111 @@
+package thorchain
+
+import (
+	"fmt"
+
+	"github.com/blang/semver"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"gitlab.com/thorchain/thornode/constants"
+	"gitlab.com/thorchain/thornode/x/thorchain/keep"
+)
+
+// CancelSwapHandler is to process MsgCancelSwap, which take a resting limit order out of the swap queue and refund it
+type CancelSwapHandler struct {
+	keeper                keep.Keeper
+	versionedTxOutStore   VersionedTxOutStore
+	versionedEventManager VersionedEventManager
+}
+
+// NewCancelSwapHandler create a new instance of CancelSwapHandler
+func NewCancelSwapHandler(keeper keep.Keeper, versionedTxOutStore VersionedTxOutStore, versionedEventManager VersionedEventManager) CancelSwapHandler {
+	return CancelSwapHandler{
+		keeper:                keeper,
+		versionedTxOutStore:   versionedTxOutStore,
+		versionedEventManager: versionedEventManager,
+	}
+}
+
+// Run execute the handler
+func (h CancelSwapHandler) Run(ctx sdk.Context, m sdk.Msg, version semver.Version, constAccessor constants.ConstantValues) sdk.Result {
+	msg, ok := m.(MsgCancelSwap)
+	if !ok {
+		return errInvalidMessage.Result()
+	}
+	ctx.Logger().Info("receive MsgCancelSwap", "request tx hash", msg.Tx.ID, "limit order", msg.TxID)
+	if err := h.validate(ctx, msg, version); err != nil {
+		ctx.Logger().Error("msg cancel swap fail validation", "error", err)
+		return err.Result()
+	}
+	if err := h.handle(ctx, msg, version, constAccessor); err != nil {
+		ctx.Logger().Error("fail to process msg cancel swap", "error", err)
+		return err.Result()
+	}
+	return sdk.Result{
+		Code:      sdk.CodeOK,
+		Codespace: DefaultCodespace,
+	}
+}
+
+func (h CancelSwapHandler) validate(ctx sdk.Context, msg MsgCancelSwap, version semver.Version) sdk.Error {
+	if version.GTE(semver.MustParse("0.1.0")) {
+		return h.validateV1(ctx, msg)
+	}
+	return errBadVersion
+}
+
+func (h CancelSwapHandler) validateV1(ctx sdk.Context, msg MsgCancelSwap) sdk.Error {
+	if err := msg.ValidateBasic(); err != nil {
+		return err
+	}
+	if !isSignedByActiveNodeAccounts(ctx, h.keeper, msg.GetSigners()) {
+		return sdk.ErrUnauthorized("not authorized")
+	}
+	order, err := h.keeper.GetSwapQueueItem(ctx, msg.TxID)
+	if err != nil {
+		return sdk.ErrUnknownRequest(fmt.Sprintf("limit order %s doesn't exist", msg.TxID))
+	}
+	if !order.LimitOrder {
+		return sdk.ErrUnknownRequest(fmt.Sprintf("swap %s is not a limit order", msg.TxID))
+	}
+	// only the address placed the limit order is allowed to cancel it
+	if !order.Tx.FromAddress.Equals(msg.Tx.FromAddress) {
+		return sdk.ErrUnauthorized(fmt.Sprintf("%s is not the owner of limit order %s", msg.Tx.FromAddress, msg.TxID))
+	}
+	return nil
+}
+
+func (h CancelSwapHandler) handle(ctx sdk.Context, msg MsgCancelSwap, version semver.Version, constAccessor constants.ConstantValues) sdk.Error {
+	if version.GTE(semver.MustParse("0.1.0")) {
+		return h.handleV1(ctx, msg, version, constAccessor)
+	}
+	return errBadVersion
+}
+
+func (h CancelSwapHandler) handleV1(ctx sdk.Context, msg MsgCancelSwap, version semver.Version, constAccessor constants.ConstantValues) sdk.Error {
+	order, err := h.keeper.GetSwapQueueItem(ctx, msg.TxID)
+	if err != nil {
+		return sdk.ErrInternal(fmt.Errorf("fail to get limit order: %w", err).Error())
+	}
+	txOutStore, err := h.versionedTxOutStore.GetTxOutStore(ctx, h.keeper, version)
+	if err != nil {
+		ctx.Logger().Error("fail to get txout store", "error", err)
+		return errBadVersion
+	}
+	eventMgr, err := h.versionedEventManager.GetEventManager(ctx, version)
+	if err != nil {
+		ctx.Logger().Error("fail to get event manager", "error", err)
+		return errFailGetEventManager
+	}
+	h.keeper.RemoveSwapQueueItem(ctx, order.Tx.ID)
+	if err := refundTx(ctx, ObservedTx{Tx: order.Tx}, txOutStore, h.keeper, constAccessor, CodeSwapLimitOrderCancelled, "limit order cancelled", eventMgr); err != nil {
+		return sdk.ErrInternal(fmt.Errorf("fail to refund limit order: %w", err).Error())
+	}
+	// whatever came with the cancel request goes back as well
+	if len(msg.Tx.Coins) > 0 {
+		if err := refundTx(ctx, ObservedTx{Tx: msg.Tx}, txOutStore, h.keeper, constAccessor, CodeSwapLimitOrderCancelled, "limit order cancelled", eventMgr); err != nil {
+			return sdk.ErrInternal(fmt.Errorf("fail to refund cancel request: %w", err).Error())
+		}
+	}
+	return nil
+}
@@ -0,0 +1,83 @@
+package thorchain
+
+import (
+	"github.com/blang/semver"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	. "gopkg.in/check.v1"
+
+	"gitlab.com/thorchain/thornode/common"
+	"gitlab.com/thorchain/thornode/constants"
+)
+
+type HandlerCancelSwapSuite struct{}
+
+var _ = Suite(&HandlerCancelSwapSuite{})
+
+func (HandlerCancelSwapSuite) TestCancelSwapHandler(c *C) {
+	w := getHandlerTestWrapper(c, 1, true, true)
+	ver := constants.SWVersion
+	constAccessor := constants.GetConstantValues(ver)
+	handler := NewCancelSwapHandler(w.keeper, w.versionedTxOutStore, NewVersionedEventMgr())
+	vault := GetRandomVault()
+	vault.Coins = common.Coins{
+		common.NewCoin(common.BNBAsset, sdk.NewUint(100*common.One)),
+	}
+	c.Assert(w.keeper.SetVault(w.ctx, vault), IsNil)
+
+	owner := GetRandomBNBAddress()
+	order := NewMsgSwap(common.NewTx(
+		GetRandomTxHash(),
+		owner,
+		GetRandomBNBAddress(),
+		common.Coins{common.NewCoin(common.BNBAsset, sdk.NewUint(10*common.One))},
+		BNBGasFeeSingleton,
+		"SWAP:RUNE-A1F:::limit",
+	), common.RuneAsset(), GetRandomBNBAddress(), sdk.NewUint(common.One), w.activeNodeAccount.NodeAddress)
+	order.LimitOrder = true
+	order.ExpiryHeight = w.ctx.BlockHeight() + 100
+	c.Assert(w.keeper.SetSwapQueueItem(w.ctx, order), IsNil)
+	market := order
+	market.Tx.ID = GetRandomTxHash()
+	market.LimitOrder = false
+	c.Assert(w.keeper.SetSwapQueueItem(w.ctx, market), IsNil)
+
+	newCancel := func(from common.Address, txID common.TxID) MsgCancelSwap {
+		tx := common.NewTx(GetRandomTxHash(), from, GetRandomBNBAddress(), common.Coins{common.NewCoin(common.BNBAsset, sdk.NewUint(10*common.One))}, BNBGasFeeSingleton, "CANCEL:"+txID.String())
+		return NewMsgCancelSwap(tx, txID, w.activeNodeAccount.NodeAddress)
+	}
+
+	// bad version
+	result := handler.Run(w.ctx, newCancel(owner, order.Tx.ID), semver.Version{}, constAccessor)
+	c.Check(result.Code, Equals, CodeBadVersion)
+	// unknown order
+	result = handler.Run(w.ctx, newCancel(owner, GetRandomTxHash()), ver, constAccessor)
+	c.Check(result.Code, Equals, sdk.CodeUnknownRequest)
+	// not a limit order
+	result = handler.Run(w.ctx, newCancel(owner, market.Tx.ID), ver, constAccessor)
+	c.Check(result.Code, Equals, sdk.CodeUnknownRequest)
+	// not the owner
+	result = handler.Run(w.ctx, newCancel(GetRandomBNBAddress(), order.Tx.ID), ver, constAccessor)
+	c.Check(result.Code, Equals, sdk.CodeUnauthorized)
+	// not signed by an active node account
+	msg := newCancel(owner, order.Tx.ID)
+	msg.Signer = GetRandomBech32Addr()
+	result = handler.Run(w.ctx, msg, ver, constAccessor)
+	c.Check(result.Code, Equals, sdk.CodeUnauthorized)
+
+	msg = newCancel(owner, order.Tx.ID)
+	result = handler.Run(w.ctx, msg, ver, constAccessor)
+	c.Assert(result.Code, Equals, sdk.CodeOK, Commentf("%+v", result))
+	_, err := w.keeper.GetSwapQueueItem(w.ctx, order.Tx.ID)
+	c.Check(err, NotNil)
+	_, err = w.keeper.GetSwapQueueItem(w.ctx, market.Tx.ID)
+	c.Check(err, IsNil)
+	txOutStore, err := w.versionedTxOutStore.GetTxOutStore(w.ctx, w.keeper, ver)
+	c.Assert(err, IsNil)
+	items, err := txOutStore.GetOutboundItems(w.ctx)
+	c.Assert(err, IsNil)
+	// both the limit order and the cancel request are refunded
+	c.Assert(items, HasLen, 2)
+	c.Check(items[0].InHash.Equals(order.Tx.ID), Equals, true)
+	c.Check(items[0].ToAddress.Equals(owner), Equals, true)
+	c.Check(items[1].InHash.Equals(msg.Tx.ID), Equals, true)
+}
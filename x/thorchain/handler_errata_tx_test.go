@@ -132,12 +132,16 @@ func (s *HandlerErrataTxSuite) TestHandle(c *C) {
 				LastStakeHeight: 5,
 				Units:           totalUnits.QuoUint64(2),
 				PendingRune:     sdk.ZeroUint(),
+				RuneDeposit:     sdk.ZeroUint(),
+				AssetDeposit:    sdk.ZeroUint(),
 			},
 			Staker{
 				RuneAddress:     GetRandomBNBAddress(),
 				LastStakeHeight: 10,
 				Units:           totalUnits.QuoUint64(2),
 				PendingRune:     sdk.ZeroUint(),
+				RuneDeposit:     sdk.ZeroUint(),
+				AssetDeposit:    sdk.ZeroUint(),
 			},
 		},
 	}
@@ -86,7 +86,7 @@ func (h LeaveHandler) handle(ctx sdk.Context, msg MsgLeave, version semver.Versi
 
 	coin := msg.Tx.Coins.GetCoin(common.RuneAsset())
 	if !coin.IsEmpty() {
-		nodeAcc.Bond = nodeAcc.Bond.Add(coin.Amount)
+		nodeAcc.AddProviderBond(msg.Tx.FromAddress, coin.Amount)
 	}
 
 	if nodeAcc.Status == NodeActive {
@@ -69,6 +69,10 @@ func (HandlerLeaveSuite) TestLeaveHandler_ActiveNodeLeave(c *C) {
 	acc2, err = w.keeper.GetNodeAccountByBondAddress(w.ctx, acc2.BondAddress)
 	c.Assert(err, IsNil)
 	c.Check(acc2.Bond.Equal(sdk.NewUint(10000000001)), Equals, true, Commentf("Bond:%d\n", acc2.Bond.Uint64()))
+	providers := acc2.GetBondProviders()
+	c.Assert(providers, HasLen, 1)
+	c.Check(providers[0].BondAddress.Equals(acc2.BondAddress), Equals, true)
+	c.Check(providers[0].Bond.Equal(sdk.NewUint(10000000001)), Equals, true)
 }
 
 func (HandlerLeaveSuite) TestLeaveValidation(c *C) {
@@ -0,0 +1,115 @@
+package thorchain
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/blang/semver"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"gitlab.com/thorchain/thornode/constants"
+	"gitlab.com/thorchain/thornode/x/thorchain/keep"
+)
+
+// NetworkFeeHandler a handler to process MsgNetworkFee messages
+type NetworkFeeHandler struct {
+	keeper keep.Keeper
+}
+
+// NewNetworkFeeHandler create a new instance of network fee handler
+func NewNetworkFeeHandler(keeper keep.Keeper) NetworkFeeHandler {
+	return NetworkFeeHandler{
+		keeper: keeper,
+	}
+}
+
+// Run is the main entry point for network fee logic
+func (h NetworkFeeHandler) Run(ctx sdk.Context, m sdk.Msg, version semver.Version, _ constants.ConstantValues) sdk.Result {
+	msg, ok := m.(MsgNetworkFee)
+	if !ok {
+		return errInvalidMessage.Result()
+	}
+	ctx.Logger().Info("receive network fee", "chain", msg.Chain, "size", msg.TransactionSize, "rate", msg.TransactionFeeRate)
+	if err := h.validate(ctx, msg, version); err != nil {
+		ctx.Logger().Error("msg network fee failed validation", "error", err)
+		return err.Result()
+	}
+	if err := h.handle(ctx, msg, version); err != nil {
+		ctx.Logger().Error("fail to process msg network fee", "error", err)
+		return err.Result()
+	}
+
+	return sdk.Result{
+		Code:      sdk.CodeOK,
+		Codespace: DefaultCodespace,
+	}
+}
+
+func (h NetworkFeeHandler) validate(ctx sdk.Context, msg MsgNetworkFee, version semver.Version) sdk.Error {
+	if version.GTE(semver.MustParse("0.1.0")) {
+		return h.validateV1(ctx, msg)
+	} else {
+		return errBadVersion
+	}
+}
+
+func (h NetworkFeeHandler) validateV1(ctx sdk.Context, msg MsgNetworkFee) sdk.Error {
+	if err := msg.ValidateBasic(); err != nil {
+		return err
+	}
+
+	nodeAccount, err := h.keeper.GetNodeAccount(ctx, msg.Signer)
+	if err != nil {
+		ctx.Logger().Error("fail to get node account", "error", err, "address", msg.Signer.String())
+		return sdk.ErrUnauthorized(fmt.Sprintf("%s is not authorizaed", msg.Signer))
+	}
+	if nodeAccount.IsEmpty() || nodeAccount.Status != NodeActive {
+		ctx.Logger().Error("unauthorized account", "address", msg.Signer.String())
+		return sdk.ErrUnauthorized(fmt.Sprintf("%s is not authorizaed", msg.Signer))
+	}
+
+	return nil
+}
+
+func (h NetworkFeeHandler) handle(ctx sdk.Context, msg MsgNetworkFee, version semver.Version) sdk.Error {
+	ctx.Logger().Info("handleMsgNetworkFee request", "chain", msg.Chain)
+	if version.GTE(semver.MustParse("0.1.0")) {
+		return h.handleV1(ctx, msg)
+	} else {
+		ctx.Logger().Error(errInvalidVersion.Error())
+		return errBadVersion
+	}
+}
+
+// handleV1 record the fee reported by the node, and then set the network fee of the chain to the median of the reports from active nodes
+func (h NetworkFeeHandler) handleV1(ctx sdk.Context, msg MsgNetworkFee) sdk.Error {
+	voter, err := h.keeper.GetNetworkFeeVoter(ctx, msg.Chain)
+	if err != nil {
+		return sdk.ErrInternal(fmt.Errorf("fail to get network fee voter: %w", err).Error())
+	}
+	voter.Add(NetworkFeeReport{
+		Signer:             msg.Signer,
+		BlockHeight:        msg.BlockHeight,
+		TransactionSize:    msg.TransactionSize,
+		TransactionFeeRate: msg.TransactionFeeRate,
+	})
+	activeNodes, err := h.keeper.ListActiveNodeAccounts(ctx)
+	if err != nil {
+		return sdk.ErrInternal(fmt.Errorf("fail to get active node accounts: %w", err).Error())
+	}
+	voter.Prune(activeNodes)
+	h.keeper.SetNetworkFeeVoter(ctx, voter)
+
+	networkFee := voter.GetNetworkFee(ctx.BlockHeight())
+	if err := h.keeper.SaveNetworkFee(ctx, msg.Chain, networkFee); err != nil {
+		return sdk.ErrInternal(fmt.Errorf("fail to save network fee: %w", err).Error())
+	}
+
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent("set_network_fee",
+			sdk.NewAttribute("chain", networkFee.Chain.String()),
+			sdk.NewAttribute("transaction_size", strconv.FormatUint(networkFee.TransactionSize, 10)),
+			sdk.NewAttribute("transaction_fee_rate", strconv.FormatUint(networkFee.TransactionFeeRate, 10))))
+
+	return nil
+}
@@ -0,0 +1,122 @@
+package thorchain
+
+import (
+	"github.com/blang/semver"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	. "gopkg.in/check.v1"
+
+	"gitlab.com/thorchain/thornode/common"
+	"gitlab.com/thorchain/thornode/constants"
+)
+
+type HandlerNetworkFeeSuite struct{}
+
+var _ = Suite(&HandlerNetworkFeeSuite{})
+
+type TestNetworkFeeKeeper struct {
+	KVStoreDummy
+	nas        NodeAccounts
+	voter      NetworkFeeVoter
+	networkFee NetworkFee
+}
+
+func (k *TestNetworkFeeKeeper) GetNodeAccount(_ sdk.Context, addr sdk.AccAddress) (NodeAccount, error) {
+	for _, na := range k.nas {
+		if na.NodeAddress.Equals(addr) {
+			return na, nil
+		}
+	}
+	return NodeAccount{}, nil
+}
+
+func (k *TestNetworkFeeKeeper) ListActiveNodeAccounts(_ sdk.Context) (NodeAccounts, error) {
+	var active NodeAccounts
+	for _, na := range k.nas {
+		if na.Status == NodeActive {
+			active = append(active, na)
+		}
+	}
+	return active, nil
+}
+
+func (k *TestNetworkFeeKeeper) GetNetworkFeeVoter(_ sdk.Context, chain common.Chain) (NetworkFeeVoter, error) {
+	if k.voter.Chain.IsEmpty() {
+		return NewNetworkFeeVoter(chain), nil
+	}
+	return k.voter, nil
+}
+
+func (k *TestNetworkFeeKeeper) SetNetworkFeeVoter(_ sdk.Context, voter NetworkFeeVoter) {
+	k.voter = voter
+}
+
+func (k *TestNetworkFeeKeeper) SaveNetworkFee(_ sdk.Context, _ common.Chain, networkFee NetworkFee) error {
+	k.networkFee = networkFee
+	return nil
+}
+
+func (s *HandlerNetworkFeeSuite) TestValidate(c *C) {
+	ctx, _ := setupKeeperForTest(c)
+
+	keeper := &TestNetworkFeeKeeper{
+		nas: NodeAccounts{
+			GetRandomNodeAccount(NodeActive),
+			GetRandomNodeAccount(NodeStandby),
+		},
+	}
+	handler := NewNetworkFeeHandler(keeper)
+	ver := constants.SWVersion
+	// happy path
+	msg := NewMsgNetworkFee(1, common.BTCChain, 250, 10, keeper.nas[0].NodeAddress)
+	c.Assert(handler.validate(ctx, msg, ver), IsNil)
+
+	// invalid version
+	c.Assert(handler.validate(ctx, msg, semver.Version{}), Equals, errBadVersion)
+
+	// node is not active
+	msg = NewMsgNetworkFee(1, common.BTCChain, 250, 10, keeper.nas[1].NodeAddress)
+	c.Assert(handler.validate(ctx, msg, ver), NotNil)
+
+	// not a node account
+	msg = NewMsgNetworkFee(1, common.BTCChain, 250, 10, GetRandomBech32Addr())
+	c.Assert(handler.validate(ctx, msg, ver), NotNil)
+
+	// invalid msg
+	c.Assert(handler.validate(ctx, MsgNetworkFee{}, ver), NotNil)
+}
+
+func (s *HandlerNetworkFeeSuite) TestHandle(c *C) {
+	ctx, _ := setupKeeperForTest(c)
+	ver := constants.SWVersion
+	constAccessor := constants.GetConstantValues(ver)
+
+	keeper := &TestNetworkFeeKeeper{
+		nas: NodeAccounts{
+			GetRandomNodeAccount(NodeActive),
+			GetRandomNodeAccount(NodeActive),
+			GetRandomNodeAccount(NodeActive),
+		},
+	}
+	handler := NewNetworkFeeHandler(keeper)
+
+	result := handler.Run(ctx, NewMsgNetworkFee(1, common.BTCChain, 250, 10, keeper.nas[0].NodeAddress), ver, constAccessor)
+	c.Assert(result.Code, Equals, sdk.CodeOK)
+	c.Check(keeper.networkFee.TransactionFeeRate, Equals, uint64(10))
+	result = handler.Run(ctx, NewMsgNetworkFee(1, common.BTCChain, 250, 20, keeper.nas[1].NodeAddress), ver, constAccessor)
+	c.Assert(result.Code, Equals, sdk.CodeOK)
+	result = handler.Run(ctx, NewMsgNetworkFee(1, common.BTCChain, 250, 1000, keeper.nas[2].NodeAddress), ver, constAccessor)
+	c.Assert(result.Code, Equals, sdk.CodeOK)
+	c.Check(keeper.voter.Reports, HasLen, 3)
+	c.Check(keeper.networkFee.Chain.Equals(common.BTCChain), Equals, true)
+	c.Check(keeper.networkFee.TransactionSize, Equals, uint64(250))
+	c.Check(keeper.networkFee.TransactionFeeRate, Equals, uint64(20))
+
+	// reports from nodes no longer active are dropped
+	keeper.nas[2].Status = NodeStandby
+	c.Assert(handler.handle(ctx, NewMsgNetworkFee(2, common.BTCChain, 250, 30, keeper.nas[0].NodeAddress), ver), IsNil)
+	c.Check(keeper.voter.Reports, HasLen, 2)
+	c.Check(keeper.networkFee.TransactionFeeRate, Equals, uint64(25))
+
+	result = handler.Run(ctx, NewMsgMimir("whatever", 1, GetRandomBech32Addr()), ver, constAccessor)
+	c.Check(result.Code, Equals, CodeInvalidMessage)
+}
@@ -253,9 +253,37 @@ func (h ObservedTxInHandler) handleV1(ctx sdk.Context, version semver.Version, m
 			}
 		}
 
+		// the affiliate fee is skimmed before the swap is queued or the stake is processed, so a refund only return what is left
+		// limit orders might never execute, their affiliate fee is taken by the swap queue when they do
+		if msgSwap, ok := m.(MsgSwap); ok && !msgSwap.LimitOrder {
+			msgSwap.Tx = skimAffiliateFees(ctx, h.keeper, txOutStore, eventMgr, constAccessor, msgSwap.Tx, msgSwap.AffiliateAddress, msgSwap.AffiliateBasisPoints)
+			m = msgSwap
+		}
+		if isStake {
+			msgStake := m.(MsgSetStakeData)
+			msgStake.Tx = skimAffiliateFees(ctx, h.keeper, txOutStore, eventMgr, constAccessor, msgStake.Tx, msgStake.AffiliateAddress, msgStake.AffiliateBasisPoints)
+			for _, coin := range msgStake.Tx.Coins {
+				if coin.Asset.IsRune() {
+					msgStake.RuneAmount = coin.Amount
+				}
+				if coin.Asset.Equals(msgStake.Asset) {
+					msgStake.AssetAmount = coin.Amount
+				}
+			}
+			tx.Tx.Coins = msgStake.Tx.Coins
+			m = msgStake
+		}
+
 		// if its a swap, send it to our queue for processing later
 		if isSwap {
-			if err := h.keeper.SetSwapQueueItem(ctx, m.(MsgSwap)); err != nil {
+			msgSwap := m.(MsgSwap)
+			if msgSwap.LimitOrder {
+				msgSwap.ExpiryHeight = ctx.BlockHeight() + getLimitOrderTTL(ctx, h.keeper, constAccessor)
+			}
+			if maxQuantity := getMaxStreamingSwapQuantity(ctx, h.keeper, constAccessor); msgSwap.StreamingQuantity > maxQuantity {
+				msgSwap.StreamingQuantity = maxQuantity
+			}
+			if err := h.keeper.SetSwapQueueItem(ctx, msgSwap); err != nil {
 				return sdk.ErrInternal(err.Error()).Result()
 			}
 			return sdk.Result{
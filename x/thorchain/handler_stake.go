@@ -127,6 +127,7 @@ func (h StakeHandler) handle(ctx sdk.Context, msg MsgSetStakeData, version semve
 		msg.RuneAddress,
 		msg.AssetAddress,
 		msg.Tx.ID,
+		msg.Asymmetric,
 		constAccessor,
 	)
 	if err != nil {
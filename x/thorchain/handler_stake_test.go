@@ -62,6 +62,8 @@ func (m *MockStackKeeper) GetStaker(_ sdk.Context, asset common.Asset, addr comm
 		RuneAddress:  addr,
 		AssetAddress: addr,
 		Units:        sdk.ZeroUint(),
+		RuneDeposit:  sdk.ZeroUint(),
+		AssetDeposit: sdk.ZeroUint(),
 	}, nil
 }
 
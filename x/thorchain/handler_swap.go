@@ -77,7 +77,8 @@ func (h SwapHandler) handleV1(ctx sdk.Context, msg MsgSwap, version semver.Versi
 		msg.TargetAsset,
 		msg.Destination,
 		msg.TradeTarget,
-		sdk.NewUint(uint64(transactionFee)))
+		sdk.NewUint(uint64(transactionFee)),
+		constAccessor)
 	if swapErr != nil {
 		ctx.Logger().Error("fail to process swap message", "error", swapErr)
 		return swapErr.Result()
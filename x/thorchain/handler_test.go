@@ -181,6 +181,8 @@ func (HandlerSuite) TestHandleTxInUnstakeMemo(c *C) {
 		RuneAddress:  runeAddr,
 		AssetAddress: GetRandomBNBAddress(),
 		PendingRune:  sdk.ZeroUint(),
+		RuneDeposit:  sdk.ZeroUint(),
+		AssetDeposit: sdk.ZeroUint(),
 		Units:        sdk.NewUint(100),
 	}
 	w.keeper.SetStaker(w.ctx, staker)
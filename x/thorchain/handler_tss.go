@@ -145,7 +145,7 @@ func (h TssHandler) handleV1(ctx sdk.Context, msg MsgTssPool, version semver.Ver
 				}
 				if na.Status == NodeActive {
 					// 720 blocks per hour
-					if err := h.keeper.IncNodeAccountSlashPoints(ctx, na.NodeAddress, slashPoints); err != nil {
+					if err := incSlashPoints(ctx, h.keeper, constAccessor, na.NodeAddress, slashPoints, SlashReasonFailKeygen); err != nil {
 						ctx.Logger().Error("fail to inc slash points", "error", err)
 					}
 				} else {
@@ -159,6 +159,7 @@ func (h TssHandler) handleV1(ctx sdk.Context, msg MsgTssPool, version semver.Ver
 
 					slashBond := reserveVault.CalcNodeRewards(sdk.NewUint(uint64(slashPoints)))
 					na.Bond = common.SafeSub(na.Bond, slashBond)
+					recordSlash(ctx, h.keeper, constAccessor, na.NodeAddress, SlashReasonFailKeygen, 0, slashBond)
 					if common.RuneAsset().Chain.Equals(common.THORChain) {
 						coin := common.NewCoin(common.RuneNative, slashBond)
 						if err := h.keeper.SendFromModuleToModule(ctx, BondName, ReserveName, coin); err != nil {
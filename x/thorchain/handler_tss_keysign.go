@@ -108,7 +108,7 @@ func (h TssKeysignHandler) handleV1(ctx sdk.Context, msg MsgTssKeysignFail, vers
 				ctx.Logger().Error("fail to get node from it's pub key", "error", err, "pub key", nodePubKey.String())
 				return sdk.ErrInternal("fail to get node account").Result()
 			}
-			if err := h.keeper.IncNodeAccountSlashPoints(ctx, na.NodeAddress, slashPoints); err != nil {
+			if err := incSlashPoints(ctx, h.keeper, constAccessor, na.NodeAddress, slashPoints, SlashReasonFailKeysign); err != nil {
 				ctx.Logger().Error("fail to inc slash points", "error", err)
 			}
 		}
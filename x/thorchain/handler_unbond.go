@@ -0,0 +1,186 @@
+package thorchain
+
+import (
+	"fmt"
+
+	"github.com/blang/semver"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"gitlab.com/thorchain/thornode/common"
+	"gitlab.com/thorchain/thornode/constants"
+	"gitlab.com/thorchain/thornode/x/thorchain/keep"
+)
+
+// UnBondHandler a handler to process unbond request
+// a bond provider can take back part of its bond without the node leaving, as long as the node keeps enough bond
+type UnBondHandler struct {
+	keeper                keep.Keeper
+	versionedTxOutStore   VersionedTxOutStore
+	versionedEventManager VersionedEventManager
+}
+
+// NewUnBondHandler create new UnBondHandler
+func NewUnBondHandler(keeper keep.Keeper, versionedTxOutStore VersionedTxOutStore, versionedEventManager VersionedEventManager) UnBondHandler {
+	return UnBondHandler{
+		keeper:                keeper,
+		versionedTxOutStore:   versionedTxOutStore,
+		versionedEventManager: versionedEventManager,
+	}
+}
+
+// Run execute the handler
+func (h UnBondHandler) Run(ctx sdk.Context, m sdk.Msg, version semver.Version, constAccessor constants.ConstantValues) sdk.Result {
+	msg, ok := m.(MsgUnBond)
+	if !ok {
+		return errInvalidMessage.Result()
+	}
+	ctx.Logger().Info("receive MsgUnBond",
+		"node address", msg.NodeAddress,
+		"request hash", msg.TxIn.ID,
+		"amount", msg.Amount)
+	if err := h.validate(ctx, msg, version, constAccessor); err != nil {
+		ctx.Logger().Error("msg unbond fail validation", "error", err)
+		return err.Result()
+	}
+	if err := h.handle(ctx, msg, version); err != nil {
+		ctx.Logger().Error("fail to process msg unbond", "error", err)
+		return err.Result()
+	}
+	return sdk.Result{
+		Code:      sdk.CodeOK,
+		Codespace: DefaultCodespace,
+	}
+}
+
+func (h UnBondHandler) validate(ctx sdk.Context, msg MsgUnBond, version semver.Version, constAccessor constants.ConstantValues) sdk.Error {
+	if version.GTE(semver.MustParse("0.1.0")) {
+		return h.validateV1(ctx, msg, constAccessor)
+	}
+	return errBadVersion
+}
+
+func (h UnBondHandler) validateV1(ctx sdk.Context, msg MsgUnBond, constAccessor constants.ConstantValues) sdk.Error {
+	if err := msg.ValidateBasic(); err != nil {
+		return err
+	}
+	if !isSignedByActiveNodeAccounts(ctx, h.keeper, msg.GetSigners()) {
+		return sdk.ErrUnauthorized("msg is not signed by an active node account")
+	}
+
+	nodeAccount, err := h.keeper.GetNodeAccount(ctx, msg.NodeAddress)
+	if err != nil {
+		return sdk.ErrInternal(fmt.Sprintf("fail to get node account(%s): %s", msg.NodeAddress, err))
+	}
+	if nodeAccount.IsEmpty() {
+		return sdk.ErrUnknownRequest(fmt.Sprintf("node account(%s) doesn't exist", msg.NodeAddress))
+	}
+	bp, ok := nodeAccount.GetBondProviders().Get(msg.BondAddress)
+	if !ok {
+		return sdk.ErrUnauthorized(fmt.Sprintf("%s is not a bond provider of node account(%s)", msg.BondAddress, msg.NodeAddress))
+	}
+	if msg.Amount.GT(bp.Bond) {
+		return sdk.ErrUnknownRequest(fmt.Sprintf("unbond amount(%s) is more than the bond(%s) of %s", msg.Amount, bp.Bond, msg.BondAddress))
+	}
+
+	minBond, err := h.keeper.GetMimir(ctx, constants.MinimumBondInRune.String())
+	if minBond < 0 || err != nil {
+		minBond = constAccessor.GetInt64Value(constants.MinimumBondInRune)
+	}
+	// the bond that has to stay with the node
+	lockedBond := sdk.NewUint(uint64(minBond))
+
+	switch nodeAccount.Status {
+	case NodeWhiteListed, NodeStandby:
+	case NodeActive:
+		// an active node has to keep enough bond to cover what is in its yggdrasil vault, with the same 1.5 times
+		// margin the vault is slashed by when the node leaves with funds left in it
+		if h.keeper.VaultExists(ctx, nodeAccount.PubKeySet.Secp256k1) {
+			ygg, err := h.keeper.GetVault(ctx, nodeAccount.PubKeySet.Secp256k1)
+			if err != nil {
+				return sdk.ErrInternal(fmt.Sprintf("fail to get yggdrasil vault: %s", err))
+			}
+			yggRune, err := getTotalYggValueInRune(ctx, h.keeper, ygg)
+			if err != nil {
+				return sdk.ErrInternal(fmt.Sprintf("fail to get total ygg value in RUNE: %s", err))
+			}
+			if yggCover := yggRune.MulUint64(3).QuoUint64(2); yggCover.GT(lockedBond) {
+				lockedBond = yggCover
+			}
+		}
+	default:
+		return sdk.ErrUnknownRequest(fmt.Sprintf("node account(%s) is %s, can't unbond", msg.NodeAddress, nodeAccount.Status))
+	}
+
+	if nodeAccount.Bond.LT(lockedBond.Add(msg.Amount)) {
+		return sdk.ErrUnknownRequest(fmt.Sprintf("node account(%s) has to keep a bond of %s, can't unbond %s from bond(%s)", msg.NodeAddress, lockedBond, msg.Amount, nodeAccount.Bond))
+	}
+	return nil
+}
+
+func (h UnBondHandler) handle(ctx sdk.Context, msg MsgUnBond, version semver.Version) sdk.Error {
+	if version.GTE(semver.MustParse("0.1.0")) {
+		return h.handleV1(ctx, msg, version)
+	}
+	return errBadVersion
+}
+
+func (h UnBondHandler) handleV1(ctx sdk.Context, msg MsgUnBond, version semver.Version) sdk.Error {
+	nodeAccount, err := h.keeper.GetNodeAccount(ctx, msg.NodeAddress)
+	if err != nil {
+		return sdk.ErrInternal(fmt.Sprintf("fail to get node account(%s): %s", msg.NodeAddress, err))
+	}
+	txOutStore, err := h.versionedTxOutStore.GetTxOutStore(ctx, h.keeper, version)
+	if err != nil {
+		ctx.Logger().Error("fail to get txout store", "error", err)
+		return errBadVersion
+	}
+	eventMgr, err := h.versionedEventManager.GetEventManager(ctx, version)
+	if err != nil {
+		ctx.Logger().Error("fail to get event manager", "error", err)
+		return errFailGetEventManager
+	}
+
+	active, err := h.keeper.GetAsgardVaultsByStatus(ctx, ActiveVault)
+	if err != nil {
+		return sdk.ErrInternal(fmt.Errorf("fail to get active vaults: %w", err).Error())
+	}
+	vault := active.SelectByMinCoin(common.RuneAsset())
+	if vault.IsEmpty() {
+		return sdk.ErrInternal("unable to determine asgard vault to send funds")
+	}
+
+	refundAddress := msg.BondAddress
+	if common.RuneAsset().Chain.Equals(common.THORChain) && msg.BondAddress.Equals(nodeAccount.BondAddress) {
+		refundAddress = common.Address(nodeAccount.NodeAddress.String())
+	}
+	txOutItem := &TxOutItem{
+		Chain:       common.RuneAsset().Chain,
+		ToAddress:   refundAddress,
+		VaultPubKey: vault.PubKey,
+		InHash:      msg.TxIn.ID,
+		Coin:        common.NewCoin(common.RuneAsset(), msg.Amount),
+	}
+	ok, err := txOutStore.TryAddTxOutItem(ctx, txOutItem)
+	if err != nil {
+		return sdk.ErrInternal(fmt.Errorf("fail to add outbound tx: %w", err).Error())
+	}
+	if !ok {
+		return sdk.ErrUnknownRequest("unbond amount is not enough to pay for the outbound transaction")
+	}
+
+	// whatever RUNE came with the unbond request is added to the bond, the same way leave does
+	coin := msg.TxIn.Coins.GetCoin(common.RuneAsset())
+	if !coin.IsEmpty() {
+		nodeAccount.AddProviderBond(msg.BondAddress, coin.Amount)
+	}
+	nodeAccount.SubProviderBond(msg.BondAddress, msg.Amount)
+	if err := h.keeper.SetNodeAccount(ctx, nodeAccount); err != nil {
+		return sdk.ErrInternal(fmt.Errorf("fail to save node account(%s): %w", nodeAccount.NodeAddress, err).Error())
+	}
+
+	bondEvent := NewEventBond(msg.Amount, BondReturned, msg.TxIn)
+	if err := eventMgr.EmitBondEvent(ctx, h.keeper, bondEvent); err != nil {
+		return sdk.NewError(DefaultCodespace, CodeFailSaveEvent, "fail to emit bond event")
+	}
+	return nil
+}
@@ -0,0 +1,84 @@
+package thorchain
+
+import (
+	"github.com/blang/semver"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	. "gopkg.in/check.v1"
+
+	"gitlab.com/thorchain/thornode/common"
+	"gitlab.com/thorchain/thornode/constants"
+)
+
+type HandlerUnBondSuite struct{}
+
+var _ = Suite(&HandlerUnBondSuite{})
+
+func (HandlerUnBondSuite) TestUnBondHandler(c *C) {
+	w := getHandlerTestWrapper(c, 1, true, true)
+	ver := constants.SWVersion
+	constAccessor := constants.GetConstantValues(ver)
+	handler := NewUnBondHandler(w.keeper, w.versionedTxOutStore, NewVersionedEventMgr())
+	w.keeper.SetMimir(w.ctx, constants.MinimumBondInRune.String(), 100*common.One)
+	vault := GetRandomVault()
+	vault.Coins = common.Coins{
+		common.NewCoin(common.RuneAsset(), sdk.NewUint(10000*common.One)),
+	}
+	c.Assert(w.keeper.SetVault(w.ctx, vault), IsNil)
+
+	standby := GetRandomNodeAccount(NodeStandby)
+	standby.Bond = sdk.NewUint(1000 * common.One)
+	c.Assert(w.keeper.SetNodeAccount(w.ctx, standby), IsNil)
+
+	newUnBond := func(na NodeAccount, from common.Address, amount uint64) MsgUnBond {
+		tx := common.NewTx(GetRandomTxHash(), from, GetRandomBNBAddress(), nil, BNBGasFeeSingleton, "")
+		tx.Memo = NewUnbondMemo(na.NodeAddress, sdk.NewUint(amount)).String()
+		return NewMsgUnBond(tx, na.NodeAddress, sdk.NewUint(amount), from, w.activeNodeAccount.NodeAddress)
+	}
+
+	// bad version
+	result := handler.Run(w.ctx, newUnBond(standby, standby.BondAddress, 500*common.One), semver.Version{}, constAccessor)
+	c.Check(result.Code, Equals, CodeBadVersion)
+	// not a bond provider
+	result = handler.Run(w.ctx, newUnBond(standby, GetRandomBNBAddress(), 500*common.One), ver, constAccessor)
+	c.Check(result.Code, Equals, sdk.CodeUnauthorized)
+	// has to keep the minimum bond
+	result = handler.Run(w.ctx, newUnBond(standby, standby.BondAddress, 950*common.One), ver, constAccessor)
+	c.Check(result.Code, Equals, sdk.CodeUnknownRequest)
+
+	result = handler.Run(w.ctx, newUnBond(standby, standby.BondAddress, 500*common.One), ver, constAccessor)
+	c.Assert(result.Code, Equals, sdk.CodeOK, Commentf("%+v", result))
+	na, err := w.keeper.GetNodeAccount(w.ctx, standby.NodeAddress)
+	c.Assert(err, IsNil)
+	c.Check(na.Bond.Equal(sdk.NewUint(500*common.One)), Equals, true)
+	txOutStore, err := w.versionedTxOutStore.GetTxOutStore(w.ctx, w.keeper, ver)
+	c.Assert(err, IsNil)
+	items, err := txOutStore.GetOutboundItems(w.ctx)
+	c.Assert(err, IsNil)
+	c.Assert(items, HasLen, 1)
+	c.Check(items[0].ToAddress.Equals(standby.BondAddress), Equals, true)
+	c.Check(items[0].Coin.Asset.Equals(common.RuneAsset()), Equals, true)
+
+	// an active node has to keep enough bond to cover its yggdrasil vault
+	active := GetRandomNodeAccount(NodeActive)
+	active.Bond = sdk.NewUint(1000 * common.One)
+	c.Assert(w.keeper.SetNodeAccount(w.ctx, active), IsNil)
+	ygg := NewVault(w.ctx.BlockHeight(), ActiveVault, YggdrasilVault, active.PubKeySet.Secp256k1, common.Chains{common.BNBChain})
+	ygg.Coins = common.Coins{
+		common.NewCoin(common.RuneAsset(), sdk.NewUint(400*common.One)),
+	}
+	c.Assert(w.keeper.SetVault(w.ctx, ygg), IsNil)
+	result = handler.Run(w.ctx, newUnBond(active, active.BondAddress, 500*common.One), ver, constAccessor)
+	c.Check(result.Code, Equals, sdk.CodeUnknownRequest)
+	result = handler.Run(w.ctx, newUnBond(active, active.BondAddress, 400*common.One), ver, constAccessor)
+	c.Assert(result.Code, Equals, sdk.CodeOK, Commentf("%+v", result))
+	na, err = w.keeper.GetNodeAccount(w.ctx, active.NodeAddress)
+	c.Assert(err, IsNil)
+	c.Check(na.Bond.Equal(sdk.NewUint(600*common.One)), Equals, true)
+
+	// a disabled node can't unbond
+	disabled := GetRandomNodeAccount(NodeDisabled)
+	disabled.Bond = sdk.NewUint(1000 * common.One)
+	c.Assert(w.keeper.SetNodeAccount(w.ctx, disabled), IsNil)
+	result = handler.Run(w.ctx, newUnBond(disabled, disabled.BondAddress, 500*common.One), ver, constAccessor)
+	c.Check(result.Code, Equals, sdk.CodeUnknownRequest)
+}
@@ -99,7 +99,7 @@ func (h UnstakeHandler) handle(ctx sdk.Context, msg MsgSetUnStake, version semve
 		ctx.Logger().Error("fail to get event manager", "error", err)
 		return nil, errFailGetEventManager
 	}
-	runeAmt, assetAmount, units, gasAsset, err := unstake(ctx, version, h.keeper, msg, eventManager)
+	runeAmt, assetAmount, units, gasAsset, impLossProtection, err := unstake(ctx, version, h.keeper, msg, eventManager)
 	if err != nil {
 		return nil, sdk.ErrInternal(fmt.Errorf("fail to process UnStake request: %w", err).Error())
 	}
@@ -119,6 +119,7 @@ func (h UnstakeHandler) handle(ctx sdk.Context, msg MsgSetUnStake, version semve
 		units,
 		int64(msg.UnstakeBasisPoints.Uint64()),
 		sdk.ZeroDec(), // TODO: What is Asymmetry, how to calculate it?
+		impLossProtection,
 		msg.Tx,
 	)
 	if err := eventManager.EmitUnstakeEvent(ctx, h.keeper, unstakeEvt); err != nil {
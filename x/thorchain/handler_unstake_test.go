@@ -100,8 +100,10 @@ func (HandlerUnstakeSuite) TestUnstakeHandler(c *C) {
 			Status:       PoolEnabled,
 		},
 		staker: Staker{
-			Units:       sdk.ZeroUint(),
-			PendingRune: sdk.ZeroUint(),
+			Units:        sdk.ZeroUint(),
+			PendingRune:  sdk.ZeroUint(),
+			RuneDeposit:  sdk.ZeroUint(),
+			AssetDeposit: sdk.ZeroUint(),
 		},
 	}
 	ver := constants.SWVersion
@@ -116,6 +118,7 @@ func (HandlerUnstakeSuite) TestUnstakeHandler(c *C) {
 		runeAddr,
 		GetRandomBNBAddress(),
 		GetRandomTxHash(),
+		false,
 		constAccessor)
 	c.Assert(err, IsNil)
 	c.Logf("stake unit: %d", unit)
@@ -187,8 +190,10 @@ func (HandlerUnstakeSuite) TestUnstakeHandler_mockFailScenarios(c *C) {
 		Status:       PoolEnabled,
 	}
 	staker := Staker{
-		Units:       sdk.ZeroUint(),
-		PendingRune: sdk.ZeroUint(),
+		Units:        sdk.ZeroUint(),
+		PendingRune:  sdk.ZeroUint(),
+		RuneDeposit:  sdk.ZeroUint(),
+		AssetDeposit: sdk.ZeroUint(),
 	}
 	testCases := []struct {
 		name           string
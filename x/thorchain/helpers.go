@@ -211,22 +211,28 @@ func refundBond(ctx sdk.Context, tx common.Tx, nodeAcc NodeAccount, keeper keep.
 			return fmt.Errorf("fail to emit bond event: %w", err)
 		}
 
-		refundAddress := nodeAcc.BondAddress
-		if common.RuneAsset().Chain.Equals(common.THORChain) {
-			refundAddress = common.Address(nodeAcc.NodeAddress.String())
-		}
+		// refund each bond provider its share of what is left of the bond
+		for _, bp := range nodeAcc.GetBondProviders() {
+			if bp.Bond.IsZero() {
+				continue
+			}
+			refundAddress := bp.BondAddress
+			if common.RuneAsset().Chain.Equals(common.THORChain) && bp.BondAddress.Equals(nodeAcc.BondAddress) {
+				refundAddress = common.Address(nodeAcc.NodeAddress.String())
+			}
 
-		// refund bond
-		txOutItem := &TxOutItem{
-			Chain:       common.RuneAsset().Chain,
-			ToAddress:   refundAddress,
-			VaultPubKey: vault.PubKey,
-			InHash:      tx.ID,
-			Coin:        common.NewCoin(common.RuneAsset(), nodeAcc.Bond),
-		}
-		_, err = txOut.TryAddTxOutItem(ctx, txOutItem)
-		if err != nil {
-			return fmt.Errorf("fail to add outbound tx: %w", err)
+			// refund bond
+			txOutItem := &TxOutItem{
+				Chain:       common.RuneAsset().Chain,
+				ToAddress:   refundAddress,
+				VaultPubKey: vault.PubKey,
+				InHash:      tx.ID,
+				Coin:        common.NewCoin(common.RuneAsset(), bp.Bond),
+			}
+			_, err = txOut.TryAddTxOutItem(ctx, txOutItem)
+			if err != nil {
+				return fmt.Errorf("fail to add outbound tx: %w", err)
+			}
 		}
 	} else {
 		// if it get into here that means the node account doesn't have any bond left after slash.
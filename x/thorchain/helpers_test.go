@@ -244,6 +244,36 @@ func (s *HelperSuite) TestRefundBondHappyPath(c *C) {
 	c.Assert(p.BalanceAsset.Equal(expectedPoolBNB), Equals, true, Commentf("expected BNB in pool %s , however we got %s", expectedPoolBNB, p.BalanceAsset))
 }
 
+func (s *HelperSuite) TestRefundBondProviders(c *C) {
+	ctx, _ := setupKeeperForTest(c)
+	na := GetRandomNodeAccount(NodeStandby)
+	na.Bond = sdk.NewUint(100 * common.One)
+	provider := GetRandomBNBAddress()
+	na.AddProviderBond(provider, sdk.NewUint(300*common.One))
+	txOut := NewTxStoreDummy()
+	eventMgr := NewEventMgr()
+	pk := GetRandomPubKey()
+	na.PubKeySet.Secp256k1 = pk
+	keeper := &TestRefundBondKeeper{
+		pool: Pool{
+			Asset:        common.BNBAsset,
+			BalanceRune:  sdk.NewUint(23789 * common.One),
+			BalanceAsset: sdk.NewUint(167 * common.One),
+		},
+		ygg:    NewVault(ctx.BlockHeight(), ActiveVault, YggdrasilVault, pk, common.Chains{common.BNBChain}),
+		vaults: Vaults{GetRandomVault()},
+	}
+	c.Assert(refundBond(ctx, GetRandomTx(), na, keeper, txOut, eventMgr), IsNil)
+	items, err := txOut.GetOutboundItems(ctx)
+	c.Assert(err, IsNil)
+	c.Assert(items, HasLen, 2)
+	c.Check(items[0].ToAddress.Equals(na.BondAddress), Equals, true)
+	c.Check(items[0].Coin.Amount.Equal(sdk.NewUint(100*common.One)), Equals, true)
+	c.Check(items[1].ToAddress.Equals(provider), Equals, true)
+	c.Check(items[1].Coin.Amount.Equal(sdk.NewUint(300*common.One)), Equals, true)
+	c.Check(keeper.na.Bond.IsZero(), Equals, true)
+}
+
 func (s *HelperSuite) TestEnableNextPool(c *C) {
 	var err error
 	ctx, k := setupKeeperForTest(c)
@@ -0,0 +1,85 @@
+package thorchain
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"gitlab.com/thorchain/thornode/common"
+	"gitlab.com/thorchain/thornode/constants"
+	"gitlab.com/thorchain/thornode/x/thorchain/keep"
+)
+
+// getFullImpLossProtectionBlocks return the number of blocks after the last stake before a staker is fully protected against
+// impermanent loss, mimir takes precedence over the constant, zero means there is no protection
+func getFullImpLossProtectionBlocks(ctx sdk.Context, keeper keep.Keeper, constAccessor constants.ConstantValues) int64 {
+	blocks := constAccessor.GetInt64Value(constants.FullImpLossProtectionBlocks)
+	if mimirBlocks, err := keeper.GetMimir(ctx, constants.FullImpLossProtectionBlocks.String()); err == nil && mimirBlocks >= 0 {
+		blocks = mimirBlocks
+	}
+	return blocks
+}
+
+// calcImpLossProtection return the RUNE a staker who withdraws the given units is owed for the shortfall of what they get back
+// against the value of what they deposited, both valued at the time weighted average price of the pool before the withdraw.
+// The protection vests linearly over the blocks since the staker's last stake
+func calcImpLossProtection(ctx sdk.Context, keeper keep.Keeper, constAccessor constants.ConstantValues, pool Pool, staker Staker, units, withdrawRune, withdrawAsset sdk.Uint) (sdk.Uint, error) {
+	fullBlocks := getFullImpLossProtectionBlocks(ctx, keeper, constAccessor)
+	if fullBlocks <= 0 {
+		return sdk.ZeroUint(), nil
+	}
+	stakeAge := ctx.BlockHeight() - staker.LastStakeHeight
+	if stakeAge <= 0 {
+		return sdk.ZeroUint(), nil
+	}
+	twapPool, err := getTWAPPool(ctx, keeper, pool, getTWAPWindow(ctx, keeper, constAccessor))
+	if err != nil {
+		return sdk.ZeroUint(), fmt.Errorf("fail to price pool(%s): %w", pool.Asset, err)
+	}
+	depositValue := staker.GetDepositValue(twapPool, units)
+	withdrawValue := withdrawRune.Add(twapPool.AssetValueInRune(withdrawAsset))
+	if depositValue.LTE(withdrawValue) {
+		return sdk.ZeroUint(), nil
+	}
+	shortfall := depositValue.Sub(withdrawValue)
+	if stakeAge < fullBlocks {
+		shortfall = common.GetShare(sdk.NewUint(uint64(stakeAge)), sdk.NewUint(uint64(fullBlocks)), shortfall)
+	}
+	return shortfall, nil
+}
+
+// payImpLossProtection take the given amount of RUNE out of the reserve, so it is paid out along with the RUNE the staker
+// withdraws from the pool, it returns the amount actually taken, which is capped at what the reserve holds
+func payImpLossProtection(ctx sdk.Context, keeper keep.Keeper, amount sdk.Uint) (sdk.Uint, error) {
+	if amount.IsZero() {
+		return sdk.ZeroUint(), nil
+	}
+	if common.RuneAsset().Chain.Equals(common.THORChain) {
+		if reserve := keeper.GetRuneBalaceOfModule(ctx, ReserveName); amount.GT(reserve) {
+			amount = reserve
+		}
+		if amount.IsZero() {
+			return sdk.ZeroUint(), nil
+		}
+		coin := common.NewCoin(common.RuneNative, amount)
+		if err := keeper.SendFromModuleToModule(ctx, ReserveName, AsgardName, coin); err != nil {
+			return sdk.ZeroUint(), fmt.Errorf("fail to transfer funds from reserve to asgard: %w", err)
+		}
+		return amount, nil
+	}
+	vaultData, err := keeper.GetVaultData(ctx)
+	if err != nil {
+		return sdk.ZeroUint(), fmt.Errorf("fail to get vault data: %w", err)
+	}
+	if amount.GT(vaultData.TotalReserve) {
+		amount = vaultData.TotalReserve
+	}
+	if amount.IsZero() {
+		return sdk.ZeroUint(), nil
+	}
+	vaultData.TotalReserve = common.SafeSub(vaultData.TotalReserve, amount)
+	if err := keeper.SetVaultData(ctx, vaultData); err != nil {
+		return sdk.ZeroUint(), fmt.Errorf("fail to save vault data: %w", err)
+	}
+	return amount, nil
+}
@@ -0,0 +1,85 @@
+package thorchain
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	. "gopkg.in/check.v1"
+
+	"gitlab.com/thorchain/thornode/common"
+	"gitlab.com/thorchain/thornode/constants"
+)
+
+type ImpLossProtectionSuite struct{}
+
+var _ = Suite(&ImpLossProtectionSuite{})
+
+func (s *ImpLossProtectionSuite) TestCalcImpLossProtection(c *C) {
+	ctx, k := setupKeeperForTest(c)
+	constAccessor := constants.GetConstantValues(constants.SWVersion)
+	k.SetMimir(ctx, constants.FullImpLossProtectionBlocks.String(), 100)
+	ctx = ctx.WithBlockHeight(51)
+	// the asset was 1 RUNE when the staker deposited, now it is 4
+	pool := NewPool()
+	pool.Asset = common.BNBAsset
+	pool.BalanceRune = sdk.NewUint(200 * common.One)
+	pool.BalanceAsset = sdk.NewUint(50 * common.One)
+	pool.PoolUnits = sdk.NewUint(100)
+	pool.Status = PoolEnabled
+	c.Assert(k.SetPool(ctx, pool), IsNil)
+	pool, err := k.GetPool(ctx, common.BNBAsset)
+	c.Assert(err, IsNil)
+	staker := Staker{
+		Asset:           common.BNBAsset,
+		RuneAddress:     GetRandomRUNEAddress(),
+		LastStakeHeight: 1,
+		Units:           sdk.NewUint(100),
+		PendingRune:     sdk.ZeroUint(),
+		RuneDeposit:     sdk.NewUint(100 * common.One),
+		AssetDeposit:    sdk.NewUint(100 * common.One),
+	}
+
+	// the deposit is worth 500 RUNE, the pool share 400 RUNE, half of the shortfall vested
+	protection, err := calcImpLossProtection(ctx, k, constAccessor, pool, staker, sdk.NewUint(100), pool.BalanceRune, pool.BalanceAsset)
+	c.Assert(err, IsNil)
+	c.Check(protection.Equal(sdk.NewUint(50*common.One)), Equals, true, Commentf("%d", protection.Uint64()))
+	protection, err = calcImpLossProtection(ctx, k, constAccessor, pool, staker, sdk.NewUint(50), sdk.NewUint(100*common.One), sdk.NewUint(25*common.One))
+	c.Assert(err, IsNil)
+	c.Check(protection.Equal(sdk.NewUint(25*common.One)), Equals, true, Commentf("%d", protection.Uint64()))
+
+	// fully vested
+	ctx = ctx.WithBlockHeight(500)
+	protection, err = calcImpLossProtection(ctx, k, constAccessor, pool, staker, sdk.NewUint(100), pool.BalanceRune, pool.BalanceAsset)
+	c.Assert(err, IsNil)
+	c.Check(protection.Equal(sdk.NewUint(100*common.One)), Equals, true, Commentf("%d", protection.Uint64()))
+
+	// no loss
+	staker.AssetDeposit = sdk.NewUint(25 * common.One)
+	protection, err = calcImpLossProtection(ctx, k, constAccessor, pool, staker, sdk.NewUint(100), pool.BalanceRune, pool.BalanceAsset)
+	c.Assert(err, IsNil)
+	c.Check(protection.IsZero(), Equals, true)
+
+	// protection turned off
+	staker.AssetDeposit = sdk.NewUint(100 * common.One)
+	k.SetMimir(ctx, constants.FullImpLossProtectionBlocks.String(), 0)
+	protection, err = calcImpLossProtection(ctx, k, constAccessor, pool, staker, sdk.NewUint(100), pool.BalanceRune, pool.BalanceAsset)
+	c.Assert(err, IsNil)
+	c.Check(protection.IsZero(), Equals, true)
+}
+
+func (s *ImpLossProtectionSuite) TestPayImpLossProtection(c *C) {
+	ctx, k := setupKeeperForTest(c)
+	vaultData := NewVaultData()
+	vaultData.TotalReserve = sdk.NewUint(30 * common.One)
+	c.Assert(k.SetVaultData(ctx, vaultData), IsNil)
+
+	paid, err := payImpLossProtection(ctx, k, sdk.NewUint(10*common.One))
+	c.Assert(err, IsNil)
+	c.Check(paid.Equal(sdk.NewUint(10*common.One)), Equals, true)
+
+	// capped at what is left in the reserve
+	paid, err = payImpLossProtection(ctx, k, sdk.NewUint(100*common.One))
+	c.Assert(err, IsNil)
+	c.Check(paid.Equal(sdk.NewUint(20*common.One)), Equals, true)
+	vaultData, err = k.GetVaultData(ctx)
+	c.Assert(err, IsNil)
+	c.Check(vaultData.TotalReserve.IsZero(), Equals, true)
+}
@@ -0,0 +1,242 @@
+package thorchain
+
+import (
+	"fmt"
+	"strings"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"gitlab.com/thorchain/thornode/common"
+	"gitlab.com/thorchain/thornode/x/thorchain/keep"
+)
+
+// HaltOnInvariantBroken is the mimir key, when set to a value greater than zero, invariants are checked at the end of every block
+// and trading is halted when any of them is broken
+const HaltOnInvariantBroken = "HaltOnInvariantBroken"
+
+// invariant routes
+const (
+	RuneSolvencyInvariantRoute  = "rune-solvency"
+	StakerUnitsInvariantRoute   = "staker-units"
+	VaultSolvencyInvariantRoute = "vault-solvency"
+)
+
+// InvariantResult the result of checking one invariant
+type InvariantResult struct {
+	Route  string `json:"route"`
+	Broken bool   `json:"broken"`
+	Msg    string `json:"msg"`
+}
+
+type invariantRoute struct {
+	route     string
+	invariant func(keeper keep.Keeper) sdk.Invariant
+}
+
+var invariantRoutes = []invariantRoute{
+	{route: RuneSolvencyInvariantRoute, invariant: RuneSolvencyInvariant},
+	{route: StakerUnitsInvariantRoute, invariant: StakerUnitsInvariant},
+	{route: VaultSolvencyInvariantRoute, invariant: VaultSolvencyInvariant},
+}
+
+// RegisterInvariants register all thorchain invariants
+func RegisterInvariants(ir sdk.InvariantRegistry, keeper keep.Keeper) {
+	for _, item := range invariantRoutes {
+		ir.RegisterRoute(ModuleName, item.route, item.invariant(keeper))
+	}
+}
+
+// CheckInvariants run all thorchain invariants, and return the result of each of them
+func CheckInvariants(ctx sdk.Context, keeper keep.Keeper) []InvariantResult {
+	results := make([]InvariantResult, 0, len(invariantRoutes))
+	for _, item := range invariantRoutes {
+		msg, broken := item.invariant(keeper)(ctx)
+		results = append(results, InvariantResult{
+			Route:  item.route,
+			Broken: broken,
+			Msg:    msg,
+		})
+	}
+	return results
+}
+
+// RuneSolvencyInvariant check the RUNE thorchain owes, which is the RUNE in pools, pending stakes, reserve and bonds, equals the RUNE it holds
+// when RUNE is native, it is held by the asgard, reserve and bond module, otherwise it is held by the vaults, and vaults could hold more as outbound txs
+// are in flight
+func RuneSolvencyInvariant(keeper keep.Keeper) sdk.Invariant {
+	return func(ctx sdk.Context) (string, bool) {
+		owed, err := getRuneOwed(ctx, keeper)
+		if err != nil {
+			return sdk.FormatInvariant(ModuleName, RuneSolvencyInvariantRoute, err.Error()), true
+		}
+		if common.RuneAsset().Chain.Equals(common.THORChain) {
+			held := keeper.GetRuneBalaceOfModule(ctx, AsgardName).
+				Add(keeper.GetRuneBalaceOfModule(ctx, ReserveName)).
+				Add(keeper.GetRuneBalaceOfModule(ctx, BondName))
+			broken := !held.Equal(owed)
+			return sdk.FormatInvariant(ModuleName, RuneSolvencyInvariantRoute,
+				fmt.Sprintf("\tRUNE owed: %s\n\tRUNE held by modules: %s\n", owed, held)), broken
+		}
+		held, err := getVaultsBalance(ctx, keeper, common.RuneAsset())
+		if err != nil {
+			return sdk.FormatInvariant(ModuleName, RuneSolvencyInvariantRoute, err.Error()), true
+		}
+		broken := held.LT(owed)
+		return sdk.FormatInvariant(ModuleName, RuneSolvencyInvariantRoute,
+			fmt.Sprintf("\tRUNE owed: %s\n\tRUNE held by vaults: %s\n", owed, held)), broken
+	}
+}
+
+// StakerUnitsInvariant check the units of all stakers of a pool add up to the pool units
+func StakerUnitsInvariant(keeper keep.Keeper) sdk.Invariant {
+	return func(ctx sdk.Context) (string, bool) {
+		pools, err := keeper.GetPools(ctx)
+		if err != nil {
+			return sdk.FormatInvariant(ModuleName, StakerUnitsInvariantRoute, fmt.Sprintf("fail to get pools: %s", err)), true
+		}
+		var msg strings.Builder
+		broken := false
+		for _, pool := range pools {
+			units, err := getTotalStakerUnits(ctx, keeper, pool.Asset)
+			if err != nil {
+				return sdk.FormatInvariant(ModuleName, StakerUnitsInvariantRoute, err.Error()), true
+			}
+			if !units.Equal(pool.PoolUnits) {
+				broken = true
+				msg.WriteString(fmt.Sprintf("\tpool(%s) units: %s, staker units: %s\n", pool.Asset, pool.PoolUnits, units))
+			}
+		}
+		return sdk.FormatInvariant(ModuleName, StakerUnitsInvariantRoute, msg.String()), broken
+	}
+}
+
+// VaultSolvencyInvariant check the asset held by asgard and yggdrasil vaults cover the asset balance of each pool
+func VaultSolvencyInvariant(keeper keep.Keeper) sdk.Invariant {
+	return func(ctx sdk.Context) (string, bool) {
+		pools, err := keeper.GetPools(ctx)
+		if err != nil {
+			return sdk.FormatInvariant(ModuleName, VaultSolvencyInvariantRoute, fmt.Sprintf("fail to get pools: %s", err)), true
+		}
+		var msg strings.Builder
+		broken := false
+		for _, pool := range pools {
+			held, err := getVaultsBalance(ctx, keeper, pool.Asset)
+			if err != nil {
+				return sdk.FormatInvariant(ModuleName, VaultSolvencyInvariantRoute, err.Error()), true
+			}
+			if held.LT(pool.BalanceAsset) {
+				broken = true
+				msg.WriteString(fmt.Sprintf("\tpool(%s) asset: %s, held by vaults: %s\n", pool.Asset, pool.BalanceAsset, held))
+			}
+		}
+		return sdk.FormatInvariant(ModuleName, VaultSolvencyInvariantRoute, msg.String()), broken
+	}
+}
+
+// getRuneOwed return the total RUNE in pools, pending stakes, reserve and bonds
+func getRuneOwed(ctx sdk.Context, keeper keep.Keeper) (sdk.Uint, error) {
+	total := sdk.ZeroUint()
+	pools, err := keeper.GetPools(ctx)
+	if err != nil {
+		return total, fmt.Errorf("fail to get pools: %w", err)
+	}
+	for _, pool := range pools {
+		total = total.Add(pool.BalanceRune)
+		pending, err := getTotalPendingRune(ctx, keeper, pool.Asset)
+		if err != nil {
+			return total, err
+		}
+		total = total.Add(pending)
+	}
+	vaultData, err := keeper.GetVaultData(ctx)
+	if err != nil {
+		return total, fmt.Errorf("fail to get vault data: %w", err)
+	}
+	// bond rewards are paid into the bond module, but they are only added to node bond when the node leave
+	total = total.Add(vaultData.BondRewardRune)
+	if common.RuneAsset().Chain.Equals(common.THORChain) {
+		total = total.Add(keeper.GetRuneBalaceOfModule(ctx, ReserveName))
+	} else {
+		total = total.Add(vaultData.TotalReserve)
+	}
+	iter := keeper.GetNodeAccountIterator(ctx)
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		var na NodeAccount
+		if err := keeper.Cdc().UnmarshalBinaryBare(iter.Value(), &na); err != nil {
+			return total, fmt.Errorf("fail to unmarshal node account: %w", err)
+		}
+		total = total.Add(na.Bond)
+	}
+	return total, nil
+}
+
+func getTotalStakerUnits(ctx sdk.Context, keeper keep.Keeper, asset common.Asset) (sdk.Uint, error) {
+	total := sdk.ZeroUint()
+	err := iterateStakers(ctx, keeper, asset, func(staker Staker) {
+		total = total.Add(staker.Units)
+	})
+	return total, err
+}
+
+func getTotalPendingRune(ctx sdk.Context, keeper keep.Keeper, asset common.Asset) (sdk.Uint, error) {
+	total := sdk.ZeroUint()
+	err := iterateStakers(ctx, keeper, asset, func(staker Staker) {
+		if !staker.PendingRune.IsZero() {
+			total = total.Add(staker.PendingRune)
+		}
+	})
+	return total, err
+}
+
+func iterateStakers(ctx sdk.Context, keeper keep.Keeper, asset common.Asset, fn func(staker Staker)) error {
+	iter := keeper.GetStakerIterator(ctx, asset)
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		var staker Staker
+		if err := keeper.Cdc().UnmarshalBinaryBare(iter.Value(), &staker); err != nil {
+			return fmt.Errorf("fail to unmarshal staker: %w", err)
+		}
+		fn(staker)
+	}
+	return nil
+}
+
+// getVaultsBalance return the total amount of the given asset held by all vaults
+func getVaultsBalance(ctx sdk.Context, keeper keep.Keeper, asset common.Asset) (sdk.Uint, error) {
+	total := sdk.ZeroUint()
+	iter := keeper.GetVaultIterator(ctx)
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		var vault Vault
+		if err := keeper.Cdc().UnmarshalBinaryBare(iter.Value(), &vault); err != nil {
+			return total, fmt.Errorf("fail to unmarshal vault: %w", err)
+		}
+		total = total.Add(vault.GetCoin(asset).Amount)
+	}
+	return total, nil
+}
+
+// checkInvariantsAndHalt halt trading through the HaltTrading mimir when any invariant is broken and the HaltOnInvariantBroken
+// mimir switch is on
+func checkInvariantsAndHalt(ctx sdk.Context, keeper keep.Keeper) {
+	haltOnBroken, err := keeper.GetMimir(ctx, HaltOnInvariantBroken)
+	if err != nil {
+		ctx.Logger().Error("fail to get mimir", "key", HaltOnInvariantBroken, "error", err)
+		return
+	}
+	if haltOnBroken <= 0 {
+		return
+	}
+	// no need to check again once trading is halted
+	if haltTrading, err := keeper.GetMimir(ctx, "HaltTrading"); err == nil && haltTrading > 0 {
+		return
+	}
+	for _, result := range CheckInvariants(ctx, keeper) {
+		if result.Broken {
+			ctx.Logger().Error("invariant broken, halt trading", "route", result.Route, "msg", result.Msg)
+			keeper.SetMimir(ctx, "HaltTrading", ctx.BlockHeight())
+			return
+		}
+	}
+}
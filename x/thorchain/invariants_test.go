@@ -0,0 +1,144 @@
+package thorchain
+
+import (
+	"encoding/json"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	abci "github.com/tendermint/tendermint/abci/types"
+	. "gopkg.in/check.v1"
+
+	"gitlab.com/thorchain/thornode/common"
+	"gitlab.com/thorchain/thornode/x/thorchain/keep"
+)
+
+type InvariantsSuite struct{}
+
+var _ = Suite(&InvariantsSuite{})
+
+type TestInvariantRegistry struct {
+	routes []string
+}
+
+func (r *TestInvariantRegistry) RegisterRoute(moduleName, route string, _ sdk.Invariant) {
+	r.routes = append(r.routes, moduleName+"/"+route)
+}
+
+func (s *InvariantsSuite) setupSolventState(c *C, ctx sdk.Context, k keep.Keeper) (Pool, Staker) {
+	pool := NewPool()
+	pool.Asset = common.BNBAsset
+	pool.BalanceRune = sdk.NewUint(100 * common.One)
+	pool.BalanceAsset = sdk.NewUint(50 * common.One)
+	pool.PoolUnits = sdk.NewUint(100)
+	pool.Status = PoolEnabled
+	c.Assert(k.SetPool(ctx, pool), IsNil)
+
+	staker := Staker{
+		Asset:           common.BNBAsset,
+		RuneAddress:     GetRandomRUNEAddress(),
+		LastStakeHeight: 1,
+		Units:           sdk.NewUint(100),
+		PendingRune:     sdk.NewUint(10 * common.One),
+	}
+	k.SetStaker(ctx, staker)
+
+	na := GetRandomNodeAccount(NodeActive)
+	na.Bond = sdk.NewUint(20 * common.One)
+	c.Assert(k.SetNodeAccount(ctx, na), IsNil)
+
+	vaultData := NewVaultData()
+	vaultData.TotalReserve = sdk.NewUint(30 * common.One)
+	vaultData.BondRewardRune = sdk.NewUint(5 * common.One)
+	c.Assert(k.SetVaultData(ctx, vaultData), IsNil)
+
+	asgard := GetRandomVault()
+	asgard.Coins = common.Coins{
+		common.NewCoin(common.RuneAsset(), sdk.NewUint(150*common.One)),
+		common.NewCoin(common.BNBAsset, sdk.NewUint(40*common.One)),
+	}
+	c.Assert(k.SetVault(ctx, asgard), IsNil)
+	ygg := GetRandomVault()
+	ygg.Type = YggdrasilVault
+	ygg.Coins = common.Coins{
+		common.NewCoin(common.RuneAsset(), sdk.NewUint(15*common.One)),
+		common.NewCoin(common.BNBAsset, sdk.NewUint(10*common.One)),
+	}
+	c.Assert(k.SetVault(ctx, ygg), IsNil)
+	return pool, staker
+}
+
+func (s *InvariantsSuite) TestRegisterInvariants(c *C) {
+	_, k := setupKeeperForTest(c)
+	ir := &TestInvariantRegistry{}
+	RegisterInvariants(ir, k)
+	c.Check(ir.routes, DeepEquals, []string{
+		ModuleName + "/" + RuneSolvencyInvariantRoute,
+		ModuleName + "/" + StakerUnitsInvariantRoute,
+		ModuleName + "/" + VaultSolvencyInvariantRoute,
+	})
+}
+
+func (s *InvariantsSuite) TestInvariants(c *C) {
+	ctx, k := setupKeeperForTest(c)
+	pool, staker := s.setupSolventState(c, ctx, k)
+
+	for _, result := range CheckInvariants(ctx, k) {
+		c.Check(result.Broken, Equals, false, Commentf("%s: %s", result.Route, result.Msg))
+	}
+	checkInvariantsAndHalt(ctx, k)
+
+	// pool owe more RUNE than vaults hold
+	pool.BalanceRune = sdk.NewUint(200 * common.One)
+	// staker units no longer add up to pool units
+	pool.PoolUnits = sdk.NewUint(150)
+	// pool owe more asset than vaults hold
+	pool.BalanceAsset = sdk.NewUint(60 * common.One)
+	c.Assert(k.SetPool(ctx, pool), IsNil)
+	msg, broken := RuneSolvencyInvariant(k)(ctx)
+	c.Check(broken, Equals, true, Commentf("%s", msg))
+	msg, broken = StakerUnitsInvariant(k)(ctx)
+	c.Check(broken, Equals, true, Commentf("%s", msg))
+	msg, broken = VaultSolvencyInvariant(k)(ctx)
+	c.Check(broken, Equals, true, Commentf("%s", msg))
+
+	// trading only halts when the mimir switch is on
+	checkInvariantsAndHalt(ctx, k)
+	haltTrading, err := k.GetMimir(ctx, "HaltTrading")
+	c.Assert(err, IsNil)
+	c.Check(haltTrading, Equals, int64(-1))
+	k.SetMimir(ctx, HaltOnInvariantBroken, 1)
+	ctx = ctx.WithBlockHeight(10)
+	checkInvariantsAndHalt(ctx, k)
+	haltTrading, err = k.GetMimir(ctx, "HaltTrading")
+	c.Assert(err, IsNil)
+	c.Check(haltTrading, Equals, int64(10))
+	// trading stays halted from the height it was first halted at
+	checkInvariantsAndHalt(ctx.WithBlockHeight(11), k)
+	haltTrading, err = k.GetMimir(ctx, "HaltTrading")
+	c.Assert(err, IsNil)
+	c.Check(haltTrading, Equals, int64(10))
+
+	staker.Units = sdk.NewUint(150)
+	k.SetStaker(ctx, staker)
+	msg, broken = StakerUnitsInvariant(k)(ctx)
+	c.Check(broken, Equals, false, Commentf("%s", msg))
+}
+
+func (s *InvariantsSuite) TestQueryInvariants(c *C) {
+	ctx, k := setupKeeperForTest(c)
+	s.setupSolventState(c, ctx, k)
+
+	versionedTxOutStoreDummy := NewVersionedTxOutStoreDummy()
+	versionedVaultMgrDummy := NewVersionedVaultMgrDummy(versionedTxOutStoreDummy)
+	versionedEventManagerDummy := NewDummyVersionedEventMgr()
+	validatorMgr := NewVersionedValidatorMgr(k, versionedTxOutStoreDummy, versionedVaultMgrDummy, versionedEventManagerDummy)
+	querier := NewQuerier(k, validatorMgr)
+
+	res, err := querier(ctx, []string{"invariants"}, abci.RequestQuery{})
+	c.Assert(err, IsNil)
+	var results []InvariantResult
+	c.Assert(json.Unmarshal(res, &results), IsNil)
+	c.Assert(results, HasLen, 3)
+	for _, result := range results {
+		c.Check(result.Broken, Equals, false)
+	}
+}
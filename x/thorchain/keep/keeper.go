@@ -46,6 +46,8 @@ type Keeper interface {
 	KeeperBanVoter
 	KeeperSwapQueue
 	KeeperMimir
+	KeeperNetworkFee
+	KeeperSlash
 }
 
 // NOTE: Always end a dbPrefix with a slash ("/"). This is to ensure that there
@@ -59,10 +61,12 @@ type dbPrefix string
 const (
 	prefixObservedTx         dbPrefix = "observed_tx/"
 	prefixPool               dbPrefix = "pool/"
+	prefixPoolPrice          dbPrefix = "pool_price/"
 	prefixTxOut              dbPrefix = "txout/"
 	prefixTotalLiquidityFee  dbPrefix = "total_liquidity_fee/"
 	prefixPoolLiquidityFee   dbPrefix = "pool_liquidity_fee/"
 	prefixStaker             dbPrefix = "staker/"
+	prefixPendingRune        dbPrefix = "pending_rune/"
 	prefixEvents             dbPrefix = "events/"
 	prefixTxHashEvents       dbPrefix = "tx_events/"
 	prefixPendingEvents      dbPrefix = "pending_events/"
@@ -84,8 +88,12 @@ const (
 	prefixErrataTx           dbPrefix = "errata/"
 	prefixBanVoter           dbPrefix = "ban/"
 	prefixNodeSlashPoints    dbPrefix = "slash/"
+	prefixNodeSlashes        dbPrefix = "node_slashes/"
+	prefixSlashRecords       dbPrefix = "slash_records/"
 	prefixSwapQueueItem      dbPrefix = "swapitem/"
 	prefixMimir              dbPrefix = "mimir/"
+	prefixNetworkFee         dbPrefix = "network_fee/"
+	prefixNetworkFeeVoter    dbPrefix = "network_fee_voter/"
 )
 
 func dbError(ctx sdk.Context, wrapper string, err error) error {
@@ -81,6 +81,12 @@ func (k KVStoreDummy) EnableAPool(_ sdk.Context)                               {
 func (k KVStoreDummy) GetPool(_ sdk.Context, _ common.Asset) (Pool, error) {
 	return Pool{}, kaboom
 }
+func (k KVStoreDummy) GetPoolPriceCumulative(_ sdk.Context, _ common.Asset, _ int64) (PoolPriceCumulative, error) {
+	return PoolPriceCumulative{}, kaboom
+}
+func (k KVStoreDummy) RemovePoolPriceCumulatives(_ sdk.Context, _ common.Asset, _ int64) {
+}
+
 func (k KVStoreDummy) GetPools(_ sdk.Context) (Pools, error)                        { return nil, kaboom }
 func (k KVStoreDummy) SetPool(_ sdk.Context, _ Pool) error                          { return kaboom }
 func (k KVStoreDummy) PoolExist(_ sdk.Context, _ common.Asset) bool                 { return false }
@@ -88,6 +94,9 @@ func (k KVStoreDummy) GetStakerIterator(_ sdk.Context, _ common.Asset) sdk.Itera
 func (k KVStoreDummy) GetStaker(_ sdk.Context, _ common.Asset, _ common.Address) (Staker, error) {
 	return Staker{}, kaboom
 }
+func (k KVStoreDummy) GetStakersWithPendingRune(_ sdk.Context, _ int64) ([]Staker, error) {
+	return nil, kaboom
+}
 func (k KVStoreDummy) SetStaker(_ sdk.Context, _ Staker)                 {}
 func (k KVStoreDummy) RemoveStaker(_ sdk.Context, _ Staker)              {}
 func (k KVStoreDummy) TotalActiveNodeAccount(_ sdk.Context) (int, error) { return 0, kaboom }
@@ -130,6 +139,18 @@ func (k KVStoreDummy) IncNodeAccountSlashPoints(_ sdk.Context, _ sdk.AccAddress,
 	return kaboom
 }
 
+func (k KVStoreDummy) GetNodeAccountSlashes(_ sdk.Context, addr sdk.AccAddress) (NodeSlashes, error) {
+	return NewNodeSlashes(addr), kaboom
+}
+func (k KVStoreDummy) SetNodeAccountSlashes(_ sdk.Context, _ NodeSlashes) {}
+func (k KVStoreDummy) AddSlashRecord(_ sdk.Context, _ sdk.AccAddress, _ SlashRecord) error {
+	return kaboom
+}
+func (k KVStoreDummy) GetSlashRecords(_ sdk.Context, _ sdk.AccAddress, _ int64) (SlashRecords, error) {
+	return nil, kaboom
+}
+func (k KVStoreDummy) RemoveSlashRecords(_ sdk.Context, _ sdk.AccAddress, _ int64) {}
+
 func (k KVStoreDummy) DecNodeAccountSlashPoints(_ sdk.Context, _ sdk.AccAddress, _ int64) error {
 	return kaboom
 }
@@ -261,6 +282,17 @@ func (k KVStoreDummy) GetSwapQueueItem(ctx sdk.Context, txID common.TxID) (MsgSw
 func (k KVStoreDummy) GetMimir(_ sdk.Context, key string) (int64, error) { return 0, kaboom }
 func (k KVStoreDummy) SetMimir(_ sdk.Context, key string, value int64)   {}
 func (k KVStoreDummy) GetMimirIterator(ctx sdk.Context) sdk.Iterator     { return nil }
+func (k KVStoreDummy) GetNetworkFee(ctx sdk.Context, chain common.Chain) (NetworkFee, error) {
+	return NetworkFee{}, kaboom
+}
+func (k KVStoreDummy) SaveNetworkFee(ctx sdk.Context, chain common.Chain, networkFee NetworkFee) error {
+	return kaboom
+}
+func (k KVStoreDummy) GetNetworkFeeVoter(ctx sdk.Context, chain common.Chain) (NetworkFeeVoter, error) {
+	return NetworkFeeVoter{}, kaboom
+}
+func (k KVStoreDummy) SetNetworkFeeVoter(ctx sdk.Context, voter NetworkFeeVoter) {}
+func (k KVStoreDummy) GetNetworkFeeIterator(ctx sdk.Context) sdk.Iterator        { return nil }
 
 // a mock sdk.Iterator implementation for testing purposes
 type DummyIterator struct {
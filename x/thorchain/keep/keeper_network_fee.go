@@ -0,0 +1,69 @@
+package keep
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"gitlab.com/thorchain/thornode/common"
+)
+
+type KeeperNetworkFee interface {
+	GetNetworkFee(ctx sdk.Context, chain common.Chain) (NetworkFee, error)
+	SaveNetworkFee(ctx sdk.Context, chain common.Chain, networkFee NetworkFee) error
+	GetNetworkFeeVoter(ctx sdk.Context, chain common.Chain) (NetworkFeeVoter, error)
+	SetNetworkFeeVoter(ctx sdk.Context, voter NetworkFeeVoter)
+	GetNetworkFeeIterator(ctx sdk.Context) sdk.Iterator
+}
+
+// GetNetworkFee get the network fee of the given chain, an empty NetworkFee will be returned when it has not been reported
+func (k KVStore) GetNetworkFee(ctx sdk.Context, chain common.Chain) (NetworkFee, error) {
+	key := k.GetKey(ctx, prefixNetworkFee, chain.String())
+	store := ctx.KVStore(k.storeKey)
+	if !store.Has([]byte(key)) {
+		return NetworkFee{}, nil
+	}
+	var networkFee NetworkFee
+	buf := store.Get([]byte(key))
+	if err := k.cdc.UnmarshalBinaryBare(buf, &networkFee); err != nil {
+		return NetworkFee{}, dbError(ctx, "Unmarshal: network fee", err)
+	}
+	return networkFee, nil
+}
+
+// SaveNetworkFee save the network fee of the given chain
+func (k KVStore) SaveNetworkFee(ctx sdk.Context, chain common.Chain, networkFee NetworkFee) error {
+	if err := networkFee.Valid(); err != nil {
+		return err
+	}
+	store := ctx.KVStore(k.storeKey)
+	key := k.GetKey(ctx, prefixNetworkFee, chain.String())
+	store.Set([]byte(key), k.cdc.MustMarshalBinaryBare(networkFee))
+	return nil
+}
+
+// GetNetworkFeeVoter get the network fee reports of the given chain
+func (k KVStore) GetNetworkFeeVoter(ctx sdk.Context, chain common.Chain) (NetworkFeeVoter, error) {
+	voter := NewNetworkFeeVoter(chain)
+	key := k.GetKey(ctx, prefixNetworkFeeVoter, chain.String())
+	store := ctx.KVStore(k.storeKey)
+	if !store.Has([]byte(key)) {
+		return voter, nil
+	}
+	buf := store.Get([]byte(key))
+	if err := k.cdc.UnmarshalBinaryBare(buf, &voter); err != nil {
+		return voter, dbError(ctx, "Unmarshal: network fee voter", err)
+	}
+	return voter, nil
+}
+
+// SetNetworkFeeVoter save the network fee reports
+func (k KVStore) SetNetworkFeeVoter(ctx sdk.Context, voter NetworkFeeVoter) {
+	store := ctx.KVStore(k.storeKey)
+	key := k.GetKey(ctx, prefixNetworkFeeVoter, voter.Chain.String())
+	store.Set([]byte(key), k.cdc.MustMarshalBinaryBare(voter))
+}
+
+// GetNetworkFeeIterator iterate network fees
+func (k KVStore) GetNetworkFeeIterator(ctx sdk.Context) sdk.Iterator {
+	store := ctx.KVStore(k.storeKey)
+	return sdk.KVStorePrefixIterator(store, []byte(prefixNetworkFee))
+}
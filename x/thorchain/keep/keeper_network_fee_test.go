@@ -0,0 +1,44 @@
+package keep
+
+import (
+	. "gopkg.in/check.v1"
+
+	"gitlab.com/thorchain/thornode/common"
+)
+
+type KeeperNetworkFeeSuite struct{}
+
+var _ = Suite(&KeeperNetworkFeeSuite{})
+
+func (s *KeeperNetworkFeeSuite) TestNetworkFee(c *C) {
+	ctx, k := setupKeeperForTest(c)
+
+	networkFee, err := k.GetNetworkFee(ctx, common.BTCChain)
+	c.Assert(err, IsNil)
+	c.Check(networkFee.IsEmpty(), Equals, true)
+
+	c.Check(k.SaveNetworkFee(ctx, common.BTCChain, NetworkFee{}), NotNil)
+	c.Assert(k.SaveNetworkFee(ctx, common.BTCChain, NewNetworkFee(common.BTCChain, 1, 250, 10)), IsNil)
+	networkFee, err = k.GetNetworkFee(ctx, common.BTCChain)
+	c.Assert(err, IsNil)
+	c.Check(networkFee.TransactionSize, Equals, uint64(250))
+	c.Check(networkFee.TransactionFeeRate, Equals, uint64(10))
+	iter := k.GetNetworkFeeIterator(ctx)
+	c.Check(iter, NotNil)
+	iter.Close()
+}
+
+func (s *KeeperNetworkFeeSuite) TestNetworkFeeVoter(c *C) {
+	ctx, k := setupKeeperForTest(c)
+
+	voter, err := k.GetNetworkFeeVoter(ctx, common.BTCChain)
+	c.Assert(err, IsNil)
+	c.Check(voter.Chain.Equals(common.BTCChain), Equals, true)
+	c.Check(voter.Reports, HasLen, 0)
+
+	voter.Add(NetworkFeeReport{Signer: GetRandomBech32Addr(), BlockHeight: 1, TransactionSize: 250, TransactionFeeRate: 10})
+	k.SetNetworkFeeVoter(ctx, voter)
+	voter, err = k.GetNetworkFeeVoter(ctx, common.BTCChain)
+	c.Assert(err, IsNil)
+	c.Check(voter.Reports, HasLen, 1)
+}
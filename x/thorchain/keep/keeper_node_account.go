@@ -288,6 +288,17 @@ func (k KVStore) ResetNodeAccountSlashPoints(ctx sdk.Context, addr sdk.AccAddres
 	store := ctx.KVStore(k.storeKey)
 	key := k.GetKey(ctx, prefixNodeSlashPoints, addr.String())
 	store.Delete([]byte(key))
+
+	// the per reason counters start over as well, the ledger is kept so it is still clear why the node got slashed
+	slashes, err := k.GetNodeAccountSlashes(ctx, addr)
+	if err != nil {
+		ctx.Logger().Error("fail to get node account slashes", "error", err)
+		return
+	}
+	if len(slashes.Counters) > 0 {
+		slashes.ResetCounters()
+		k.SetNodeAccountSlashes(ctx, slashes)
+	}
 }
 
 // IncNodeAccountSlashPoints - increments the slash points associated with the
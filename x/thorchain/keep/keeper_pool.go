@@ -2,6 +2,7 @@ package keep
 
 import (
 	"errors"
+	"fmt"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 
@@ -14,6 +15,8 @@ type KeeperPool interface {
 	GetPools(ctx sdk.Context) (Pools, error)
 	SetPool(ctx sdk.Context, pool Pool) error
 	PoolExist(ctx sdk.Context, asset common.Asset) bool
+	GetPoolPriceCumulative(ctx sdk.Context, asset common.Asset, height int64) (PoolPriceCumulative, error)
+	RemovePoolPriceCumulatives(ctx sdk.Context, asset common.Asset, height int64)
 }
 
 // GetPoolIterator iterate pools
@@ -61,10 +64,137 @@ func (k KVStore) SetPool(ctx sdk.Context, pool Pool) error {
 		return errors.New("cannot save a pool with an empty asset")
 	}
 
+	if err := k.updatePoolPriceCumulative(ctx, &pool); err != nil {
+		return err
+	}
 	store.Set([]byte(key), k.cdc.MustMarshalBinaryBare(pool))
 	return nil
 }
 
+// updatePoolPriceCumulative carry the price accumulator of the stored pool over to the given pool. The first time a pool is saved
+// in a block, the accumulator is brought up to the block height with the price the pool had since it was last saved, and a
+// checkpoint is written, so the accumulator can be worked out for any height later. The price can't be moved within a block
+// this way, whatever a block does to the pool only counts from the next block on
+func (k KVStore) updatePoolPriceCumulative(ctx sdk.Context, pool *Pool) error {
+	height := ctx.BlockHeight()
+	if height <= 0 {
+		// pools set up in genesis start to be tracked from the first block
+		pool.PriceCumulative = sdk.ZeroUint()
+		pool.PriceCumulativeHeight = 0
+		return nil
+	}
+	stored, err := k.GetPool(ctx, pool.Asset)
+	if err != nil {
+		return err
+	}
+	if stored.PriceCumulativeHeight > 0 && stored.PriceCumulativeHeight >= height {
+		pool.PriceCumulative = stored.PriceCumulative
+		pool.PriceCumulativeHeight = stored.PriceCumulativeHeight
+		return nil
+	}
+	pool.PriceCumulative = stored.GetPriceCumulative(height)
+	pool.PriceCumulativeHeight = height
+	store := ctx.KVStore(k.storeKey)
+	checkpoint := PoolPriceCumulative{
+		Asset:           pool.Asset,
+		Height:          height,
+		PriceCumulative: pool.PriceCumulative,
+	}
+	store.Set([]byte(k.getPoolPriceKey(ctx, pool.Asset, height)), k.cdc.MustMarshalBinaryBare(checkpoint))
+	return nil
+}
+
+func (k KVStore) getPoolPriceKey(ctx sdk.Context, asset common.Asset, height int64) string {
+	// heights are zero padded, so the checkpoints of a pool are iterated in height order
+	return k.GetKey(ctx, prefixPoolPrice, fmt.Sprintf("%s/%020d", asset.String(), height))
+}
+
+func (k KVStore) getPoolPriceIterator(ctx sdk.Context, asset common.Asset, reverse bool) sdk.Iterator {
+	store := ctx.KVStore(k.storeKey)
+	prefix := []byte(k.GetKey(ctx, prefixPoolPrice, asset.String()+"/"))
+	if reverse {
+		return sdk.KVStoreReversePrefixIterator(store, prefix)
+	}
+	return sdk.KVStorePrefixIterator(store, prefix)
+}
+
+// GetPoolPriceCumulative return the price accumulator of the given pool at the given height. The accumulator only moves in a
+// straight line between two checkpoints, so it is interpolated when there is no checkpoint at the height. When the height is
+// before the oldest checkpoint, the oldest checkpoint is returned, callers should check the height of what they get back
+func (k KVStore) GetPoolPriceCumulative(ctx sdk.Context, asset common.Asset, height int64) (PoolPriceCumulative, error) {
+	pool, err := k.GetPool(ctx, asset)
+	if err != nil {
+		return PoolPriceCumulative{}, err
+	}
+	if pool.PriceCumulativeHeight == 0 {
+		return PoolPriceCumulative{}, fmt.Errorf("pool %s has no price history", asset)
+	}
+	if height >= pool.PriceCumulativeHeight {
+		return PoolPriceCumulative{
+			Asset:           asset,
+			Height:          height,
+			PriceCumulative: pool.GetPriceCumulative(height),
+		}, nil
+	}
+
+	next := PoolPriceCumulative{
+		Asset:           asset,
+		Height:          pool.PriceCumulativeHeight,
+		PriceCumulative: pool.PriceCumulative,
+	}
+	iterator := k.getPoolPriceIterator(ctx, asset, true)
+	defer iterator.Close()
+	for ; iterator.Valid(); iterator.Next() {
+		var checkpoint PoolPriceCumulative
+		if err := k.cdc.UnmarshalBinaryBare(iterator.Value(), &checkpoint); err != nil {
+			return PoolPriceCumulative{}, dbError(ctx, "Unmarshal: pool price cumulative", err)
+		}
+		if checkpoint.Height > height {
+			next = checkpoint
+			continue
+		}
+		if checkpoint.Height == height {
+			return checkpoint, nil
+		}
+		// interpolate between the checkpoint before and the one after the height
+		elapsed := sdk.NewUint(uint64(height - checkpoint.Height))
+		span := sdk.NewUint(uint64(next.Height - checkpoint.Height))
+		delta := common.SafeSub(next.PriceCumulative, checkpoint.PriceCumulative)
+		checkpoint.PriceCumulative = checkpoint.PriceCumulative.Add(delta.Mul(elapsed).Quo(span))
+		checkpoint.Height = height
+		return checkpoint, nil
+	}
+	// the height is before the oldest checkpoint
+	return next, nil
+}
+
+// RemovePoolPriceCumulatives remove the price checkpoints of the given pool that are no longer needed to work out the
+// accumulator at the given height or after it
+func (k KVStore) RemovePoolPriceCumulatives(ctx sdk.Context, asset common.Asset, height int64) {
+	var keys [][]byte
+	iterator := k.getPoolPriceIterator(ctx, asset, false)
+	for ; iterator.Valid(); iterator.Next() {
+		var checkpoint PoolPriceCumulative
+		if err := k.cdc.UnmarshalBinaryBare(iterator.Value(), &checkpoint); err != nil {
+			_ = dbError(ctx, "Unmarshal: pool price cumulative", err)
+			break
+		}
+		if checkpoint.Height > height {
+			break
+		}
+		keys = append(keys, iterator.Key())
+	}
+	iterator.Close()
+	// the last checkpoint at or before the height is still needed to interpolate from
+	if len(keys) > 0 {
+		keys = keys[:len(keys)-1]
+	}
+	store := ctx.KVStore(k.storeKey)
+	for _, key := range keys {
+		store.Delete(key)
+	}
+}
+
 // PoolExist check whether the given pool exist in the datastore
 func (k KVStore) PoolExist(ctx sdk.Context, asset common.Asset) bool {
 	store := ctx.KVStore(k.storeKey)
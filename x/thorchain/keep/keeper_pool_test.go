@@ -1,6 +1,7 @@
 package keep
 
 import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
 	. "gopkg.in/check.v1"
 
 	"gitlab.com/thorchain/thornode/common"
@@ -29,3 +30,50 @@ func (s *KeeperPoolSuite) TestPool(c *C) {
 	c.Assert(err, IsNil)
 	c.Assert(pools, HasLen, 1)
 }
+
+func (s *KeeperPoolSuite) TestPoolPriceCumulative(c *C) {
+	ctx, k := setupKeeperForTest(c)
+	pool := NewPool()
+	pool.Asset = common.BNBAsset
+	pool.BalanceRune = sdk.NewUint(100 * common.One)
+	pool.BalanceAsset = sdk.NewUint(100 * common.One)
+	ctx = ctx.WithBlockHeight(10)
+	c.Assert(k.SetPool(ctx, pool), IsNil)
+
+	// price 2 RUNE from block 20, the change within the block doesn't count until the next one
+	ctx = ctx.WithBlockHeight(20)
+	pool.BalanceRune = sdk.NewUint(300 * common.One)
+	c.Assert(k.SetPool(ctx, pool), IsNil)
+	pool.BalanceRune = sdk.NewUint(200 * common.One)
+	c.Assert(k.SetPool(ctx, pool), IsNil)
+	pool, err := k.GetPool(ctx, common.BNBAsset)
+	c.Assert(err, IsNil)
+	c.Check(pool.PriceCumulativeHeight, Equals, int64(20))
+	c.Check(pool.PriceCumulative.Equal(sdk.NewUint(10*common.One)), Equals, true)
+
+	ctx = ctx.WithBlockHeight(40)
+	pool.BalanceRune = sdk.NewUint(100 * common.One)
+	c.Assert(k.SetPool(ctx, pool), IsNil)
+
+	checkpoint, err := k.GetPoolPriceCumulative(ctx, common.BNBAsset, 30)
+	c.Assert(err, IsNil)
+	c.Check(checkpoint.Height, Equals, int64(30))
+	c.Check(checkpoint.PriceCumulative.Equal(sdk.NewUint(30*common.One)), Equals, true)
+	checkpoint, err = k.GetPoolPriceCumulative(ctx, common.BNBAsset, 50)
+	c.Assert(err, IsNil)
+	c.Check(checkpoint.PriceCumulative.Equal(sdk.NewUint(60*common.One)), Equals, true)
+	checkpoint, err = k.GetPoolPriceCumulative(ctx, common.BNBAsset, 5)
+	c.Assert(err, IsNil)
+	c.Check(checkpoint.Height, Equals, int64(10))
+
+	k.RemovePoolPriceCumulatives(ctx, common.BNBAsset, 30)
+	checkpoint, err = k.GetPoolPriceCumulative(ctx, common.BNBAsset, 5)
+	c.Assert(err, IsNil)
+	c.Check(checkpoint.Height, Equals, int64(20))
+	checkpoint, err = k.GetPoolPriceCumulative(ctx, common.BNBAsset, 30)
+	c.Assert(err, IsNil)
+	c.Check(checkpoint.PriceCumulative.Equal(sdk.NewUint(30*common.One)), Equals, true)
+
+	_, err = k.GetPoolPriceCumulative(ctx, common.BTCAsset, 30)
+	c.Check(err, NotNil)
+}
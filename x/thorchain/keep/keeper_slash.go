@@ -0,0 +1,99 @@
+package keep
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+type KeeperSlash interface {
+	GetNodeAccountSlashes(ctx sdk.Context, addr sdk.AccAddress) (NodeSlashes, error)
+	SetNodeAccountSlashes(ctx sdk.Context, slashes NodeSlashes)
+	AddSlashRecord(ctx sdk.Context, addr sdk.AccAddress, record SlashRecord) error
+	GetSlashRecords(ctx sdk.Context, addr sdk.AccAddress, height int64) (SlashRecords, error)
+	RemoveSlashRecords(ctx sdk.Context, addr sdk.AccAddress, height int64)
+}
+
+// GetNodeAccountSlashes get the slash points of the given node address broken down by reason
+func (k KVStore) GetNodeAccountSlashes(ctx sdk.Context, addr sdk.AccAddress) (NodeSlashes, error) {
+	slashes := NewNodeSlashes(addr)
+	key := k.GetKey(ctx, prefixNodeSlashes, addr.String())
+	store := ctx.KVStore(k.storeKey)
+	if !store.Has([]byte(key)) {
+		return slashes, nil
+	}
+	buf := store.Get([]byte(key))
+	if err := k.cdc.UnmarshalBinaryBare(buf, &slashes); err != nil {
+		return slashes, dbError(ctx, "Unmarshal: node account slashes", err)
+	}
+	return slashes, nil
+}
+
+// SetNodeAccountSlashes save the slash points of a node address broken down by reason
+func (k KVStore) SetNodeAccountSlashes(ctx sdk.Context, slashes NodeSlashes) {
+	key := k.GetKey(ctx, prefixNodeSlashes, slashes.NodeAddress.String())
+	store := ctx.KVStore(k.storeKey)
+	store.Set([]byte(key), k.cdc.MustMarshalBinaryBare(slashes))
+}
+
+func (k KVStore) getSlashRecordsPrefix(ctx sdk.Context, addr sdk.AccAddress) []byte {
+	return []byte(k.GetKey(ctx, prefixSlashRecords, addr.String()+"/"))
+}
+
+func (k KVStore) getSlashRecordKey(ctx sdk.Context, addr sdk.AccAddress, height int64, reason SlashReason) []byte {
+	// heights are zero padded, so the records of a node account are iterated in height order
+	return []byte(k.GetKey(ctx, prefixSlashRecords, fmt.Sprintf("%s/%020d/%s", addr.String(), height, reason)))
+}
+
+// AddSlashRecord save the given slash record of a node address, slashes for the same reason in the same block go into one record
+func (k KVStore) AddSlashRecord(ctx sdk.Context, addr sdk.AccAddress, record SlashRecord) error {
+	key := k.getSlashRecordKey(ctx, addr, record.Height, record.Reason)
+	store := ctx.KVStore(k.storeKey)
+	if store.Has(key) {
+		var stored SlashRecord
+		if err := k.cdc.UnmarshalBinaryBare(store.Get(key), &stored); err != nil {
+			return dbError(ctx, "Unmarshal: slash record", err)
+		}
+		record.Points += stored.Points
+		record.Rune = record.Rune.Add(stored.Rune)
+	}
+	store.Set(key, k.cdc.MustMarshalBinaryBare(record))
+	return nil
+}
+
+// GetSlashRecords return the slash records of a node address at or after the given height, in height order
+func (k KVStore) GetSlashRecords(ctx sdk.Context, addr sdk.AccAddress, height int64) (SlashRecords, error) {
+	store := ctx.KVStore(k.storeKey)
+	if height < 0 {
+		height = 0
+	}
+	start := k.getSlashRecordKey(ctx, addr, height, "")
+	iterator := store.Iterator(start, sdk.PrefixEndBytes(k.getSlashRecordsPrefix(ctx, addr)))
+	defer iterator.Close()
+	records := make(SlashRecords, 0)
+	for ; iterator.Valid(); iterator.Next() {
+		var record SlashRecord
+		if err := k.cdc.UnmarshalBinaryBare(iterator.Value(), &record); err != nil {
+			return nil, dbError(ctx, "Unmarshal: slash record", err)
+		}
+		records = append(records, record)
+	}
+	return records, nil
+}
+
+// RemoveSlashRecords remove the slash records of a node address older than the given height
+func (k KVStore) RemoveSlashRecords(ctx sdk.Context, addr sdk.AccAddress, height int64) {
+	store := ctx.KVStore(k.storeKey)
+	if height <= 0 {
+		return
+	}
+	var keys [][]byte
+	iterator := store.Iterator(k.getSlashRecordsPrefix(ctx, addr), k.getSlashRecordKey(ctx, addr, height, ""))
+	for ; iterator.Valid(); iterator.Next() {
+		keys = append(keys, iterator.Key())
+	}
+	iterator.Close()
+	for _, key := range keys {
+		store.Delete(key)
+	}
+}
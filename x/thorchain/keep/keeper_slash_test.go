@@ -0,0 +1,68 @@
+package keep
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	. "gopkg.in/check.v1"
+)
+
+type KeeperSlashSuite struct{}
+
+var _ = Suite(&KeeperSlashSuite{})
+
+func (s *KeeperSlashSuite) TestNodeAccountSlashes(c *C) {
+	ctx, k := setupKeeperForTest(c)
+	addr := GetRandomBech32Addr()
+
+	slashes, err := k.GetNodeAccountSlashes(ctx, addr)
+	c.Assert(err, IsNil)
+	c.Check(slashes.NodeAddress.Equals(addr), Equals, true)
+	c.Check(slashes.Counters, HasLen, 0)
+
+	slashes.Add(SlashReasonFailKeysign, 100)
+	k.SetNodeAccountSlashes(ctx, slashes)
+	slashes, err = k.GetNodeAccountSlashes(ctx, addr)
+	c.Assert(err, IsNil)
+	c.Check(slashes.GetPoints(SlashReasonFailKeysign), Equals, int64(100))
+
+	// resetting the slash points start the counters over
+	k.ResetNodeAccountSlashPoints(ctx, addr)
+	slashes, err = k.GetNodeAccountSlashes(ctx, addr)
+	c.Assert(err, IsNil)
+	c.Check(slashes.GetPoints(SlashReasonFailKeysign), Equals, int64(0))
+}
+
+func (s *KeeperSlashSuite) TestSlashRecords(c *C) {
+	ctx, k := setupKeeperForTest(c)
+	addr := GetRandomBech32Addr()
+	other := GetRandomBech32Addr()
+
+	c.Assert(k.AddSlashRecord(ctx, addr, NewSlashRecord(10, SlashReasonLackObserving, 2, sdk.ZeroUint())), IsNil)
+	c.Assert(k.AddSlashRecord(ctx, addr, NewSlashRecord(10, SlashReasonLackObserving, 2, sdk.ZeroUint())), IsNil)
+	c.Assert(k.AddSlashRecord(ctx, addr, NewSlashRecord(10, SlashReasonFailKeysign, 100, sdk.ZeroUint())), IsNil)
+	c.Assert(k.AddSlashRecord(ctx, addr, NewSlashRecord(9, SlashReasonDoubleSign, 0, sdk.NewUint(500))), IsNil)
+	c.Assert(k.AddSlashRecord(ctx, addr, NewSlashRecord(12, SlashReasonLackObserving, 2, sdk.ZeroUint())), IsNil)
+	c.Assert(k.AddSlashRecord(ctx, other, NewSlashRecord(10, SlashReasonLackObserving, 2, sdk.ZeroUint())), IsNil)
+
+	records, err := k.GetSlashRecords(ctx, addr, 0)
+	c.Assert(err, IsNil)
+	c.Assert(records, HasLen, 4)
+	c.Check(records[0].Height, Equals, int64(9))
+	c.Check(records[0].Rune.Equal(sdk.NewUint(500)), Equals, true)
+	c.Check(records[1].Reason, Equals, SlashReasonFailKeysign)
+	c.Check(records[2].Reason, Equals, SlashReasonLackObserving)
+	c.Check(records[2].Points, Equals, int64(4))
+	c.Check(records[3].Height, Equals, int64(12))
+	records, err = k.GetSlashRecords(ctx, addr, 10)
+	c.Assert(err, IsNil)
+	c.Check(records, HasLen, 3)
+
+	// records older than the height are pruned, other node accounts are left alone
+	k.RemoveSlashRecords(ctx, addr, 12)
+	records, err = k.GetSlashRecords(ctx, addr, 0)
+	c.Assert(err, IsNil)
+	c.Assert(records, HasLen, 1)
+	c.Check(records[0].Height, Equals, int64(12))
+	records, err = k.GetSlashRecords(ctx, other, 0)
+	c.Assert(err, IsNil)
+	c.Check(records, HasLen, 1)
+}
@@ -1,6 +1,8 @@
 package keep
 
 import (
+	"fmt"
+
 	sdk "github.com/cosmos/cosmos-sdk/types"
 
 	"gitlab.com/thorchain/thornode/common"
@@ -11,6 +13,7 @@ type KeeperStaker interface {
 	GetStaker(ctx sdk.Context, asset common.Asset, addr common.Address) (Staker, error)
 	SetStaker(ctx sdk.Context, staker Staker)
 	RemoveStaker(ctx sdk.Context, staker Staker)
+	GetStakersWithPendingRune(ctx sdk.Context, height int64) ([]Staker, error)
 }
 
 // GetStakerIterator iterate stakers
@@ -24,10 +27,12 @@ func (k KVStore) GetStakerIterator(ctx sdk.Context, asset common.Asset) sdk.Iter
 func (k KVStore) GetStaker(ctx sdk.Context, asset common.Asset, addr common.Address) (Staker, error) {
 	store := ctx.KVStore(k.storeKey)
 	staker := Staker{
-		Asset:       asset,
-		RuneAddress: addr,
-		Units:       sdk.ZeroUint(),
-		PendingRune: sdk.ZeroUint(),
+		Asset:        asset,
+		RuneAddress:  addr,
+		Units:        sdk.ZeroUint(),
+		PendingRune:  sdk.ZeroUint(),
+		RuneDeposit:  sdk.ZeroUint(),
+		AssetDeposit: sdk.ZeroUint(),
 	}
 	key := k.GetKey(ctx, prefixStaker, staker.Key())
 	if !store.Has([]byte(key)) {
@@ -40,16 +45,64 @@ func (k KVStore) GetStaker(ctx sdk.Context, asset common.Asset, addr common.Addr
 	return staker, nil
 }
 
-// SetStaker store the staker to kvstore
+// SetStaker store the staker to kvstore, a staker with pending RUNE is indexed by the height the RUNE started to wait at, the
+// height is only set while there is pending RUNE
 func (k KVStore) SetStaker(ctx sdk.Context, staker Staker) {
 	store := ctx.KVStore(k.storeKey)
 	key := k.GetKey(ctx, prefixStaker, staker.Key())
+	k.removePendingRuneIndex(ctx, staker)
 	store.Set([]byte(key), k.cdc.MustMarshalBinaryBare(staker))
+	if staker.PendingRuneHeight > 0 {
+		store.Set([]byte(k.getPendingRuneKey(ctx, staker)), []byte(staker.Key()))
+	}
 }
 
 // RemoveStaker remove the staker to kvstore
 func (k KVStore) RemoveStaker(ctx sdk.Context, staker Staker) {
 	store := ctx.KVStore(k.storeKey)
 	key := k.GetKey(ctx, prefixStaker, staker.Key())
+	k.removePendingRuneIndex(ctx, staker)
 	store.Delete([]byte(key))
 }
+
+func (k KVStore) getPendingRuneKey(ctx sdk.Context, staker Staker) string {
+	// heights are zero padded, so the index is iterated in height order
+	return k.GetKey(ctx, prefixPendingRune, fmt.Sprintf("%020d/%s", staker.PendingRuneHeight, staker.Key()))
+}
+
+// removePendingRuneIndex remove the index entry of the pending RUNE the given staker has in the kvstore
+func (k KVStore) removePendingRuneIndex(ctx sdk.Context, staker Staker) {
+	stored, err := k.GetStaker(ctx, staker.Asset, staker.RuneAddress)
+	if err != nil {
+		_ = dbError(ctx, "Unmarshal: staker", err)
+		return
+	}
+	if stored.PendingRuneHeight == 0 {
+		return
+	}
+	store := ctx.KVStore(k.storeKey)
+	store.Delete([]byte(k.getPendingRuneKey(ctx, stored)))
+}
+
+// GetStakersWithPendingRune return the stakers whose RUNE has been waiting for the asset since the given height or earlier
+func (k KVStore) GetStakersWithPendingRune(ctx sdk.Context, height int64) ([]Staker, error) {
+	store := ctx.KVStore(k.storeKey)
+	var stakers []Staker
+	iterator := sdk.KVStorePrefixIterator(store, []byte(k.GetKey(ctx, prefixPendingRune, "")))
+	defer iterator.Close()
+	for ; iterator.Valid(); iterator.Next() {
+		buf := store.Get([]byte(k.GetKey(ctx, prefixStaker, string(iterator.Value()))))
+		if buf == nil {
+			continue
+		}
+		var staker Staker
+		if err := k.cdc.UnmarshalBinaryBare(buf, &staker); err != nil {
+			return nil, dbError(ctx, "Unmarshal: staker", err)
+		}
+		if staker.PendingRuneHeight > height {
+			break
+		}
+		stakers = append(stakers, staker)
+	}
+	return stakers, nil
+}
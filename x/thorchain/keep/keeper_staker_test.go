@@ -33,3 +33,49 @@ func (s *KeeperStakerSuite) TestStaker(c *C) {
 	c.Check(staker.Asset.Equals(asset), Equals, true)
 	c.Check(staker.Units.Equal(sdk.NewUint(12)), Equals, true)
 }
+
+func (s *KeeperStakerSuite) TestStakersWithPendingRune(c *C) {
+	ctx, k := setupKeeperForTest(c)
+	newStaker := func(asset common.Asset, height int64) Staker {
+		staker := Staker{
+			Asset:             asset,
+			Units:             sdk.ZeroUint(),
+			RuneAddress:       GetRandomBNBAddress(),
+			AssetAddress:      GetRandomBTCAddress(),
+			PendingRune:       sdk.NewUint(common.One),
+			PendingRuneHeight: height,
+		}
+		k.SetStaker(ctx, staker)
+		return staker
+	}
+	staker1 := newStaker(common.BTCAsset, 5)
+	staker2 := newStaker(common.BCHAsset, 3)
+	newStaker(common.BTCAsset, 10)
+
+	stakers, err := k.GetStakersWithPendingRune(ctx, 2)
+	c.Assert(err, IsNil)
+	c.Check(stakers, HasLen, 0)
+	stakers, err = k.GetStakersWithPendingRune(ctx, 5)
+	c.Assert(err, IsNil)
+	c.Assert(stakers, HasLen, 2)
+	c.Check(stakers[0].RuneAddress.Equals(staker2.RuneAddress), Equals, true)
+	c.Check(stakers[1].RuneAddress.Equals(staker1.RuneAddress), Equals, true)
+
+	// the RUNE waits again from a later height
+	staker1.PendingRuneHeight = 8
+	k.SetStaker(ctx, staker1)
+	stakers, err = k.GetStakersWithPendingRune(ctx, 5)
+	c.Assert(err, IsNil)
+	c.Assert(stakers, HasLen, 1)
+	c.Check(stakers[0].RuneAddress.Equals(staker2.RuneAddress), Equals, true)
+
+	// pending RUNE is cleared
+	staker2.PendingRune = sdk.ZeroUint()
+	staker2.PendingRuneHeight = 0
+	k.SetStaker(ctx, staker2)
+	k.RemoveStaker(ctx, staker1)
+	stakers, err = k.GetStakersWithPendingRune(ctx, 100)
+	c.Assert(err, IsNil)
+	c.Assert(stakers, HasLen, 1)
+	c.Check(stakers[0].PendingRuneHeight, Equals, int64(10))
+}
@@ -0,0 +1,57 @@
+package thorchain
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"gitlab.com/thorchain/thornode/common"
+	"gitlab.com/thorchain/thornode/constants"
+	"gitlab.com/thorchain/thornode/x/thorchain/keep"
+)
+
+// getLimitOrderTTL return how many blocks a limit order can rest in the swap queue, mimir takes precedence over the constant
+func getLimitOrderTTL(ctx sdk.Context, keeper keep.Keeper, constAccessor constants.ConstantValues) int64 {
+	ttl := constAccessor.GetInt64Value(constants.LimitOrderTTL)
+	if mimirTTL, err := keeper.GetMimir(ctx, constants.LimitOrderTTL.String()); err == nil && mimirTTL > 0 {
+		ttl = mimirTTL
+	}
+	return ttl
+}
+
+// isLimitOrderMet check whether the given limit order would emit at least its trade target at the current pool prices
+// the estimate doesn't take the transaction fee into account, the swap handler will still enforce the trade target when it runs
+func isLimitOrderMet(ctx sdk.Context, keeper keep.Keeper, msg MsgSwap) (bool, error) {
+	if len(msg.Tx.Coins) == 0 {
+		return false, fmt.Errorf("limit order %s has no coins", msg.Tx.ID)
+	}
+	source := msg.Tx.Coins[0]
+	amount := source.Amount
+	if !source.Asset.IsRune() {
+		pool, err := getLimitOrderPool(ctx, keeper, source.Asset)
+		if err != nil || pool.Empty() {
+			return false, err
+		}
+		amount = calcAssetEmission(pool.BalanceAsset, amount, pool.BalanceRune)
+	}
+	if !msg.TargetAsset.IsRune() {
+		pool, err := getLimitOrderPool(ctx, keeper, msg.TargetAsset)
+		if err != nil || pool.Empty() {
+			return false, err
+		}
+		amount = calcAssetEmission(pool.BalanceRune, amount, pool.BalanceAsset)
+	}
+	return amount.GTE(msg.TradeTarget), nil
+}
+
+// getLimitOrderPool return the pool a limit order swaps through, an empty pool is returned when it can't be swapped with
+func getLimitOrderPool(ctx sdk.Context, keeper keep.Keeper, asset common.Asset) (Pool, error) {
+	pool, err := keeper.GetPool(ctx, asset)
+	if err != nil {
+		return Pool{}, fmt.Errorf("fail to get pool(%s): %w", asset, err)
+	}
+	if !pool.IsEnabled() || pool.BalanceRune.IsZero() || pool.BalanceAsset.IsZero() {
+		return Pool{}, nil
+	}
+	return pool, nil
+}
@@ -34,6 +34,8 @@ const (
 	TxMigrate
 	TxRagnarok
 	TxSwitch
+	TxCancel
+	TxUnbond
 )
 
 var stringToTxTypeMap = map[string]TxType{
@@ -60,6 +62,8 @@ var stringToTxTypeMap = map[string]TxType{
 	"migrate":    TxMigrate,
 	"ragnarok":   TxRagnarok,
 	"switch":     TxSwitch,
+	"cancel":     TxCancel,
+	"unbond":     TxUnbond,
 }
 
 var txToStringMap = map[TxType]string{
@@ -77,6 +81,8 @@ var txToStringMap = map[TxType]string{
 	TxMigrate:         "migrate",
 	TxRagnarok:        "ragnarok",
 	TxSwitch:          "switch",
+	TxCancel:          "cancel",
+	TxUnbond:          "unbond",
 }
 
 // converts a string into a txType
@@ -91,7 +97,7 @@ func StringToTxType(s string) (TxType, error) {
 
 func (tx TxType) IsInbound() bool {
 	switch tx {
-	case TxStake, TxUnstake, TxSwap, TxAdd, TxBond, TxLeave, TxSwitch, TxReserve:
+	case TxStake, TxUnstake, TxSwap, TxAdd, TxBond, TxUnbond, TxLeave, TxSwitch, TxReserve, TxCancel:
 		return true
 	default:
 		return false
@@ -167,11 +173,19 @@ type AddMemo struct {
 	MemoBase
 }
 
+// asymmetricStakeFlag is the optional last part of a stake memo, to declare the coins should be staked right away, rather than
+// wait for the other leg of the stake, for example STAKE:BTC.BTC:<btc address>:ASYM
+const asymmetricStakeFlag = "asym"
+
 type StakeMemo struct {
 	MemoBase
 	RuneAmount  string
 	AssetAmount string
 	Address     common.Address
+	Asymmetric  bool
+	// affiliate earn the given basis points of the stake, skimmed in RUNE
+	AffiliateAddress     common.Address
+	AffiliateBasisPoints sdk.Uint
 }
 
 type UnstakeMemo struct {
@@ -179,10 +193,23 @@ type UnstakeMemo struct {
 	Amount string
 }
 
+// limitOrderFlag is the optional last part of a swap memo, to keep the swap in the queue until its trade target can be met, or it
+// expires, for example SWAP:BNB.BNB:<bnb address>:<trade target>:LIMIT
+const limitOrderFlag = "limit"
+
+// streamingSwapFlag is the optional last but one part of a swap memo, followed by the number of sub-swaps the swap is split into,
+// for example SWAP:BNB.BNB:<bnb address>:<trade target>:STREAM:10
+const streamingSwapFlag = "stream"
+
 type SwapMemo struct {
 	MemoBase
-	Destination common.Address
-	SlipLimit   sdk.Uint
+	Destination       common.Address
+	SlipLimit         sdk.Uint
+	LimitOrder        bool
+	StreamingQuantity int64
+	// affiliate earn the given basis points of the swap, skimmed in RUNE
+	AffiliateAddress     common.Address
+	AffiliateBasisPoints sdk.Uint
 }
 
 type AdminMemo struct {
@@ -202,9 +229,21 @@ type RefundMemo struct {
 	TxID common.TxID
 }
 
+type CancelMemo struct {
+	MemoBase
+	TxID common.TxID
+}
+
 type BondMemo struct {
+	MemoBase
+	NodeAddress            sdk.AccAddress
+	OperatorFeeBasisPoints int64
+}
+
+type UnbondMemo struct {
 	MemoBase
 	NodeAddress sdk.AccAddress
+	Amount      sdk.Uint
 }
 
 type LeaveMemo struct {
@@ -247,6 +286,13 @@ func NewSwitchMemo(addr common.Address) SwitchMemo {
 	}
 }
 
+func NewCancelMemo(txID common.TxID) CancelMemo {
+	return CancelMemo{
+		MemoBase: MemoBase{TxType: TxCancel},
+		TxID:     txID,
+	}
+}
+
 func NewLeaveMemo() LeaveMemo {
 	return LeaveMemo{
 		MemoBase: MemoBase{TxType: TxLeave},
@@ -268,8 +314,9 @@ func NewRagnarokMemo(blockHeight int64) RagnarokMemo {
 
 func NewStakeMemo(asset common.Asset, addr common.Address) StakeMemo {
 	return StakeMemo{
-		MemoBase: MemoBase{TxType: TxStake, Asset: asset},
-		Address:  addr,
+		MemoBase:             MemoBase{TxType: TxStake, Asset: asset},
+		Address:              addr,
+		AffiliateBasisPoints: sdk.ZeroUint(),
 	}
 }
 
@@ -324,19 +371,63 @@ func NewRefundMemo(txID common.TxID) RefundMemo {
 
 func NewBondMemo(addr sdk.AccAddress) BondMemo {
 	return BondMemo{
-		MemoBase:    MemoBase{TxType: TxBond},
+		MemoBase:               MemoBase{TxType: TxBond},
+		NodeAddress:            addr,
+		OperatorFeeBasisPoints: -1,
+	}
+}
+
+func NewUnbondMemo(addr sdk.AccAddress, amount sdk.Uint) UnbondMemo {
+	return UnbondMemo{
+		MemoBase:    MemoBase{TxType: TxUnbond},
 		NodeAddress: addr,
+		Amount:      amount,
 	}
 }
 
 func NewSwapMemo(asset common.Asset, dest common.Address, slip sdk.Uint) SwapMemo {
 	return SwapMemo{
-		MemoBase:    MemoBase{TxType: TxSwap, Asset: asset},
-		Destination: dest,
-		SlipLimit:   slip,
+		MemoBase:             MemoBase{TxType: TxSwap, Asset: asset},
+		Destination:          dest,
+		SlipLimit:            slip,
+		AffiliateBasisPoints: sdk.ZeroUint(),
 	}
 }
 
+// affiliateMemoIndex is where the affiliate address and fee basis points are in swap and stake memos, the parts before it a memo
+// doesn't use are left empty
+const affiliateMemoIndex = 6
+
+// checkUnusedMemoParts return an error when any of the memo parts from index from up to index to is not empty, as the memo
+// doesn't use them
+func checkUnusedMemoParts(parts []string, from, to int) error {
+	for i := from; i < to && i < len(parts); i++ {
+		if len(parts[i]) > 0 {
+			return fmt.Errorf("memo part %d:%s is not used, it must be empty", i, parts[i])
+		}
+	}
+	return nil
+}
+
+// parseAffiliate parse the affiliate address and fee basis points that can be appended to swap and stake memos
+func parseAffiliate(parts []string) (common.Address, sdk.Uint, error) {
+	if err := checkUnusedMemoParts(parts, 2, len(parts)); err != nil {
+		return common.NoAddress, sdk.ZeroUint(), err
+	}
+	if len(parts) < 2 {
+		return common.NoAddress, sdk.ZeroUint(), errors.New("affiliate fee basis points is missing")
+	}
+	addr, err := common.NewAddress(parts[0])
+	if err != nil {
+		return common.NoAddress, sdk.ZeroUint(), fmt.Errorf("affiliate address:%s is invalid: %w", parts[0], err)
+	}
+	bps, err := sdk.ParseUint(parts[1])
+	if err != nil {
+		return common.NoAddress, sdk.ZeroUint(), fmt.Errorf("affiliate fee basis points:%s is invalid", parts[1])
+	}
+	return addr, bps, nil
+}
+
 func ParseMemo(memo string) (Memo, error) {
 	var err error
 	noMemo := MemoBase{}
@@ -351,9 +442,9 @@ func ParseMemo(memo string) (Memo, error) {
 
 	// list of memo types that do not contain an asset in their memo
 	noAssetMemos := []TxType{
-		TxOutbound, TxBond, TxLeave, TxRefund,
+		TxOutbound, TxBond, TxUnbond, TxLeave, TxRefund,
 		TxYggdrasilFund, TxYggdrasilReturn, TxReserve,
-		TxMigrate, TxRagnarok, TxSwitch,
+		TxMigrate, TxRagnarok, TxSwitch, TxCancel,
 	}
 	hasAsset := true
 	for _, memoType := range noAssetMemos {
@@ -392,7 +483,23 @@ func ParseMemo(memo string) (Memo, error) {
 				return noMemo, err
 			}
 		}
-		return NewStakeMemo(asset, addr), nil
+		stakeMemo := NewStakeMemo(asset, addr)
+		if len(parts) > 3 && len(parts[3]) > 0 {
+			if !strings.EqualFold(parts[3], asymmetricStakeFlag) {
+				return noMemo, fmt.Errorf("invalid stake. %s is not a valid stake flag", parts[3])
+			}
+			stakeMemo.Asymmetric = true
+		}
+		if err := checkUnusedMemoParts(parts, 4, affiliateMemoIndex); err != nil {
+			return noMemo, fmt.Errorf("invalid stake. %w", err)
+		}
+		if len(parts) > affiliateMemoIndex {
+			stakeMemo.AffiliateAddress, stakeMemo.AffiliateBasisPoints, err = parseAffiliate(parts[affiliateMemoIndex:])
+			if err != nil {
+				return noMemo, fmt.Errorf("invalid stake. %w", err)
+			}
+		}
+		return stakeMemo, nil
 
 	case TxUnstake:
 		if len(parts) < 2 {
@@ -435,7 +542,41 @@ func ParseMemo(memo string) (Memo, error) {
 
 			slip = amount
 		}
-		return NewSwapMemo(asset, destination, slip), nil
+		swapMemo := NewSwapMemo(asset, destination, slip)
+		// only a streaming swap has a quantity
+		if len(parts) < 5 || !strings.EqualFold(parts[4], streamingSwapFlag) {
+			if err := checkUnusedMemoParts(parts, 5, affiliateMemoIndex); err != nil {
+				return noMemo, fmt.Errorf("invalid swap. %w", err)
+			}
+		}
+		if len(parts) > 4 && len(parts[4]) > 0 {
+			switch {
+			case strings.EqualFold(parts[4], limitOrderFlag):
+				// a limit order without a trade target would be executed right away, so it is not a limit order at all
+				if slip.IsZero() {
+					return noMemo, errors.New("invalid swap. limit order must have a trade target")
+				}
+				swapMemo.LimitOrder = true
+			case strings.EqualFold(parts[4], streamingSwapFlag):
+				if len(parts) < 6 {
+					return noMemo, errors.New("invalid swap. streaming swap must have a quantity")
+				}
+				quantity, err := strconv.ParseInt(parts[5], 10, 64)
+				if err != nil || quantity < 2 {
+					return noMemo, fmt.Errorf("invalid swap. streaming quantity:%s is invalid", parts[5])
+				}
+				swapMemo.StreamingQuantity = quantity
+			default:
+				return noMemo, fmt.Errorf("invalid swap. %s is not a valid swap flag", parts[4])
+			}
+		}
+		if len(parts) > affiliateMemoIndex {
+			swapMemo.AffiliateAddress, swapMemo.AffiliateBasisPoints, err = parseAffiliate(parts[affiliateMemoIndex:])
+			if err != nil {
+				return noMemo, fmt.Errorf("invalid swap. %w", err)
+			}
+		}
+		return swapMemo, nil
 	case TxOutbound:
 		if len(parts) < 2 {
 			return noMemo, fmt.Errorf("not enough parameters")
@@ -448,6 +589,12 @@ func ParseMemo(memo string) (Memo, error) {
 		}
 		txID, err := common.NewTxID(parts[1])
 		return NewRefundMemo(txID), err
+	case TxCancel:
+		if len(parts) < 2 {
+			return noMemo, fmt.Errorf("not enough parameters")
+		}
+		txID, err := common.NewTxID(parts[1])
+		return NewCancelMemo(txID), err
 	case TxBond:
 		if len(parts) < 2 {
 			return noMemo, fmt.Errorf("not enough parameters")
@@ -456,7 +603,27 @@ func ParseMemo(memo string) (Memo, error) {
 		if err != nil {
 			return noMemo, fmt.Errorf("%s is an invalid thorchain address: %w", parts[1], err)
 		}
-		return NewBondMemo(addr), nil
+		bondMemo := NewBondMemo(addr)
+		if len(parts) > 2 && len(parts[2]) > 0 {
+			bondMemo.OperatorFeeBasisPoints, err = strconv.ParseInt(parts[2], 10, 64)
+			if err != nil || bondMemo.OperatorFeeBasisPoints < 0 {
+				return noMemo, fmt.Errorf("operator fee basis points:%s is invalid", parts[2])
+			}
+		}
+		return bondMemo, nil
+	case TxUnbond:
+		if len(parts) < 3 {
+			return noMemo, fmt.Errorf("not enough parameters")
+		}
+		addr, err := sdk.AccAddressFromBech32(parts[1])
+		if err != nil {
+			return noMemo, fmt.Errorf("%s is an invalid thorchain address: %w", parts[1], err)
+		}
+		amount, err := sdk.ParseUint(parts[2])
+		if err != nil {
+			return noMemo, fmt.Errorf("unbond amount:%s is invalid: %w", parts[2], err)
+		}
+		return NewUnbondMemo(addr, amount), nil
 	case TxYggdrasilFund:
 		if len(parts) < 2 {
 			return noMemo, errors.New("not enough parameters")
@@ -537,8 +704,10 @@ func (m SwapMemo) GetSlipLimit() sdk.Uint          { return m.SlipLimit }
 func (m AdminMemo) GetKey() string                 { return m.Key }
 func (m AdminMemo) GetValue() string               { return m.Value }
 func (m BondMemo) GetAccAddress() sdk.AccAddress   { return m.NodeAddress }
+func (m UnbondMemo) GetAccAddress() sdk.AccAddress { return m.NodeAddress }
 func (m StakeMemo) GetDestination() common.Address { return m.Address }
 func (m OutboundMemo) GetTxID() common.TxID        { return m.TxID }
+func (m CancelMemo) GetTxID() common.TxID          { return m.TxID }
 func (m OutboundMemo) String() string {
 	return fmt.Sprintf("OUTBOUND:%s", m.TxID.String())
 }
@@ -551,6 +720,60 @@ func (m RefundMemo) String() string {
 	return fmt.Sprintf("REFUND:%s", m.TxID.String())
 }
 
+// String implement fmt.Stringer, the optional parts are left out when they are not set
+func (m SwapMemo) String() string {
+	parts := []string{"SWAP", m.Asset.String(), m.Destination.String(), "", "", "", "", ""}
+	if !m.SlipLimit.IsZero() {
+		parts[3] = m.SlipLimit.String()
+	}
+	if m.LimitOrder {
+		parts[4] = limitOrderFlag
+	}
+	if m.StreamingQuantity > 1 {
+		parts[4] = streamingSwapFlag
+		parts[5] = strconv.FormatInt(m.StreamingQuantity, 10)
+	}
+	if !m.AffiliateAddress.IsEmpty() {
+		parts[affiliateMemoIndex] = m.AffiliateAddress.String()
+		parts[affiliateMemoIndex+1] = m.AffiliateBasisPoints.String()
+	}
+	return joinMemoParts(parts)
+}
+
+// String implement fmt.Stringer, the optional parts are left out when they are not set
+func (m StakeMemo) String() string {
+	parts := []string{"STAKE", m.Asset.String(), m.Address.String(), "", "", "", "", ""}
+	if m.Asymmetric {
+		parts[3] = asymmetricStakeFlag
+	}
+	if !m.AffiliateAddress.IsEmpty() {
+		parts[affiliateMemoIndex] = m.AffiliateAddress.String()
+		parts[affiliateMemoIndex+1] = m.AffiliateBasisPoints.String()
+	}
+	return joinMemoParts(parts)
+}
+
+// String implement fmt.Stringer
+func (m UnstakeMemo) String() string {
+	return joinMemoParts([]string{"WITHDRAW", m.Asset.String(), m.Amount})
+}
+
+// joinMemoParts join the given parts into a memo, empty parts at the end are dropped
+func joinMemoParts(parts []string) string {
+	for len(parts) > 0 && len(parts[len(parts)-1]) == 0 {
+		parts = parts[:len(parts)-1]
+	}
+	return strings.Join(parts, ":")
+}
+
+func (m UnbondMemo) String() string {
+	return fmt.Sprintf("UNBOND:%s:%s", m.NodeAddress.String(), m.Amount.String())
+}
+
+func (m CancelMemo) String() string {
+	return fmt.Sprintf("CANCEL:%s", m.TxID.String())
+}
+
 func (m YggdrasilFundMemo) String() string {
 	return fmt.Sprintf("YGGDRASIL+:%d", m.BlockHeight)
 }
@@ -5,6 +5,8 @@ import (
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	. "gopkg.in/check.v1"
+
+	"gitlab.com/thorchain/thornode/common"
 )
 
 type MemoSuite struct{}
@@ -16,7 +18,7 @@ func (s *MemoSuite) SetUpSuite(c *C) {
 }
 
 func (s *MemoSuite) TestTxType(c *C) {
-	for _, trans := range []TxType{TxStake, TxUnstake, TxSwap, TxOutbound, TxAdd, TxBond, TxLeave, TxSwitch} {
+	for _, trans := range []TxType{TxStake, TxUnstake, TxSwap, TxOutbound, TxAdd, TxBond, TxUnbond, TxLeave, TxSwitch, TxCancel} {
 		tx, err := StringToTxType(trans.String())
 		c.Assert(err, IsNil)
 		c.Check(tx, Equals, trans)
@@ -78,6 +80,14 @@ func (s *MemoSuite) TestParseWithAbbreviated(c *C) {
 	c.Check(memo.IsType(TxRefund), Equals, true)
 	c.Check(memo.IsOutbound(), Equals, true)
 
+	memo, err = ParseMemo("CANCEL:MUKVQILIHIAUSEOVAXBFEZAJKYHFJYHRUUYGQJZGFYBYVXCXYNEMUOAIQKFQLLCX")
+	c.Assert(err, IsNil)
+	c.Check(memo.IsType(TxCancel), Equals, true)
+	c.Check(memo.IsInbound(), Equals, true)
+	c.Check(memo.GetTxID().String(), Equals, "MUKVQILIHIAUSEOVAXBFEZAJKYHFJYHRUUYGQJZGFYBYVXCXYNEMUOAIQKFQLLCX")
+	_, err = ParseMemo("CANCEL")
+	c.Assert(err, NotNil)
+
 	memo, err = ParseMemo("leave:whatever")
 	c.Assert(err, IsNil)
 	c.Check(memo.IsType(TxLeave), Equals, true)
@@ -155,6 +165,27 @@ func (s *MemoSuite) TestParse(c *C) {
 	c.Assert(err, IsNil)
 	c.Check(memo.GetDestination().String(), Equals, "bc1qwqdg6squsna38e46795at95yu9atm8azzmyvckulcc7kytlcckxswvvzej")
 	c.Check(memo.IsType(TxStake), Equals, true, Commentf("MEMO: %+v", memo))
+	c.Check(memo.(StakeMemo).Asymmetric, Equals, false)
+	memo, err = ParseMemo("STAKE:BTC.BTC:bc1qwqdg6squsna38e46795at95yu9atm8azzmyvckulcc7kytlcckxswvvzej:ASYM")
+	c.Assert(err, IsNil)
+	c.Check(memo.IsType(TxStake), Equals, true, Commentf("MEMO: %+v", memo))
+	c.Check(memo.(StakeMemo).Asymmetric, Equals, true)
+	_, err = ParseMemo("STAKE:BTC.BTC:bc1qwqdg6squsna38e46795at95yu9atm8azzmyvckulcc7kytlcckxswvvzej:whatever")
+	c.Assert(err, NotNil)
+	memo, err = ParseMemo("STAKE:BTC.BTC:bc1qwqdg6squsna38e46795at95yu9atm8azzmyvckulcc7kytlcckxswvvzej::::bnb1lejrrtta9cgr49fuh7ktu3sddhe0ff7wenlpn6:30")
+	c.Assert(err, IsNil)
+	c.Check(memo.(StakeMemo).Asymmetric, Equals, false)
+	c.Check(memo.(StakeMemo).AffiliateAddress.String(), Equals, "bnb1lejrrtta9cgr49fuh7ktu3sddhe0ff7wenlpn6")
+	c.Check(memo.(StakeMemo).AffiliateBasisPoints.Equal(sdk.NewUint(30)), Equals, true)
+	_, err = ParseMemo("STAKE:BTC.BTC:bc1qwqdg6squsna38e46795at95yu9atm8azzmyvckulcc7kytlcckxswvvzej:ASYM:::bnb1lejrrtta9cgr49fuh7ktu3sddhe0ff7wenlpn6")
+	c.Assert(err, NotNil)
+	_, err = ParseMemo("STAKE:BTC.BTC:bc1qwqdg6squsna38e46795at95yu9atm8azzmyvckulcc7kytlcckxswvvzej:ASYM:::bnb1lejrrtta9cgr49fuh7ktu3sddhe0ff7wenlpn6:thirty")
+	c.Assert(err, NotNil)
+	// the affiliate is at the same position as in swap memos, the parts in between must be empty
+	_, err = ParseMemo("STAKE:BTC.BTC:bc1qwqdg6squsna38e46795at95yu9atm8azzmyvckulcc7kytlcckxswvvzej:ASYM:bnb1lejrrtta9cgr49fuh7ktu3sddhe0ff7wenlpn6:30")
+	c.Assert(err, NotNil)
+	_, err = ParseMemo("STAKE:BTC.BTC:bc1qwqdg6squsna38e46795at95yu9atm8azzmyvckulcc7kytlcckxswvvzej:ASYM:::bnb1lejrrtta9cgr49fuh7ktu3sddhe0ff7wenlpn6:30:whatever")
+	c.Assert(err, NotNil)
 
 	memo, err = ParseMemo("WITHDRAW:BNB.RUNE-1BA:25")
 	c.Assert(err, IsNil)
@@ -176,6 +207,49 @@ func (s *MemoSuite) TestParse(c *C) {
 	c.Check(memo.IsType(TxSwap), Equals, true, Commentf("MEMO: %+v", memo))
 	c.Check(memo.GetDestination().String(), Equals, "bnb1lejrrtta9cgr49fuh7ktu3sddhe0ff7wenlpn6")
 	c.Check(memo.GetSlipLimit().Uint64(), Equals, uint64(0))
+	c.Check(memo.(SwapMemo).LimitOrder, Equals, false)
+
+	memo, err = ParseMemo("SWAP:BNB.RUNE-1BA:bnb1lejrrtta9cgr49fuh7ktu3sddhe0ff7wenlpn6:870000000:LIMIT")
+	c.Assert(err, IsNil)
+	c.Check(memo.IsType(TxSwap), Equals, true, Commentf("MEMO: %+v", memo))
+	c.Check(memo.GetSlipLimit().Equal(sdk.NewUint(870000000)), Equals, true)
+	c.Check(memo.(SwapMemo).LimitOrder, Equals, true)
+	// limit order need a trade target
+	_, err = ParseMemo("SWAP:BNB.RUNE-1BA:bnb1lejrrtta9cgr49fuh7ktu3sddhe0ff7wenlpn6::LIMIT")
+	c.Assert(err, NotNil)
+	_, err = ParseMemo("SWAP:BNB.RUNE-1BA:bnb1lejrrtta9cgr49fuh7ktu3sddhe0ff7wenlpn6:870000000:whatever")
+	c.Assert(err, NotNil)
+	// only streaming swaps have a quantity
+	_, err = ParseMemo("SWAP:BNB.RUNE-1BA:bnb1lejrrtta9cgr49fuh7ktu3sddhe0ff7wenlpn6:870000000:LIMIT:10")
+	c.Assert(err, NotNil)
+	_, err = ParseMemo("SWAP:BNB.RUNE-1BA:bnb1lejrrtta9cgr49fuh7ktu3sddhe0ff7wenlpn6:870000000::10")
+	c.Assert(err, NotNil)
+
+	memo, err = ParseMemo("SWAP:BNB.RUNE-1BA:bnb1lejrrtta9cgr49fuh7ktu3sddhe0ff7wenlpn6::STREAM:10")
+	c.Assert(err, IsNil)
+	c.Check(memo.IsType(TxSwap), Equals, true, Commentf("MEMO: %+v", memo))
+	c.Check(memo.(SwapMemo).StreamingQuantity, Equals, int64(10))
+	c.Check(memo.(SwapMemo).LimitOrder, Equals, false)
+	_, err = ParseMemo("SWAP:BNB.RUNE-1BA:bnb1lejrrtta9cgr49fuh7ktu3sddhe0ff7wenlpn6::STREAM")
+	c.Assert(err, NotNil)
+	_, err = ParseMemo("SWAP:BNB.RUNE-1BA:bnb1lejrrtta9cgr49fuh7ktu3sddhe0ff7wenlpn6::STREAM:1")
+	c.Assert(err, NotNil)
+	_, err = ParseMemo("SWAP:BNB.RUNE-1BA:bnb1lejrrtta9cgr49fuh7ktu3sddhe0ff7wenlpn6::STREAM:ten")
+	c.Assert(err, NotNil)
+
+	memo, err = ParseMemo("SWAP:BNB.RUNE-1BA:bnb1lejrrtta9cgr49fuh7ktu3sddhe0ff7wenlpn6::STREAM:10:bnb1lejrrtta9cgr49fuh7ktu3sddhe0ff7wenlpn6:30")
+	c.Assert(err, IsNil)
+	c.Check(memo.(SwapMemo).StreamingQuantity, Equals, int64(10))
+	c.Check(memo.(SwapMemo).AffiliateAddress.String(), Equals, "bnb1lejrrtta9cgr49fuh7ktu3sddhe0ff7wenlpn6")
+	c.Check(memo.(SwapMemo).AffiliateBasisPoints.Equal(sdk.NewUint(30)), Equals, true)
+	memo, err = ParseMemo("SWAP:BNB.RUNE-1BA:bnb1lejrrtta9cgr49fuh7ktu3sddhe0ff7wenlpn6::::bnb1lejrrtta9cgr49fuh7ktu3sddhe0ff7wenlpn6:30")
+	c.Assert(err, IsNil)
+	c.Check(memo.(SwapMemo).LimitOrder, Equals, false)
+	c.Check(memo.(SwapMemo).AffiliateBasisPoints.Equal(sdk.NewUint(30)), Equals, true)
+	_, err = ParseMemo("SWAP:BNB.RUNE-1BA:bnb1lejrrtta9cgr49fuh7ktu3sddhe0ff7wenlpn6::::bnb1lejrrtta9cgr49fuh7ktu3sddhe0ff7wenlpn6")
+	c.Assert(err, NotNil)
+	_, err = ParseMemo("SWAP:BNB.RUNE-1BA:bnb1lejrrtta9cgr49fuh7ktu3sddhe0ff7wenlpn6::::notanaddress:30")
+	c.Assert(err, NotNil)
 
 	memo, err = ParseMemo("SWAP:BNB.RUNE-1BA:bnb1lejrrtta9cgr49fuh7ktu3sddhe0ff7wenlpn6:")
 	c.Assert(err, IsNil)
@@ -189,6 +263,24 @@ func (s *MemoSuite) TestParse(c *C) {
 	c.Assert(err, IsNil)
 	c.Assert(memo.IsType(TxBond), Equals, true)
 	c.Assert(memo.GetAccAddress().String(), Equals, whiteListAddr.String())
+	c.Check(memo.(BondMemo).OperatorFeeBasisPoints, Equals, int64(-1))
+
+	memo, err = ParseMemo("bond:" + whiteListAddr.String() + ":500")
+	c.Assert(err, IsNil)
+	c.Check(memo.(BondMemo).OperatorFeeBasisPoints, Equals, int64(500))
+	_, err = ParseMemo("bond:" + whiteListAddr.String() + ":-5")
+	c.Assert(err, NotNil)
+
+	memo, err = ParseMemo("unbond:" + whiteListAddr.String() + ":100")
+	c.Assert(err, IsNil)
+	c.Assert(memo.IsType(TxUnbond), Equals, true)
+	c.Check(memo.GetAccAddress().String(), Equals, whiteListAddr.String())
+	c.Check(memo.(UnbondMemo).Amount.Equal(sdk.NewUint(100)), Equals, true)
+	c.Check(memo.(UnbondMemo).String(), Equals, "UNBOND:"+whiteListAddr.String()+":100")
+	_, err = ParseMemo("unbond:" + whiteListAddr.String())
+	c.Assert(err, NotNil)
+	_, err = ParseMemo("unbond:" + whiteListAddr.String() + ":abc")
+	c.Assert(err, NotNil)
 
 	memo, err = ParseMemo("leave")
 	c.Assert(err, IsNil)
@@ -224,3 +316,36 @@ func (s *MemoSuite) TestParse(c *C) {
 	_, err = ParseMemo("migrate:abc")
 	c.Assert(err, NotNil)
 }
+
+func (s *MemoSuite) TestMemoString(c *C) {
+	dest := GetRandomBNBAddress()
+	swapMemo := NewSwapMemo(common.BNBAsset, dest, sdk.NewUint(100))
+	c.Check(swapMemo.String(), Equals, "SWAP:BNB.BNB:"+dest.String()+":100")
+	swapMemo.SlipLimit = sdk.ZeroUint()
+	swapMemo.StreamingQuantity = 5
+	swapMemo.AffiliateAddress = dest
+	swapMemo.AffiliateBasisPoints = sdk.NewUint(20)
+	c.Check(swapMemo.String(), Equals, "SWAP:BNB.BNB:"+dest.String()+"::stream:5:"+dest.String()+":20")
+	memo, err := ParseMemo(swapMemo.String())
+	c.Assert(err, IsNil)
+	c.Check(memo.(SwapMemo).StreamingQuantity, Equals, int64(5))
+	c.Check(memo.(SwapMemo).AffiliateBasisPoints.Equal(sdk.NewUint(20)), Equals, true)
+
+	stakeMemo := NewStakeMemo(common.BNBAsset, "")
+	c.Check(stakeMemo.String(), Equals, "STAKE:BNB.BNB")
+	stakeMemo.Asymmetric = true
+	memo, err = ParseMemo(stakeMemo.String())
+	c.Assert(err, IsNil)
+	c.Check(memo.(StakeMemo).Asymmetric, Equals, true)
+	stakeMemo.AffiliateAddress = dest
+	stakeMemo.AffiliateBasisPoints = sdk.NewUint(20)
+	c.Check(stakeMemo.String(), Equals, "STAKE:BNB.BNB::asym:::"+dest.String()+":20")
+	memo, err = ParseMemo(stakeMemo.String())
+	c.Assert(err, IsNil)
+	c.Check(memo.(StakeMemo).AffiliateAddress.Equals(dest), Equals, true)
+
+	unstakeMemo := NewUnstakeMemo(common.BNBAsset, "2500")
+	memo, err = ParseMemo(unstakeMemo.String())
+	c.Assert(err, IsNil)
+	c.Check(memo.(UnstakeMemo).GetAmount(), Equals, "2500")
+}
@@ -107,7 +107,9 @@ func (AppModule) Name() string {
 	return ModuleName
 }
 
-func (am AppModule) RegisterInvariants(ir sdk.InvariantRegistry) {}
+func (am AppModule) RegisterInvariants(ir sdk.InvariantRegistry) {
+	RegisterInvariants(ir, am.keeper)
+}
 
 func (am AppModule) Route() string {
 	return RouterKey
@@ -185,6 +187,11 @@ func (am AppModule) EndBlock(ctx sdk.Context, req abci.RequestEndBlock) []abci.V
 		return nil
 	}
 
+	// refund or commit RUNE that waited too long for the asset leg of a stake
+	if err := processStalePendingRune(ctx, am.keeper, txStore, eventMgr, constantValues); err != nil {
+		ctx.Logger().Error("fail to process stale pending rune", "error", err)
+	}
+
 	swapQueue, err := NewVersionedSwapQ(am.txOutStore, am.versionedEventManager).GetSwapQueue(ctx, am.keeper, version)
 	if err != nil {
 		ctx.Logger().Error("fail to get swap queue", "error", err)
@@ -206,6 +213,9 @@ func (am AppModule) EndBlock(ctx sdk.Context, req abci.RequestEndBlock) []abci.V
 	if err := slasher.LackSigning(ctx, constantValues, txStore); err != nil {
 		ctx.Logger().Error("Unable to slash for lack of signing:", "error", err)
 	}
+	if err := slasher.DecaySlashPoints(ctx, constantValues); err != nil {
+		ctx.Logger().Error("Unable to decay slash points:", "error", err)
+	}
 	newPoolCycle := constantValues.GetInt64Value(constants.NewPoolCycle)
 	// Enable a pool every newPoolCycle
 	if ctx.BlockHeight()%newPoolCycle == 0 {
@@ -262,6 +272,10 @@ func (am AppModule) EndBlock(ctx sdk.Context, req abci.RequestEndBlock) []abci.V
 		ctx.Logger().Error("unable to fund yggdrasil", "error", err)
 	}
 	gasMgr.EndBlock(ctx, am.keeper, eventMgr)
+	if err := pruneTWAPHistory(ctx, am.keeper, constantValues); err != nil {
+		ctx.Logger().Error("fail to prune pool price history", "error", err)
+	}
+	checkInvariantsAndHalt(ctx, am.keeper)
 
 	return validators
 }
@@ -0,0 +1,115 @@
+package thorchain
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"gitlab.com/thorchain/thornode/common"
+	"gitlab.com/thorchain/thornode/constants"
+	"gitlab.com/thorchain/thornode/x/thorchain/keep"
+)
+
+// processStalePendingRune deal with RUNE that had waited for the asset leg of a stake for longer than PendingRuneTimeout blocks
+// when AutoCommitPendingRune is on and the pool has depth, the RUNE is committed to the pool as an asymmetric stake, otherwise it is
+// refunded to the staker
+func processStalePendingRune(ctx sdk.Context, keeper keep.Keeper, txStore TxOutStore, eventMgr EventManager, constAccessor constants.ConstantValues) error {
+	timeout := constAccessor.GetInt64Value(constants.PendingRuneTimeout)
+	if mimirTimeout, err := keeper.GetMimir(ctx, constants.PendingRuneTimeout.String()); err == nil && mimirTimeout > 0 {
+		timeout = mimirTimeout
+	}
+	if timeout <= 0 {
+		return nil
+	}
+	autoCommit := constAccessor.GetBoolValue(constants.AutoCommitPendingRune)
+	if mimirAutoCommit, err := keeper.GetMimir(ctx, constants.AutoCommitPendingRune.String()); err == nil && mimirAutoCommit >= 0 {
+		autoCommit = mimirAutoCommit > 0
+	}
+
+	// only the stakers whose RUNE is due are visited, the pending RUNE is indexed by the height it started to wait at
+	stakers, err := keeper.GetStakersWithPendingRune(ctx, ctx.BlockHeight()-timeout)
+	if err != nil {
+		return fmt.Errorf("fail to get stakers with pending rune: %w", err)
+	}
+	for _, staker := range stakers {
+		if autoCommit {
+			committed, err := commitPendingRune(ctx, keeper, eventMgr, staker)
+			if err != nil {
+				ctx.Logger().Error("fail to commit pending rune", "pool", staker.Asset, "staker", staker.RuneAddress, "error", err)
+			}
+			if committed {
+				continue
+			}
+		}
+		if err := refundPendingRune(ctx, keeper, txStore, eventMgr, staker); err != nil {
+			ctx.Logger().Error("fail to refund pending rune", "pool", staker.Asset, "staker", staker.RuneAddress, "error", err)
+		}
+	}
+	return nil
+}
+
+// commitPendingRune stake the pending RUNE of the staker to the pool asymmetrically, it returns false when the pool is not able to take it
+func commitPendingRune(ctx sdk.Context, keeper keep.Keeper, eventMgr EventManager, staker Staker) (bool, error) {
+	pool, err := keeper.GetPool(ctx, staker.Asset)
+	if err != nil {
+		return false, fmt.Errorf("fail to get pool(%s): %w", staker.Asset, err)
+	}
+	// a pool without asset has no price, RUNE can't be staked into it on its own
+	if pool.Status != PoolEnabled || pool.BalanceAsset.IsZero() || pool.BalanceRune.IsZero() {
+		return false, nil
+	}
+	pendingRune := staker.PendingRune
+	txID := staker.PendingTxID
+	staker.PendingRune = sdk.ZeroUint()
+	staker.PendingRuneHeight = 0
+	staker.PendingTxID = ""
+	stakeUnits, sdkErr := stakeToPool(ctx, keeper, pool, staker, pendingRune, sdk.ZeroUint())
+	if sdkErr != nil {
+		return false, fmt.Errorf("fail to stake pending rune: %s", sdkErr.Error())
+	}
+	evt := NewEventPendingRuneCommit(staker.Asset, staker.RuneAddress, pendingRune, stakeUnits, txID)
+	if err := eventMgr.EmitPendingRuneCommitEvent(ctx, evt); err != nil {
+		return true, fmt.Errorf("fail to emit pending rune commit event: %w", err)
+	}
+	return true, nil
+}
+
+// refundPendingRune send the pending RUNE of the staker back to its RUNE address, the RUNE stays pending when the outbound can't be added
+func refundPendingRune(ctx sdk.Context, keeper keep.Keeper, txStore TxOutStore, eventMgr EventManager, staker Staker) error {
+	pendingRune := staker.PendingRune
+	txID := staker.PendingTxID
+	if txID.IsEmpty() {
+		txID = common.BlankTxID
+	}
+	toi := &TxOutItem{
+		Chain:     common.RuneAsset().Chain,
+		InHash:    txID,
+		ToAddress: staker.RuneAddress,
+		Coin:      common.NewCoin(common.RuneAsset(), pendingRune),
+		Memo:      NewRefundMemo(txID).String(),
+	}
+	ok, err := txStore.TryAddTxOutItem(ctx, toi)
+	if err != nil {
+		return fmt.Errorf("fail to prepare outbound tx: %w", err)
+	}
+	if !ok {
+		// the RUNE is not enough to cover the transaction fee, it keeps waiting for the asset, and is tried again after another timeout
+		ctx.Logger().Info("pending rune can't cover the transaction fee", "pool", staker.Asset, "staker", staker.RuneAddress, "rune", pendingRune)
+		staker.PendingRuneHeight = ctx.BlockHeight()
+		keeper.SetStaker(ctx, staker)
+		return nil
+	}
+	staker.PendingRune = sdk.ZeroUint()
+	staker.PendingRuneHeight = 0
+	staker.PendingTxID = ""
+	if staker.Units.IsZero() {
+		keeper.RemoveStaker(ctx, staker)
+	} else {
+		keeper.SetStaker(ctx, staker)
+	}
+	evt := NewEventPendingRuneRefund(staker.Asset, staker.RuneAddress, pendingRune, txID)
+	if err := eventMgr.EmitPendingRuneRefundEvent(ctx, evt); err != nil {
+		return fmt.Errorf("fail to emit pending rune refund event: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,145 @@
+package thorchain
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	. "gopkg.in/check.v1"
+
+	"gitlab.com/thorchain/thornode/common"
+	"gitlab.com/thorchain/thornode/constants"
+	"gitlab.com/thorchain/thornode/x/thorchain/keep"
+	"gitlab.com/thorchain/thornode/x/thorchain/types"
+)
+
+type PendingRuneSuite struct{}
+
+var _ = Suite(&PendingRuneSuite{})
+
+func (s *PendingRuneSuite) setupPendingStaker(ctx sdk.Context, k keep.Keeper, asset common.Asset, height int64) Staker {
+	staker := Staker{
+		Asset:             asset,
+		RuneAddress:       GetRandomRUNEAddress(),
+		AssetAddress:      GetRandomBNBAddress(),
+		LastStakeHeight:   height,
+		Units:             sdk.ZeroUint(),
+		PendingRune:       sdk.NewUint(10 * common.One),
+		PendingRuneHeight: height,
+		PendingTxID:       GetRandomTxHash(),
+	}
+	k.SetStaker(ctx, staker)
+	return staker
+}
+
+func (s *PendingRuneSuite) TestRefundStalePendingRune(c *C) {
+	ctx, k := setupKeeperForTest(c)
+	constAccessor := constants.GetConstantValues(constants.SWVersion)
+	timeout := constAccessor.GetInt64Value(constants.PendingRuneTimeout)
+	pool := NewPool()
+	pool.Asset = common.BTCAsset
+	pool.BalanceRune = sdk.NewUint(100 * common.One)
+	pool.BalanceAsset = sdk.NewUint(100 * common.One)
+	pool.PoolUnits = sdk.NewUint(100 * common.One)
+	pool.Status = PoolEnabled
+	c.Assert(k.SetPool(ctx, pool), IsNil)
+	staker := s.setupPendingStaker(ctx, k, common.BTCAsset, 1)
+	txOutStore := NewTxStoreDummy()
+
+	// not timed out yet
+	ctx = ctx.WithBlockHeight(timeout)
+	c.Assert(processStalePendingRune(ctx, k, txOutStore, NewEventMgr(), constAccessor), IsNil)
+	items, err := txOutStore.GetOutboundItems(ctx)
+	c.Assert(err, IsNil)
+	c.Check(items, HasLen, 0)
+
+	ctx = ctx.WithBlockHeight(timeout + 1)
+	c.Assert(processStalePendingRune(ctx, k, txOutStore, NewEventMgr(), constAccessor), IsNil)
+	items, err = txOutStore.GetOutboundItems(ctx)
+	c.Assert(err, IsNil)
+	c.Assert(items, HasLen, 1)
+	c.Check(items[0].ToAddress.Equals(staker.RuneAddress), Equals, true)
+	c.Check(items[0].Coin.Equals(common.NewCoin(common.RuneAsset(), sdk.NewUint(10*common.One))), Equals, true)
+	c.Check(items[0].InHash.Equals(staker.PendingTxID), Equals, true)
+	su, err := k.GetStaker(ctx, common.BTCAsset, staker.RuneAddress)
+	c.Assert(err, IsNil)
+	c.Check(su.PendingRune.IsZero(), Equals, true)
+
+	found := false
+	for _, evt := range ctx.EventManager().Events() {
+		if evt.Type == types.PendingRuneRefundEventType {
+			found = true
+		}
+	}
+	c.Check(found, Equals, true)
+	p, err := k.GetPool(ctx, common.BTCAsset)
+	c.Assert(err, IsNil)
+	c.Check(p.BalanceRune.Equal(pool.BalanceRune), Equals, true)
+}
+
+func (s *PendingRuneSuite) TestCommitStalePendingRune(c *C) {
+	ctx, k := setupKeeperForTest(c)
+	constAccessor := constants.GetConstantValues(constants.SWVersion)
+	k.SetMimir(ctx, constants.PendingRuneTimeout.String(), 10)
+	k.SetMimir(ctx, constants.AutoCommitPendingRune.String(), 1)
+	pool := NewPool()
+	pool.Asset = common.BTCAsset
+	pool.BalanceRune = sdk.NewUint(100 * common.One)
+	pool.BalanceAsset = sdk.NewUint(100 * common.One)
+	pool.PoolUnits = sdk.NewUint(100 * common.One)
+	pool.Status = PoolEnabled
+	c.Assert(k.SetPool(ctx, pool), IsNil)
+	staker := s.setupPendingStaker(ctx, k, common.BTCAsset, 1)
+	txOutStore := NewTxStoreDummy()
+
+	ctx = ctx.WithBlockHeight(11)
+	c.Assert(processStalePendingRune(ctx, k, txOutStore, NewEventMgr(), constAccessor), IsNil)
+	items, err := txOutStore.GetOutboundItems(ctx)
+	c.Assert(err, IsNil)
+	c.Check(items, HasLen, 0)
+	su, err := k.GetStaker(ctx, common.BTCAsset, staker.RuneAddress)
+	c.Assert(err, IsNil)
+	c.Check(su.PendingRune.IsZero(), Equals, true)
+	c.Check(su.Units.IsZero(), Equals, false)
+	p, err := k.GetPool(ctx, common.BTCAsset)
+	c.Assert(err, IsNil)
+	c.Check(p.BalanceRune.Equal(sdk.NewUint(110*common.One)), Equals, true)
+	c.Check(p.PoolUnits.Equal(pool.PoolUnits.Add(su.Units)), Equals, true)
+
+	// pool without depth can't take the RUNE, so it is refunded
+	pool.Asset = common.BCHAsset
+	pool.BalanceRune = sdk.ZeroUint()
+	pool.BalanceAsset = sdk.ZeroUint()
+	pool.PoolUnits = sdk.ZeroUint()
+	c.Assert(k.SetPool(ctx, pool), IsNil)
+	staker = s.setupPendingStaker(ctx, k, common.BCHAsset, 1)
+	c.Assert(processStalePendingRune(ctx, k, txOutStore, NewEventMgr(), constAccessor), IsNil)
+	items, err = txOutStore.GetOutboundItems(ctx)
+	c.Assert(err, IsNil)
+	c.Assert(items, HasLen, 1)
+	c.Check(items[0].ToAddress.Equals(staker.RuneAddress), Equals, true)
+}
+
+func (s *PendingRuneSuite) TestRefundPendingRuneNotAdded(c *C) {
+	ctx, k := setupKeeperForTest(c)
+	constAccessor := constants.GetConstantValues(constants.SWVersion)
+	k.SetMimir(ctx, constants.PendingRuneTimeout.String(), 10)
+	staker := s.setupPendingStaker(ctx, k, common.BTCAsset, 1)
+	txOutStore := &TxOutStoreNotAddedDummy{TxOutStoreDummy: NewTxStoreDummy()}
+
+	ctx = ctx.WithBlockHeight(11)
+	c.Assert(processStalePendingRune(ctx, k, txOutStore, NewEventMgr(), constAccessor), IsNil)
+	su, err := k.GetStaker(ctx, common.BTCAsset, staker.RuneAddress)
+	c.Assert(err, IsNil)
+	c.Check(su.PendingRune.Equal(staker.PendingRune), Equals, true)
+	c.Check(su.PendingTxID.Equals(staker.PendingTxID), Equals, true)
+	c.Check(su.PendingRuneHeight, Equals, int64(11))
+	for _, evt := range ctx.EventManager().Events() {
+		c.Check(evt.Type, Not(Equals), types.PendingRuneRefundEventType)
+	}
+
+	// the RUNE is not visited again until another timeout passed
+	stakers, err := k.GetStakersWithPendingRune(ctx.WithBlockHeight(20), 20-10)
+	c.Assert(err, IsNil)
+	c.Check(stakers, HasLen, 0)
+	stakers, err = k.GetStakersWithPendingRune(ctx.WithBlockHeight(21), 21-10)
+	c.Assert(err, IsNil)
+	c.Check(stakers, HasLen, 1)
+}
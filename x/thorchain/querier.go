@@ -1,11 +1,13 @@
 package thorchain
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"net/url"
 	"strconv"
 
+	"github.com/blang/semver"
 	"github.com/cosmos/cosmos-sdk/codec"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
@@ -51,6 +53,12 @@ func NewQuerier(keeper keep.Keeper, validatorMgr VersionedValidatorManager) sdk.
 			return queryObserver(ctx, path[1:], req, keeper)
 		case q.QueryNodeAccount.Key:
 			return queryNodeAccount(ctx, path[1:], req, keeper)
+		case q.QueryNodeAccountSlashes.Key:
+			return queryNodeAccountSlashes(ctx, path[1:], req, keeper)
+		case q.QueryNodeScorecard.Key:
+			return queryNodeScorecard(ctx, path[1:], req, keeper)
+		case q.QueryChurnPreview.Key:
+			return queryChurnPreview(ctx, req, keeper, validatorMgr)
 		case q.QueryNodeAccounts.Key:
 			return queryNodeAccounts(ctx, path[1:], req, keeper)
 		case q.QueryPoolAddresses.Key:
@@ -71,6 +79,24 @@ func NewQuerier(keeper keep.Keeper, validatorMgr VersionedValidatorManager) sdk.
 			return queryMimirValues(ctx, path[1:], req, keeper)
 		case q.QueryBan.Key:
 			return queryBan(ctx, path[1:], req, keeper)
+		case q.QueryInvariants.Key:
+			return queryInvariants(ctx, keeper)
+		case q.QueryLimitOrdersPool.Key:
+			return queryLimitOrdersByPool(ctx, path[1:], req, keeper)
+		case q.QueryLimitOrdersAddress.Key:
+			return queryLimitOrdersByAddress(ctx, path[1:], req, keeper)
+		case q.QueryQuoteSwap.Key:
+			return queryQuoteSwap(ctx, path[1:], req, keeper)
+		case q.QueryQuoteStake.Key:
+			return queryQuoteStake(ctx, path[1:], req, keeper)
+		case q.QueryQuoteUnstake.Key:
+			return queryQuoteUnstake(ctx, path[1:], req, keeper)
+		case q.QueryPoolTWAP.Key:
+			return queryPoolTWAP(ctx, path[1:], req, keeper)
+		case q.QueryPoolsTWAP.Key:
+			return queryPoolsTWAP(ctx, path[1:], req, keeper)
+		case q.QueryStakerPosition.Key:
+			return queryStakerPosition(ctx, path[1:], req, keeper)
 		default:
 			return nil, sdk.ErrUnknownRequest(
 				fmt.Sprintf("unknown thorchain query endpoint: %s", path[0]),
@@ -218,6 +244,15 @@ func queryVaultData(ctx sdk.Context, keeper keep.Keeper) ([]byte, sdk.Error) {
 	return res, nil
 }
 
+func queryInvariants(ctx sdk.Context, keeper keep.Keeper) ([]byte, sdk.Error) {
+	res, err := codec.MarshalJSONIndent(keeper.Cdc(), CheckInvariants(ctx, keeper))
+	if err != nil {
+		ctx.Logger().Error("fail to marshal invariants to json", "error", err)
+		return nil, sdk.ErrInternal("fail to marshal response to json")
+	}
+	return res, nil
+}
+
 func queryPoolAddresses(ctx sdk.Context, path []string, req abci.RequestQuery, keeper keep.Keeper) ([]byte, sdk.Error) {
 	active, err := keeper.GetAsgardVaultsByStatus(ctx, ActiveVault)
 	if err != nil {
@@ -298,6 +333,213 @@ func queryNodeAccount(ctx sdk.Context, path []string, req abci.RequestQuery, kee
 	return res, nil
 }
 
+func queryNodeAccountSlashes(ctx sdk.Context, path []string, req abci.RequestQuery, keeper keep.Keeper) ([]byte, sdk.Error) {
+	if len(path) == 0 {
+		return nil, sdk.ErrUnknownRequest("node address is empty")
+	}
+	addr, err := sdk.AccAddressFromBech32(path[0])
+	if err != nil {
+		return nil, sdk.ErrUnknownRequest("invalid account address")
+	}
+
+	slashPts, err := keeper.GetNodeAccountSlashPoints(ctx, addr)
+	if err != nil {
+		return nil, sdk.ErrInternal("fail to get node slash points")
+	}
+	slashes, err := keeper.GetNodeAccountSlashes(ctx, addr)
+	if err != nil {
+		return nil, sdk.ErrInternal("fail to get node slashes")
+	}
+	// records older than the ledger window are only pruned when the node account gets slashed again
+	constAccessor := constants.GetConstantValues(keeper.GetLowestActiveVersion(ctx))
+	records, err := keeper.GetSlashRecords(ctx, addr, ctx.BlockHeight()-constAccessor.GetInt64Value(constants.SlashLedgerBlocks))
+	if err != nil {
+		return nil, sdk.ErrInternal("fail to get node slash records")
+	}
+
+	res, err := codec.MarshalJSONIndent(keeper.Cdc(), NewQueryResNodeSlashes(slashPts, slashes, records))
+	if err != nil {
+		ctx.Logger().Error("fail to marshal node slashes to json", "error", err)
+		return nil, sdk.ErrInternal("fail to marshal node slashes to json")
+	}
+
+	return res, nil
+}
+
+func queryNodeScorecard(ctx sdk.Context, path []string, req abci.RequestQuery, keeper keep.Keeper) ([]byte, sdk.Error) {
+	if len(path) == 0 {
+		return nil, sdk.ErrUnknownRequest("node address is empty")
+	}
+	addr, err := sdk.AccAddressFromBech32(path[0])
+	if err != nil {
+		return nil, sdk.ErrUnknownRequest("invalid account address")
+	}
+	na, err := keeper.GetNodeAccount(ctx, addr)
+	if err != nil {
+		ctx.Logger().Error("fail to get node account", "error", err)
+		return nil, sdk.ErrInternal("fail to get node account")
+	}
+	if na.IsEmpty() {
+		return nil, sdk.ErrUnknownRequest(fmt.Sprintf("node account(%s) doesn't exist", addr))
+	}
+	scorecard := NewQueryResNodeScorecard(na)
+
+	// observations and keysign failures only count from the moment the node got its current status
+	iter := keeper.GetObservedTxVoterIterator(ctx)
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		var voter ObservedTxVoter
+		if err := keeper.Cdc().UnmarshalBinaryBare(iter.Value(), &voter); err != nil {
+			ctx.Logger().Error("fail to unmarshal observed tx voter", "error", err)
+			return nil, sdk.ErrInternal("fail to unmarshal observed tx voter")
+		}
+		if voter.Height < na.StatusSince {
+			continue
+		}
+		scorecard.ObservedTxs++
+		for _, tx := range voter.Txs {
+			if tx.HasSigned(na.NodeAddress) {
+				scorecard.ObservedTxsSigned++
+				break
+			}
+		}
+	}
+	if scorecard.ObservedTxs > 0 {
+		scorecard.ObservationRate = sdk.NewDec(scorecard.ObservedTxsSigned).QuoInt64(scorecard.ObservedTxs)
+	}
+
+	keysignIter := keeper.GetTssKeysignFailVoterIterator(ctx)
+	defer keysignIter.Close()
+	for ; keysignIter.Valid(); keysignIter.Next() {
+		// keysign fail voters share their store prefix with keygen voters, skip whatever isn't a keysign fail voter
+		var voter TssKeysignFailVoter
+		if err := keeper.Cdc().UnmarshalBinaryBare(keysignIter.Value(), &voter); err != nil {
+			continue
+		}
+		if voter.Height < na.StatusSince {
+			continue
+		}
+		scorecard.KeysignFailures++
+		if voter.HasSigned(na.NodeAddress) {
+			scorecard.KeysignFailuresSigned++
+		}
+		if voter.Blame.BlameNodes.Contains(na.PubKeySet.Secp256k1) {
+			scorecard.KeysignBlames++
+		}
+	}
+	if scorecard.KeysignFailures > 0 {
+		scorecard.KeysignParticipationRate = sdk.NewDec(scorecard.KeysignFailuresSigned).QuoInt64(scorecard.KeysignFailures)
+	}
+
+	keygenIter := keeper.GetTssVoterIterator(ctx)
+	defer keygenIter.Close()
+	for ; keygenIter.Valid(); keygenIter.Next() {
+		// same as above, skip whatever isn't a keygen voter
+		var voter TssVoter
+		if err := keeper.Cdc().UnmarshalBinaryBare(keygenIter.Value(), &voter); err != nil {
+			continue
+		}
+		if !voter.PubKeys.Contains(na.PubKeySet.Secp256k1) {
+			continue
+		}
+		scorecard.Keygens++
+		if voter.HasSigned(na.NodeAddress) {
+			scorecard.KeygensSigned++
+		}
+	}
+
+	constAccessor := constants.GetConstantValues(keeper.GetLowestActiveVersion(ctx))
+	ledgerWindow := constAccessor.GetInt64Value(constants.SlashLedgerBlocks)
+	records, err := keeper.GetSlashRecords(ctx, addr, ctx.BlockHeight()-ledgerWindow)
+	if err != nil {
+		return nil, sdk.ErrInternal("fail to get node slash records")
+	}
+	scorecard.KeygenFailures = records.CountRecords(SlashReasonFailKeygen)
+
+	scorecard.MinJoinVersion = keeper.GetMinJoinVersion(ctx)
+	scorecard.VersionBehind = na.Version.LT(scorecard.MinJoinVersion)
+
+	scorecard.SlashPoints, err = keeper.GetNodeAccountSlashPoints(ctx, addr)
+	if err != nil {
+		return nil, sdk.ErrInternal("fail to get node slash points")
+	}
+	// the trend compares the rate of slash points in the recent window with the rate over the whole ledger window
+	recentWindow := getSlashPointsDecayBlocks(ctx, keeper, constAccessor)
+	if ledgerWindow > ctx.BlockHeight() {
+		ledgerWindow = ctx.BlockHeight()
+	}
+	if recentWindow <= 0 || recentWindow > ledgerWindow {
+		recentWindow = ledgerWindow
+	}
+	scorecard.SlashPointsRecent = records.PointsSince(ctx.BlockHeight() - recentWindow)
+	scorecard.SlashPointsLedger = records.PointsSince(ctx.BlockHeight() - ledgerWindow)
+	recentRate := scorecard.SlashPointsRecent * ledgerWindow
+	ledgerRate := scorecard.SlashPointsLedger * recentWindow
+	switch {
+	case recentRate > ledgerRate:
+		scorecard.SlashPointsTrend = "rising"
+	case recentRate < ledgerRate:
+		scorecard.SlashPointsTrend = "falling"
+	default:
+		scorecard.SlashPointsTrend = "steady"
+	}
+
+	// only active node accounts get churned out, using the same ranking the validator manager uses to pick them
+	scorecard.ChurnOutRisk = "none"
+	if na.Status == NodeActive {
+		active, err := keeper.ListActiveNodeAccounts(ctx)
+		if err != nil {
+			return nil, sdk.ErrInternal("fail to get active node accounts")
+		}
+		tracker, redline := rankBadActors(ctx, keeper, active)
+		scorecard.BadActorRedline = redline
+		for i, track := range tracker {
+			if !track.NodeAccount.NodeAddress.Equals(na.NodeAddress) {
+				continue
+			}
+			scorecard.BadActorScore = track.Score
+			scorecard.BadActorRank = int64(i + 1)
+			// if no one crossed the redline, the worst offender is churned out
+			scorecard.BadActor = redline.GTE(track.Score) || (i == 0 && redline.LT(tracker[0].Score))
+		}
+		scorecard.OldActor = findOldestNodeAccount(ctx, active).NodeAddress.Equals(na.NodeAddress)
+		switch {
+		case scorecard.BadActor:
+			scorecard.ChurnOutRisk = "high"
+		case scorecard.OldActor:
+			scorecard.ChurnOutRisk = "medium"
+		default:
+			scorecard.ChurnOutRisk = "low"
+		}
+	}
+
+	res, err := codec.MarshalJSONIndent(keeper.Cdc(), scorecard)
+	if err != nil {
+		ctx.Logger().Error("fail to marshal node scorecard to json", "error", err)
+		return nil, sdk.ErrInternal("fail to marshal node scorecard to json")
+	}
+
+	return res, nil
+}
+
+func queryChurnPreview(ctx sdk.Context, req abci.RequestQuery, keeper keep.Keeper, validatorMgr VersionedValidatorManager) ([]byte, sdk.Error) {
+	ver := keeper.GetLowestActiveVersion(ctx)
+	constAccessor := constants.GetConstantValues(ver)
+	preview, err := validatorMgr.ChurnPreview(ctx, ver, constAccessor)
+	if err != nil {
+		ctx.Logger().Error("fail to preview churn", "error", err)
+		return nil, sdk.ErrInternal(fmt.Sprintf("fail to preview churn: %s", err))
+	}
+
+	res, err := codec.MarshalJSONIndent(keeper.Cdc(), preview)
+	if err != nil {
+		ctx.Logger().Error("fail to marshal churn preview to json", "error", err)
+		return nil, sdk.ErrInternal("fail to marshal churn preview to json")
+	}
+
+	return res, nil
+}
+
 func queryNodeAccounts(ctx sdk.Context, path []string, req abci.RequestQuery, keeper keep.Keeper) ([]byte, sdk.Error) {
 	nodeAccounts, err := keeper.ListNodeAccountsWithBond(ctx)
 	if err != nil {
@@ -892,3 +1134,501 @@ func queryBan(ctx sdk.Context, path []string, req abci.RequestQuery, keeper keep
 	}
 	return res, nil
 }
+
+func queryLimitOrdersByPool(ctx sdk.Context, path []string, req abci.RequestQuery, keeper keep.Keeper) ([]byte, sdk.Error) {
+	asset, err := common.NewAsset(path[0])
+	if err != nil {
+		ctx.Logger().Error("fail to parse asset", "error", err)
+		return nil, sdk.ErrInternal("Could not parse asset")
+	}
+	return queryLimitOrders(ctx, keeper, func(msg MsgSwap) bool {
+		return msg.TargetAsset.Equals(asset) || msg.Tx.Coins[0].Asset.Equals(asset)
+	})
+}
+
+func queryLimitOrdersByAddress(ctx sdk.Context, path []string, req abci.RequestQuery, keeper keep.Keeper) ([]byte, sdk.Error) {
+	addr, err := common.NewAddress(path[0])
+	if err != nil {
+		ctx.Logger().Error("fail to parse address", "error", err)
+		return nil, sdk.ErrInternal("Could not parse address")
+	}
+	return queryLimitOrders(ctx, keeper, func(msg MsgSwap) bool {
+		return msg.Tx.FromAddress.Equals(addr) || msg.Destination.Equals(addr)
+	})
+}
+
+// queryLimitOrders return all the limit orders resting in the swap queue that match the given filter
+func queryLimitOrders(ctx sdk.Context, keeper keep.Keeper, match func(msg MsgSwap) bool) ([]byte, sdk.Error) {
+	orders := make([]MsgSwap, 0)
+	iterator := keeper.GetSwapQueueIterator(ctx)
+	defer iterator.Close()
+	for ; iterator.Valid(); iterator.Next() {
+		var msg MsgSwap
+		if err := keeper.Cdc().UnmarshalBinaryBare(iterator.Value(), &msg); err != nil {
+			ctx.Logger().Error("fail to unmarshal swap queue item", "error", err)
+			return nil, sdk.ErrInternal("fail to unmarshal swap queue item")
+		}
+		if !msg.LimitOrder || len(msg.Tx.Coins) == 0 {
+			continue
+		}
+		if match(msg) {
+			orders = append(orders, msg)
+		}
+	}
+	res, err := codec.MarshalJSONIndent(keeper.Cdc(), orders)
+	if err != nil {
+		ctx.Logger().Error("fail to marshal limit orders to json", "error", err)
+		return nil, sdk.ErrInternal("fail to marshal limit orders to json")
+	}
+	return res, nil
+}
+
+// queryQuoteSwap estimate the outcome of a swap at the current pool depths, the swap runs on a cached context that is
+// never written back, so nothing the estimate does sticks
+func queryQuoteSwap(ctx sdk.Context, path []string, req abci.RequestQuery, keeper keep.Keeper) ([]byte, sdk.Error) {
+	u, err := getURLFromData(req.Data)
+	if err != nil {
+		ctx.Logger().Error("fail to get url from query data", "error", err)
+		return nil, sdk.ErrUnknownRequest("fail to parse query parameters")
+	}
+	params := u.Query()
+	fromAsset, err := common.NewAsset(params.Get("from_asset"))
+	if err != nil {
+		return nil, sdk.ErrUnknownRequest(fmt.Sprintf("invalid from_asset: %s", err))
+	}
+	toAsset, err := common.NewAsset(params.Get("to_asset"))
+	if err != nil {
+		return nil, sdk.ErrUnknownRequest(fmt.Sprintf("invalid to_asset: %s", err))
+	}
+	amount, err := getQuoteAmount(params.Get("amount"))
+	if err != nil || amount.IsZero() {
+		return nil, sdk.ErrUnknownRequest("invalid amount")
+	}
+	var destination common.Address
+	if len(params.Get("destination")) > 0 {
+		destination, err = common.NewAddress(params.Get("destination"))
+		if err != nil {
+			return nil, sdk.ErrUnknownRequest(fmt.Sprintf("invalid destination: %s", err))
+		}
+	}
+
+	version := keeper.GetLowestActiveVersion(ctx)
+	constAccessor := constants.GetConstantValues(version)
+	transactionFee := sdk.NewUint(uint64(constAccessor.GetInt64Value(constants.TransactionFee)))
+	tx := getQuoteTx(fromAsset, amount)
+	swapDestination := destination
+	if swapDestination.IsEmpty() {
+		swapDestination = tx.FromAddress
+	}
+	cacheCtx, _ := ctx.CacheContext()
+	emit, events, swapErr := swap(cacheCtx, keeper, tx, toAsset, swapDestination, sdk.ZeroUint(), transactionFee, constAccessor)
+	if swapErr != nil {
+		return nil, swapErr
+	}
+	slip := sdk.ZeroUint()
+	liquidityFee := sdk.ZeroUint()
+	for _, evt := range events {
+		slip = slip.Add(evt.TradeSlip)
+		liquidityFee = liquidityFee.Add(evt.LiquidityFeeInRune)
+	}
+
+	// the outbound pays its fee out of the emitted amount, the same way the txout store takes it
+	outboundFee := NewFeeModel(keeper, constAccessor).GetOutboundFee(cacheCtx, toAsset.Chain)
+	if !toAsset.IsRune() {
+		pool, err := keeper.GetPool(cacheCtx, toAsset)
+		if err != nil {
+			ctx.Logger().Error("fail to get pool", "error", err)
+			return nil, sdk.ErrInternal("fail to get pool")
+		}
+		outboundFee = pool.RuneValueInAsset(outboundFee)
+	}
+	if outboundFee.GT(emit) {
+		outboundFee = emit
+	}
+	outboundGas, sdkErr := getQuoteOutboundGas(ctx, keeper, version, toAsset.Chain)
+	if sdkErr != nil {
+		return nil, sdkErr
+	}
+
+	quote := QueryResQuoteSwap{
+		ExpectedOutput: common.SafeSub(emit, outboundFee),
+		Slip:           slip,
+		LiquidityFee:   liquidityFee,
+		OutboundFee:    outboundFee,
+		OutboundGas:    outboundGas,
+		Memo:           NewSwapMemo(toAsset, destination, sdk.ZeroUint()).String(),
+	}
+	res, err := codec.MarshalJSONIndent(keeper.Cdc(), quote)
+	if err != nil {
+		ctx.Logger().Error("fail to marshal swap quote to json", "error", err)
+		return nil, sdk.ErrInternal("fail to marshal swap quote to json")
+	}
+	return res, nil
+}
+
+// queryQuoteStake estimate the pool units a stake would get at the current pool depths
+func queryQuoteStake(ctx sdk.Context, path []string, req abci.RequestQuery, keeper keep.Keeper) ([]byte, sdk.Error) {
+	u, err := getURLFromData(req.Data)
+	if err != nil {
+		ctx.Logger().Error("fail to get url from query data", "error", err)
+		return nil, sdk.ErrUnknownRequest("fail to parse query parameters")
+	}
+	params := u.Query()
+	asset, err := common.NewAsset(params.Get("asset"))
+	if err != nil || asset.IsRune() {
+		return nil, sdk.ErrUnknownRequest("invalid asset")
+	}
+	runeAmount, err := getQuoteAmount(params.Get("rune_amount"))
+	if err != nil {
+		return nil, sdk.ErrUnknownRequest("invalid rune_amount")
+	}
+	assetAmount, err := getQuoteAmount(params.Get("asset_amount"))
+	if err != nil {
+		return nil, sdk.ErrUnknownRequest("invalid asset_amount")
+	}
+	if runeAmount.IsZero() && assetAmount.IsZero() {
+		return nil, sdk.ErrUnknownRequest("both rune_amount and asset_amount are zero")
+	}
+	var address common.Address
+	if len(params.Get("address")) > 0 {
+		address, err = common.NewAddress(params.Get("address"))
+		if err != nil {
+			return nil, sdk.ErrUnknownRequest(fmt.Sprintf("invalid address: %s", err))
+		}
+	}
+
+	pool, err := keeper.GetPool(ctx, asset)
+	if err != nil {
+		ctx.Logger().Error("fail to get pool", "error", err)
+		return nil, sdk.ErrInternal("fail to get pool")
+	}
+	poolUnits, stakeUnits, err := calculatePoolUnits(pool.PoolUnits, pool.BalanceRune, pool.BalanceAsset, runeAmount, assetAmount)
+	if err != nil {
+		return nil, sdk.ErrUnknownRequest(err.Error())
+	}
+
+	memo := NewStakeMemo(asset, address)
+	memo.Asymmetric = runeAmount.IsZero() || assetAmount.IsZero()
+	quote := QueryResQuoteStake{
+		StakeUnits: stakeUnits,
+		PoolUnits:  poolUnits,
+		Memo:       memo.String(),
+	}
+	res, err := codec.MarshalJSONIndent(keeper.Cdc(), quote)
+	if err != nil {
+		ctx.Logger().Error("fail to marshal stake quote to json", "error", err)
+		return nil, sdk.ErrInternal("fail to marshal stake quote to json")
+	}
+	return res, nil
+}
+
+// queryQuoteUnstake estimate what a staker would get back by unstaking the given basis points of its units
+func queryQuoteUnstake(ctx sdk.Context, path []string, req abci.RequestQuery, keeper keep.Keeper) ([]byte, sdk.Error) {
+	u, err := getURLFromData(req.Data)
+	if err != nil {
+		ctx.Logger().Error("fail to get url from query data", "error", err)
+		return nil, sdk.ErrUnknownRequest("fail to parse query parameters")
+	}
+	params := u.Query()
+	asset, err := common.NewAsset(params.Get("asset"))
+	if err != nil || asset.IsRune() {
+		return nil, sdk.ErrUnknownRequest("invalid asset")
+	}
+	address, err := common.NewAddress(params.Get("address"))
+	if err != nil {
+		return nil, sdk.ErrUnknownRequest(fmt.Sprintf("invalid address: %s", err))
+	}
+	basisPoints := sdk.NewUint(MaxUnstakeBasisPoints)
+	if len(params.Get("basis_points")) > 0 {
+		basisPoints, err = getQuoteAmount(params.Get("basis_points"))
+		if err != nil || basisPoints.IsZero() || basisPoints.GT(sdk.NewUint(MaxUnstakeBasisPoints)) {
+			return nil, sdk.ErrUnknownRequest("invalid basis_points")
+		}
+	}
+
+	pool, err := keeper.GetPool(ctx, asset)
+	if err != nil {
+		ctx.Logger().Error("fail to get pool", "error", err)
+		return nil, sdk.ErrInternal("fail to get pool")
+	}
+	staker, err := keeper.GetStaker(ctx, asset, address)
+	if err != nil {
+		ctx.Logger().Error("fail to get staker", "error", err)
+		return nil, sdk.ErrInternal("fail to get staker")
+	}
+	runeAmount, assetAmount, unitsLeft, err := calculateUnstake(pool.PoolUnits, pool.BalanceRune, pool.BalanceAsset, staker.Units, basisPoints)
+	if err != nil {
+		return nil, sdk.ErrUnknownRequest(err.Error())
+	}
+	version := keeper.GetLowestActiveVersion(ctx)
+	chains := common.Chains{common.RuneAsset().Chain}
+	if !asset.Chain.Equals(common.RuneAsset().Chain) {
+		chains = append(chains, asset.Chain)
+	}
+	outboundGas, sdkErr := getQuoteOutboundGas(ctx, keeper, version, chains...)
+	if sdkErr != nil {
+		return nil, sdkErr
+	}
+
+	quote := QueryResQuoteUnstake{
+		RuneAmount:  runeAmount,
+		AssetAmount: assetAmount,
+		StakeUnits:  common.SafeSub(staker.Units, unitsLeft),
+		OutboundGas: outboundGas,
+		Memo:        NewUnstakeMemo(asset, basisPoints.String()).String(),
+	}
+	res, err := codec.MarshalJSONIndent(keeper.Cdc(), quote)
+	if err != nil {
+		ctx.Logger().Error("fail to marshal unstake quote to json", "error", err)
+		return nil, sdk.ErrInternal("fail to marshal unstake quote to json")
+	}
+	return res, nil
+}
+
+// getQuoteAmount parse an amount query parameter, an empty parameter is zero
+func getQuoteAmount(param string) (sdk.Uint, error) {
+	if len(param) == 0 {
+		return sdk.ZeroUint(), nil
+	}
+	return sdk.ParseUint(param)
+}
+
+// getQuoteTx build an inbound tx that carries the given coin, it is only used to estimate and never gets observed
+func getQuoteTx(asset common.Asset, amount sdk.Uint) common.Tx {
+	addr := common.Address("quote")
+	return common.NewTx(
+		common.BlankTxID,
+		addr,
+		addr,
+		common.Coins{common.NewCoin(asset, amount)},
+		common.Gas{common.NewCoin(asset.Chain.GetGasAsset(), sdk.OneUint())},
+		"",
+	)
+}
+
+// getQuoteOutboundGas return the gas the outbound txs on the given chains are allowed to spend, as the gas manager works it out
+func getQuoteOutboundGas(ctx sdk.Context, keeper keep.Keeper, version semver.Version, chains ...common.Chain) (common.Gas, sdk.Error) {
+	gasMgr, err := NewVersionedGasMgr().GetGasManager(ctx, version)
+	if err != nil {
+		ctx.Logger().Error("fail to get gas manager", "error", err)
+		return nil, errBadVersion
+	}
+	constAccessor := constants.GetConstantValues(version)
+	transactionFee := constAccessor.GetInt64Value(constants.TransactionFee)
+	var outboundGas common.Gas
+	for _, chain := range chains {
+		if chain.Equals(common.THORChain) {
+			continue
+		}
+		gas, err := gasMgr.GetFee(ctx, keeper, chain, transactionFee)
+		if err != nil {
+			ctx.Logger().Error("fail to get outbound gas", "chain", chain, "error", err)
+			return nil, sdk.ErrInternal("fail to get outbound gas")
+		}
+		outboundGas = outboundGas.Add(gas)
+	}
+	return outboundGas, nil
+}
+
+// queryPoolTWAP return the time weighted average price of a pool, the window defaults to the one swap scoring uses
+func queryPoolTWAP(ctx sdk.Context, path []string, req abci.RequestQuery, keeper keep.Keeper) ([]byte, sdk.Error) {
+	asset, err := common.NewAsset(path[0])
+	if err != nil {
+		ctx.Logger().Error("fail to parse asset", "error", err)
+		return nil, sdk.ErrInternal("Could not parse asset")
+	}
+	window, sdkErr := getTWAPWindowFromQuery(ctx, req, keeper)
+	if sdkErr != nil {
+		return nil, sdkErr
+	}
+	pool, err := keeper.GetPool(ctx, asset)
+	if err != nil {
+		ctx.Logger().Error("fail to get pool", "error", err)
+		return nil, sdk.ErrInternal("Could not get pool")
+	}
+	if pool.Empty() {
+		return nil, sdk.ErrUnknownRequest(fmt.Sprintf("pool: %s doesn't exist", path[0]))
+	}
+	twap, sdkErr := getQueryResPoolTWAP(ctx, keeper, pool, window)
+	if sdkErr != nil {
+		return nil, sdkErr
+	}
+	res, err := codec.MarshalJSONIndent(keeper.Cdc(), twap)
+	if err != nil {
+		ctx.Logger().Error("fail to marshal pool twap to json", "error", err)
+		return nil, sdk.ErrInternal("fail to marshal pool twap to json")
+	}
+	return res, nil
+}
+
+// queryPoolsTWAP return the time weighted average price of every pool
+func queryPoolsTWAP(ctx sdk.Context, path []string, req abci.RequestQuery, keeper keep.Keeper) ([]byte, sdk.Error) {
+	window, sdkErr := getTWAPWindowFromQuery(ctx, req, keeper)
+	if sdkErr != nil {
+		return nil, sdkErr
+	}
+	pools, err := keeper.GetPools(ctx)
+	if err != nil {
+		ctx.Logger().Error("fail to get pools", "error", err)
+		return nil, sdk.ErrInternal("fail to get pools")
+	}
+	twaps := make([]QueryResPoolTWAP, 0, len(pools))
+	for _, pool := range pools {
+		twap, sdkErr := getQueryResPoolTWAP(ctx, keeper, pool, window)
+		if sdkErr != nil {
+			return nil, sdkErr
+		}
+		twaps = append(twaps, twap)
+	}
+	res, err := codec.MarshalJSONIndent(keeper.Cdc(), twaps)
+	if err != nil {
+		ctx.Logger().Error("fail to marshal pools twap to json", "error", err)
+		return nil, sdk.ErrInternal("fail to marshal pools twap to json")
+	}
+	return res, nil
+}
+
+// getTWAPWindowFromQuery return the window parameter of a twap query, it can't be longer than the price history kept
+func getTWAPWindowFromQuery(ctx sdk.Context, req abci.RequestQuery, keeper keep.Keeper) (int64, sdk.Error) {
+	constAccessor := constants.GetConstantValues(keeper.GetLowestActiveVersion(ctx))
+	window := getTWAPWindow(ctx, keeper, constAccessor)
+	if u, err := getURLFromData(req.Data); err == nil && len(u.Query().Get("window")) > 0 {
+		window, err = strconv.ParseInt(u.Query().Get("window"), 10, 64)
+		if err != nil || window < 0 {
+			return 0, sdk.ErrUnknownRequest("invalid window")
+		}
+	}
+	if maxWindow := getTWAPMaxWindow(ctx, keeper, constAccessor); window > maxWindow {
+		window = maxWindow
+	}
+	return window, nil
+}
+
+func getQueryResPoolTWAP(ctx sdk.Context, keeper keep.Keeper, pool Pool, window int64) (QueryResPoolTWAP, sdk.Error) {
+	twap, err := getPoolTWAP(ctx, keeper, pool, window)
+	if err != nil {
+		ctx.Logger().Error("fail to get pool twap", "error", err)
+		return QueryResPoolTWAP{}, sdk.ErrInternal("fail to get pool twap")
+	}
+	return QueryResPoolTWAP{
+		Asset:     pool.Asset,
+		Height:    ctx.BlockHeight(),
+		Window:    window,
+		TWAP:      twap,
+		SpotPrice: pool.AssetPrice(),
+	}, nil
+}
+
+// queryStakerPosition return the position of a staker in every pool it has units or pending RUNE in
+func queryStakerPosition(ctx sdk.Context, path []string, req abci.RequestQuery, keeper keep.Keeper) ([]byte, sdk.Error) {
+	addr, err := common.NewAddress(path[0])
+	if err != nil || addr.IsEmpty() {
+		ctx.Logger().Error("fail to parse address", "address", path[0], "error", err)
+		return nil, sdk.ErrUnknownRequest("invalid address")
+	}
+	pools, err := keeper.GetPools(ctx)
+	if err != nil {
+		ctx.Logger().Error("fail to get pools", "error", err)
+		return nil, sdk.ErrInternal("fail to get pools")
+	}
+	constAccessor := constants.GetConstantValues(keeper.GetLowestActiveVersion(ctx))
+	lockUpBlocks := constAccessor.GetInt64Value(constants.StakeLockUpBlocks)
+	positions := make([]QueryResStakerPosition, 0)
+	for _, pool := range pools {
+		staker, err := keeper.GetStaker(ctx, pool.Asset, addr)
+		if err != nil {
+			ctx.Logger().Error("fail to get staker", "error", err)
+			return nil, sdk.ErrInternal("fail to get staker")
+		}
+		if staker.Units.IsZero() && staker.PendingRune.IsZero() {
+			continue
+		}
+		position := NewQueryResStakerPosition(staker)
+		if !staker.Units.IsZero() {
+			redeemableRune, redeemableAsset, _, err := calculateUnstake(pool.PoolUnits, pool.BalanceRune, pool.BalanceAsset, staker.Units, sdk.NewUint(MaxUnstakeBasisPoints))
+			if err != nil {
+				// an empty pool has nothing to redeem
+				ctx.Logger().Info("fail to calculate redeemable amount", "pool", pool.Asset, "error", err)
+			} else {
+				position.RedeemableRune = redeemableRune
+				position.RedeemableAsset = redeemableAsset
+			}
+		}
+		// unstake is only rate limited on chains other than binance chain
+		if !pool.Asset.Chain.Equals(common.BNBChain) {
+			if remaining := staker.LastStakeHeight + lockUpBlocks - ctx.BlockHeight(); remaining > 0 {
+				position.LockUpRemaining = remaining
+			}
+		}
+		positions = append(positions, position)
+	}
+	if err := addStakerPositionHistory(ctx, keeper, positions); err != nil {
+		ctx.Logger().Error("fail to get staker history", "error", err)
+		return nil, sdk.ErrInternal("fail to get staker history")
+	}
+	res, err := codec.MarshalJSONIndent(keeper.Cdc(), positions)
+	if err != nil {
+		ctx.Logger().Error("fail to marshal staker position to json", "error", err)
+		return nil, sdk.ErrInternal("fail to marshal staker position to json")
+	}
+	return res, nil
+}
+
+// addStakerPositionHistory add up the coins of the stake events and the outbound coins of the unstake events that belong to
+// the given positions. A stake event belongs to a position when it's sent from either of the staker's addresses, an unstake
+// event when it's sent from the staker's RUNE address
+func addStakerPositionHistory(ctx sdk.Context, keeper keep.Keeper, positions []QueryResStakerPosition) error {
+	if len(positions) == 0 {
+		return nil
+	}
+	iterator := keeper.GetEventsIterator(ctx)
+	defer iterator.Close()
+	for ; iterator.Valid(); iterator.Next() {
+		var evt Event
+		if err := keeper.Cdc().UnmarshalBinaryBare(iterator.Value(), &evt); err != nil {
+			return fmt.Errorf("fail to unmarshal event: %w", err)
+		}
+		switch evt.Type {
+		case StakeEventType:
+			var stakeEvt EventStake
+			if err := json.Unmarshal(evt.Event, &stakeEvt); err != nil {
+				return fmt.Errorf("fail to unmarshal stake event: %w", err)
+			}
+			for i, position := range positions {
+				if !position.Asset.Equals(stakeEvt.Pool) {
+					continue
+				}
+				if !evt.InTx.FromAddress.Equals(position.RuneAddress) && !evt.InTx.FromAddress.Equals(position.AssetAddress) {
+					continue
+				}
+				for _, coin := range evt.InTx.Coins {
+					if coin.Asset.IsRune() {
+						positions[i].RuneDeposited = positions[i].RuneDeposited.Add(coin.Amount)
+					} else if coin.Asset.Equals(position.Asset) {
+						positions[i].AssetDeposited = positions[i].AssetDeposited.Add(coin.Amount)
+					}
+				}
+			}
+		case UnstakeEventType:
+			var unstakeEvt EventUnstake
+			if err := json.Unmarshal(evt.Event, &unstakeEvt); err != nil {
+				return fmt.Errorf("fail to unmarshal unstake event: %w", err)
+			}
+			for i, position := range positions {
+				if !position.Asset.Equals(unstakeEvt.Pool) || !evt.InTx.FromAddress.Equals(position.RuneAddress) {
+					continue
+				}
+				for _, tx := range evt.OutTxs {
+					for _, coin := range tx.Coins {
+						if coin.Asset.IsRune() {
+							positions[i].RuneWithdrawn = positions[i].RuneWithdrawn.Add(coin.Amount)
+						} else if coin.Asset.Equals(position.Asset) {
+							positions[i].AssetWithdrawn = positions[i].AssetWithdrawn.Add(coin.Amount)
+						}
+					}
+				}
+			}
+		}
+	}
+	return nil
+}
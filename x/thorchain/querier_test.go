@@ -2,12 +2,14 @@ package thorchain
 
 import (
 	"encoding/json"
+	"net/url"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	abci "github.com/tendermint/tendermint/abci/types"
 	. "gopkg.in/check.v1"
 
 	"gitlab.com/thorchain/thornode/common"
+	"gitlab.com/thorchain/thornode/constants"
 	"gitlab.com/thorchain/thornode/x/thorchain/types"
 )
 
@@ -163,6 +165,102 @@ func (s *QuerierSuite) TestQueryNodeAccounts(c *C) {
 	c.Assert(len(out), Equals, 1)
 }
 
+func (s *QuerierSuite) TestQueryNodeAccountSlashes(c *C) {
+	ctx, keeper := setupKeeperForTest(c)
+	querier := NewQuerier(keeper, NewVersionedValidatorMgr(keeper, NewVersionedTxOutStoreDummy(), NewVersionedVaultMgrDummy(NewVersionedTxOutStoreDummy()), NewDummyVersionedEventMgr()))
+	na := GetRandomNodeAccount(NodeActive)
+	c.Assert(keeper.SetNodeAccount(ctx, na), IsNil)
+	constAccessor := constants.GetConstantValues(constants.SWVersion)
+	c.Assert(incSlashPoints(ctx, keeper, constAccessor, na.NodeAddress, 720, SlashReasonFailKeygen), IsNil)
+	c.Assert(incSlashPoints(ctx, keeper, constAccessor, na.NodeAddress, 2, SlashReasonLackObserving), IsNil)
+
+	_, err := querier(ctx, []string{"nodeaccountslashes", "bogus"}, abci.RequestQuery{})
+	c.Assert(err, NotNil)
+
+	res, err := querier(ctx, []string{"nodeaccountslashes", na.NodeAddress.String()}, abci.RequestQuery{})
+	c.Assert(err, IsNil)
+	var out QueryResNodeSlashes
+	c.Assert(keeper.Cdc().UnmarshalJSON(res, &out), IsNil)
+	c.Check(out.NodeAddress.Equals(na.NodeAddress), Equals, true)
+	c.Check(out.SlashPoints, Equals, int64(722))
+	c.Check(out.Counters, HasLen, 2)
+	c.Check(out.Ledger, HasLen, 2)
+}
+
+func (s *QuerierSuite) TestQueryNodeScorecard(c *C) {
+	ctx, keeper := setupKeeperForTest(c)
+	ctx = ctx.WithBlockHeight(1000)
+	querier := NewQuerier(keeper, NewVersionedValidatorMgr(keeper, NewVersionedTxOutStoreDummy(), NewVersionedVaultMgrDummy(NewVersionedTxOutStoreDummy()), NewDummyVersionedEventMgr()))
+	na := GetRandomNodeAccount(NodeActive)
+	na.StatusSince = 0
+	c.Assert(keeper.SetNodeAccount(ctx, na), IsNil)
+	na2 := GetRandomNodeAccount(NodeActive)
+	na2.StatusSince = 100
+	c.Assert(keeper.SetNodeAccount(ctx, na2), IsNil)
+
+	// one observed tx signed by each node
+	for _, signer := range []sdk.AccAddress{na.NodeAddress, na2.NodeAddress} {
+		tx := GetRandomObservedTx()
+		tx.Sign(signer)
+		voter := NewObservedTxVoter(tx.Tx.ID, ObservedTxs{tx})
+		voter.Height = 200
+		keeper.SetObservedTxVoter(ctx, voter)
+	}
+	// a keysign failure blaming the node, which it didn't vote on
+	keysignFail := NewTssKeysignFailVoter("keysign", 200)
+	keysignFail.Blame = common.Blame{FailReason: "timeout", BlameNodes: common.PubKeys{na.PubKeySet.Secp256k1}}
+	keysignFail.Sign(na2.NodeAddress)
+	keeper.SetTssKeysignFailVoter(ctx, keysignFail)
+	// a keygen the node took part in
+	keygen := NewTssVoter("keygen", common.PubKeys{na.PubKeySet.Secp256k1, na2.PubKeySet.Secp256k1}, GetRandomPubKey())
+	keygen.Sign(na.NodeAddress, common.Chains{common.BNBChain})
+	keeper.SetTssVoter(ctx, keygen)
+
+	constAccessor := constants.GetConstantValues(constants.SWVersion)
+	c.Assert(incSlashPoints(ctx, keeper, constAccessor, na.NodeAddress, 10, SlashReasonFailKeygen), IsNil)
+
+	_, err := querier(ctx, []string{"nodescorecard", "bogus"}, abci.RequestQuery{})
+	c.Assert(err, NotNil)
+	_, err = querier(ctx, []string{"nodescorecard", GetRandomBech32Addr().String()}, abci.RequestQuery{})
+	c.Assert(err, NotNil)
+
+	res, err := querier(ctx, []string{"nodescorecard", na.NodeAddress.String()}, abci.RequestQuery{})
+	c.Assert(err, IsNil)
+	var out QueryResNodeScorecard
+	c.Assert(keeper.Cdc().UnmarshalJSON(res, &out), IsNil)
+	c.Check(out.NodeAddress.Equals(na.NodeAddress), Equals, true)
+	c.Check(out.ObservedTxs, Equals, int64(2))
+	c.Check(out.ObservedTxsSigned, Equals, int64(1))
+	c.Check(out.ObservationRate.Equal(sdk.NewDecWithPrec(5, 1)), Equals, true)
+	c.Check(out.KeysignFailures, Equals, int64(1))
+	c.Check(out.KeysignFailuresSigned, Equals, int64(0))
+	c.Check(out.KeysignBlames, Equals, int64(1))
+	c.Check(out.Keygens, Equals, int64(1))
+	c.Check(out.KeygensSigned, Equals, int64(1))
+	c.Check(out.KeygenFailures, Equals, int64(1))
+	c.Check(out.VersionBehind, Equals, false)
+	c.Check(out.SlashPoints, Equals, int64(10))
+	c.Check(out.SlashPointsRecent, Equals, int64(10))
+	c.Check(out.SlashPointsTrend, Equals, "rising")
+	c.Check(out.BadActorRank, Equals, int64(1))
+	c.Check(out.BadActor, Equals, true)
+	c.Check(out.OldActor, Equals, true)
+	c.Check(out.ChurnOutRisk, Equals, "high")
+
+	res, err = querier(ctx, []string{"nodescorecard", na2.NodeAddress.String()}, abci.RequestQuery{})
+	c.Assert(err, IsNil)
+	c.Assert(keeper.Cdc().UnmarshalJSON(res, &out), IsNil)
+	c.Check(out.ObservedTxs, Equals, int64(2))
+	c.Check(out.KeysignFailuresSigned, Equals, int64(1))
+	c.Check(out.KeysignBlames, Equals, int64(0))
+	c.Check(out.KeygensSigned, Equals, int64(0))
+	c.Check(out.SlashPointsTrend, Equals, "steady")
+	c.Check(out.BadActorRank, Equals, int64(0))
+	c.Check(out.BadActor, Equals, false)
+	c.Check(out.OldActor, Equals, false)
+	c.Check(out.ChurnOutRisk, Equals, "low")
+}
+
 func (s *QuerierSuite) TestQueryCompEvents(c *C) {
 	ctx, keeper := setupKeeperForTest(c)
 
@@ -299,3 +397,248 @@ func (s *QuerierSuite) TestQueryCompEvents(c *C) {
 	c.Assert(out[2].OutTxs[0].Chain.Equals(common.BTCChain), Equals, true)
 	c.Assert(out[3].InTx.Chain.IsEmpty(), Equals, true)
 }
+
+func (s *QuerierSuite) TestQueryLimitOrders(c *C) {
+	ctx, keeper := setupKeeperForTest(c)
+
+	versionedTxOutStoreDummy := NewVersionedTxOutStoreDummy()
+	versionedVaultMgrDummy := NewVersionedVaultMgrDummy(versionedTxOutStoreDummy)
+	versionedEventManagerDummy := NewDummyVersionedEventMgr()
+
+	validatorMgr := NewVersionedValidatorMgr(keeper, versionedTxOutStoreDummy, versionedVaultMgrDummy, versionedEventManagerDummy)
+
+	querier := NewQuerier(keeper, validatorMgr)
+
+	from := GetRandomBNBAddress()
+	order := NewMsgSwap(common.Tx{
+		ID:          GetRandomTxHash(),
+		Chain:       common.BNBChain,
+		FromAddress: from,
+		Coins:       common.Coins{common.NewCoin(common.BNBAsset, sdk.NewUint(common.One))},
+	}, common.RuneAsset(), GetRandomBNBAddress(), sdk.NewUint(common.One), GetRandomBech32Addr())
+	order.LimitOrder = true
+	order.ExpiryHeight = 100
+	c.Assert(keeper.SetSwapQueueItem(ctx, order), IsNil)
+	market := order
+	market.Tx.ID = GetRandomTxHash()
+	market.LimitOrder = false
+	c.Assert(keeper.SetSwapQueueItem(ctx, market), IsNil)
+
+	res, err := querier(ctx, []string{"limitorderspool", common.BNBAsset.String()}, abci.RequestQuery{})
+	c.Assert(err, IsNil)
+	var out []MsgSwap
+	c.Assert(keeper.Cdc().UnmarshalJSON(res, &out), IsNil)
+	c.Assert(out, HasLen, 1)
+	c.Check(out[0].Tx.ID.Equals(order.Tx.ID), Equals, true)
+
+	res, err = querier(ctx, []string{"limitorderspool", common.BTCAsset.String()}, abci.RequestQuery{})
+	c.Assert(err, IsNil)
+	c.Assert(keeper.Cdc().UnmarshalJSON(res, &out), IsNil)
+	c.Check(out, HasLen, 0)
+
+	res, err = querier(ctx, []string{"limitordersaddress", from.String()}, abci.RequestQuery{})
+	c.Assert(err, IsNil)
+	c.Assert(keeper.Cdc().UnmarshalJSON(res, &out), IsNil)
+	c.Check(out, HasLen, 1)
+
+	res, err = querier(ctx, []string{"limitordersaddress", GetRandomBNBAddress().String()}, abci.RequestQuery{})
+	c.Assert(err, IsNil)
+	c.Assert(keeper.Cdc().UnmarshalJSON(res, &out), IsNil)
+	c.Check(out, HasLen, 0)
+}
+
+func (s *QuerierSuite) TestQueryQuotes(c *C) {
+	ctx, keeper := setupKeeperForTest(c)
+	querier := NewQuerier(keeper, NewVersionedValidatorMgr(keeper, NewVersionedTxOutStoreDummy(), NewVersionedVaultMgrDummy(NewVersionedTxOutStoreDummy()), NewDummyVersionedEventMgr()))
+	c.Assert(keeper.SetNodeAccount(ctx, GetRandomNodeAccount(NodeActive)), IsNil)
+	pool := NewPool()
+	pool.Asset = common.BNBAsset
+	pool.BalanceRune = sdk.NewUint(100 * common.One)
+	pool.BalanceAsset = sdk.NewUint(100 * common.One)
+	pool.PoolUnits = sdk.NewUint(100 * common.One)
+	pool.Status = PoolEnabled
+	c.Assert(keeper.SetPool(ctx, pool), IsNil)
+	getQueryData := func(params url.Values) []byte {
+		u := url.URL{RawQuery: params.Encode()}
+		data, err := u.MarshalBinary()
+		c.Assert(err, IsNil)
+		return data
+	}
+
+	destination := GetRandomBNBAddress()
+	data := getQueryData(url.Values{
+		"from_asset":  []string{common.RuneAsset().String()},
+		"to_asset":    []string{common.BNBAsset.String()},
+		"amount":      []string{sdk.NewUint(10 * common.One).String()},
+		"destination": []string{destination.String()},
+	})
+	res, err := querier(ctx, []string{"quoteswap"}, abci.RequestQuery{Data: data})
+	c.Assert(err, IsNil)
+	var swapQuote QueryResQuoteSwap
+	c.Assert(keeper.Cdc().UnmarshalJSON(res, &swapQuote), IsNil)
+	c.Check(swapQuote.ExpectedOutput.IsZero(), Equals, false)
+	c.Check(swapQuote.Slip.Equal(sdk.NewUint(2100)), Equals, true, Commentf("%d", swapQuote.Slip.Uint64()))
+	c.Check(swapQuote.LiquidityFee.IsZero(), Equals, false)
+	c.Check(swapQuote.OutboundGas.IsEmpty(), Equals, false)
+	c.Check(swapQuote.Memo, Equals, "SWAP:"+common.BNBAsset.String()+":"+destination.String())
+	// the quote doesn't touch the pool
+	p, getErr := keeper.GetPool(ctx, common.BNBAsset)
+	c.Assert(getErr, IsNil)
+	c.Check(p.BalanceRune.Equal(pool.BalanceRune), Equals, true)
+
+	res, err = querier(ctx, []string{"quoteswap"}, abci.RequestQuery{Data: getQueryData(url.Values{"from_asset": []string{common.RuneAsset().String()}})})
+	c.Assert(err, NotNil)
+	c.Check(res, IsNil)
+
+	data = getQueryData(url.Values{
+		"asset":        []string{common.BNBAsset.String()},
+		"rune_amount":  []string{sdk.NewUint(10 * common.One).String()},
+		"asset_amount": []string{sdk.NewUint(10 * common.One).String()},
+	})
+	res, err = querier(ctx, []string{"quotestake"}, abci.RequestQuery{Data: data})
+	c.Assert(err, IsNil)
+	var stakeQuote QueryResQuoteStake
+	c.Assert(keeper.Cdc().UnmarshalJSON(res, &stakeQuote), IsNil)
+	c.Check(stakeQuote.StakeUnits.Equal(sdk.NewUint(10*common.One)), Equals, true)
+	c.Check(stakeQuote.Memo, Equals, "STAKE:"+common.BNBAsset.String())
+
+	staker := Staker{
+		Asset:        common.BNBAsset,
+		RuneAddress:  GetRandomBNBAddress(),
+		Units:        sdk.NewUint(10 * common.One),
+		PendingRune:  sdk.ZeroUint(),
+		RuneDeposit:  sdk.ZeroUint(),
+		AssetDeposit: sdk.ZeroUint(),
+	}
+	keeper.SetStaker(ctx, staker)
+	data = getQueryData(url.Values{
+		"asset":        []string{common.BNBAsset.String()},
+		"address":      []string{staker.RuneAddress.String()},
+		"basis_points": []string{"5000"},
+	})
+	res, err = querier(ctx, []string{"quoteunstake"}, abci.RequestQuery{Data: data})
+	c.Assert(err, IsNil)
+	var unstakeQuote QueryResQuoteUnstake
+	c.Assert(keeper.Cdc().UnmarshalJSON(res, &unstakeQuote), IsNil)
+	c.Check(unstakeQuote.RuneAmount.Equal(sdk.NewUint(5*common.One)), Equals, true)
+	c.Check(unstakeQuote.AssetAmount.Equal(sdk.NewUint(5*common.One)), Equals, true)
+	c.Check(unstakeQuote.StakeUnits.Equal(sdk.NewUint(5*common.One)), Equals, true)
+	c.Check(unstakeQuote.Memo, Equals, "WITHDRAW:"+common.BNBAsset.String()+":5000")
+}
+
+func (s *QuerierSuite) TestQueryStakerPosition(c *C) {
+	ctx, keeper := setupKeeperForTest(c)
+	ctx = ctx.WithBlockHeight(20)
+	querier := NewQuerier(keeper, NewVersionedValidatorMgr(keeper, NewVersionedTxOutStoreDummy(), NewVersionedVaultMgrDummy(NewVersionedTxOutStoreDummy()), NewDummyVersionedEventMgr()))
+	c.Assert(keeper.SetNodeAccount(ctx, GetRandomNodeAccount(NodeActive)), IsNil)
+	runeAddr := GetRandomBNBAddress()
+	for _, asset := range []common.Asset{common.BNBAsset, common.BTCAsset} {
+		pool := NewPool()
+		pool.Asset = asset
+		pool.BalanceRune = sdk.NewUint(100 * common.One)
+		pool.BalanceAsset = sdk.NewUint(50 * common.One)
+		pool.PoolUnits = sdk.NewUint(100)
+		pool.Status = PoolEnabled
+		c.Assert(keeper.SetPool(ctx, pool), IsNil)
+	}
+	keeper.SetStaker(ctx, Staker{
+		Asset:           common.BNBAsset,
+		RuneAddress:     runeAddr,
+		AssetAddress:    runeAddr,
+		LastStakeHeight: 10,
+		Units:           sdk.NewUint(10),
+		PendingRune:     sdk.ZeroUint(),
+		RuneDeposit:     sdk.ZeroUint(),
+		AssetDeposit:    sdk.ZeroUint(),
+	})
+	keeper.SetStaker(ctx, Staker{
+		Asset:           common.BTCAsset,
+		RuneAddress:     runeAddr,
+		AssetAddress:    GetRandomBTCAddress(),
+		LastStakeHeight: 10,
+		Units:           sdk.ZeroUint(),
+		PendingRune:     sdk.NewUint(common.One),
+	})
+
+	stakeTx := GetRandomTx()
+	stakeTx.FromAddress = runeAddr
+	stakeTx.Coins = common.Coins{
+		common.NewCoin(common.RuneAsset(), sdk.NewUint(20*common.One)),
+		common.NewCoin(common.BNBAsset, sdk.NewUint(10*common.One)),
+	}
+	stakeBytes, err := json.Marshal(NewEventStake(common.BNBAsset, sdk.NewUint(20), stakeTx))
+	c.Assert(err, IsNil)
+	c.Assert(keeper.UpsertEvent(ctx, NewEvent(StakeEventType, 10, stakeTx, stakeBytes, EventSuccess)), IsNil)
+	unstakeTx := GetRandomTx()
+	unstakeTx.FromAddress = runeAddr
+	unstakeBytes, err := json.Marshal(NewEventUnstake(common.BNBAsset, sdk.NewUint(10), 5000, sdk.ZeroDec(), sdk.ZeroUint(), unstakeTx))
+	c.Assert(err, IsNil)
+	unstakeEvt := NewEvent(UnstakeEventType, 15, unstakeTx, unstakeBytes, EventSuccess)
+	unstakeEvt.OutTxs = common.Txs{
+		common.NewTx(GetRandomTxHash(), runeAddr, runeAddr, common.Coins{common.NewCoin(common.RuneAsset(), sdk.NewUint(9*common.One))}, nil, ""),
+		common.NewTx(GetRandomTxHash(), runeAddr, runeAddr, common.Coins{common.NewCoin(common.BNBAsset, sdk.NewUint(4*common.One))}, nil, ""),
+	}
+	c.Assert(keeper.UpsertEvent(ctx, unstakeEvt), IsNil)
+
+	res, err := querier(ctx, []string{"stakerposition", runeAddr.String()}, abci.RequestQuery{})
+	c.Assert(err, IsNil)
+	var positions []QueryResStakerPosition
+	c.Assert(keeper.Cdc().UnmarshalJSON(res, &positions), IsNil)
+	c.Assert(positions, HasLen, 2)
+	for _, position := range positions {
+		if position.Asset.Equals(common.BNBAsset) {
+			c.Check(position.RedeemableRune.Equal(sdk.NewUint(10*common.One)), Equals, true)
+			c.Check(position.RedeemableAsset.Equal(sdk.NewUint(5*common.One)), Equals, true)
+			c.Check(position.RuneDeposited.Equal(sdk.NewUint(20*common.One)), Equals, true)
+			c.Check(position.AssetDeposited.Equal(sdk.NewUint(10*common.One)), Equals, true)
+			c.Check(position.RuneWithdrawn.Equal(sdk.NewUint(9*common.One)), Equals, true)
+			c.Check(position.AssetWithdrawn.Equal(sdk.NewUint(4*common.One)), Equals, true)
+			c.Check(position.LockUpRemaining, Equals, int64(0))
+			continue
+		}
+		c.Check(position.Units.IsZero(), Equals, true)
+		c.Check(position.RedeemableRune.IsZero(), Equals, true)
+		c.Check(position.PendingRune.Equal(sdk.NewUint(common.One)), Equals, true)
+		lockUp := constants.GetConstantValues(constants.SWVersion).GetInt64Value(constants.StakeLockUpBlocks)
+		c.Check(position.LockUpRemaining, Equals, lockUp-10)
+	}
+
+	_, err = querier(ctx, []string{"stakerposition", "bogus"}, abci.RequestQuery{})
+	c.Assert(err, NotNil)
+}
+
+func (s *QuerierSuite) TestQueryChurnPreview(c *C) {
+	ctx, keeper := setupKeeperForTest(c)
+	querier := NewQuerier(keeper, NewVersionedValidatorMgr(keeper, NewVersionedTxOutStoreDummy(), NewVersionedVaultMgrDummy(NewVersionedTxOutStoreDummy()), NewDummyVersionedEventMgr()))
+	active := make(NodeAccounts, 5)
+	for i := range active {
+		active[i] = GetRandomNodeAccount(NodeActive)
+	}
+	// the first node account is marked to be churned out
+	active[0].LeaveHeight = 1
+	for _, na := range active {
+		c.Assert(keeper.SetNodeAccount(ctx, na), IsNil)
+	}
+	standby := GetRandomNodeAccount(NodeStandby)
+	standby.Bond = sdk.NewUint(1_000_000 * common.One)
+	c.Assert(keeper.SetNodeAccount(ctx, standby), IsNil)
+
+	res, err := querier(ctx, []string{"churnpreview"}, abci.RequestQuery{})
+	c.Assert(err, IsNil)
+	var out QueryResChurnPreview
+	c.Assert(keeper.Cdc().UnmarshalJSON(res, &out), IsNil)
+	c.Check(out.Rotation, Equals, true)
+	c.Assert(out.Leaving, HasLen, 1)
+	c.Check(out.Leaving[0].NodeAddress.Equals(active[0].NodeAddress), Equals, true)
+	c.Assert(out.Joining, HasLen, 1)
+	c.Check(out.Joining[0].NodeAddress.Equals(standby.NodeAddress), Equals, true)
+	c.Check(out.Members, HasLen, 5)
+
+	// nothing is persisted
+	na, getErr := keeper.GetNodeAccount(ctx, standby.NodeAddress)
+	c.Assert(getErr, IsNil)
+	c.Check(na.Status, Equals, NodeStandby)
+	nas, getErr := keeper.ListActiveNodeAccounts(ctx)
+	c.Assert(getErr, IsNil)
+	c.Check(nas, HasLen, 5)
+}
@@ -58,6 +58,18 @@ var (
 	QueryConstantValues     = Query{Key: "constants", EndpointTemplate: "/%s/constants"}
 	QueryMimirValues        = Query{Key: "mimirs", EndpointTemplate: "/%s/mimir"}
 	QueryBan                = Query{Key: "ban", EndpointTemplate: "/%s/ban/{%s}"}
+	QueryInvariants         = Query{Key: "invariants", EndpointTemplate: "/%s/invariants"}
+	QueryLimitOrdersPool    = Query{Key: "limitorderspool", EndpointTemplate: "/%s/limitorders/pool/{%s}"}
+	QueryLimitOrdersAddress = Query{Key: "limitordersaddress", EndpointTemplate: "/%s/limitorders/address/{%s}"}
+	QueryQuoteSwap          = Query{Key: "quoteswap", EndpointTemplate: "/%s/quote/swap"}
+	QueryQuoteStake         = Query{Key: "quotestake", EndpointTemplate: "/%s/quote/stake"}
+	QueryQuoteUnstake       = Query{Key: "quoteunstake", EndpointTemplate: "/%s/quote/unstake"}
+	QueryPoolTWAP           = Query{Key: "pooltwap", EndpointTemplate: "/%s/pool/{%s}/twap"}
+	QueryPoolsTWAP          = Query{Key: "poolstwap", EndpointTemplate: "/%s/pools/twap"}
+	QueryStakerPosition     = Query{Key: "stakerposition", EndpointTemplate: "/%s/staker/{%s}"}
+	QueryNodeAccountSlashes = Query{Key: "nodeaccountslashes", EndpointTemplate: "/%s/nodeaccount/{%s}/slashes"}
+	QueryNodeScorecard      = Query{Key: "nodescorecard", EndpointTemplate: "/%s/nodeaccount/{%s}/scorecard"}
+	QueryChurnPreview       = Query{Key: "churnpreview", EndpointTemplate: "/%s/churn/preview"}
 )
 
 // Queries all queries
@@ -87,4 +99,16 @@ var Queries = []Query{
 	QueryConstantValues,
 	QueryMimirValues,
 	QueryBan,
+	QueryInvariants,
+	QueryLimitOrdersPool,
+	QueryLimitOrdersAddress,
+	QueryQuoteSwap,
+	QueryQuoteStake,
+	QueryQuoteUnstake,
+	QueryPoolTWAP,
+	QueryPoolsTWAP,
+	QueryStakerPosition,
+	QueryNodeAccountSlashes,
+	QueryNodeScorecard,
+	QueryChurnPreview,
 }
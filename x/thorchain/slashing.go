@@ -82,6 +82,7 @@ func (s *Slasher) HandleDoubleSign(ctx sdk.Context, addr crypto.Address, infract
 			}
 			slashAmount := sdk.NewUint(uint64(minBond)).MulUint64(5).QuoUint64(100)
 			na.Bond = common.SafeSub(na.Bond, slashAmount)
+			recordSlash(ctx, s.keeper, constAccessor, na.NodeAddress, SlashReasonDoubleSign, 0, slashAmount)
 
 			if common.RuneAsset().Chain.Equals(common.THORChain) {
 				coin := common.NewCoin(common.RuneNative, slashAmount)
@@ -139,7 +140,7 @@ func (s *Slasher) LackObserving(ctx sdk.Context, constAccessor constants.Constan
 		// this na is not found, therefore it should be slashed
 		if !found {
 			lackOfObservationPenalty := constAccessor.GetInt64Value(constants.LackOfObservationPenalty)
-			if err := s.keeper.IncNodeAccountSlashPoints(ctx, na.NodeAddress, lackOfObservationPenalty); err != nil {
+			if err := incSlashPoints(ctx, s.keeper, constAccessor, na.NodeAddress, lackOfObservationPenalty, SlashReasonLackObserving); err != nil {
 				ctx.Logger().Error("fail to inc slash points", "error", err)
 			}
 		}
@@ -181,7 +182,7 @@ func (s *Slasher) LackSigning(ctx sdk.Context, constAccessor constants.ConstantV
 							ctx.Logger().Error("Unable to get node account", "error", err)
 							continue
 						}
-						if err := s.keeper.IncNodeAccountSlashPoints(ctx, na.NodeAddress, signingTransPeriod*2); err != nil {
+						if err := incSlashPoints(ctx, s.keeper, constAccessor, na.NodeAddress, signingTransPeriod*2, SlashReasonLackSigning); err != nil {
 							ctx.Logger().Error("fail to inc slash points", "error", err)
 						}
 					}
@@ -304,3 +305,91 @@ func (s *Slasher) SlashNodeAccount(ctx sdk.Context, observedPubKey common.PubKey
 
 	return s.keeper.SetNodeAccount(ctx, nodeAccount)
 }
+
+// DecaySlashPoints take a share of the slash points of every active node account off, every SlashPointsDecayBlocks
+func (s *Slasher) DecaySlashPoints(ctx sdk.Context, constAccessor constants.ConstantValues) error {
+	decayBlocks := getSlashPointsDecayBlocks(ctx, s.keeper, constAccessor)
+	decayBasisPoints := getSlashPointsDecayBasisPoints(ctx, s.keeper, constAccessor)
+	if decayBlocks <= 0 || decayBasisPoints <= 0 || ctx.BlockHeight()%decayBlocks != 0 {
+		return nil
+	}
+	if decayBasisPoints > 10_000 {
+		decayBasisPoints = 10_000
+	}
+	nodes, err := s.keeper.ListActiveNodeAccounts(ctx)
+	if err != nil {
+		return fmt.Errorf("fail to get active node accounts: %w", err)
+	}
+	for _, na := range nodes {
+		slashPts, err := s.keeper.GetNodeAccountSlashPoints(ctx, na.NodeAddress)
+		if err != nil {
+			ctx.Logger().Error("fail to get node slash points", "error", err)
+			continue
+		}
+		decayed := slashPts * decayBasisPoints / 10_000
+		if decayed <= 0 {
+			continue
+		}
+		if err := s.keeper.DecNodeAccountSlashPoints(ctx, na.NodeAddress, decayed); err != nil {
+			ctx.Logger().Error("fail to dec slash points", "error", err)
+			continue
+		}
+		slashes, err := s.keeper.GetNodeAccountSlashes(ctx, na.NodeAddress)
+		if err != nil {
+			ctx.Logger().Error("fail to get node account slashes", "error", err)
+			continue
+		}
+		slashes.Decay(decayBasisPoints)
+		s.keeper.SetNodeAccountSlashes(ctx, slashes)
+		record := NewSlashRecord(ctx.BlockHeight(), SlashReasonDecay, -decayed, sdk.ZeroUint())
+		if err := s.keeper.AddSlashRecord(ctx, na.NodeAddress, record); err != nil {
+			ctx.Logger().Error("fail to add slash record", "error", err)
+		}
+		s.keeper.RemoveSlashRecords(ctx, na.NodeAddress, ctx.BlockHeight()-constAccessor.GetInt64Value(constants.SlashLedgerBlocks))
+	}
+	return nil
+}
+
+// incSlashPoints add the given slash points to the node account, and record them with the reason in its slash history
+func incSlashPoints(ctx sdk.Context, keeper keep.Keeper, constAccessor constants.ConstantValues, addr sdk.AccAddress, points int64, reason SlashReason) error {
+	if err := keeper.IncNodeAccountSlashPoints(ctx, addr, points); err != nil {
+		return err
+	}
+	recordSlash(ctx, keeper, constAccessor, addr, reason, points, sdk.ZeroUint())
+	return nil
+}
+
+// recordSlash add a slash to the history of the node account, the history is only there to explain the slashes, so a
+// failure is logged rather than failing the slash
+func recordSlash(ctx sdk.Context, keeper keep.Keeper, constAccessor constants.ConstantValues, addr sdk.AccAddress, reason SlashReason, points int64, rune sdk.Uint) {
+	slashes, err := keeper.GetNodeAccountSlashes(ctx, addr)
+	if err != nil {
+		ctx.Logger().Error("fail to get node account slashes", "error", err)
+		return
+	}
+	slashes.Add(reason, points)
+	keeper.SetNodeAccountSlashes(ctx, slashes)
+	if err := keeper.AddSlashRecord(ctx, addr, NewSlashRecord(ctx.BlockHeight(), reason, points, rune)); err != nil {
+		ctx.Logger().Error("fail to add slash record", "error", err)
+	}
+	keeper.RemoveSlashRecords(ctx, addr, ctx.BlockHeight()-constAccessor.GetInt64Value(constants.SlashLedgerBlocks))
+}
+
+// getSlashPointsDecayBlocks return how often slash points decay, mimir takes precedence over the constant
+func getSlashPointsDecayBlocks(ctx sdk.Context, keeper keep.Keeper, constAccessor constants.ConstantValues) int64 {
+	blocks := constAccessor.GetInt64Value(constants.SlashPointsDecayBlocks)
+	if mimirBlocks, err := keeper.GetMimir(ctx, constants.SlashPointsDecayBlocks.String()); err == nil && mimirBlocks >= 0 {
+		blocks = mimirBlocks
+	}
+	return blocks
+}
+
+// getSlashPointsDecayBasisPoints return the share of slash points that decays each time, mimir takes precedence over the
+// constant
+func getSlashPointsDecayBasisPoints(ctx sdk.Context, keeper keep.Keeper, constAccessor constants.ConstantValues) int64 {
+	bps := constAccessor.GetInt64Value(constants.SlashPointsDecayBasisPoints)
+	if mimirBps, err := keeper.GetMimir(ctx, constants.SlashPointsDecayBasisPoints.String()); err == nil && mimirBps >= 0 {
+		bps = mimirBps
+	}
+	return bps
+}
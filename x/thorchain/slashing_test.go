@@ -486,3 +486,60 @@ func (s *SlashingSuite) TestDoubleSign(c *C) {
 	c.Check(keeper.na.Bond.Equal(sdk.NewUint(9995000000)), Equals, true, Commentf("%d", keeper.na.Bond.Uint64()))
 	c.Check(keeper.vaultData.TotalReserve.Equal(sdk.NewUint(5000000)), Equals, true)
 }
+
+func (s *SlashingSuite) TestSlashPointsHistoryAndDecay(c *C) {
+	ctx, k := setupKeeperForTest(c)
+	ctx = ctx.WithBlockHeight(20)
+	constAccessor := constants.GetConstantValues(constants.SWVersion)
+	na := GetRandomNodeAccount(NodeActive)
+	c.Assert(k.SetNodeAccount(ctx, na), IsNil)
+	slasher, err := NewSlasher(k, constants.SWVersion, NewVersionedEventMgr())
+	c.Assert(err, IsNil)
+
+	c.Assert(incSlashPoints(ctx, k, constAccessor, na.NodeAddress, 10, SlashReasonLackObserving), IsNil)
+	c.Assert(incSlashPoints(ctx, k, constAccessor, na.NodeAddress, 100, SlashReasonFailKeysign), IsNil)
+	slashes, err := k.GetNodeAccountSlashes(ctx, na.NodeAddress)
+	c.Assert(err, IsNil)
+	c.Check(slashes.GetPoints(SlashReasonLackObserving), Equals, int64(10))
+	c.Check(slashes.GetPoints(SlashReasonFailKeysign), Equals, int64(100))
+	records, err := k.GetSlashRecords(ctx, na.NodeAddress, 0)
+	c.Assert(err, IsNil)
+	c.Check(records, HasLen, 2)
+
+	// decay is off by default
+	c.Assert(slasher.DecaySlashPoints(ctx, constAccessor), IsNil)
+	pts, err := k.GetNodeAccountSlashPoints(ctx, na.NodeAddress)
+	c.Assert(err, IsNil)
+	c.Check(pts, Equals, int64(110))
+
+	// half of the slash points decay every 10 blocks
+	k.SetMimir(ctx, constants.SlashPointsDecayBlocks.String(), 10)
+	k.SetMimir(ctx, constants.SlashPointsDecayBasisPoints.String(), 5000)
+	c.Assert(slasher.DecaySlashPoints(ctx.WithBlockHeight(21), constAccessor), IsNil)
+	pts, err = k.GetNodeAccountSlashPoints(ctx, na.NodeAddress)
+	c.Assert(err, IsNil)
+	c.Check(pts, Equals, int64(110))
+	c.Assert(slasher.DecaySlashPoints(ctx, constAccessor), IsNil)
+	pts, err = k.GetNodeAccountSlashPoints(ctx, na.NodeAddress)
+	c.Assert(err, IsNil)
+	c.Check(pts, Equals, int64(55))
+	slashes, err = k.GetNodeAccountSlashes(ctx, na.NodeAddress)
+	c.Assert(err, IsNil)
+	c.Check(slashes.GetPoints(SlashReasonLackObserving), Equals, int64(5))
+	c.Check(slashes.GetPoints(SlashReasonFailKeysign), Equals, int64(50))
+	records, err = k.GetSlashRecords(ctx, na.NodeAddress, 0)
+	c.Assert(err, IsNil)
+	c.Assert(records, HasLen, 3)
+	c.Check(records[0].Reason, Equals, SlashReasonDecay)
+	c.Check(records[0].Points, Equals, int64(-55))
+
+	// the ledger only keeps the recent blocks
+	ctx = ctx.WithBlockHeight(20 + constAccessor.GetInt64Value(constants.SlashLedgerBlocks) + 1)
+	c.Assert(incSlashPoints(ctx, k, constAccessor, na.NodeAddress, 2, SlashReasonLackObserving), IsNil)
+	slashes, err = k.GetNodeAccountSlashes(ctx, na.NodeAddress)
+	c.Assert(err, IsNil)
+	c.Check(slashes.GetPoints(SlashReasonLackObserving), Equals, int64(7))
+	records, err = k.GetSlashRecords(ctx, na.NodeAddress, 0)
+	c.Assert(err, IsNil)
+	c.Assert(records, HasLen, 1)
+}
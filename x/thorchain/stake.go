@@ -38,7 +38,9 @@ func stake(ctx sdk.Context, keeper keep.Keeper,
 	asset common.Asset,
 	stakeRuneAmount, stakeAssetAmount sdk.Uint,
 	runeAddr, assetAddr common.Address,
-	requestTxHash common.TxID, constAccessor constants.ConstantValues) (sdk.Uint, sdk.Error) {
+	requestTxHash common.TxID,
+	asymmetric bool,
+	constAccessor constants.ConstantValues) (sdk.Uint, sdk.Error) {
 	ctx.Logger().Info(fmt.Sprintf("%s staking %s %s", asset, stakeRuneAmount, stakeAssetAmount))
 	if err := validateStakeMessage(ctx, keeper, asset, requestTxHash, runeAddr, assetAddr); err != nil {
 		ctx.Logger().Error("stake message fail validation", "error", err)
@@ -84,15 +86,27 @@ func stake(ctx sdk.Context, keeper keep.Keeper,
 	}
 
 	if !asset.Chain.IsBNB() {
-		if stakeAssetAmount.IsZero() {
+		// unless the staker asked to stake asymmetrically, RUNE wait for the asset leg of the stake, until it times out
+		if stakeAssetAmount.IsZero() && !asymmetric {
+			if su.PendingRune.IsZero() {
+				su.PendingRuneHeight = ctx.BlockHeight()
+				su.PendingTxID = requestTxHash
+			}
 			su.PendingRune = su.PendingRune.Add(stakeRuneAmount)
 			keeper.SetStaker(ctx, su)
 			return sdk.ZeroUint(), nil
 		}
 		stakeRuneAmount = su.PendingRune.Add(stakeRuneAmount)
 		su.PendingRune = sdk.ZeroUint()
+		su.PendingRuneHeight = 0
+		su.PendingTxID = ""
 	}
+	return stakeToPool(ctx, keeper, pool, su, stakeRuneAmount, stakeAssetAmount)
+}
 
+// stakeToPool add the given RUNE and asset to the pool, and credit the staker with the units, when one of the amounts is zero it is an
+// asymmetric stake, which pays the slip it implies by getting less units
+func stakeToPool(ctx sdk.Context, keeper keep.Keeper, pool Pool, su Staker, stakeRuneAmount, stakeAssetAmount sdk.Uint) (sdk.Uint, sdk.Error) {
 	fAssetAmt := stakeAssetAmount
 	fRuneAmt := stakeRuneAmount
 
@@ -126,6 +140,8 @@ func stake(ctx sdk.Context, keeper keep.Keeper,
 	totalStakerUnits := fex.Add(stakerUnits)
 
 	su.Units = totalStakerUnits
+	su.RuneDeposit = su.RuneDeposit.Add(fRuneAmt)
+	su.AssetDeposit = su.AssetDeposit.Add(fAssetAmt)
 	keeper.SetStaker(ctx, su)
 	return stakerUnits, nil
 }
@@ -49,10 +49,12 @@ func (p *StakeTestKeeper) GetStaker(ctx sdk.Context, asset common.Asset, addr co
 		return Staker{}, errors.New("simulate error for test")
 	}
 	staker := Staker{
-		Asset:       asset,
-		RuneAddress: addr,
-		Units:       sdk.ZeroUint(),
-		PendingRune: sdk.ZeroUint(),
+		Asset:        asset,
+		RuneAddress:  addr,
+		Units:        sdk.ZeroUint(),
+		PendingRune:  sdk.ZeroUint(),
+		RuneDeposit:  sdk.ZeroUint(),
+		AssetDeposit: sdk.ZeroUint(),
 	}
 	key := p.GetKey(ctx, prefixStaker, staker.Key())
 	if res, ok := p.store[key]; ok {
@@ -176,7 +178,7 @@ func (StakeSuite) TestStake(c *C) {
 	btcAddress, err := common.NewAddress("bc1qwqdg6squsna38e46795at95yu9atm8azzmyvckulcc7kytlcckxswvvzej")
 	c.Assert(err, IsNil)
 	constAccessor := constants.GetConstantValues(constants.SWVersion)
-	_, err = stake(ctx, ps, common.Asset{}, sdk.NewUint(100*common.One), sdk.NewUint(100*common.One), bnbAddress, assetAddress, txID, constAccessor)
+	_, err = stake(ctx, ps, common.Asset{}, sdk.NewUint(100*common.One), sdk.NewUint(100*common.One), bnbAddress, assetAddress, txID, false, constAccessor)
 	c.Assert(err, NotNil)
 	c.Assert(ps.SetPool(ctx, Pool{
 		BalanceRune:  sdk.ZeroUint(),
@@ -186,7 +188,7 @@ func (StakeSuite) TestStake(c *C) {
 		PoolAddress:  bnbAddress,
 		Status:       PoolEnabled,
 	}), IsNil)
-	stakerUnit, err := stake(ctx, ps, common.BNBAsset, sdk.NewUint(100*common.One), sdk.NewUint(100*common.One), bnbAddress, assetAddress, txID, constAccessor)
+	stakerUnit, err := stake(ctx, ps, common.BNBAsset, sdk.NewUint(100*common.One), sdk.NewUint(100*common.One), bnbAddress, assetAddress, txID, false, constAccessor)
 	c.Assert(stakerUnit.Equal(sdk.NewUint(11250000000)), Equals, true)
 	c.Assert(err, IsNil)
 
@@ -199,12 +201,12 @@ func (StakeSuite) TestStake(c *C) {
 		Status:       PoolEnabled,
 	}), IsNil)
 	// stake asymmetically
-	_, err = stake(ctx, ps, common.BNBAsset, sdk.NewUint(100*common.One), sdk.ZeroUint(), bnbAddress, assetAddress, txID, constAccessor)
+	_, err = stake(ctx, ps, common.BNBAsset, sdk.NewUint(100*common.One), sdk.ZeroUint(), bnbAddress, assetAddress, txID, false, constAccessor)
 	c.Assert(err, IsNil)
-	_, err = stake(ctx, ps, common.BNBAsset, sdk.ZeroUint(), sdk.NewUint(100*common.One), bnbAddress, assetAddress, txID, constAccessor)
+	_, err = stake(ctx, ps, common.BNBAsset, sdk.ZeroUint(), sdk.NewUint(100*common.One), bnbAddress, assetAddress, txID, false, constAccessor)
 	c.Assert(err, IsNil)
 
-	_, err = stake(ctx, ps, notExistStakerAsset, sdk.NewUint(100*common.One), sdk.NewUint(100*common.One), bnbAddress, assetAddress, txID, constAccessor)
+	_, err = stake(ctx, ps, notExistStakerAsset, sdk.NewUint(100*common.One), sdk.NewUint(100*common.One), bnbAddress, assetAddress, txID, false, constAccessor)
 	c.Assert(err, NotNil)
 	c.Assert(ps.SetPool(ctx, Pool{
 		BalanceRune:  sdk.NewUint(100 * common.One),
@@ -219,10 +221,10 @@ func (StakeSuite) TestStake(c *C) {
 		staker := Staker{Units: sdk.NewUint(common.One / 5000)}
 		ps.SetStaker(ctx, staker)
 	}
-	_, err = stake(ctx, ps, common.BNBAsset, sdk.NewUint(common.One), sdk.NewUint(common.One), bnbAddress, assetAddress, txID, constAccessor)
+	_, err = stake(ctx, ps, common.BNBAsset, sdk.NewUint(common.One), sdk.NewUint(common.One), bnbAddress, assetAddress, txID, false, constAccessor)
 	c.Assert(err, IsNil)
 
-	_, err = stake(ctx, ps, common.BNBAsset, sdk.NewUint(100*common.One), sdk.NewUint(100*common.One), bnbAddress, assetAddress, txID, constAccessor)
+	_, err = stake(ctx, ps, common.BNBAsset, sdk.NewUint(100*common.One), sdk.NewUint(100*common.One), bnbAddress, assetAddress, txID, false, constAccessor)
 	c.Assert(err, IsNil)
 	p, err := ps.GetPool(ctx, common.BNBAsset)
 	c.Assert(err, IsNil)
@@ -240,11 +242,11 @@ func (StakeSuite) TestStake(c *C) {
 	}), IsNil)
 
 	// stake rune
-	stakerUnit, err = stake(ctx, ps, common.BTCAsset, sdk.NewUint(100*common.One), sdk.ZeroUint(), bnbAddress, btcAddress, txID, constAccessor)
+	stakerUnit, err = stake(ctx, ps, common.BTCAsset, sdk.NewUint(100*common.One), sdk.ZeroUint(), bnbAddress, btcAddress, txID, false, constAccessor)
 	c.Assert(err, IsNil)
 	c.Check(stakerUnit.IsZero(), Equals, true)
 	// stake btc
-	stakerUnit, err = stake(ctx, ps, common.BTCAsset, sdk.ZeroUint(), sdk.NewUint(100*common.One), bnbAddress, btcAddress, txID, constAccessor)
+	stakerUnit, err = stake(ctx, ps, common.BTCAsset, sdk.ZeroUint(), sdk.NewUint(100*common.One), bnbAddress, btcAddress, txID, false, constAccessor)
 	c.Assert(err, IsNil)
 	c.Check(stakerUnit.IsZero(), Equals, false)
 	p, err = ps.GetPool(ctx, common.BTCAsset)
@@ -253,3 +255,64 @@ func (StakeSuite) TestStake(c *C) {
 	c.Check(p.BalanceRune.Equal(sdk.NewUint(100*common.One)), Equals, true, Commentf("%d", p.BalanceRune.Uint64()))
 	c.Check(p.PoolUnits.Equal(sdk.NewUint(100*common.One)), Equals, true, Commentf("%d", p.PoolUnits.Uint64()))
 }
+
+func (StakeSuite) TestAsymmetricStake(c *C) {
+	ps := NewStakeTestKeeper()
+	ctx, _ := setupKeeperForTest(c)
+	ctx = ctx.WithBlockHeight(10)
+	constAccessor := constants.GetConstantValues(constants.SWVersion)
+	bnbAddress := GetRandomBNBAddress()
+	btcAddress, err := common.NewAddress("bc1qwqdg6squsna38e46795at95yu9atm8azzmyvckulcc7kytlcckxswvvzej")
+	c.Assert(err, IsNil)
+	c.Assert(ps.SetPool(ctx, Pool{
+		BalanceRune:  sdk.NewUint(100 * common.One),
+		BalanceAsset: sdk.NewUint(100 * common.One),
+		Asset:        common.BTCAsset,
+		PoolUnits:    sdk.NewUint(100 * common.One),
+		PoolAddress:  btcAddress,
+		Status:       PoolEnabled,
+	}), IsNil)
+
+	// RUNE without the asymmetric flag wait for the asset
+	txID := GetRandomTxHash()
+	stakerUnit, err := stake(ctx, ps, common.BTCAsset, sdk.NewUint(10*common.One), sdk.ZeroUint(), bnbAddress, btcAddress, txID, false, constAccessor)
+	c.Assert(err, IsNil)
+	c.Check(stakerUnit.IsZero(), Equals, true)
+	su, err := ps.GetStaker(ctx, common.BTCAsset, bnbAddress)
+	c.Assert(err, IsNil)
+	c.Check(su.PendingRune.Equal(sdk.NewUint(10*common.One)), Equals, true)
+	c.Check(su.PendingRuneHeight, Equals, int64(10))
+	c.Check(su.PendingTxID.Equals(txID), Equals, true)
+
+	// asymmetric stake get units right away, and take the pending RUNE with it
+	ctx = ctx.WithBlockHeight(20)
+	stakerUnit, err = stake(ctx, ps, common.BTCAsset, sdk.NewUint(10*common.One), sdk.ZeroUint(), bnbAddress, btcAddress, GetRandomTxHash(), true, constAccessor)
+	c.Assert(err, IsNil)
+	_, expectedUnits, err := calculatePoolUnits(sdk.NewUint(100*common.One), sdk.NewUint(100*common.One), sdk.NewUint(100*common.One), sdk.NewUint(20*common.One), sdk.ZeroUint())
+	c.Assert(err, IsNil)
+	c.Check(stakerUnit.Equal(expectedUnits), Equals, true, Commentf("%s != %s", stakerUnit, expectedUnits))
+	// asymmetric stake pay slip, so it get less units than a symmetric stake of the same value
+	c.Check(stakerUnit.LT(sdk.NewUint(10*common.One)), Equals, true)
+	su, err = ps.GetStaker(ctx, common.BTCAsset, bnbAddress)
+	c.Assert(err, IsNil)
+	c.Check(su.PendingRune.IsZero(), Equals, true)
+	c.Check(su.PendingRuneHeight, Equals, int64(0))
+	c.Check(su.PendingTxID.IsEmpty(), Equals, true)
+	c.Check(su.Units.Equal(expectedUnits), Equals, true)
+	p, err := ps.GetPool(ctx, common.BTCAsset)
+	c.Assert(err, IsNil)
+	c.Check(p.BalanceRune.Equal(sdk.NewUint(120*common.One)), Equals, true)
+	c.Check(p.BalanceAsset.Equal(sdk.NewUint(100*common.One)), Equals, true)
+
+	// asymmetric stake into a pool without depth fail
+	c.Assert(ps.SetPool(ctx, Pool{
+		BalanceRune:  sdk.ZeroUint(),
+		BalanceAsset: sdk.ZeroUint(),
+		Asset:        common.BTCAsset,
+		PoolUnits:    sdk.ZeroUint(),
+		PoolAddress:  btcAddress,
+		Status:       PoolEnabled,
+	}), IsNil)
+	_, err = stake(ctx, ps, common.BTCAsset, sdk.NewUint(10*common.One), sdk.ZeroUint(), GetRandomBNBAddress(), btcAddress, GetRandomTxHash(), true, constAccessor)
+	c.Assert(err, NotNil)
+}
@@ -0,0 +1,95 @@
+package thorchain
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"gitlab.com/thorchain/thornode/common"
+	"gitlab.com/thorchain/thornode/constants"
+	"gitlab.com/thorchain/thornode/x/thorchain/keep"
+)
+
+// getMaxStreamingSwapQuantity return the maximum number of sub-swaps a streaming swap can be split into, mimir takes precedence over the constant
+func getMaxStreamingSwapQuantity(ctx sdk.Context, keeper keep.Keeper, constAccessor constants.ConstantValues) int64 {
+	quantity := constAccessor.GetInt64Value(constants.MaxStreamingSwapQuantity)
+	if mimirQuantity, err := keeper.GetMimir(ctx, constants.MaxStreamingSwapQuantity.String()); err == nil && mimirQuantity > 0 {
+		quantity = mimirQuantity
+	}
+	return quantity
+}
+
+// processStreamingSwap execute the next sub-swap of the given streaming swap, the output of each sub-swap is accumulated
+// and paid out once all the sub-swaps are done. When a sub-swap fails, for example it doesn't meet its share of the trade
+// target, the stream stops, the output accumulated so far is paid out and the source amount not swapped yet is refunded
+func processStreamingSwap(ctx sdk.Context, keeper keep.Keeper, txStore TxOutStore, eventMgr EventManager, constAccessor constants.ConstantValues, msg MsgSwap) error {
+	transactionFee := constAccessor.GetInt64Value(constants.TransactionFee)
+	source := msg.Tx.Coins[0]
+	amount := msg.GetStreamingSwapAmount()
+	subTx := msg.Tx
+	subTx.Coins = common.Coins{common.NewCoin(source.Asset, amount)}
+	emit, events, swapErr := swap(
+		ctx,
+		keeper,
+		subTx,
+		msg.TargetAsset,
+		msg.Destination,
+		msg.GetStreamingTradeTarget(amount),
+		sdk.NewUint(uint64(transactionFee)),
+		constAccessor)
+	if swapErr != nil {
+		ctx.Logger().Error("fail to process streaming sub-swap", "tx", msg.Tx.ID, "count", msg.StreamingCount, "error", swapErr)
+		return finishStreamingSwap(ctx, keeper, txStore, eventMgr, constAccessor, msg, swapErr)
+	}
+	msg.StreamingCount++
+	msg.StreamingIn = msg.StreamingIn.Add(amount)
+	msg.StreamingOut = msg.StreamingOut.Add(emit)
+	for _, evt := range events {
+		evt.StreamingQuantity = msg.StreamingQuantity
+		evt.StreamingCount = msg.StreamingCount
+		if err := eventMgr.EmitSwapEvent(ctx, keeper, evt); err != nil {
+			ctx.Logger().Error("fail to emit swap event", "error", err)
+		}
+		if err := keeper.AddToLiquidityFees(ctx, evt.Pool, evt.LiquidityFeeInRune); err != nil {
+			return fmt.Errorf("fail to add liquidity fees: %w", err)
+		}
+	}
+	if msg.IsStreamingDone() {
+		return finishStreamingSwap(ctx, keeper, txStore, eventMgr, constAccessor, msg, nil)
+	}
+	return keeper.SetSwapQueueItem(ctx, msg)
+}
+
+// finishStreamingSwap pay out the accumulated output of the streaming swap, refund the source amount not swapped yet when
+// the stream stopped because of the given error, and take the swap out of the queue
+func finishStreamingSwap(ctx sdk.Context, keeper keep.Keeper, txStore TxOutStore, eventMgr EventManager, constAccessor constants.ConstantValues, msg MsgSwap, swapErr sdk.Error) error {
+	keeper.RemoveSwapQueueItem(ctx, msg.Tx.ID)
+	if !msg.StreamingOut.IsZero() {
+		toi := &TxOutItem{
+			Chain:     msg.TargetAsset.Chain,
+			InHash:    msg.Tx.ID,
+			ToAddress: msg.Destination,
+			Coin:      common.NewCoin(msg.TargetAsset, msg.StreamingOut),
+		}
+		if _, err := txStore.TryAddTxOutItem(ctx, toi); err != nil {
+			return fmt.Errorf("fail to add outbound tx: %w", err)
+		}
+	}
+	if swapErr == nil {
+		return nil
+	}
+	remaining := common.SafeSub(msg.Tx.Coins[0].Amount, msg.StreamingIn)
+	if remaining.IsZero() {
+		return nil
+	}
+	refundMsg, err := getErrMessageFromABCILog(swapErr.Result().Log)
+	if err != nil {
+		ctx.Logger().Error("fail to get refund msg", "err", err.Error())
+	}
+	tx := msg.Tx
+	tx.Coins = common.Coins{common.NewCoin(msg.Tx.Coins[0].Asset, remaining)}
+	if err := refundTx(ctx, ObservedTx{Tx: tx}, txStore, keeper, constAccessor, swapErr.Code(), refundMsg, eventMgr); err != nil {
+		return fmt.Errorf("fail to refund streaming swap: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,110 @@
+package thorchain
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	. "gopkg.in/check.v1"
+
+	"gitlab.com/thorchain/thornode/common"
+	"gitlab.com/thorchain/thornode/constants"
+	"gitlab.com/thorchain/thornode/x/thorchain/keep"
+	"gitlab.com/thorchain/thornode/x/thorchain/types"
+)
+
+type StreamingSwapSuite struct{}
+
+var _ = Suite(&StreamingSwapSuite{})
+
+func (s *StreamingSwapSuite) setup(c *C, ctx sdk.Context, k keep.Keeper, tradeTarget sdk.Uint) MsgSwap {
+	pool := NewPool()
+	pool.Asset = common.BNBAsset
+	pool.BalanceRune = sdk.NewUint(1000 * common.One)
+	pool.BalanceAsset = sdk.NewUint(1000 * common.One)
+	pool.PoolUnits = sdk.NewUint(1000 * common.One)
+	pool.Status = PoolEnabled
+	c.Assert(k.SetPool(ctx, pool), IsNil)
+
+	msg := NewMsgSwap(common.Tx{
+		ID:          GetRandomTxHash(),
+		Chain:       common.BNBChain,
+		FromAddress: GetRandomBNBAddress(),
+		ToAddress:   GetRandomBNBAddress(),
+		Coins:       common.Coins{common.NewCoin(common.BNBAsset, sdk.NewUint(30*common.One))},
+		Gas:         BNBGasFeeSingleton,
+	}, common.RuneAsset(), GetRandomBNBAddress(), tradeTarget, GetRandomBech32Addr())
+	msg.StreamingQuantity = 3
+	c.Assert(k.SetSwapQueueItem(ctx, msg), IsNil)
+	return msg
+}
+
+func (s *StreamingSwapSuite) TestStreamingSwap(c *C) {
+	ctx, k := setupKeeperForTest(c)
+	constAccessor := constants.GetConstantValues(constants.SWVersion)
+	txOutStore := NewTxStoreDummy()
+	msg := s.setup(c, ctx, k, sdk.ZeroUint())
+
+	for i := int64(1); i < 3; i++ {
+		c.Assert(processStreamingSwap(ctx, k, txOutStore, NewEventMgr(), constAccessor, msg), IsNil)
+		var err error
+		msg, err = k.GetSwapQueueItem(ctx, msg.Tx.ID)
+		c.Assert(err, IsNil)
+		c.Check(msg.StreamingCount, Equals, i)
+		c.Check(msg.StreamingIn.Equal(sdk.NewUint(uint64(i*10*common.One))), Equals, true)
+		items, err := txOutStore.GetOutboundItems(ctx)
+		c.Assert(err, IsNil)
+		c.Check(items, HasLen, 0)
+	}
+	c.Assert(processStreamingSwap(ctx, k, txOutStore, NewEventMgr(), constAccessor, msg), IsNil)
+	_, err := k.GetSwapQueueItem(ctx, msg.Tx.ID)
+	c.Check(err, NotNil)
+	items, err := txOutStore.GetOutboundItems(ctx)
+	c.Assert(err, IsNil)
+	c.Assert(items, HasLen, 1)
+	c.Check(items[0].InHash.Equals(msg.Tx.ID), Equals, true)
+	c.Check(items[0].ToAddress.Equals(msg.Destination), Equals, true)
+	c.Check(items[0].Coin.Asset.IsRune(), Equals, true)
+	// streaming get a better price than swapping 30 BNB in one shot
+	c.Check(items[0].Coin.Amount.GT(calcAssetEmission(sdk.NewUint(1000*common.One), sdk.NewUint(30*common.One), sdk.NewUint(1000*common.One))), Equals, true)
+
+	pool, err := k.GetPool(ctx, common.BNBAsset)
+	c.Assert(err, IsNil)
+	c.Check(pool.BalanceAsset.Equal(sdk.NewUint(1030*common.One)), Equals, true)
+
+	// one swap event per sub-swap
+	count := 0
+	for _, evt := range ctx.EventManager().Events() {
+		if evt.Type == types.SwapEventType {
+			count++
+		}
+	}
+	c.Check(count, Equals, 3)
+}
+
+func (s *StreamingSwapSuite) TestStreamingSwapTradeTargetFail(c *C) {
+	ctx, k := setupKeeperForTest(c)
+	constAccessor := constants.GetConstantValues(constants.SWVersion)
+	txOutStore := NewTxStoreDummy()
+	msg := s.setup(c, ctx, k, sdk.NewUint(27*common.One))
+
+	c.Assert(processStreamingSwap(ctx, k, txOutStore, NewEventMgr(), constAccessor, msg), IsNil)
+	msg, err := k.GetSwapQueueItem(ctx, msg.Tx.ID)
+	c.Assert(err, IsNil)
+	c.Check(msg.StreamingCount, Equals, int64(1))
+
+	// the price of BNB drops, the next sub-swap can't meet its trade target
+	pool, err := k.GetPool(ctx, common.BNBAsset)
+	c.Assert(err, IsNil)
+	pool.BalanceRune = pool.BalanceRune.QuoUint64(2)
+	c.Assert(k.SetPool(ctx, pool), IsNil)
+
+	c.Assert(processStreamingSwap(ctx, k, txOutStore, NewEventMgr(), constAccessor, msg), IsNil)
+	_, err = k.GetSwapQueueItem(ctx, msg.Tx.ID)
+	c.Check(err, NotNil)
+	items, err := txOutStore.GetOutboundItems(ctx)
+	c.Assert(err, IsNil)
+	c.Assert(items, HasLen, 2)
+	c.Check(items[0].Coin.Asset.IsRune(), Equals, true)
+	c.Check(items[0].Coin.Amount.Equal(msg.StreamingOut), Equals, true)
+	c.Check(items[1].Coin.Asset.Equals(common.BNBAsset), Equals, true)
+	c.Check(items[1].Coin.Amount.Equal(sdk.NewUint(20*common.One)), Equals, true)
+	c.Check(items[1].ToAddress.Equals(msg.Tx.FromAddress), Equals, true)
+}
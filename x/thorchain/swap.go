@@ -7,6 +7,7 @@ import (
 	sdk "github.com/cosmos/cosmos-sdk/types"
 
 	"gitlab.com/thorchain/thornode/common"
+	"gitlab.com/thorchain/thornode/constants"
 	"gitlab.com/thorchain/thornode/x/thorchain/keep"
 )
 
@@ -50,7 +51,8 @@ func swap(ctx sdk.Context,
 	target common.Asset,
 	destination common.Address,
 	tradeTarget sdk.Uint,
-	transactionFee sdk.Uint) (sdk.Uint, []EventSwap, sdk.Error) {
+	transactionFee sdk.Uint,
+	constAccessor constants.ConstantValues) (sdk.Uint, []EventSwap, sdk.Error) {
 	var swapEvents []EventSwap
 
 	if err := validateMessage(tx, target, destination); err != nil {
@@ -68,7 +70,7 @@ func swap(ctx sdk.Context,
 		var swapEvt EventSwap
 		var amt sdk.Uint
 		// Here we use a tradeTarget of 0 because the target is for the next swap asset in a double swap
-		amt, sourcePool, swapEvt, swapErr := swapOne(ctx, keeper, tx, common.RuneAsset(), destination, sdk.ZeroUint(), transactionFee)
+		amt, sourcePool, swapEvt, swapErr := swapOne(ctx, keeper, tx, common.RuneAsset(), destination, sdk.ZeroUint(), transactionFee, constAccessor)
 		if swapErr != nil {
 			return sdk.ZeroUint(), swapEvents, swapErr
 		}
@@ -79,7 +81,7 @@ func swap(ctx sdk.Context,
 		swapEvents = append(swapEvents, swapEvt)
 	}
 
-	assetAmount, pool, swapEvt, swapErr := swapOne(ctx, keeper, tx, target, destination, tradeTarget, transactionFee)
+	assetAmount, pool, swapEvt, swapErr := swapOne(ctx, keeper, tx, target, destination, tradeTarget, transactionFee, constAccessor)
 	if swapErr != nil {
 		return sdk.ZeroUint(), swapEvents, swapErr
 	}
@@ -113,7 +115,8 @@ func swapOne(ctx sdk.Context,
 	target common.Asset,
 	destination common.Address,
 	tradeTarget sdk.Uint,
-	transactionFee sdk.Uint) (amt sdk.Uint, poolResult Pool, evt EventSwap, swapErr sdk.Error) {
+	transactionFee sdk.Uint,
+	constAccessor constants.ConstantValues) (amt sdk.Uint, poolResult Pool, evt EventSwap, swapErr sdk.Error) {
 	source := tx.Coins[0].Asset
 	amount := tx.Coins[0].Amount
 
@@ -175,9 +178,8 @@ func swapOne(ctx sdk.Context,
 		return sdk.ZeroUint(), pool, evt, sdk.NewError(DefaultCodespace, CodeSwapFailInvalidBalance, "invalid balance")
 	}
 
-	liquidityFee = calcLiquidityFee(X, x, Y)
+	emitAssets, liquidityFee = NewFeeModel(keeper, constAccessor).CalcSwapEmission(ctx, asset, X, x, Y)
 	tradeSlip = calcTradeSlip(X, x)
-	emitAssets = calcAssetEmission(X, x, Y)
 	swapEvt.LiquidityFee = liquidityFee
 
 	if source.IsRune() {
@@ -70,8 +70,10 @@ func (vm *SwapQv1) EndBlock(ctx sdk.Context, version semver.Version, constAccess
 		ctx.Logger().Error("fail to fetch swap queue from store", "error", err)
 		return err
 	}
+	msgs = vm.processStreamingSwaps(ctx, msgs, txOutStore, eventMgr, constAccessor)
+	msgs = vm.filterLimitOrders(ctx, msgs, txOutStore, eventMgr, constAccessor)
 
-	swaps, err := vm.ScoreMsgs(ctx, msgs)
+	swaps, err := vm.ScoreMsgs(ctx, msgs, constAccessor)
 	if err != nil {
 		ctx.Logger().Error("fail to fetch swap items", "error", err)
 		// continue, don't exit, just do them out of order (instead of not
@@ -82,8 +84,17 @@ func (vm *SwapQv1) EndBlock(ctx sdk.Context, version semver.Version, constAccess
 	for i := 0; i < vm.getTodoNum(len(swaps)); i++ {
 		pick := swaps[i]
 
-		result := handler.handle(ctx, pick.msg, version, constAccessor)
+		var result sdk.Result
+		if pick.msg.LimitOrder {
+			result = vm.handleLimitOrder(ctx, handler, pick.msg, version, constAccessor, txOutStore, eventMgr)
+		} else {
+			result = handler.handle(ctx, pick.msg, version, constAccessor)
+		}
 		if !result.IsOK() {
+			// the price moved away since the limit order was checked, leave it resting in the queue
+			if pick.msg.LimitOrder && result.Code == CodeSwapFailTradeTarget {
+				continue
+			}
 			ctx.Logger().Error("fail to swap", "msg", pick.msg.Tx.String(), "error", result.Log)
 			refundMsg, err := getErrMessageFromABCILog(result.Log)
 			if err != nil {
@@ -99,6 +110,66 @@ func (vm *SwapQv1) EndBlock(ctx sdk.Context, version semver.Version, constAccess
 	return nil
 }
 
+// handleLimitOrder - takes the affiliate fee out of the limit order and swaps
+// what is left. Nothing is kept when the swap fails, so a limit order that
+// keeps resting or gets refunded still has its full amount
+func (vm *SwapQv1) handleLimitOrder(ctx sdk.Context, handler SwapHandler, msg MsgSwap, version semver.Version, constAccessor constants.ConstantValues, txOutStore TxOutStore, eventMgr EventManager) sdk.Result {
+	cacheCtx, commit := ctx.CacheContext()
+	msg.Tx = skimAffiliateFees(cacheCtx, vm.k, txOutStore, eventMgr, constAccessor, msg.Tx, msg.AffiliateAddress, msg.AffiliateBasisPoints)
+	result := handler.handle(cacheCtx, msg, version, constAccessor)
+	if result.IsOK() {
+		commit()
+		ctx.EventManager().EmitEvents(cacheCtx.EventManager().Events())
+	}
+	return result
+}
+
+// processStreamingSwaps - executes the next sub-swap of every streaming swap,
+// so a streaming swap advance in each block regardless how busy the queue is,
+// it returns the swaps that are not streamed
+func (vm *SwapQv1) processStreamingSwaps(ctx sdk.Context, msgs []MsgSwap, txOutStore TxOutStore, eventMgr EventManager, constAccessor constants.ConstantValues) []MsgSwap {
+	result := make([]MsgSwap, 0, len(msgs))
+	for _, msg := range msgs {
+		if !msg.IsStreaming() {
+			result = append(result, msg)
+			continue
+		}
+		if err := processStreamingSwap(ctx, vm.k, txOutStore, eventMgr, constAccessor, msg); err != nil {
+			ctx.Logger().Error("fail to process streaming swap", "tx", msg.Tx.ID, "error", err)
+		}
+	}
+	return result
+}
+
+// filterLimitOrders - refunds the expired limit orders and drops the limit
+// orders whose trade target can't be met at the current pool prices, those
+// stay in the queue until a later block
+func (vm *SwapQv1) filterLimitOrders(ctx sdk.Context, msgs []MsgSwap, txOutStore TxOutStore, eventMgr EventManager, constAccessor constants.ConstantValues) []MsgSwap {
+	result := make([]MsgSwap, 0, len(msgs))
+	for _, msg := range msgs {
+		if !msg.LimitOrder {
+			result = append(result, msg)
+			continue
+		}
+		if msg.IsExpired(ctx.BlockHeight()) {
+			if err := refundTx(ctx, ObservedTx{Tx: msg.Tx}, txOutStore, vm.k, constAccessor, CodeSwapLimitOrderExpired, "limit order expired", eventMgr); err != nil {
+				ctx.Logger().Error("fail to refund expired limit order", "tx", msg.Tx.ID, "error", err)
+			}
+			vm.k.RemoveSwapQueueItem(ctx, msg.Tx.ID)
+			continue
+		}
+		met, err := isLimitOrderMet(ctx, vm.k, msg)
+		if err != nil {
+			ctx.Logger().Error("fail to check limit order", "tx", msg.Tx.ID, "error", err)
+			continue
+		}
+		if met {
+			result = append(result, msg)
+		}
+	}
+	return result
+}
+
 // getTodoNum - determine how many swaps to do.
 func (vm *SwapQv1) getTodoNum(queueLen int) int {
 	// Do half the length of the queue. Unless...
@@ -117,10 +188,13 @@ func (vm *SwapQv1) getTodoNum(queueLen int) int {
 }
 
 // ScoreMsgs - this takes a list of MsgSwap, and converts them to a scored
-// swapItem list
-func (vm *SwapQv1) ScoreMsgs(ctx sdk.Context, msgs []MsgSwap) (swapItems, error) {
+// swapItem list. Liquidity fees are valued at the time weighted average
+// price, so a single block can't move a swap up the queue
+func (vm *SwapQv1) ScoreMsgs(ctx sdk.Context, msgs []MsgSwap, constAccessor constants.ConstantValues) (swapItems, error) {
 	pools := make(map[common.Asset]Pool, 0)
+	twapPools := make(map[common.Asset]Pool, 0)
 	items := make(swapItems, 0)
+	window := getTWAPWindow(ctx, vm.k, constAccessor)
 
 	for _, msg := range msgs {
 		if _, ok := pools[msg.TargetAsset]; !ok {
@@ -129,6 +203,10 @@ func (vm *SwapQv1) ScoreMsgs(ctx sdk.Context, msgs []MsgSwap) (swapItems, error)
 			if err != nil {
 				return items, err
 			}
+			twapPools[msg.TargetAsset], err = getTWAPPool(ctx, vm.k, pools[msg.TargetAsset], window)
+			if err != nil {
+				return items, err
+			}
 		}
 
 		item := swapItem{
@@ -158,7 +236,7 @@ func (vm *SwapQv1) ScoreMsgs(ctx sdk.Context, msgs []MsgSwap) (swapItems, error)
 
 		item.fee = calcLiquidityFee(X, x, Y)
 		if sourceCoin.Asset.IsRune() {
-			item.fee = pool.AssetValueInRune(item.fee)
+			item.fee = twapPools[msg.TargetAsset].AssetValueInRune(item.fee)
 		}
 		item.slip = calcTradeSlip(X, x)
 
@@ -6,6 +6,8 @@ import (
 	sdk "github.com/cosmos/cosmos-sdk/types"
 
 	"gitlab.com/thorchain/thornode/common"
+	"gitlab.com/thorchain/thornode/constants"
+	"gitlab.com/thorchain/thornode/x/thorchain/types"
 )
 
 type SwapQueueSuite struct{}
@@ -64,7 +66,7 @@ func (s SwapQueueSuite) TestScoreMsgs(c *C) {
 		}, common.BNBAsset, GetRandomBNBAddress(), sdk.ZeroUint(), GetRandomBech32Addr()),
 	}
 
-	swaps, err := queue.ScoreMsgs(ctx, msgs)
+	swaps, err := queue.ScoreMsgs(ctx, msgs, constants.GetConstantValues(constants.SWVersion))
 	c.Assert(err, IsNil)
 	swaps = swaps.Sort()
 	c.Check(swaps, HasLen, 5)
@@ -118,7 +120,7 @@ func (s SwapQueueSuite) TestScoreMsgs(c *C) {
 		}, common.BTCAsset, GetRandomBNBAddress(), sdk.ZeroUint(), GetRandomBech32Addr()),
 	}
 
-	swaps, err = queue.ScoreMsgs(ctx, msgs)
+	swaps, err = queue.ScoreMsgs(ctx, msgs, constants.GetConstantValues(constants.SWVersion))
 	c.Assert(err, IsNil)
 	swaps = swaps.Sort()
 	c.Check(swaps, HasLen, 10)
@@ -143,3 +145,115 @@ func (s SwapQueueSuite) TestScoreMsgs(c *C) {
 	c.Check(swaps[9].msg.Tx.Coins[0].Amount.Equal(sdk.NewUint(1*common.One)), Equals, true, Commentf("%d", swaps[0].msg.Tx.Coins[0].Amount.Uint64()))
 	c.Check(swaps[9].msg.Tx.Coins[0].Asset.Equals(common.BNBAsset), Equals, true)
 }
+
+func (s SwapQueueSuite) TestFilterLimitOrders(c *C) {
+	ctx, k := setupKeeperForTest(c)
+	ctx = ctx.WithBlockHeight(10)
+	constAccessor := constants.GetConstantValues(constants.SWVersion)
+
+	pool := NewPool()
+	pool.Asset = common.BNBAsset
+	pool.BalanceRune = sdk.NewUint(100 * common.One)
+	pool.BalanceAsset = sdk.NewUint(100 * common.One)
+	pool.Status = PoolEnabled
+	c.Assert(k.SetPool(ctx, pool), IsNil)
+
+	versionedTxOutStore := NewVersionedTxOutStoreDummy()
+	queue := NewSwapQv1(k, versionedTxOutStore, NewVersionedEventMgr())
+	newLimitOrder := func(target sdk.Uint, expiry int64) MsgSwap {
+		msg := NewMsgSwap(common.Tx{
+			ID:          GetRandomTxHash(),
+			Chain:       common.BNBChain,
+			FromAddress: GetRandomBNBAddress(),
+			Coins:       common.Coins{common.NewCoin(common.BNBAsset, sdk.NewUint(common.One))},
+		}, common.RuneAsset(), GetRandomBNBAddress(), target, GetRandomBech32Addr())
+		msg.LimitOrder = true
+		msg.ExpiryHeight = expiry
+		c.Assert(k.SetSwapQueueItem(ctx, msg), IsNil)
+		return msg
+	}
+	market := NewMsgSwap(common.Tx{
+		ID:    GetRandomTxHash(),
+		Coins: common.Coins{common.NewCoin(common.BNBAsset, sdk.NewUint(common.One))},
+	}, common.RuneAsset(), GetRandomBNBAddress(), sdk.ZeroUint(), GetRandomBech32Addr())
+	met := newLimitOrder(sdk.NewUint(common.One/2), 20)
+	unmet := newLimitOrder(sdk.NewUint(2*common.One), 20)
+	expired := newLimitOrder(sdk.NewUint(common.One/2), 10)
+
+	txOutStore, err := versionedTxOutStore.GetTxOutStore(ctx, k, constants.SWVersion)
+	c.Assert(err, IsNil)
+	msgs := queue.filterLimitOrders(ctx, []MsgSwap{market, met, unmet, expired}, txOutStore, NewEventMgr(), constAccessor)
+	c.Assert(msgs, HasLen, 2)
+	c.Check(msgs[0].Tx.ID.Equals(market.Tx.ID), Equals, true)
+	c.Check(msgs[1].Tx.ID.Equals(met.Tx.ID), Equals, true)
+
+	// the unmet limit order keeps resting, the expired one is refunded
+	_, err = k.GetSwapQueueItem(ctx, unmet.Tx.ID)
+	c.Check(err, IsNil)
+	_, err = k.GetSwapQueueItem(ctx, expired.Tx.ID)
+	c.Check(err, NotNil)
+	items, err := txOutStore.GetOutboundItems(ctx)
+	c.Assert(err, IsNil)
+	c.Assert(items, HasLen, 1)
+	c.Check(items[0].InHash.Equals(expired.Tx.ID), Equals, true)
+}
+
+func (s SwapQueueSuite) TestHandleLimitOrderAffiliateFee(c *C) {
+	ctx, k := setupKeeperForTest(c)
+	constAccessor := constants.GetConstantValues(constants.SWVersion)
+
+	pool := NewPool()
+	pool.Asset = common.BNBAsset
+	pool.BalanceRune = sdk.NewUint(1000 * common.One)
+	pool.BalanceAsset = sdk.NewUint(1000 * common.One)
+	pool.PoolUnits = sdk.NewUint(1000 * common.One)
+	pool.Status = PoolEnabled
+	c.Assert(k.SetPool(ctx, pool), IsNil)
+
+	versionedTxOutStore := NewVersionedTxOutStoreDummy()
+	versionedEventMgr := NewVersionedEventMgr()
+	queue := NewSwapQv1(k, versionedTxOutStore, versionedEventMgr)
+	handler := NewSwapHandler(k, versionedTxOutStore, versionedEventMgr)
+	txOutStore, err := versionedTxOutStore.GetTxOutStore(ctx, k, constants.SWVersion)
+	c.Assert(err, IsNil)
+	affiliate := GetRandomBNBAddress()
+	newLimitOrder := func(target sdk.Uint) MsgSwap {
+		tx := common.NewTx(GetRandomTxHash(), GetRandomBNBAddress(), GetRandomBNBAddress(), common.Coins{common.NewCoin(common.RuneAsset(), sdk.NewUint(1000*common.One))}, BNBGasFeeSingleton, "")
+		msg := NewMsgSwap(tx, common.BNBAsset, GetRandomBNBAddress(), target, GetRandomBech32Addr())
+		msg.LimitOrder = true
+		msg.AffiliateAddress = affiliate
+		msg.AffiliateBasisPoints = sdk.NewUint(100)
+		return msg
+	}
+	hasAffiliateFeeEvent := func(ctx sdk.Context) bool {
+		for _, evt := range ctx.EventManager().Events() {
+			if evt.Type == types.AffiliateFeeEventType {
+				return true
+			}
+		}
+		return false
+	}
+
+	// the affiliate isn't paid when the limit order can't be swapped, and the pool doesn't change
+	result := queue.handleLimitOrder(ctx, handler, newLimitOrder(sdk.NewUint(1000*common.One)), constants.SWVersion, constAccessor, txOutStore, NewEventMgr())
+	c.Check(result.Code, Equals, CodeSwapFailTradeTarget)
+	c.Check(hasAffiliateFeeEvent(ctx), Equals, false)
+	after, err := k.GetPool(ctx, common.BNBAsset)
+	c.Assert(err, IsNil)
+	c.Check(after.BalanceRune.Equal(pool.BalanceRune), Equals, true)
+	c.Check(after.BalanceAsset.Equal(pool.BalanceAsset), Equals, true)
+
+	// the affiliate fee is taken when the limit order is swapped
+	txOutStore.ClearOutboundItems(ctx)
+	result = queue.handleLimitOrder(ctx, handler, newLimitOrder(sdk.NewUint(common.One)), constants.SWVersion, constAccessor, txOutStore, NewEventMgr())
+	c.Assert(result.IsOK(), Equals, true, Commentf("%s", result.Log))
+	c.Check(hasAffiliateFeeEvent(ctx), Equals, true)
+	after, err = k.GetPool(ctx, common.BNBAsset)
+	c.Assert(err, IsNil)
+	c.Check(after.BalanceRune.Equal(sdk.NewUint(1990*common.One)), Equals, true, Commentf("%s", after.BalanceRune))
+	items, err := txOutStore.GetOutboundItems(ctx)
+	c.Assert(err, IsNil)
+	c.Assert(items, HasLen, 2)
+	c.Check(items[0].ToAddress.Equals(affiliate), Equals, true)
+	c.Check(items[0].Coin.Equals(common.NewCoin(common.RuneAsset(), sdk.NewUint(10*common.One))), Equals, true)
+}
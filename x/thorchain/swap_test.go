@@ -59,6 +59,8 @@ func (k *TestSwapKeeper) GetStaker(ctx sdk.Context, asset common.Asset, addr com
 		AssetAddress: addr,
 		Units:        sdk.NewUint(100),
 		PendingRune:  sdk.ZeroUint(),
+		RuneDeposit:  sdk.ZeroUint(),
+		AssetDeposit: sdk.ZeroUint(),
 	}, nil
 }
 
@@ -275,7 +277,7 @@ func (s *SwapSuite) TestSwap(c *C) {
 			"",
 		)
 		tx.Chain = common.BNBChain
-		amount, evts, err := swap(ctx, poolStorage, tx, item.target, item.destination, item.tradeTarget, sdk.NewUint(1000_000))
+		amount, evts, err := swap(ctx, poolStorage, tx, item.target, item.destination, item.tradeTarget, sdk.NewUint(1000_000), constants.GetConstantValues(constants.SWVersion))
 		if item.expectedErr == nil {
 			c.Assert(err, IsNil)
 			c.Assert(evts, HasLen, len(item.events))
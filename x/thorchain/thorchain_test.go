@@ -51,18 +51,18 @@ func (s *ThorchainSuite) TestStaking(c *C) {
 	c.Assert(keeper.SetPool(ctx, pool), IsNil)
 
 	// stake for user1
-	_, err = stake(ctx, keeper, common.BNBAsset, sdk.NewUint(100*common.One), sdk.NewUint(100*common.One), user1, user1, txID, constAccessor)
+	_, err = stake(ctx, keeper, common.BNBAsset, sdk.NewUint(100*common.One), sdk.NewUint(100*common.One), user1, user1, txID, false, constAccessor)
 	c.Assert(err, IsNil)
-	_, err = stake(ctx, keeper, common.BNBAsset, sdk.NewUint(100*common.One), sdk.NewUint(100*common.One), user1, user1, txID, constAccessor)
+	_, err = stake(ctx, keeper, common.BNBAsset, sdk.NewUint(100*common.One), sdk.NewUint(100*common.One), user1, user1, txID, false, constAccessor)
 	c.Assert(err, IsNil)
 	staker1, err := keeper.GetStaker(ctx, common.BNBAsset, user1)
 	c.Assert(err, IsNil)
 	c.Check(staker1.Units.IsZero(), Equals, false)
 
 	// stake for user2
-	_, err = stake(ctx, keeper, common.BNBAsset, sdk.NewUint(75*common.One), sdk.NewUint(75*common.One), user2, user2, txID, constAccessor)
+	_, err = stake(ctx, keeper, common.BNBAsset, sdk.NewUint(75*common.One), sdk.NewUint(75*common.One), user2, user2, txID, false, constAccessor)
 	c.Assert(err, IsNil)
-	_, err = stake(ctx, keeper, common.BNBAsset, sdk.NewUint(75*common.One), sdk.NewUint(75*common.One), user2, user2, txID, constAccessor)
+	_, err = stake(ctx, keeper, common.BNBAsset, sdk.NewUint(75*common.One), sdk.NewUint(75*common.One), user2, user2, txID, false, constAccessor)
 	c.Assert(err, IsNil)
 	staker2, err := keeper.GetStaker(ctx, common.BNBAsset, user2)
 	c.Assert(err, IsNil)
@@ -71,7 +71,7 @@ func (s *ThorchainSuite) TestStaking(c *C) {
 	version := constants.SWVersion
 	// unstake for user1
 	msg := NewMsgSetUnStake(GetRandomTx(), user1, sdk.NewUint(10000), common.BNBAsset, GetRandomBech32Addr())
-	_, _, _, _, err = unstake(ctx, version, keeper, msg, eventManager)
+	_, _, _, _, _, err = unstake(ctx, version, keeper, msg, eventManager)
 	c.Assert(err, IsNil)
 	staker1, err = keeper.GetStaker(ctx, common.BNBAsset, user1)
 	c.Assert(err, IsNil)
@@ -79,7 +79,7 @@ func (s *ThorchainSuite) TestStaking(c *C) {
 
 	// unstake for user2
 	msg = NewMsgSetUnStake(GetRandomTx(), user2, sdk.NewUint(10000), common.BNBAsset, GetRandomBech32Addr())
-	_, _, _, _, err = unstake(ctx, version, keeper, msg, eventManager)
+	_, _, _, _, _, err = unstake(ctx, version, keeper, msg, eventManager)
 	c.Assert(err, IsNil)
 	staker2, err = keeper.GetStaker(ctx, common.BNBAsset, user2)
 	c.Assert(err, IsNil)
@@ -93,9 +93,9 @@ func (s *ThorchainSuite) TestStaking(c *C) {
 	c.Check(pool.PoolUnits.IsZero(), Equals, true)
 
 	// stake for user1, again
-	_, err = stake(ctx, keeper, common.BNBAsset, sdk.NewUint(100*common.One), sdk.NewUint(100*common.One), user1, user1, txID, constAccessor)
+	_, err = stake(ctx, keeper, common.BNBAsset, sdk.NewUint(100*common.One), sdk.NewUint(100*common.One), user1, user1, txID, false, constAccessor)
 	c.Assert(err, IsNil)
-	_, err = stake(ctx, keeper, common.BNBAsset, sdk.NewUint(100*common.One), sdk.NewUint(100*common.One), user1, user1, txID, constAccessor)
+	_, err = stake(ctx, keeper, common.BNBAsset, sdk.NewUint(100*common.One), sdk.NewUint(100*common.One), user1, user1, txID, false, constAccessor)
 	c.Assert(err, IsNil)
 	staker1, err = keeper.GetStaker(ctx, common.BNBAsset, user1)
 	c.Assert(err, IsNil)
@@ -273,17 +273,17 @@ func (s *ThorchainSuite) TestRagnarok(c *C) {
 
 	// add stakers
 	staker1 := GetRandomBNBAddress() // Staker1
-	_, err = stake(ctx, keeper, common.BNBAsset, sdk.NewUint(100*common.One), sdk.NewUint(10*common.One), staker1, staker1, GetRandomTxHash(), consts)
+	_, err = stake(ctx, keeper, common.BNBAsset, sdk.NewUint(100*common.One), sdk.NewUint(10*common.One), staker1, staker1, GetRandomTxHash(), false, consts)
 	c.Assert(err, IsNil)
-	_, err = stake(ctx, keeper, boltAsset, sdk.NewUint(50*common.One), sdk.NewUint(11*common.One), staker1, staker1, GetRandomTxHash(), consts)
+	_, err = stake(ctx, keeper, boltAsset, sdk.NewUint(50*common.One), sdk.NewUint(11*common.One), staker1, staker1, GetRandomTxHash(), false, consts)
 	c.Assert(err, IsNil)
 	staker2 := GetRandomBNBAddress() // staker2
-	_, err = stake(ctx, keeper, common.BNBAsset, sdk.NewUint(155*common.One), sdk.NewUint(15*common.One), staker2, staker2, GetRandomTxHash(), consts)
+	_, err = stake(ctx, keeper, common.BNBAsset, sdk.NewUint(155*common.One), sdk.NewUint(15*common.One), staker2, staker2, GetRandomTxHash(), false, consts)
 	c.Assert(err, IsNil)
-	_, err = stake(ctx, keeper, boltAsset, sdk.NewUint(20*common.One), sdk.NewUint(4*common.One), staker2, staker2, GetRandomTxHash(), consts)
+	_, err = stake(ctx, keeper, boltAsset, sdk.NewUint(20*common.One), sdk.NewUint(4*common.One), staker2, staker2, GetRandomTxHash(), false, consts)
 	c.Assert(err, IsNil)
 	staker3 := GetRandomBNBAddress() // staker3
-	_, err = stake(ctx, keeper, common.BNBAsset, sdk.NewUint(155*common.One), sdk.NewUint(15*common.One), staker3, staker3, GetRandomTxHash(), consts)
+	_, err = stake(ctx, keeper, common.BNBAsset, sdk.NewUint(155*common.One), sdk.NewUint(15*common.One), staker3, staker3, GetRandomTxHash(), false, consts)
 	c.Assert(err, IsNil)
 	stakers := []common.Address{
 		staker1, staker2, staker3,
@@ -463,17 +463,17 @@ func (s *ThorchainSuite) TestRagnarokNoOneLeave(c *C) {
 
 	// add stakers
 	staker1 := GetRandomBNBAddress() // Staker1
-	_, err = stake(ctx, keeper, common.BNBAsset, sdk.NewUint(100*common.One), sdk.NewUint(10*common.One), staker1, staker1, GetRandomTxHash(), consts)
+	_, err = stake(ctx, keeper, common.BNBAsset, sdk.NewUint(100*common.One), sdk.NewUint(10*common.One), staker1, staker1, GetRandomTxHash(), false, consts)
 	c.Assert(err, IsNil)
-	_, err = stake(ctx, keeper, boltAsset, sdk.NewUint(50*common.One), sdk.NewUint(11*common.One), staker1, staker1, GetRandomTxHash(), consts)
+	_, err = stake(ctx, keeper, boltAsset, sdk.NewUint(50*common.One), sdk.NewUint(11*common.One), staker1, staker1, GetRandomTxHash(), false, consts)
 	c.Assert(err, IsNil)
 	staker2 := GetRandomBNBAddress() // staker2
-	_, err = stake(ctx, keeper, common.BNBAsset, sdk.NewUint(155*common.One), sdk.NewUint(15*common.One), staker2, staker2, GetRandomTxHash(), consts)
+	_, err = stake(ctx, keeper, common.BNBAsset, sdk.NewUint(155*common.One), sdk.NewUint(15*common.One), staker2, staker2, GetRandomTxHash(), false, consts)
 	c.Assert(err, IsNil)
-	_, err = stake(ctx, keeper, boltAsset, sdk.NewUint(20*common.One), sdk.NewUint(4*common.One), staker2, staker2, GetRandomTxHash(), consts)
+	_, err = stake(ctx, keeper, boltAsset, sdk.NewUint(20*common.One), sdk.NewUint(4*common.One), staker2, staker2, GetRandomTxHash(), false, consts)
 	c.Assert(err, IsNil)
 	staker3 := GetRandomBNBAddress() // staker3
-	_, err = stake(ctx, keeper, common.BNBAsset, sdk.NewUint(155*common.One), sdk.NewUint(15*common.One), staker3, staker3, GetRandomTxHash(), consts)
+	_, err = stake(ctx, keeper, common.BNBAsset, sdk.NewUint(155*common.One), sdk.NewUint(15*common.One), staker3, staker3, GetRandomTxHash(), false, consts)
 	c.Assert(err, IsNil)
 	stakers := []common.Address{
 		staker1, staker2, staker3,
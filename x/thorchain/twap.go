@@ -0,0 +1,96 @@
+package thorchain
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"gitlab.com/thorchain/thornode/common"
+	"gitlab.com/thorchain/thornode/constants"
+	"gitlab.com/thorchain/thornode/x/thorchain/keep"
+)
+
+// getTWAPWindow return the number of blocks the time weighted average price used to score swaps and fund yggdrasil vaults
+// is taken over, mimir takes precedence over the constant, zero means the spot price is used
+func getTWAPWindow(ctx sdk.Context, keeper keep.Keeper, constAccessor constants.ConstantValues) int64 {
+	window := constAccessor.GetInt64Value(constants.TWAPWindow)
+	if mimirWindow, err := keeper.GetMimir(ctx, constants.TWAPWindow.String()); err == nil && mimirWindow >= 0 {
+		window = mimirWindow
+	}
+	return window
+}
+
+// getTWAPMaxWindow return the number of blocks of pool price history to keep, mimir takes precedence over the constant
+func getTWAPMaxWindow(ctx sdk.Context, keeper keep.Keeper, constAccessor constants.ConstantValues) int64 {
+	window := constAccessor.GetInt64Value(constants.TWAPMaxWindow)
+	if mimirWindow, err := keeper.GetMimir(ctx, constants.TWAPMaxWindow.String()); err == nil && mimirWindow > 0 {
+		window = mimirWindow
+	}
+	return window
+}
+
+// getPoolTWAP return the time weighted average price of one asset in RUNE over the given number of blocks before the
+// current one. The window is cut short when the pool doesn't have that much price history, the spot price is returned
+// when there is no history at all
+func getPoolTWAP(ctx sdk.Context, keeper keep.Keeper, pool Pool, window int64) (sdk.Uint, error) {
+	if window <= 0 || pool.PriceCumulativeHeight == 0 {
+		return pool.AssetPrice(), nil
+	}
+	height := ctx.BlockHeight()
+	start, err := keeper.GetPoolPriceCumulative(ctx, pool.Asset, height-window)
+	if err != nil {
+		return sdk.ZeroUint(), fmt.Errorf("fail to get price cumulative of pool(%s): %w", pool.Asset, err)
+	}
+	if start.Height >= height {
+		return pool.AssetPrice(), nil
+	}
+	end := pool.GetPriceCumulative(height)
+	return common.SafeSub(end, start.PriceCumulative).QuoUint64(uint64(height - start.Height)), nil
+}
+
+// getTWAPPool return a copy of the given pool with its asset depth moved, so the pool prices the asset at its time weighted
+// average price rather than the spot price. The copy is only good for valuing things, it must never be saved
+func getTWAPPool(ctx sdk.Context, keeper keep.Keeper, pool Pool, window int64) (Pool, error) {
+	if window <= 0 || pool.BalanceRune.IsZero() || pool.BalanceAsset.IsZero() {
+		return pool, nil
+	}
+	twap, err := getPoolTWAP(ctx, keeper, pool, window)
+	if err != nil {
+		return pool, err
+	}
+	if twap.IsZero() {
+		return pool, nil
+	}
+	pool.BalanceAsset = common.GetShare(sdk.NewUint(common.One), twap, pool.BalanceRune)
+	return pool, nil
+}
+
+// getTWAPPools return a copy of each of the given pools priced at its time weighted average price
+func getTWAPPools(ctx sdk.Context, keeper keep.Keeper, pools Pools, window int64) (Pools, error) {
+	twapPools := make(Pools, len(pools))
+	for i, pool := range pools {
+		twapPool, err := getTWAPPool(ctx, keeper, pool, window)
+		if err != nil {
+			return nil, err
+		}
+		twapPools[i] = twapPool
+	}
+	return twapPools, nil
+}
+
+// pruneTWAPHistory remove the pool price history that is older than the longest window a time weighted average price
+// can be asked for
+func pruneTWAPHistory(ctx sdk.Context, keeper keep.Keeper, constAccessor constants.ConstantValues) error {
+	height := ctx.BlockHeight() - getTWAPMaxWindow(ctx, keeper, constAccessor)
+	if height <= 0 {
+		return nil
+	}
+	pools, err := keeper.GetPools(ctx)
+	if err != nil {
+		return fmt.Errorf("fail to get pools: %w", err)
+	}
+	for _, pool := range pools {
+		keeper.RemovePoolPriceCumulatives(ctx, pool.Asset, height)
+	}
+	return nil
+}
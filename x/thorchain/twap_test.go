@@ -0,0 +1,78 @@
+package thorchain
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	. "gopkg.in/check.v1"
+
+	"gitlab.com/thorchain/thornode/common"
+	"gitlab.com/thorchain/thornode/constants"
+)
+
+type TWAPSuite struct{}
+
+var _ = Suite(&TWAPSuite{})
+
+func (s *TWAPSuite) TestPoolTWAP(c *C) {
+	ctx, k := setupKeeperForTest(c)
+	pool := NewPool()
+	pool.Asset = common.BNBAsset
+	pool.BalanceRune = sdk.NewUint(100 * common.One)
+	pool.BalanceAsset = sdk.NewUint(100 * common.One)
+	pool.Status = PoolEnabled
+	ctx = ctx.WithBlockHeight(10)
+	c.Assert(k.SetPool(ctx, pool), IsNil)
+
+	// someone pumps the price right before the swap queue runs
+	ctx = ctx.WithBlockHeight(110)
+	pool.BalanceRune = sdk.NewUint(400 * common.One)
+	c.Assert(k.SetPool(ctx, pool), IsNil)
+	pool, err := k.GetPool(ctx, common.BNBAsset)
+	c.Assert(err, IsNil)
+
+	twap, err := getPoolTWAP(ctx, k, pool, 100)
+	c.Assert(err, IsNil)
+	c.Check(twap.Equal(sdk.NewUint(common.One)), Equals, true, Commentf("%d", twap.Uint64()))
+	twap, err = getPoolTWAP(ctx, k, pool, 0)
+	c.Assert(err, IsNil)
+	c.Check(twap.Equal(sdk.NewUint(4*common.One)), Equals, true)
+
+	// a window longer than the history is cut short
+	ctx = ctx.WithBlockHeight(120)
+	twap, err = getPoolTWAP(ctx, k, pool, 1000)
+	c.Assert(err, IsNil)
+	c.Check(twap.Equal(sdk.NewUint(140*common.One/110)), Equals, true, Commentf("%d", twap.Uint64()))
+
+	twapPool, err := getTWAPPool(ctx, k, pool, 10)
+	c.Assert(err, IsNil)
+	c.Check(twapPool.BalanceRune.Equal(pool.BalanceRune), Equals, true)
+	c.Check(twapPool.AssetValueInRune(sdk.NewUint(common.One)).Equal(sdk.NewUint(4*common.One)), Equals, true)
+	twapPool, err = getTWAPPool(ctx, k, pool, 110)
+	c.Assert(err, IsNil)
+	c.Check(twapPool.BalanceAsset.Equal(pool.BalanceAsset), Equals, false)
+
+	// a pool without price history is priced at spot
+	pool.Asset = common.BTCAsset
+	pool.PriceCumulativeHeight = 0
+	twapPool, err = getTWAPPool(ctx, k, pool, 100)
+	c.Assert(err, IsNil)
+	c.Check(twapPool.BalanceAsset.Equal(pool.BalanceAsset), Equals, true)
+}
+
+func (s *TWAPSuite) TestPruneTWAPHistory(c *C) {
+	ctx, k := setupKeeperForTest(c)
+	constAccessor := constants.GetConstantValues(constants.SWVersion)
+	k.SetMimir(ctx, constants.TWAPMaxWindow.String(), 10)
+	pool := NewPool()
+	pool.Asset = common.BNBAsset
+	pool.BalanceRune = sdk.NewUint(100 * common.One)
+	pool.BalanceAsset = sdk.NewUint(100 * common.One)
+	for _, height := range []int64{1, 5, 20} {
+		ctx = ctx.WithBlockHeight(height)
+		c.Assert(k.SetPool(ctx, pool), IsNil)
+	}
+	ctx = ctx.WithBlockHeight(25)
+	c.Assert(pruneTWAPHistory(ctx, k, constAccessor), IsNil)
+	checkpoint, err := k.GetPoolPriceCumulative(ctx, common.BNBAsset, 1)
+	c.Assert(err, IsNil)
+	c.Check(checkpoint.Height, Equals, int64(5))
+}
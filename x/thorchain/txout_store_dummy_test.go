@@ -78,3 +78,12 @@ func (tos *TxOutStoreDummy) UnSafeAddTxOutItem(ctx sdk.Context, toi *TxOutItem)
 func (tos *TxOutStoreDummy) addToBlockOut(_ sdk.Context, toi *TxOutItem) {
 	tos.blockOut.TxArray = append(tos.blockOut.TxArray, toi)
 }
+
+// TxOutStoreNotAddedDummy is a TxOutStore that can't add any outbound, as if the amount can't cover the transaction fee
+type TxOutStoreNotAddedDummy struct {
+	*TxOutStoreDummy
+}
+
+func (tos *TxOutStoreNotAddedDummy) TryAddTxOutItem(_ sdk.Context, _ *TxOutItem) (bool, error) {
+	return false, nil
+}
@@ -145,3 +145,29 @@ func (s TxOutStoreSuite) TestAddOutTxItemWithoutBFT(c *C) {
 	c.Assert(msgs, HasLen, 1)
 	c.Assert(msgs[0].Coin.Amount.Equal(sdk.NewUint(19*common.One)), Equals, true)
 }
+
+func (s TxOutStoreSuite) TestAddOutTxItemWithNetworkFee(c *C) {
+	w := getHandlerTestWrapper(c, 1, true, true)
+	vault := GetRandomVault()
+	vault.Coins = common.Coins{
+		common.NewCoin(common.BNBAsset, sdk.NewUint(100*common.One)),
+	}
+	w.keeper.SetVault(w.ctx, vault)
+	c.Assert(w.keeper.SaveNetworkFee(w.ctx, common.BNBChain, NewNetworkFee(common.BNBChain, 1, 1, 37500)), IsNil)
+
+	item := &TxOutItem{
+		Chain:     common.BNBChain,
+		ToAddress: GetRandomBNBAddress(),
+		InHash:    GetRandomTxHash(),
+		Coin:      common.NewCoin(common.BNBAsset, sdk.NewUint(20*common.One)),
+	}
+	txOutStore, err := w.versionedTxOutStore.GetTxOutStore(w.ctx, w.keeper, constants.SWVersion)
+	c.Assert(err, IsNil)
+	success, err := txOutStore.TryAddTxOutItem(w.ctx, item)
+	c.Assert(err, IsNil)
+	c.Assert(success, Equals, true)
+	msgs, err := txOutStore.GetOutboundItems(w.ctx)
+	c.Assert(err, IsNil)
+	c.Assert(msgs, HasLen, 1)
+	c.Check(msgs[0].MaxGas.Equals(common.Gas{common.NewCoin(common.BNBAsset, sdk.NewUint(37500))}), Equals, true)
+}
@@ -146,28 +146,22 @@ func (tos *TxOutStorageV1) prepareTxOutItem(ctx sdk.Context, toi *TxOutItem) (bo
 
 	transactionFee := tos.constAccessor.GetInt64Value(constants.TransactionFee)
 	if toi.MaxGas.IsEmpty() {
-		gasAsset := toi.Chain.GetGasAsset()
-		pool, err := tos.keeper.GetPool(ctx, gasAsset)
+		maxGas, err := calcOutboundGas(ctx, tos.keeper, toi.Chain, transactionFee)
 		if err != nil {
-			return false, fmt.Errorf("failed to get gas asset pool: %w", err)
+			return false, err
 		}
-
-		// max gas amount is the transaction fee divided by two, in asset amount
-		maxAmt := pool.RuneValueInAsset(sdk.NewUint(uint64(transactionFee / 2)))
-		toi.MaxGas = common.Gas{
-			common.NewCoin(gasAsset, maxAmt),
-		}
-
+		toi.MaxGas = maxGas
 	}
 	// Deduct TransactionFee from TOI and add to Reserve
 	memo, err := ParseMemo(toi.Memo) // ignore err
 	if err == nil && !memo.IsType(TxYggdrasilFund) && !memo.IsType(TxYggdrasilReturn) && !memo.IsType(TxMigrate) && !memo.IsType(TxRagnarok) {
 		var runeFee sdk.Uint
+		outboundFee := NewFeeModel(tos.keeper, tos.constAccessor).GetOutboundFee(ctx, toi.Chain)
 		if toi.Coin.Asset.IsRune() {
-			if toi.Coin.Amount.LTE(sdk.NewUint(uint64(transactionFee))) {
+			if toi.Coin.Amount.LTE(outboundFee) {
 				runeFee = toi.Coin.Amount // Fee is the full amount
 			} else {
-				runeFee = sdk.NewUint(uint64(transactionFee)) // Fee is the prescribed fee
+				runeFee = outboundFee // Fee is the prescribed fee
 			}
 			toi.Coin.Amount = common.SafeSub(toi.Coin.Amount, runeFee)
 			fee := common.NewFee(common.Coins{common.NewCoin(toi.Coin.Asset, runeFee)}, sdk.ZeroUint())
@@ -187,12 +181,12 @@ func (tos *TxOutStorageV1) prepareTxOutItem(ctx sdk.Context, toi *TxOutItem) (bo
 				return false, fmt.Errorf("fail to get pool: %w", err)
 			}
 
-			assetFee := pool.RuneValueInAsset(sdk.NewUint(uint64(transactionFee))) // Get fee in Asset value
+			assetFee := pool.RuneValueInAsset(outboundFee) // Get fee in Asset value
 			if toi.Coin.Amount.LTE(assetFee) {
 				assetFee = toi.Coin.Amount // Fee is the full amount
 				runeFee = pool.AssetValueInRune(assetFee)
 			} else {
-				runeFee = sdk.NewUint(uint64(transactionFee))
+				runeFee = outboundFee
 			}
 
 			toi.Coin.Amount = common.SafeSub(toi.Coin.Amount, assetFee) // Deduct Asset fee
@@ -33,4 +33,7 @@ func RegisterCodec(cdc *codec.Codec) {
 	cdc.RegisterConcrete(MsgBan{}, "thorchain/MsgBan", nil)
 	cdc.RegisterConcrete(MsgSwitch{}, "thorchain/MsgSwitch", nil)
 	cdc.RegisterConcrete(MsgMimir{}, "thorchain/MsgMimir", nil)
+	cdc.RegisterConcrete(MsgNetworkFee{}, "thorchain/MsgNetworkFee", nil)
+	cdc.RegisterConcrete(MsgCancelSwap{}, "thorchain/MsgCancelSwap", nil)
+	cdc.RegisterConcrete(MsgUnBond{}, "thorchain/MsgUnBond", nil)
 }
@@ -13,16 +13,19 @@ type MsgBond struct {
 	Bond        sdk.Uint       `json:"bond"`
 	BondAddress common.Address `json:"bond_address"`
 	Signer      sdk.AccAddress `json:"signer"`
+	// OperatorFeeBasisPoints the fee the operator sets on the bond rewards of its bond providers, -1 leaves it unchanged
+	OperatorFeeBasisPoints int64 `json:"operator_fee_basis_points"`
 }
 
 // NewMsgBond create new MsgBond message
 func NewMsgBond(txin common.Tx, nodeAddr sdk.AccAddress, bond sdk.Uint, bondAddress common.Address, signer sdk.AccAddress) MsgBond {
 	return MsgBond{
-		TxIn:        txin,
-		NodeAddress: nodeAddr,
-		Bond:        bond,
-		BondAddress: bondAddress,
-		Signer:      signer,
+		TxIn:                   txin,
+		NodeAddress:            nodeAddr,
+		Bond:                   bond,
+		BondAddress:            bondAddress,
+		Signer:                 signer,
+		OperatorFeeBasisPoints: -1,
 	}
 }
 
@@ -43,6 +46,12 @@ func (msg MsgBond) ValidateBasic() sdk.Error {
 	if msg.BondAddress.IsEmpty() {
 		return sdk.ErrUnknownRequest("bond address cannot be empty")
 	}
+	if msg.OperatorFeeBasisPoints > MaxOperatorFeeBasisPoints {
+		return sdk.ErrUnknownRequest("operator fee basis points cannot be more than 10000")
+	}
+	if msg.OperatorFeeBasisPoints < -1 {
+		return sdk.ErrUnknownRequest("operator fee basis points cannot be less than -1")
+	}
 	if msg.TxIn.IsEmpty() {
 		return sdk.ErrUnknownRequest("request tx cannot be empty")
 	}
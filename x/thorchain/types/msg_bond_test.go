@@ -35,4 +35,14 @@ func (MsgApplySuite) TestMsgApply(c *C) {
 	c.Assert(NewMsgBond(txinNoID, nodeAddr, sdk.NewUint(common.One), bondAddr, signerAddr).ValidateBasic(), NotNil)
 	c.Assert(NewMsgBond(txin, nodeAddr, sdk.NewUint(common.One), "", signerAddr).ValidateBasic(), NotNil)
 	c.Assert(NewMsgBond(txin, nodeAddr, sdk.NewUint(common.One), bondAddr, sdk.AccAddress{}).ValidateBasic(), NotNil)
+
+	// operator fee is either -1, which leaves it unchanged, or within 0 and 10000 basis points
+	for _, fee := range []int64{-1, 0, MaxOperatorFeeBasisPoints} {
+		msgApply.OperatorFeeBasisPoints = fee
+		c.Check(msgApply.ValidateBasic(), IsNil, Commentf("%d", fee))
+	}
+	for _, fee := range []int64{-2, MaxOperatorFeeBasisPoints + 1} {
+		msgApply.OperatorFeeBasisPoints = fee
+		c.Check(msgApply.ValidateBasic(), NotNil, Commentf("%d", fee))
+	}
 }
@@ -0,0 +1,53 @@
+package types
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"gitlab.com/thorchain/thornode/common"
+)
+
+// MsgCancelSwap defines a MsgCancelSwap message, which cancel a limit order in the swap queue
+type MsgCancelSwap struct {
+	Tx     common.Tx      `json:"tx"`
+	TxID   common.TxID    `json:"tx_id"` // the tx id of the limit order
+	Signer sdk.AccAddress `json:"signer"`
+}
+
+// NewMsgCancelSwap is a constructor function for MsgCancelSwap
+func NewMsgCancelSwap(tx common.Tx, txID common.TxID, signer sdk.AccAddress) MsgCancelSwap {
+	return MsgCancelSwap{
+		Tx:     tx,
+		TxID:   txID,
+		Signer: signer,
+	}
+}
+
+// Route should return the cmname of the module
+func (msg MsgCancelSwap) Route() string { return RouterKey }
+
+// Type should return the action
+func (msg MsgCancelSwap) Type() string { return "cancel_swap" }
+
+// ValidateBasic runs stateless checks on the message
+func (msg MsgCancelSwap) ValidateBasic() sdk.Error {
+	if msg.Signer.Empty() {
+		return sdk.ErrInvalidAddress(msg.Signer.String())
+	}
+	if err := msg.Tx.IsValid(); err != nil {
+		return sdk.ErrUnknownRequest(err.Error())
+	}
+	if msg.TxID.IsEmpty() {
+		return sdk.ErrUnknownRequest("limit order tx id cannot be empty")
+	}
+	return nil
+}
+
+// GetSignBytes encodes the message for signing
+func (msg MsgCancelSwap) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+// GetSigners defines whose signature is required
+func (msg MsgCancelSwap) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Signer}
+}
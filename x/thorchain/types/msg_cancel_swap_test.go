@@ -0,0 +1,31 @@
+package types
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	. "gopkg.in/check.v1"
+
+	"gitlab.com/thorchain/thornode/common"
+)
+
+type MsgCancelSwapSuite struct{}
+
+var _ = Suite(&MsgCancelSwapSuite{})
+
+func (MsgCancelSwapSuite) TestMsgCancelSwap(c *C) {
+	tx := GetRandomTx()
+	tx.Coins = common.Coins{
+		common.NewCoin(common.BNBAsset, sdk.NewUint(common.One)),
+	}
+	txID := GetRandomTxHash()
+	signer := GetRandomBech32Addr()
+	msg := NewMsgCancelSwap(tx, txID, signer)
+	EnsureMsgBasicCorrect(msg, c)
+	c.Check(msg.Type(), Equals, "cancel_swap")
+
+	msg = NewMsgCancelSwap(tx, common.TxID(""), signer)
+	c.Check(msg.ValidateBasic(), NotNil)
+	msg = NewMsgCancelSwap(common.Tx{}, txID, signer)
+	c.Check(msg.ValidateBasic(), NotNil)
+	msg = NewMsgCancelSwap(tx, txID, sdk.AccAddress{})
+	c.Check(msg.ValidateBasic(), NotNil)
+}
@@ -13,19 +13,24 @@ type MsgSetStakeData struct {
 	RuneAmount   sdk.Uint       `json:"rune"`          // the amount of rune stake
 	RuneAddress  common.Address `json:"rune_address"`  // staker's rune address
 	AssetAddress common.Address `json:"asset_address"` // staker's asset address
+	Asymmetric   bool           `json:"asymmetric"`    // stake the given coins right away, rather than wait for the other leg
 	Signer       sdk.AccAddress `json:"signer"`
+	// affiliate earn the given basis points of the stake
+	AffiliateAddress     common.Address `json:"affiliate_address"`
+	AffiliateBasisPoints sdk.Uint       `json:"affiliate_basis_points"`
 }
 
 // NewMsgSetStakeData is a constructor function for MsgSetStakeData
 func NewMsgSetStakeData(tx common.Tx, asset common.Asset, r, amount sdk.Uint, runeAddr, assetAddr common.Address, signer sdk.AccAddress) MsgSetStakeData {
 	return MsgSetStakeData{
-		Tx:           tx,
-		Asset:        asset,
-		AssetAmount:  amount,
-		RuneAmount:   r,
-		RuneAddress:  runeAddr,
-		AssetAddress: assetAddr,
-		Signer:       signer,
+		Tx:                   tx,
+		Asset:                asset,
+		AssetAmount:          amount,
+		RuneAmount:           r,
+		RuneAddress:          runeAddr,
+		AssetAddress:         assetAddr,
+		Signer:               signer,
+		AffiliateBasisPoints: sdk.ZeroUint(),
 	}
 }
 
@@ -54,6 +59,9 @@ func (msg MsgSetStakeData) ValidateBasic() sdk.Error {
 			return sdk.ErrUnknownRequest("asset address cannot be empty")
 		}
 	}
+	if !msg.AffiliateAddress.IsEmpty() && msg.AffiliateBasisPoints.GT(sdk.NewUint(MaxAffiliateBasisPoints)) {
+		return sdk.ErrUnknownRequest("affiliate basis points can't be more than 10000")
+	}
 	return nil
 }
 
@@ -30,6 +30,11 @@ func (MsgStakeSuite) TestMsgStake(c *C) {
 	m := NewMsgSetStakeData(tx, common.BNBAsset, sdk.NewUint(100000000), sdk.NewUint(100000000), runeAddress, assetAddress, addr)
 	EnsureMsgBasicCorrect(m, c)
 	c.Check(m.Type(), Equals, "set_stakedata")
+	m.AffiliateAddress = GetRandomBNBAddress()
+	m.AffiliateBasisPoints = sdk.NewUint(100)
+	c.Check(m.ValidateBasic(), IsNil)
+	m.AffiliateBasisPoints = sdk.NewUint(MaxAffiliateBasisPoints + 1)
+	c.Check(m.ValidateBasic(), NotNil)
 
 	inputs := []struct {
 		asset     common.Asset
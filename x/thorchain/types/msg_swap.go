@@ -5,6 +5,9 @@ import (
 	"gitlab.com/thorchain/thornode/common"
 )
 
+// MaxAffiliateBasisPoints basis points for affiliate fee
+const MaxAffiliateBasisPoints = 10_000
+
 // MsgSwap defines a MsgSwap message
 type MsgSwap struct {
 	Tx          common.Tx      `json:"tx"`           // request tx
@@ -12,16 +15,30 @@ type MsgSwap struct {
 	Destination common.Address `json:"destination"`  // destination , used for swap and send , the destination address THORNode send it to
 	TradeTarget sdk.Uint       `json:"trade_target"`
 	Signer      sdk.AccAddress `json:"signer"`
+	// limit order stay in the swap queue until the trade target can be met, or it expire at the given block height
+	LimitOrder   bool  `json:"limit_order"`
+	ExpiryHeight int64 `json:"expiry_height"`
+	// streaming swap is split into StreamingQuantity sub-swaps, which are executed in consecutive blocks
+	StreamingQuantity int64    `json:"streaming_quantity"`
+	StreamingCount    int64    `json:"streaming_count"` // number of sub-swaps had been executed
+	StreamingIn       sdk.Uint `json:"streaming_in"`    // source amount had been swapped
+	StreamingOut      sdk.Uint `json:"streaming_out"`   // target amount had been accumulated, it is paid out once the stream is done
+	// affiliate earn the given basis points of the swap
+	AffiliateAddress     common.Address `json:"affiliate_address"`
+	AffiliateBasisPoints sdk.Uint       `json:"affiliate_basis_points"`
 }
 
 // NewMsgSwap is a constructor function for MsgSwap
 func NewMsgSwap(tx common.Tx, target common.Asset, destination common.Address, tradeTarget sdk.Uint, signer sdk.AccAddress) MsgSwap {
 	return MsgSwap{
-		Tx:          tx,
-		TargetAsset: target,
-		Destination: destination,
-		TradeTarget: tradeTarget,
-		Signer:      signer,
+		Tx:                   tx,
+		TargetAsset:          target,
+		Destination:          destination,
+		TradeTarget:          tradeTarget,
+		Signer:               signer,
+		StreamingIn:          sdk.ZeroUint(),
+		StreamingOut:         sdk.ZeroUint(),
+		AffiliateBasisPoints: sdk.ZeroUint(),
 	}
 }
 
@@ -53,9 +70,53 @@ func (msg MsgSwap) ValidateBasic() sdk.Error {
 	if !msg.Destination.IsChain(msg.TargetAsset.Chain) {
 		return sdk.ErrUnknownRequest("swap destination and swap target asset must be the same chain")
 	}
+	if msg.LimitOrder && msg.TradeTarget.IsZero() {
+		return sdk.ErrUnknownRequest("limit order must have a trade target")
+	}
+	if msg.StreamingQuantity < 0 {
+		return sdk.ErrUnknownRequest("streaming quantity can't be negative")
+	}
+	if msg.LimitOrder && msg.IsStreaming() {
+		return sdk.ErrUnknownRequest("limit order can't be streamed")
+	}
+	if !msg.AffiliateAddress.IsEmpty() && msg.AffiliateBasisPoints.GT(sdk.NewUint(MaxAffiliateBasisPoints)) {
+		return sdk.ErrUnknownRequest("affiliate basis points can't be more than 10000")
+	}
 	return nil
 }
 
+// IsExpired return true when the message is a limit order, and it had expired at the given block height
+func (msg MsgSwap) IsExpired(height int64) bool {
+	return msg.LimitOrder && height >= msg.ExpiryHeight
+}
+
+// IsStreaming return true when the swap is split into multiple sub-swaps
+func (msg MsgSwap) IsStreaming() bool {
+	return msg.StreamingQuantity > 1
+}
+
+// IsStreamingDone return true when all the sub-swaps of the streaming swap had been executed
+func (msg MsgSwap) IsStreamingDone() bool {
+	return msg.StreamingCount >= msg.StreamingQuantity
+}
+
+// GetStreamingSwapAmount return the source amount of the next sub-swap, the remaining amount is split evenly
+// across the remaining sub-swaps, so the last sub-swap takes whatever is left
+func (msg MsgSwap) GetStreamingSwapAmount() sdk.Uint {
+	remaining := common.SafeSub(msg.Tx.Coins[0].Amount, msg.StreamingIn)
+	left := msg.StreamingQuantity - msg.StreamingCount
+	if left <= 1 {
+		return remaining
+	}
+	return remaining.QuoUint64(uint64(left))
+}
+
+// GetStreamingTradeTarget return the trade target of a sub-swap with the given source amount, it is the share of
+// the total trade target in proportion to the source amount
+func (msg MsgSwap) GetStreamingTradeTarget(amount sdk.Uint) sdk.Uint {
+	return common.GetShare(amount, msg.Tx.Coins[0].Amount, msg.TradeTarget)
+}
+
 // GetSignBytes encodes the message for signing
 func (msg MsgSwap) GetSignBytes() []byte {
 	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
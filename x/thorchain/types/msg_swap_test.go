@@ -31,6 +31,45 @@ func (MsgSwapSuite) TestMsgSwap(c *C) {
 	m := NewMsgSwap(tx, common.BNBAsset, bnbAddress, sdk.NewUint(200000000), addr)
 	EnsureMsgBasicCorrect(m, c)
 	c.Check(m.Type(), Equals, "swap")
+	c.Check(m.IsExpired(100), Equals, false)
+
+	// limit order
+	m.LimitOrder = true
+	m.ExpiryHeight = 100
+	c.Check(m.ValidateBasic(), IsNil)
+	c.Check(m.IsExpired(99), Equals, false)
+	c.Check(m.IsExpired(100), Equals, true)
+	m.TradeTarget = sdk.ZeroUint()
+	c.Check(m.ValidateBasic(), NotNil)
+	m.TradeTarget = sdk.NewUint(200000000)
+
+	// streaming swap
+	c.Check(m.IsStreaming(), Equals, false)
+	m.Tx.Coins = common.Coins{common.NewCoin(common.BTCAsset, sdk.NewUint(300000000))}
+	m.StreamingQuantity = 3
+	c.Check(m.ValidateBasic(), NotNil) // limit order can't be streamed
+	m.LimitOrder = false
+	c.Check(m.ValidateBasic(), IsNil)
+	c.Check(m.IsStreaming(), Equals, true)
+	c.Check(m.IsStreamingDone(), Equals, false)
+	amt := m.GetStreamingSwapAmount()
+	c.Check(amt.Equal(sdk.NewUint(100000000)), Equals, true)
+	c.Check(m.GetStreamingTradeTarget(amt).Equal(sdk.NewUint(66666667)), Equals, true)
+	m.StreamingCount = 2
+	m.StreamingIn = amt.MulUint64(2)
+	c.Check(m.GetStreamingSwapAmount().Equal(common.SafeSub(m.Tx.Coins[0].Amount, amt.MulUint64(2))), Equals, true)
+	m.StreamingCount = 3
+	c.Check(m.IsStreamingDone(), Equals, true)
+	m.StreamingQuantity = -1
+	c.Check(m.ValidateBasic(), NotNil)
+	m.StreamingQuantity = 0
+
+	// affiliate fee
+	m.AffiliateAddress = GetRandomBNBAddress()
+	m.AffiliateBasisPoints = sdk.NewUint(MaxAffiliateBasisPoints)
+	c.Check(m.ValidateBasic(), IsNil)
+	m.AffiliateBasisPoints = sdk.NewUint(MaxAffiliateBasisPoints + 1)
+	c.Check(m.ValidateBasic(), NotNil)
 
 	inputs := []struct {
 		requestTxHash common.TxID
@@ -0,0 +1,63 @@
+package types
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"gitlab.com/thorchain/thornode/common"
+)
+
+// MsgUnBond when a bond provider would like to take part of its bond back without the node leaving
+type MsgUnBond struct {
+	TxIn        common.Tx      `json:"tx_in"`
+	NodeAddress sdk.AccAddress `json:"node_address"`
+	Amount      sdk.Uint       `json:"amount"`
+	BondAddress common.Address `json:"bond_address"`
+	Signer      sdk.AccAddress `json:"signer"`
+}
+
+// NewMsgUnBond create new MsgUnBond message
+func NewMsgUnBond(txin common.Tx, nodeAddr sdk.AccAddress, amount sdk.Uint, bondAddress common.Address, signer sdk.AccAddress) MsgUnBond {
+	return MsgUnBond{
+		TxIn:        txin,
+		NodeAddress: nodeAddr,
+		Amount:      amount,
+		BondAddress: bondAddress,
+		Signer:      signer,
+	}
+}
+
+// Route should return the router key of the module
+func (msg MsgUnBond) Route() string { return RouterKey }
+
+// Type should return the action
+func (msg MsgUnBond) Type() string { return "validator_unbond" }
+
+// ValidateBasic runs stateless checks on the message
+func (msg MsgUnBond) ValidateBasic() sdk.Error {
+	if msg.NodeAddress.Empty() {
+		return sdk.ErrUnknownRequest("node address cannot be empty")
+	}
+	if msg.Amount.IsZero() {
+		return sdk.ErrUnknownRequest("unbond amount cannot be zero")
+	}
+	if msg.BondAddress.IsEmpty() {
+		return sdk.ErrUnknownRequest("bond address cannot be empty")
+	}
+	if msg.TxIn.IsEmpty() {
+		return sdk.ErrUnknownRequest("request tx cannot be empty")
+	}
+	if msg.Signer.Empty() {
+		return sdk.ErrInvalidAddress("empty signer address")
+	}
+	return nil
+}
+
+// GetSignBytes encodes the message for signing
+func (msg MsgUnBond) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+// GetSigners defines whose signature is required
+func (msg MsgUnBond) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Signer}
+}
@@ -0,0 +1,33 @@
+package types
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	. "gopkg.in/check.v1"
+
+	"gitlab.com/thorchain/thornode/common"
+)
+
+type MsgUnBondSuite struct{}
+
+var _ = Suite(&MsgUnBondSuite{})
+
+func (MsgUnBondSuite) TestMsgUnBond(c *C) {
+	nodeAddr := GetRandomBech32Addr()
+	signerAddr := GetRandomBech32Addr()
+	bondAddr := GetRandomBNBAddress()
+	txin := GetRandomTx()
+	txinNoID := txin
+	txinNoID.ID = ""
+	msg := NewMsgUnBond(txin, nodeAddr, sdk.NewUint(common.One), bondAddr, signerAddr)
+	c.Assert(msg.ValidateBasic(), IsNil)
+	c.Assert(msg.Route(), Equals, RouterKey)
+	c.Assert(msg.Type(), Equals, "validator_unbond")
+	c.Assert(msg.GetSignBytes(), NotNil)
+	c.Assert(msg.GetSigners(), HasLen, 1)
+	c.Assert(msg.GetSigners()[0].Equals(signerAddr), Equals, true)
+	c.Assert(NewMsgUnBond(txin, sdk.AccAddress{}, sdk.NewUint(common.One), bondAddr, signerAddr).ValidateBasic(), NotNil)
+	c.Assert(NewMsgUnBond(txin, nodeAddr, sdk.ZeroUint(), bondAddr, signerAddr).ValidateBasic(), NotNil)
+	c.Assert(NewMsgUnBond(txinNoID, nodeAddr, sdk.NewUint(common.One), bondAddr, signerAddr).ValidateBasic(), NotNil)
+	c.Assert(NewMsgUnBond(txin, nodeAddr, sdk.NewUint(common.One), "", signerAddr).ValidateBasic(), NotNil)
+	c.Assert(NewMsgUnBond(txin, nodeAddr, sdk.NewUint(common.One), bondAddr, sdk.AccAddress{}).ValidateBasic(), NotNil)
+}
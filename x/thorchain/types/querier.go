@@ -86,3 +86,161 @@ func NewQueryNodeAccount(na NodeAccount) QueryNodeAccount {
 		Version:             na.Version,
 	}
 }
+
+// QueryResQuoteSwap is the estimate of a swap at the current pool depths
+type QueryResQuoteSwap struct {
+	ExpectedOutput sdk.Uint   `json:"expected_output"`
+	Slip           sdk.Uint   `json:"slip"`
+	LiquidityFee   sdk.Uint   `json:"liquidity_fee"`
+	OutboundFee    sdk.Uint   `json:"outbound_fee"`
+	OutboundGas    common.Gas `json:"outbound_gas"`
+	Memo           string     `json:"memo"`
+}
+
+// QueryResQuoteStake is the estimate of a stake at the current pool depths
+type QueryResQuoteStake struct {
+	StakeUnits sdk.Uint `json:"stake_units"`
+	PoolUnits  sdk.Uint `json:"pool_units"`
+	Memo       string   `json:"memo"`
+}
+
+// QueryResQuoteUnstake is the estimate of an unstake at the current pool depths
+type QueryResQuoteUnstake struct {
+	RuneAmount  sdk.Uint   `json:"rune_amount"`
+	AssetAmount sdk.Uint   `json:"asset_amount"`
+	StakeUnits  sdk.Uint   `json:"stake_units"`
+	OutboundGas common.Gas `json:"outbound_gas"`
+	Memo        string     `json:"memo"`
+}
+
+// QueryResPoolTWAP is the time weighted average price of a pool over a window of blocks
+type QueryResPoolTWAP struct {
+	Asset     common.Asset `json:"asset"`
+	Height    int64        `json:"height"`
+	Window    int64        `json:"window"`
+	TWAP      sdk.Uint     `json:"twap"`
+	SpotPrice sdk.Uint     `json:"spot_price"`
+}
+
+// QueryResStakerPosition is the position of a staker in a pool
+type QueryResStakerPosition struct {
+	Asset           common.Asset   `json:"asset"`
+	RuneAddress     common.Address `json:"rune_address"`
+	AssetAddress    common.Address `json:"asset_address"`
+	Units           sdk.Uint       `json:"units"`
+	RedeemableRune  sdk.Uint       `json:"redeemable_rune"`  // RUNE the staker get if all the units are unstaked now
+	RedeemableAsset sdk.Uint       `json:"redeemable_asset"` // asset the staker get if all the units are unstaked now
+	RuneDeposited   sdk.Uint       `json:"rune_deposited"`   // total RUNE of the staker's stake events
+	AssetDeposited  sdk.Uint       `json:"asset_deposited"`  // total asset of the staker's stake events
+	RuneWithdrawn   sdk.Uint       `json:"rune_withdrawn"`   // total RUNE sent out by the staker's unstake events
+	AssetWithdrawn  sdk.Uint       `json:"asset_withdrawn"`  // total asset sent out by the staker's unstake events
+	LastStakeHeight int64          `json:"last_stake"`
+	LockUpRemaining int64          `json:"lock_up_remaining"` // number of blocks before the staker can unstake
+	PendingRune     sdk.Uint       `json:"pending_rune"`
+}
+
+// NewQueryResStakerPosition create a new position of the given staker, with nothing redeemable, deposited or withdrawn
+func NewQueryResStakerPosition(staker Staker) QueryResStakerPosition {
+	return QueryResStakerPosition{
+		Asset:           staker.Asset,
+		RuneAddress:     staker.RuneAddress,
+		AssetAddress:    staker.AssetAddress,
+		Units:           staker.Units,
+		RedeemableRune:  sdk.ZeroUint(),
+		RedeemableAsset: sdk.ZeroUint(),
+		RuneDeposited:   sdk.ZeroUint(),
+		AssetDeposited:  sdk.ZeroUint(),
+		RuneWithdrawn:   sdk.ZeroUint(),
+		AssetWithdrawn:  sdk.ZeroUint(),
+		LastStakeHeight: staker.LastStakeHeight,
+		PendingRune:     staker.PendingRune,
+	}
+}
+
+// QueryResNodeSlashes the slash points of a node account broken down by reason, and its recent slash history
+type QueryResNodeSlashes struct {
+	NodeAddress sdk.AccAddress `json:"node_address"`
+	SlashPoints int64          `json:"slash_points"`
+	Counters    []SlashCounter `json:"counters"`
+	Ledger      SlashRecords   `json:"ledger"`
+}
+
+// NewQueryResNodeSlashes create a new QueryResNodeSlashes from the given slash points, slashes and slash records
+func NewQueryResNodeSlashes(slashPoints int64, slashes NodeSlashes, records SlashRecords) QueryResNodeSlashes {
+	return QueryResNodeSlashes{
+		NodeAddress: slashes.NodeAddress,
+		SlashPoints: slashPoints,
+		Counters:    slashes.Counters,
+		Ledger:      records,
+	}
+}
+
+// QueryResNodeScorecard how well a node account has been performing its duties, and how likely it is to be churned out
+type QueryResNodeScorecard struct {
+	NodeAddress              sdk.AccAddress `json:"node_address"`
+	Status                   NodeStatus     `json:"status"`
+	StatusSince              int64          `json:"status_since"`
+	ObservedTxs              int64          `json:"observed_txs"`        // txs observed by any node since the node got its current status
+	ObservedTxsSigned        int64          `json:"observed_txs_signed"` // of which the node observed
+	ObservationRate          sdk.Dec        `json:"observation_rate"`
+	KeysignFailures          int64          `json:"keysign_failures"`        // keysign failures reported since the node got its current status
+	KeysignFailuresSigned    int64          `json:"keysign_failures_signed"` // of which the node voted on
+	KeysignParticipationRate sdk.Dec        `json:"keysign_participation_rate"`
+	KeysignBlames            int64          `json:"keysign_blames"` // keysign failures the node got blamed for
+	Keygens                  int64          `json:"keygens"`        // keygens the node was a member of
+	KeygensSigned            int64          `json:"keygens_signed"` // of which the node voted on
+	KeygenFailures           int64          `json:"keygen_failures"`
+	Version                  semver.Version `json:"version"`
+	MinJoinVersion           semver.Version `json:"min_join_version"`
+	VersionBehind            bool           `json:"version_behind"`
+	SlashPoints              int64          `json:"slash_points"`
+	SlashPointsRecent        int64          `json:"slash_points_recent"` // slash points got in the recent window
+	SlashPointsLedger        int64          `json:"slash_points_ledger"` // slash points got in the whole ledger window
+	SlashPointsTrend         string         `json:"slash_points_trend"`
+	BadActorScore            sdk.Dec        `json:"bad_actor_score"` // the lower the score the worse, zero when the node isn't ranked
+	BadActorRank             int64          `json:"bad_actor_rank"`  // 1 is the worst ranked node, zero when the node isn't ranked
+	BadActorRedline          sdk.Dec        `json:"bad_actor_redline"`
+	BadActor                 bool           `json:"bad_actor"` // would be churned out for bad behavior
+	OldActor                 bool           `json:"old_actor"` // would be churned out for age
+	ChurnOutRisk             string         `json:"churn_out_risk"`
+}
+
+// NewQueryResNodeScorecard create a new QueryResNodeScorecard for the given node account, with nothing counted yet
+func NewQueryResNodeScorecard(na NodeAccount) QueryResNodeScorecard {
+	return QueryResNodeScorecard{
+		NodeAddress:              na.NodeAddress,
+		Status:                   na.Status,
+		StatusSince:              na.StatusSince,
+		ObservationRate:          sdk.ZeroDec(),
+		KeysignParticipationRate: sdk.ZeroDec(),
+		Version:                  na.Version,
+		BadActorScore:            sdk.ZeroDec(),
+		BadActorRedline:          sdk.ZeroDec(),
+	}
+}
+
+// QueryResChurnPreview the outcome of a churn if it happened now
+type QueryResChurnPreview struct {
+	Height   int64        `json:"height"`
+	Rotation bool         `json:"rotation"` // whether the churn would change the active node accounts at all
+	Leaving  NodeAccounts `json:"leaving"`
+	Joining  NodeAccounts `json:"joining"`
+	Members  NodeAccounts `json:"members"` // node accounts of the new asgard vault
+}
+
+// String implement fmt.Stringer
+func (p QueryResChurnPreview) String() string {
+	sb := strings.Builder{}
+	sb.WriteString(fmt.Sprintf("height: %d\n", p.Height))
+	sb.WriteString(fmt.Sprintf("rotation: %t\n", p.Rotation))
+	for _, item := range []struct {
+		name string
+		nas  NodeAccounts
+	}{{"leaving", p.Leaving}, {"joining", p.Joining}, {"members", p.Members}} {
+		sb.WriteString(item.name + ":\n")
+		for _, na := range item.nas {
+			sb.WriteString("  " + na.NodeAddress.String() + "\n")
+		}
+	}
+	return sb.String()
+}
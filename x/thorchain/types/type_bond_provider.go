@@ -0,0 +1,76 @@
+package types
+
+import (
+	"errors"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"gitlab.com/thorchain/thornode/common"
+)
+
+// MaxOperatorFeeBasisPoints the maximum fee a node operator can take from the bond rewards of its bond providers
+const MaxOperatorFeeBasisPoints = 10_000
+
+// BondProvider an address that bonded RUNE to a node account
+type BondProvider struct {
+	BondAddress common.Address `json:"bond_address"`
+	Bond        sdk.Uint       `json:"bond"`
+}
+
+// NewBondProvider create a new instance of BondProvider
+func NewBondProvider(addr common.Address, bond sdk.Uint) BondProvider {
+	return BondProvider{
+		BondAddress: addr,
+		Bond:        bond,
+	}
+}
+
+// IsValid check whether the bond provider has all necessary values
+func (bp BondProvider) IsValid() error {
+	if bp.BondAddress.IsEmpty() {
+		return errors.New("bond provider address is empty")
+	}
+	return nil
+}
+
+// BondProviders a list of BondProvider
+type BondProviders []BondProvider
+
+// Get return the bond provider with the given address, and whether it is in the list
+func (bps BondProviders) Get(addr common.Address) (BondProvider, bool) {
+	for _, bp := range bps {
+		if bp.BondAddress.Equals(addr) {
+			return bp, true
+		}
+	}
+	return BondProvider{}, false
+}
+
+// TotalBond return the sum of the bond of all providers
+func (bps BondProviders) TotalBond() sdk.Uint {
+	total := sdk.ZeroUint()
+	for _, bp := range bps {
+		total = total.Add(bp.Bond)
+	}
+	return total
+}
+
+// rescale return the bond providers with their bond scaled pro rata so they add up to the given total, the last provider
+// gets whatever is left so nothing is lost to rounding
+func (bps BondProviders) rescale(total sdk.Uint) BondProviders {
+	if len(bps) == 0 {
+		return bps
+	}
+	current := bps.TotalBond()
+	result := make(BondProviders, len(bps))
+	allocated := sdk.ZeroUint()
+	for i, bp := range bps {
+		bond := common.GetShare(bp.Bond, current, total)
+		if i == len(bps)-1 {
+			bond = common.SafeSub(total, allocated)
+		}
+		result[i] = NewBondProvider(bp.BondAddress, bond)
+		allocated = allocated.Add(bond)
+	}
+	return result
+}
@@ -36,6 +36,10 @@ const (
 	ErrataEventType   = `errata`
 	FeeEventType      = `fee`
 	OutboundEventType = `outbound`
+
+	PendingRuneCommitEventType = `pending_rune_commit`
+	PendingRuneRefundEventType = `pending_rune_refund`
+	AffiliateFeeEventType      = `affiliate_fee`
 )
 
 type PoolMod struct {
@@ -100,6 +104,9 @@ type EventSwap struct {
 	TradeSlip          sdk.Uint     `json:"trade_slip"`
 	LiquidityFee       sdk.Uint     `json:"liquidity_fee"`
 	LiquidityFeeInRune sdk.Uint     `json:"liquidity_fee_in_rune"`
+	// a streaming swap emit one swap event per sub-swap, the count start from 1
+	StreamingQuantity int64 `json:"streaming_quantity,omitempty"`
+	StreamingCount    int64 `json:"streaming_count,omitempty"`
 	//  the following two field is trying to make events change backward compatible
 	// very soon we don't need to save this event to key value store anymore , it will be removed then
 	InTx   common.Tx `json:"-"` // this is the Tx that cause the swap to happen, it is a double swap , then the txid will be blank
@@ -131,6 +138,12 @@ func (e EventSwap) Events() (sdk.Events, error) {
 		sdk.NewAttribute("liquidity_fee", e.LiquidityFee.String()),
 		sdk.NewAttribute("liquidity_fee_in_rune", e.LiquidityFeeInRune.String()),
 	)
+	if e.StreamingQuantity > 0 {
+		evt = evt.AppendAttributes(
+			sdk.NewAttribute("streaming_quantity", strconv.FormatInt(e.StreamingQuantity, 10)),
+			sdk.NewAttribute("streaming_count", strconv.FormatInt(e.StreamingCount, 10)),
+		)
+	}
 	evt = evt.AppendAttributes(e.InTx.ToAttributes()...)
 	return sdk.Events{evt}, nil
 }
@@ -168,21 +181,23 @@ func (e EventStake) Events() (sdk.Events, error) {
 
 // EventUnstake represent unstake
 type EventUnstake struct {
-	Pool        common.Asset `json:"pool"`
-	StakeUnits  sdk.Uint     `json:"stake_units"`
-	BasisPoints int64        `json:"basis_points"` // 1 ==> 10,0000
-	Asymmetry   sdk.Dec      `json:"asymmetry"`    // -1.0 <==> 1.0
-	InTx        common.Tx    `json:"-"`
+	Pool              common.Asset `json:"pool"`
+	StakeUnits        sdk.Uint     `json:"stake_units"`
+	BasisPoints       int64        `json:"basis_points"`        // 1 ==> 10,0000
+	Asymmetry         sdk.Dec      `json:"asymmetry"`           // -1.0 <==> 1.0
+	ImpLossProtection sdk.Uint     `json:"imp_loss_protection"` // RUNE paid from the reserve to cover impermanent loss
+	InTx              common.Tx    `json:"-"`
 }
 
 // NewEventUnstake create a new unstake event
-func NewEventUnstake(pool common.Asset, su sdk.Uint, basisPts int64, asym sdk.Dec, inTx common.Tx) EventUnstake {
+func NewEventUnstake(pool common.Asset, su sdk.Uint, basisPts int64, asym sdk.Dec, impLossProtection sdk.Uint, inTx common.Tx) EventUnstake {
 	return EventUnstake{
-		Pool:        pool,
-		StakeUnits:  su,
-		BasisPoints: basisPts,
-		Asymmetry:   asym,
-		InTx:        inTx,
+		Pool:              pool,
+		StakeUnits:        su,
+		BasisPoints:       basisPts,
+		Asymmetry:         asym,
+		ImpLossProtection: impLossProtection,
+		InTx:              inTx,
 	}
 }
 
@@ -197,7 +212,8 @@ func (e EventUnstake) Events() (sdk.Events, error) {
 		sdk.NewAttribute("pool", e.Pool.String()),
 		sdk.NewAttribute("stake_units", e.StakeUnits.String()),
 		sdk.NewAttribute("basis_points", strconv.FormatInt(e.BasisPoints, 10)),
-		sdk.NewAttribute("asymmetry", e.Asymmetry.String()))
+		sdk.NewAttribute("asymmetry", e.Asymmetry.String()),
+		sdk.NewAttribute("imp_loss_protection", e.ImpLossProtection.String()))
 	evt = evt.AppendAttributes(e.InTx.ToAttributes()...)
 	return sdk.Events{evt}, nil
 }
@@ -558,3 +574,117 @@ func (e EventOutbound) Events() (sdk.Events, error) {
 	evt = evt.AppendAttributes(e.Tx.ToAttributes()...)
 	return sdk.Events{evt}, nil
 }
+
+// EventPendingRuneCommit represent pending RUNE that timed out waiting for the asset, and had been committed to the pool as an asymmetric stake
+type EventPendingRuneCommit struct {
+	Pool        common.Asset   `json:"pool"`
+	RuneAddress common.Address `json:"rune_address"`
+	RuneAmount  sdk.Uint       `json:"rune_amount"`
+	StakeUnits  sdk.Uint       `json:"stake_units"`
+	TxID        common.TxID    `json:"tx_id"`
+}
+
+// NewEventPendingRuneCommit create a new EventPendingRuneCommit
+func NewEventPendingRuneCommit(pool common.Asset, runeAddr common.Address, runeAmt, stakeUnits sdk.Uint, txID common.TxID) EventPendingRuneCommit {
+	return EventPendingRuneCommit{
+		Pool:        pool,
+		RuneAddress: runeAddr,
+		RuneAmount:  runeAmt,
+		StakeUnits:  stakeUnits,
+		TxID:        txID,
+	}
+}
+
+// Type return a string which represent the type of this event
+func (e EventPendingRuneCommit) Type() string {
+	return PendingRuneCommitEventType
+}
+
+// Events return sdk events
+func (e EventPendingRuneCommit) Events() (sdk.Events, error) {
+	evt := sdk.NewEvent(e.Type(),
+		sdk.NewAttribute("pool", e.Pool.String()),
+		sdk.NewAttribute("rune_address", e.RuneAddress.String()),
+		sdk.NewAttribute("rune_amount", e.RuneAmount.String()),
+		sdk.NewAttribute("stake_units", e.StakeUnits.String()),
+		sdk.NewAttribute("tx_id", e.TxID.String()))
+	return sdk.Events{evt}, nil
+}
+
+// EventPendingRuneRefund represent pending RUNE that timed out waiting for the asset, and had been refunded to the staker
+type EventPendingRuneRefund struct {
+	Pool        common.Asset   `json:"pool"`
+	RuneAddress common.Address `json:"rune_address"`
+	RuneAmount  sdk.Uint       `json:"rune_amount"`
+	TxID        common.TxID    `json:"tx_id"`
+}
+
+// NewEventPendingRuneRefund create a new EventPendingRuneRefund
+func NewEventPendingRuneRefund(pool common.Asset, runeAddr common.Address, runeAmt sdk.Uint, txID common.TxID) EventPendingRuneRefund {
+	return EventPendingRuneRefund{
+		Pool:        pool,
+		RuneAddress: runeAddr,
+		RuneAmount:  runeAmt,
+		TxID:        txID,
+	}
+}
+
+// Type return a string which represent the type of this event
+func (e EventPendingRuneRefund) Type() string {
+	return PendingRuneRefundEventType
+}
+
+// Events return sdk events
+func (e EventPendingRuneRefund) Events() (sdk.Events, error) {
+	evt := sdk.NewEvent(e.Type(),
+		sdk.NewAttribute("pool", e.Pool.String()),
+		sdk.NewAttribute("rune_address", e.RuneAddress.String()),
+		sdk.NewAttribute("rune_amount", e.RuneAmount.String()),
+		sdk.NewAttribute("tx_id", e.TxID.String()))
+	return sdk.Events{evt}, nil
+}
+
+// EventAffiliateFee represent the fee skimmed from a swap or stake, and sent to the affiliate
+type EventAffiliateFee struct {
+	TxID        common.TxID    `json:"tx_id"`
+	Memo        string         `json:"memo"`
+	Affiliate   common.Address `json:"affiliate"`
+	BasisPoints sdk.Uint       `json:"basis_points"`
+	Asset       common.Asset   `json:"asset"`      // the asset the fee had been skimmed from
+	FeeAmount   sdk.Uint       `json:"fee_amount"` // fee amount in the skimmed asset
+	FeeInRune   sdk.Uint       `json:"fee_in_rune"`
+	Payout      common.Coin    `json:"payout"` // the coin sent to the affiliate
+}
+
+// NewEventAffiliateFee create a new EventAffiliateFee
+func NewEventAffiliateFee(txID common.TxID, memo string, affiliate common.Address, bps sdk.Uint, asset common.Asset, feeAmt, feeInRune sdk.Uint, payout common.Coin) EventAffiliateFee {
+	return EventAffiliateFee{
+		TxID:        txID,
+		Memo:        memo,
+		Affiliate:   affiliate,
+		BasisPoints: bps,
+		Asset:       asset,
+		FeeAmount:   feeAmt,
+		FeeInRune:   feeInRune,
+		Payout:      payout,
+	}
+}
+
+// Type return a string which represent the type of this event
+func (e EventAffiliateFee) Type() string {
+	return AffiliateFeeEventType
+}
+
+// Events return sdk events
+func (e EventAffiliateFee) Events() (sdk.Events, error) {
+	evt := sdk.NewEvent(e.Type(),
+		sdk.NewAttribute("tx_id", e.TxID.String()),
+		sdk.NewAttribute("memo", e.Memo),
+		sdk.NewAttribute("affiliate", e.Affiliate.String()),
+		sdk.NewAttribute("basis_points", e.BasisPoints.String()),
+		sdk.NewAttribute("asset", e.Asset.String()),
+		sdk.NewAttribute("fee_amount", e.FeeAmount.String()),
+		sdk.NewAttribute("fee_in_rune", e.FeeInRune.String()),
+		sdk.NewAttribute("payout", e.Payout.String()))
+	return sdk.Events{evt}, nil
+}
@@ -23,6 +23,15 @@ func (s EventSuite) TestSwapEvent(c *C) {
 		GetRandomTx(),
 	)
 	c.Check(evt.Type(), Equals, "swap")
+	events, err := evt.Events()
+	c.Assert(err, IsNil)
+	c.Check(events[0].Attributes, HasLen, 5+len(evt.InTx.ToAttributes()))
+
+	evt.StreamingQuantity = 3
+	evt.StreamingCount = 1
+	events, err = evt.Events()
+	c.Assert(err, IsNil)
+	c.Check(events[0].Attributes, HasLen, 7+len(evt.InTx.ToAttributes()))
 }
 
 func (s EventSuite) TestStakeEvent(c *C) {
@@ -40,9 +49,13 @@ func (s EventSuite) TestUnstakeEvent(c *C) {
 		sdk.NewUint(6),
 		5000,
 		sdk.NewDec(0),
+		sdk.NewUint(7),
 		GetRandomTx(),
 	)
 	c.Check(evt.Type(), Equals, "unstake")
+	events, err := evt.Events()
+	c.Assert(err, IsNil)
+	c.Check(events[0].Attributes[4].Value, DeepEquals, []byte("7"))
 }
 
 func (s EventSuite) TestPool(c *C) {
@@ -218,3 +231,28 @@ func (s EventSuite) TestEventFee(c *C) {
 	c.Assert(err, IsNil)
 	c.Assert(evts, HasLen, 1)
 }
+
+func (s EventSuite) TestEventPendingRune(c *C) {
+	txID := GetRandomTxHash()
+	addr := GetRandomBNBAddress()
+	commit := NewEventPendingRuneCommit(common.BTCAsset, addr, sdk.NewUint(100), sdk.NewUint(50), txID)
+	c.Check(commit.Type(), Equals, "pending_rune_commit")
+	events, err := commit.Events()
+	c.Check(err, IsNil)
+	c.Check(events, HasLen, 1)
+
+	refund := NewEventPendingRuneRefund(common.BTCAsset, addr, sdk.NewUint(100), txID)
+	c.Check(refund.Type(), Equals, "pending_rune_refund")
+	events, err = refund.Events()
+	c.Check(err, IsNil)
+	c.Check(events, HasLen, 1)
+}
+
+func (s EventSuite) TestAffiliateFeeEvent(c *C) {
+	evt := NewEventAffiliateFee(GetRandomTxHash(), "SWAP:BNB.BNB", GetRandomBNBAddress(), sdk.NewUint(30), common.BTCAsset, sdk.NewUint(100), sdk.NewUint(200), common.NewCoin(common.BNBAsset, sdk.NewUint(150)))
+	c.Check(evt.Type(), Equals, "affiliate_fee")
+	events, err := evt.Events()
+	c.Check(err, IsNil)
+	c.Check(events, HasLen, 1)
+	c.Check(events[0].Attributes, HasLen, 8)
+}
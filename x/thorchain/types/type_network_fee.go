@@ -0,0 +1,120 @@
+package types
+
+import (
+	"errors"
+	"sort"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"gitlab.com/thorchain/thornode/common"
+)
+
+// NetworkFee the fee rate a chain charges, as reported by the observers, it is used to work out the max gas of outbound txs
+type NetworkFee struct {
+	Chain              common.Chain `json:"chain"`
+	BlockHeight        int64        `json:"block_height"`
+	TransactionSize    uint64       `json:"transaction_size"`
+	TransactionFeeRate uint64       `json:"transaction_fee_rate"`
+}
+
+// NewNetworkFee create a new instance of NetworkFee
+func NewNetworkFee(chain common.Chain, height int64, transactionSize, transactionFeeRate uint64) NetworkFee {
+	return NetworkFee{
+		Chain:              chain,
+		BlockHeight:        height,
+		TransactionSize:    transactionSize,
+		TransactionFeeRate: transactionFeeRate,
+	}
+}
+
+// IsEmpty return true when the network fee has not been reported
+func (f NetworkFee) IsEmpty() bool {
+	return f.Chain.IsEmpty() || f.TransactionSize == 0 || f.TransactionFeeRate == 0
+}
+
+// Valid check whether all the fields are set
+func (f NetworkFee) Valid() error {
+	if f.Chain.IsEmpty() {
+		return errors.New("chain can't be empty")
+	}
+	if f.TransactionSize == 0 {
+		return errors.New("transaction size can't be zero")
+	}
+	if f.TransactionFeeRate == 0 {
+		return errors.New("transaction fee rate can't be zero")
+	}
+	return nil
+}
+
+// GetFee return the fee of a tx at the reported size and fee rate, in gas asset
+func (f NetworkFee) GetFee() sdk.Uint {
+	return sdk.NewUint(f.TransactionSize * f.TransactionFeeRate)
+}
+
+// NetworkFeeReport the network fee of a chain reported by one node
+type NetworkFeeReport struct {
+	Signer             sdk.AccAddress `json:"signer"`
+	BlockHeight        int64          `json:"block_height"`
+	TransactionSize    uint64         `json:"transaction_size"`
+	TransactionFeeRate uint64         `json:"transaction_fee_rate"`
+}
+
+// NetworkFeeVoter keep the latest network fee report of each node for a chain
+type NetworkFeeVoter struct {
+	Chain   common.Chain       `json:"chain"`
+	Reports []NetworkFeeReport `json:"reports"`
+}
+
+// NewNetworkFeeVoter create a new instance of NetworkFeeVoter
+func NewNetworkFeeVoter(chain common.Chain) NetworkFeeVoter {
+	return NetworkFeeVoter{
+		Chain: chain,
+	}
+}
+
+// Add the given report to the voter, replace the previous report of the same signer
+func (v *NetworkFeeVoter) Add(report NetworkFeeReport) {
+	for i, item := range v.Reports {
+		if item.Signer.Equals(report.Signer) {
+			v.Reports[i] = report
+			return
+		}
+	}
+	v.Reports = append(v.Reports, report)
+}
+
+// Prune remove the reports from the nodes that are not active anymore
+func (v *NetworkFeeVoter) Prune(nas NodeAccounts) {
+	reports := make([]NetworkFeeReport, 0, len(v.Reports))
+	for _, item := range v.Reports {
+		if nas.IsNodeKeys(item.Signer) {
+			reports = append(reports, item)
+		}
+	}
+	v.Reports = reports
+}
+
+// GetNetworkFee return the median of the transaction size and fee rate reported, so a single node can't move the network fee on its own
+func (v NetworkFeeVoter) GetNetworkFee(height int64) NetworkFee {
+	if len(v.Reports) == 0 {
+		return NetworkFee{}
+	}
+	sizes := make([]uint64, len(v.Reports))
+	rates := make([]uint64, len(v.Reports))
+	for i, item := range v.Reports {
+		sizes[i] = item.TransactionSize
+		rates[i] = item.TransactionFeeRate
+	}
+	return NewNetworkFee(v.Chain, height, medianUint64(sizes), medianUint64(rates))
+}
+
+func medianUint64(values []uint64) uint64 {
+	sort.Slice(values, func(i, j int) bool {
+		return values[i] < values[j]
+	})
+	mid := len(values) / 2
+	if len(values)%2 == 0 {
+		return (values[mid-1] + values[mid]) / 2
+	}
+	return values[mid]
+}
@@ -0,0 +1,53 @@
+package types
+
+import (
+	. "gopkg.in/check.v1"
+
+	"gitlab.com/thorchain/thornode/common"
+)
+
+type TypeNetworkFeeSuite struct{}
+
+var _ = Suite(&TypeNetworkFeeSuite{})
+
+func (s *TypeNetworkFeeSuite) TestNetworkFee(c *C) {
+	fee := NewNetworkFee(common.BTCChain, 1, 250, 10)
+	c.Check(fee.IsEmpty(), Equals, false)
+	c.Check(fee.Valid(), IsNil)
+	c.Check(fee.GetFee().Uint64(), Equals, uint64(2500))
+
+	c.Check(NetworkFee{}.IsEmpty(), Equals, true)
+	c.Check(NewNetworkFee(common.EmptyChain, 1, 250, 10).Valid(), NotNil)
+	c.Check(NewNetworkFee(common.BTCChain, 1, 0, 10).Valid(), NotNil)
+	c.Check(NewNetworkFee(common.BTCChain, 1, 250, 0).Valid(), NotNil)
+}
+
+func (s *TypeNetworkFeeSuite) TestNetworkFeeVoter(c *C) {
+	voter := NewNetworkFeeVoter(common.BTCChain)
+	c.Check(voter.GetNetworkFee(1).IsEmpty(), Equals, true)
+
+	addr1 := GetRandomBech32Addr()
+	addr2 := GetRandomBech32Addr()
+	addr3 := GetRandomBech32Addr()
+	voter.Add(NetworkFeeReport{Signer: addr1, BlockHeight: 1, TransactionSize: 250, TransactionFeeRate: 10})
+	voter.Add(NetworkFeeReport{Signer: addr2, BlockHeight: 1, TransactionSize: 250, TransactionFeeRate: 30})
+	voter.Add(NetworkFeeReport{Signer: addr3, BlockHeight: 1, TransactionSize: 300, TransactionFeeRate: 1000})
+	c.Check(voter.Reports, HasLen, 3)
+	fee := voter.GetNetworkFee(5)
+	c.Check(fee.Chain.Equals(common.BTCChain), Equals, true)
+	c.Check(fee.BlockHeight, Equals, int64(5))
+	c.Check(fee.TransactionSize, Equals, uint64(250))
+	c.Check(fee.TransactionFeeRate, Equals, uint64(30))
+
+	// report from the same signer replace the previous one
+	voter.Add(NetworkFeeReport{Signer: addr2, BlockHeight: 2, TransactionSize: 250, TransactionFeeRate: 20})
+	c.Check(voter.Reports, HasLen, 3)
+	c.Check(voter.GetNetworkFee(5).TransactionFeeRate, Equals, uint64(20))
+
+	voter.Prune(NodeAccounts{
+		NodeAccount{NodeAddress: addr1, Status: Active},
+		NodeAccount{NodeAddress: addr2, Status: Active},
+	})
+	c.Check(voter.Reports, HasLen, 2)
+	c.Check(voter.GetNetworkFee(5).TransactionFeeRate, Equals, uint64(15))
+}
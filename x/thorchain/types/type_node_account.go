@@ -99,6 +99,10 @@ type NodeAccount struct {
 	LeaveHeight         int64            `json:"leave_height"`
 	IPAddress           string           `json:"ip_address"`
 	Version             semver.Version   `json:"version"`
+	// BondProviders all the addresses that bonded to this node, empty when the operator is the only one
+	BondProviders BondProviders `json:"bond_providers"`
+	// OperatorFeeBasisPoints the share of the bond reward the operator takes before it is split among the bond providers
+	OperatorFeeBasisPoints int64 `json:"operator_fee_basis_points"`
 }
 
 // NewNodeAccount create new instance of NodeAccount
@@ -130,6 +134,14 @@ func (n NodeAccount) IsValid() error {
 	if n.Status == Unknown {
 		return errors.New("node status cannot be unknown")
 	}
+	if n.OperatorFeeBasisPoints < 0 || n.OperatorFeeBasisPoints > MaxOperatorFeeBasisPoints {
+		return fmt.Errorf("operator fee basis points(%d) is invalid", n.OperatorFeeBasisPoints)
+	}
+	for _, bp := range n.BondProviders {
+		if err := bp.IsValid(); err != nil {
+			return err
+		}
+	}
 
 	return nil
 }
@@ -198,6 +210,80 @@ func (n *NodeAccount) SubBond(amt sdk.Uint) {
 	}
 }
 
+// GetBondProviders return the addresses that bonded to the node and their share of the current bond. Slashes only ever
+// reduce the total bond, so each provider's bond is scaled pro rata to it, which spreads the slashes over all providers
+func (n NodeAccount) GetBondProviders() BondProviders {
+	if len(n.BondProviders) == 0 {
+		return BondProviders{NewBondProvider(n.BondAddress, n.Bond)}
+	}
+	return n.BondProviders.rescale(n.Bond)
+}
+
+// AddProviderBond add the given amount to the bond of the provider with the given address, a new provider is added when
+// the address has not bonded to the node yet
+func (n *NodeAccount) AddProviderBond(addr common.Address, amt sdk.Uint) {
+	providers := n.GetBondProviders()
+	found := false
+	for i, bp := range providers {
+		if bp.BondAddress.Equals(addr) {
+			providers[i].Bond = bp.Bond.Add(amt)
+			found = true
+		}
+	}
+	if !found {
+		providers = append(providers, NewBondProvider(addr, amt))
+	}
+	n.BondProviders = providers
+	n.Bond = n.Bond.Add(amt)
+}
+
+// SubProviderBond take the given amount off the bond of the provider with the given address, capped at its share of the bond
+func (n *NodeAccount) SubProviderBond(addr common.Address, amt sdk.Uint) {
+	providers := n.GetBondProviders()
+	for i, bp := range providers {
+		if bp.BondAddress.Equals(addr) {
+			if amt.GT(bp.Bond) {
+				amt = bp.Bond
+			}
+			providers[i].Bond = common.SafeSub(bp.Bond, amt)
+			n.BondProviders = providers
+			n.Bond = common.SafeSub(n.Bond, amt)
+			return
+		}
+	}
+}
+
+// AddBondReward add the given reward to the bond, the operator takes its fee first and the rest is split among the bond
+// providers pro rata to their bond
+func (n *NodeAccount) AddBondReward(reward sdk.Uint) {
+	providers := n.GetBondProviders()
+	total := providers.TotalBond()
+	fee := common.GetShare(sdk.NewUint(uint64(n.OperatorFeeBasisPoints)), sdk.NewUint(MaxOperatorFeeBasisPoints), reward)
+	if total.IsZero() {
+		fee = reward
+	}
+	distributed := sdk.ZeroUint()
+	for i, bp := range providers {
+		share := common.GetShare(bp.Bond, total, common.SafeSub(reward, fee))
+		providers[i].Bond = bp.Bond.Add(share)
+		distributed = distributed.Add(share)
+	}
+	// the operator gets its fee and anything lost to rounding
+	remainder := common.SafeSub(reward, distributed)
+	found := false
+	for i, bp := range providers {
+		if bp.BondAddress.Equals(n.BondAddress) {
+			providers[i].Bond = bp.Bond.Add(remainder)
+			found = true
+		}
+	}
+	if !found {
+		providers = append(providers, NewBondProvider(n.BondAddress, remainder))
+	}
+	n.BondProviders = providers
+	n.Bond = n.Bond.Add(reward)
+}
+
 // AddSignerPubKey add a key to node account
 func (n *NodeAccount) TryAddSignerPubKey(key common.PubKey) {
 	if key.IsEmpty() {
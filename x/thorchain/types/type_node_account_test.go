@@ -179,3 +179,64 @@ func (s *NodeAccountSuite) TestCalcNodeRewards(c *C) {
 	blocks = na.CalcBondUnits(50, 0)
 	c.Check(blocks.Uint64(), Equals, uint64(0), Commentf("%d", blocks.Uint64()))
 }
+
+func (NodeAccountSuite) TestBondProviders(c *C) {
+	operator := GetRandomBNBAddress()
+	provider := GetRandomBNBAddress()
+	na := NewNodeAccount(GetRandomBech32Addr(), Standby, GetRandomPubKeySet(), GetRandomBech32ConsensusPubKey(), sdk.NewUint(100*common.One), operator, 1)
+	// the operator is the only bond provider until someone else bonds
+	providers := na.GetBondProviders()
+	c.Assert(providers, HasLen, 1)
+	c.Check(providers[0].BondAddress.Equals(operator), Equals, true)
+	c.Check(providers[0].Bond.Equal(sdk.NewUint(100*common.One)), Equals, true)
+
+	na.AddProviderBond(provider, sdk.NewUint(300*common.One))
+	na.AddProviderBond(operator, sdk.NewUint(100*common.One))
+	c.Check(na.Bond.Equal(sdk.NewUint(500*common.One)), Equals, true)
+	c.Assert(na.GetBondProviders(), HasLen, 2)
+	bp, ok := na.GetBondProviders().Get(provider)
+	c.Assert(ok, Equals, true)
+	c.Check(bp.Bond.Equal(sdk.NewUint(300*common.One)), Equals, true)
+
+	// slash is shared by all providers
+	na.SubBond(sdk.NewUint(100 * common.One))
+	bp, _ = na.GetBondProviders().Get(provider)
+	c.Check(bp.Bond.Equal(sdk.NewUint(240*common.One)), Equals, true, Commentf("%d", bp.Bond.Uint64()))
+	bp, _ = na.GetBondProviders().Get(operator)
+	c.Check(bp.Bond.Equal(sdk.NewUint(160*common.One)), Equals, true, Commentf("%d", bp.Bond.Uint64()))
+
+	// the operator takes 10% of the reward, the rest is split pro rata
+	na.OperatorFeeBasisPoints = 1000
+	na.AddBondReward(sdk.NewUint(100 * common.One))
+	c.Check(na.Bond.Equal(sdk.NewUint(500*common.One)), Equals, true)
+	c.Check(na.GetBondProviders().TotalBond().Equal(na.Bond), Equals, true)
+	bp, _ = na.GetBondProviders().Get(provider)
+	c.Check(bp.Bond.Equal(sdk.NewUint(294*common.One)), Equals, true, Commentf("%d", bp.Bond.Uint64()))
+	bp, _ = na.GetBondProviders().Get(operator)
+	c.Check(bp.Bond.Equal(sdk.NewUint(206*common.One)), Equals, true, Commentf("%d", bp.Bond.Uint64()))
+
+	na.OperatorFeeBasisPoints = MaxOperatorFeeBasisPoints + 1
+	c.Check(na.IsValid(), NotNil)
+}
+
+func (NodeAccountSuite) TestSubProviderBond(c *C) {
+	operator := GetRandomBNBAddress()
+	provider := GetRandomBNBAddress()
+	na := NewNodeAccount(GetRandomBech32Addr(), Standby, GetRandomPubKeySet(), GetRandomBech32ConsensusPubKey(), sdk.NewUint(100*common.One), operator, 1)
+	na.AddProviderBond(provider, sdk.NewUint(100*common.One))
+
+	na.SubProviderBond(provider, sdk.NewUint(40*common.One))
+	c.Check(na.Bond.Equal(sdk.NewUint(160*common.One)), Equals, true)
+	bp, _ := na.GetBondProviders().Get(provider)
+	c.Check(bp.Bond.Equal(sdk.NewUint(60*common.One)), Equals, true)
+
+	// capped at the share of the provider
+	na.SubProviderBond(provider, sdk.NewUint(100*common.One))
+	c.Check(na.Bond.Equal(sdk.NewUint(100*common.One)), Equals, true)
+	bp, _ = na.GetBondProviders().Get(operator)
+	c.Check(bp.Bond.Equal(sdk.NewUint(100*common.One)), Equals, true)
+
+	// not a bond provider
+	na.SubProviderBond(GetRandomBNBAddress(), sdk.NewUint(common.One))
+	c.Check(na.Bond.Equal(sdk.NewUint(100*common.One)), Equals, true)
+}
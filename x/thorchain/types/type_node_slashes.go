@@ -0,0 +1,118 @@
+package types
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// SlashReason the reason a node account got slashed
+type SlashReason string
+
+// all the reasons a node account can be slashed for
+const (
+	SlashReasonLackObserving SlashReason = "lack_observing"
+	SlashReasonLackSigning   SlashReason = "lack_signing"
+	SlashReasonFailKeygen    SlashReason = "fail_keygen"
+	SlashReasonFailKeysign   SlashReason = "fail_keysign"
+	SlashReasonDoubleSign    SlashReason = "double_sign"
+	// SlashReasonDecay is not a slash, it records the slash points that decayed
+	SlashReasonDecay SlashReason = "decay"
+)
+
+// SlashCounter the slash points a node account got for one reason
+type SlashCounter struct {
+	Reason SlashReason `json:"reason"`
+	Points int64       `json:"points"`
+}
+
+// SlashRecord the slashes a node account got for one reason in one block, Rune is the bond that got slashed directly
+type SlashRecord struct {
+	Height int64       `json:"height"`
+	Reason SlashReason `json:"reason"`
+	Points int64       `json:"points"`
+	Rune   sdk.Uint    `json:"rune"`
+}
+
+// SlashRecords a list of slash records, in height order
+type SlashRecords []SlashRecord
+
+// NewSlashRecord create a new instance of SlashRecord
+func NewSlashRecord(height int64, reason SlashReason, points int64, rune sdk.Uint) SlashRecord {
+	return SlashRecord{
+		Height: height,
+		Reason: reason,
+		Points: points,
+		Rune:   rune,
+	}
+}
+
+// PointsSince return the slash points recorded at or after the given height, decay is not included
+func (records SlashRecords) PointsSince(height int64) int64 {
+	var points int64
+	for _, record := range records {
+		if record.Height >= height && record.Reason != SlashReasonDecay {
+			points += record.Points
+		}
+	}
+	return points
+}
+
+// CountRecords return the number of records with the given reason
+func (records SlashRecords) CountRecords(reason SlashReason) int64 {
+	var count int64
+	for _, record := range records {
+		if record.Reason == reason {
+			count++
+		}
+	}
+	return count
+}
+
+// NodeSlashes the slash points of a node account broken down by reason, the counters start over whenever the slash
+// points are reset
+type NodeSlashes struct {
+	NodeAddress sdk.AccAddress `json:"node_address"`
+	Counters    []SlashCounter `json:"counters"`
+}
+
+// NewNodeSlashes create a new instance of NodeSlashes
+func NewNodeSlashes(addr sdk.AccAddress) NodeSlashes {
+	return NodeSlashes{
+		NodeAddress: addr,
+	}
+}
+
+// GetPoints return the slash points the node account got for the given reason
+func (s NodeSlashes) GetPoints(reason SlashReason) int64 {
+	for _, counter := range s.Counters {
+		if counter.Reason == reason {
+			return counter.Points
+		}
+	}
+	return 0
+}
+
+// Add add the given slash points to the counter of the given reason
+func (s *NodeSlashes) Add(reason SlashReason, points int64) {
+	if points == 0 {
+		return
+	}
+	for i, counter := range s.Counters {
+		if counter.Reason == reason {
+			s.Counters[i].Points += points
+			return
+		}
+	}
+	s.Counters = append(s.Counters, SlashCounter{Reason: reason, Points: points})
+}
+
+// Decay take the given basis points off every counter
+func (s *NodeSlashes) Decay(basisPoints int64) {
+	for i, counter := range s.Counters {
+		s.Counters[i].Points -= counter.Points * basisPoints / 10_000
+	}
+}
+
+// ResetCounters start all counters over
+func (s *NodeSlashes) ResetCounters() {
+	s.Counters = nil
+}
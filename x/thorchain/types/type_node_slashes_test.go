@@ -0,0 +1,45 @@
+package types
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	. "gopkg.in/check.v1"
+)
+
+type NodeSlashesSuite struct{}
+
+var _ = Suite(&NodeSlashesSuite{})
+
+func (NodeSlashesSuite) TestNodeSlashes(c *C) {
+	slashes := NewNodeSlashes(GetRandomBech32Addr())
+	slashes.Add(SlashReasonLackObserving, 2)
+	slashes.Add(SlashReasonLackObserving, 2)
+	slashes.Add(SlashReasonFailKeysign, 100)
+	slashes.Add(SlashReasonDoubleSign, 0)
+	c.Check(slashes.GetPoints(SlashReasonLackObserving), Equals, int64(4))
+	c.Check(slashes.GetPoints(SlashReasonFailKeysign), Equals, int64(100))
+	c.Check(slashes.GetPoints(SlashReasonLackSigning), Equals, int64(0))
+	c.Check(slashes.Counters, HasLen, 2)
+
+	// 10% of each counter decays
+	slashes.Decay(1000)
+	c.Check(slashes.GetPoints(SlashReasonFailKeysign), Equals, int64(90))
+	c.Check(slashes.GetPoints(SlashReasonLackObserving), Equals, int64(4))
+
+	slashes.ResetCounters()
+	c.Check(slashes.GetPoints(SlashReasonFailKeysign), Equals, int64(0))
+	c.Check(slashes.Counters, HasLen, 0)
+}
+
+func (NodeSlashesSuite) TestSlashRecords(c *C) {
+	records := SlashRecords{
+		NewSlashRecord(10, SlashReasonLackObserving, 4, sdk.ZeroUint()),
+		NewSlashRecord(10, SlashReasonFailKeysign, 100, sdk.ZeroUint()),
+		NewSlashRecord(12, SlashReasonLackObserving, 2, sdk.ZeroUint()),
+		NewSlashRecord(12, SlashReasonDoubleSign, 0, sdk.NewUint(500)),
+		NewSlashRecord(20, SlashReasonDecay, -10, sdk.ZeroUint()),
+	}
+	c.Check(records.PointsSince(0), Equals, int64(106))
+	c.Check(records.PointsSince(12), Equals, int64(2))
+	c.Check(records.CountRecords(SlashReasonFailKeysign), Equals, int64(1))
+	c.Check(records.CountRecords(SlashReasonFailKeygen), Equals, int64(0))
+}
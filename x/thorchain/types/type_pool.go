@@ -83,6 +83,9 @@ type Pool struct {
 	PoolUnits    sdk.Uint       `json:"pool_units"`    // total units of the pool
 	PoolAddress  common.Address `json:"pool_address"`  // bnb liquidity pool address
 	Status       PoolStatus     `json:"status"`        // status
+
+	PriceCumulative       sdk.Uint `json:"price_cumulative"`        // asset price in RUNE summed over every block since the pool is tracked
+	PriceCumulativeHeight int64    `json:"price_cumulative_height"` // block height the price accumulator was last brought up to
 }
 
 type Pools []Pool
@@ -94,9 +97,18 @@ func NewPool() Pool {
 		BalanceAsset: sdk.ZeroUint(),
 		PoolUnits:    sdk.ZeroUint(),
 		Status:       Enabled,
+
+		PriceCumulative: sdk.ZeroUint(),
 	}
 }
 
+// PoolPriceCumulative is the price accumulator of a pool as it was at a block height
+type PoolPriceCumulative struct {
+	Asset           common.Asset `json:"asset"`
+	Height          int64        `json:"height"`
+	PriceCumulative sdk.Uint     `json:"price_cumulative"`
+}
+
 func (ps Pool) Valid() error {
 	if ps.Empty() {
 		return errors.New("Pool asset cannot be empty")
@@ -158,3 +170,20 @@ func (ps Pool) RuneValueInAsset(amt sdk.Uint) sdk.Uint {
 	}
 	return common.GetShare(ps.BalanceAsset, ps.BalanceRune, amt)
 }
+
+// AssetPrice return the price of one asset in RUNE, the same way common.One is used for one unit of an asset
+func (ps Pool) AssetPrice() sdk.Uint {
+	return ps.AssetValueInRune(sdk.NewUint(common.One))
+}
+
+// GetPriceCumulative return the price accumulator brought up to the given height, the current asset price counts once
+// for every block since the accumulator was last updated
+func (ps Pool) GetPriceCumulative(height int64) sdk.Uint {
+	if ps.PriceCumulativeHeight == 0 {
+		return sdk.ZeroUint()
+	}
+	if height <= ps.PriceCumulativeHeight {
+		return ps.PriceCumulative
+	}
+	return ps.PriceCumulative.Add(ps.AssetPrice().MulUint64(uint64(height - ps.PriceCumulativeHeight)))
+}
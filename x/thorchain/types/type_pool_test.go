@@ -66,3 +66,19 @@ func (PoolTestSuite) TestPoolStatus(c *C) {
 	err = json.Unmarshal([]byte(`{asdf}`), &ps)
 	c.Assert(err, NotNil)
 }
+
+func (PoolTestSuite) TestPriceCumulative(c *C) {
+	p := NewPool()
+	p.Asset = common.BNBAsset
+	p.BalanceRune = sdk.NewUint(200 * common.One)
+	p.BalanceAsset = sdk.NewUint(100 * common.One)
+	c.Check(p.AssetPrice().Equal(sdk.NewUint(2*common.One)), Equals, true)
+	// not tracked yet
+	c.Check(p.GetPriceCumulative(10).IsZero(), Equals, true)
+
+	p.PriceCumulative = sdk.NewUint(5 * common.One)
+	p.PriceCumulativeHeight = 10
+	c.Check(p.GetPriceCumulative(5).Equal(sdk.NewUint(5*common.One)), Equals, true)
+	c.Check(p.GetPriceCumulative(10).Equal(sdk.NewUint(5*common.One)), Equals, true)
+	c.Check(p.GetPriceCumulative(13).Equal(sdk.NewUint(11*common.One)), Equals, true)
+}
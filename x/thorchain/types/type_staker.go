@@ -15,7 +15,11 @@ type Staker struct {
 	LastStakeHeight   int64          `json:"last_stake"`
 	LastUnStakeHeight int64          `json:"last_unstake"`
 	Units             sdk.Uint       `json:"units"`
-	PendingRune       sdk.Uint       `json:"pending_rune"` // number of rune coins
+	PendingRune       sdk.Uint       `json:"pending_rune"`        // number of rune coins
+	PendingRuneHeight int64          `json:"pending_rune_height"` // block height the pending rune started to wait for the asset
+	PendingTxID       common.TxID    `json:"pending_tx_id"`       // the tx that brought in the pending rune
+	RuneDeposit       sdk.Uint       `json:"rune_deposit"`        // RUNE the staker put into the pool, less what was unstaked
+	AssetDeposit      sdk.Uint       `json:"asset_deposit"`       // asset the staker put into the pool, less what was unstaked
 }
 
 func (staker Staker) IsValid() error {
@@ -31,6 +35,24 @@ func (staker Staker) IsValid() error {
 	return nil
 }
 
+// IsPendingRuneExpired return true when the staker has pending rune which had waited for the asset for at least the given number of blocks
+func (staker Staker) IsPendingRuneExpired(height, timeout int64) bool {
+	if staker.PendingRune.IsZero() {
+		return false
+	}
+	return height-staker.PendingRuneHeight >= timeout
+}
+
+// GetDepositValue return the value in RUNE of the deposit behind the given number of the staker's units, priced by the given pool
+func (staker Staker) GetDepositValue(pool Pool, units sdk.Uint) sdk.Uint {
+	if staker.Units.IsZero() {
+		return sdk.ZeroUint()
+	}
+	runeDeposit := common.GetShare(units, staker.Units, staker.RuneDeposit)
+	assetDeposit := common.GetShare(units, staker.Units, staker.AssetDeposit)
+	return runeDeposit.Add(pool.AssetValueInRune(assetDeposit))
+}
+
 func (staker Staker) Key() string {
 	return fmt.Sprintf(
 		"%s/%s",
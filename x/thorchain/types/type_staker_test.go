@@ -1,6 +1,7 @@
 package types
 
 import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
 	"gitlab.com/thorchain/thornode/common"
 	. "gopkg.in/check.v1"
 )
@@ -18,3 +19,34 @@ func (StakerSuite) TestStaker(c *C) {
 	}
 	c.Check(staker.IsValid(), IsNil)
 }
+
+func (StakerSuite) TestIsPendingRuneExpired(c *C) {
+	staker := Staker{
+		Asset:           common.BTCAsset,
+		RuneAddress:     GetRandomBNBAddress(),
+		AssetAddress:    GetRandomBTCAddress(),
+		LastStakeHeight: 12,
+		PendingRune:     sdk.ZeroUint(),
+	}
+	c.Check(staker.IsPendingRuneExpired(100, 10), Equals, false)
+	staker.PendingRune = sdk.NewUint(common.One)
+	staker.PendingRuneHeight = 12
+	c.Check(staker.IsPendingRuneExpired(21, 10), Equals, false)
+	c.Check(staker.IsPendingRuneExpired(22, 10), Equals, true)
+}
+
+func (StakerSuite) TestGetDepositValue(c *C) {
+	staker := Staker{
+		Asset:        common.BNBAsset,
+		Units:        sdk.NewUint(100),
+		RuneDeposit:  sdk.NewUint(10 * common.One),
+		AssetDeposit: sdk.NewUint(20 * common.One),
+	}
+	pool := NewPool()
+	pool.BalanceRune = sdk.NewUint(200 * common.One)
+	pool.BalanceAsset = sdk.NewUint(100 * common.One)
+	c.Check(staker.GetDepositValue(pool, sdk.NewUint(100)).Equal(sdk.NewUint(50*common.One)), Equals, true)
+	c.Check(staker.GetDepositValue(pool, sdk.NewUint(50)).Equal(sdk.NewUint(25*common.One)), Equals, true)
+	staker.Units = sdk.ZeroUint()
+	c.Check(staker.GetDepositValue(pool, sdk.NewUint(50)).IsZero(), Equals, true)
+}
@@ -34,23 +34,23 @@ func validateUnstake(ctx sdk.Context, keeper keep.Keeper, msg MsgSetUnStake) err
 }
 
 // unstake withdraw all the asset
-// it returns runeAmt,assetAmount,units, lastUnstake,err
-func unstake(ctx sdk.Context, version semver.Version, keeper keep.Keeper, msg MsgSetUnStake, eventManager EventManager) (sdk.Uint, sdk.Uint, sdk.Uint, sdk.Uint, sdk.Error) {
+// it returns runeAmt,assetAmount,units,gasAsset,impLossProtection,err, runeAmt includes the impermanent loss protection paid from the reserve
+func unstake(ctx sdk.Context, version semver.Version, keeper keep.Keeper, msg MsgSetUnStake, eventManager EventManager) (sdk.Uint, sdk.Uint, sdk.Uint, sdk.Uint, sdk.Uint, sdk.Error) {
 	if err := validateUnstake(ctx, keeper, msg); err != nil {
 		ctx.Logger().Error("msg unstake fail validation", "error", err)
-		return sdk.ZeroUint(), sdk.ZeroUint(), sdk.ZeroUint(), sdk.ZeroUint(), sdk.NewError(DefaultCodespace, CodeUnstakeFailValidation, err.Error())
+		return sdk.ZeroUint(), sdk.ZeroUint(), sdk.ZeroUint(), sdk.ZeroUint(), sdk.ZeroUint(), sdk.NewError(DefaultCodespace, CodeUnstakeFailValidation, err.Error())
 	}
 
 	pool, err := keeper.GetPool(ctx, msg.Asset)
 	if err != nil {
 		ctx.Logger().Error("fail to get pool", "error", err)
-		return sdk.ZeroUint(), sdk.ZeroUint(), sdk.ZeroUint(), sdk.ZeroUint(), sdk.ErrInternal("fail to get pool")
+		return sdk.ZeroUint(), sdk.ZeroUint(), sdk.ZeroUint(), sdk.ZeroUint(), sdk.ZeroUint(), sdk.ErrInternal("fail to get pool")
 	}
 
 	stakerUnit, err := keeper.GetStaker(ctx, msg.Asset, msg.RuneAddress)
 	if err != nil {
 		ctx.Logger().Error("can't find staker", "error", err)
-		return sdk.ZeroUint(), sdk.ZeroUint(), sdk.ZeroUint(), sdk.ZeroUint(), sdk.NewError(DefaultCodespace, CodeStakerNotExist, "staker doesn't exist")
+		return sdk.ZeroUint(), sdk.ZeroUint(), sdk.ZeroUint(), sdk.ZeroUint(), sdk.ZeroUint(), sdk.NewError(DefaultCodespace, CodeStakerNotExist, "staker doesn't exist")
 
 	}
 
@@ -59,7 +59,7 @@ func unstake(ctx sdk.Context, version semver.Version, keeper keep.Keeper, msg Ms
 	poolAsset := pool.BalanceAsset
 	fStakerUnit := stakerUnit.Units
 	if stakerUnit.Units.IsZero() || msg.UnstakeBasisPoints.IsZero() {
-		return sdk.ZeroUint(), sdk.ZeroUint(), sdk.ZeroUint(), sdk.ZeroUint(), sdk.NewError(DefaultCodespace, CodeNoStakeUnitLeft, "nothing to withdraw")
+		return sdk.ZeroUint(), sdk.ZeroUint(), sdk.ZeroUint(), sdk.ZeroUint(), sdk.ZeroUint(), sdk.NewError(DefaultCodespace, CodeNoStakeUnitLeft, "nothing to withdraw")
 	}
 
 	cv := constants.GetConstantValues(version)
@@ -68,7 +68,7 @@ func unstake(ctx sdk.Context, version semver.Version, keeper keep.Keeper, msg Ms
 	if !msg.Asset.Chain.Equals(common.BNBChain) {
 		height := ctx.BlockHeight()
 		if height < (stakerUnit.LastStakeHeight + cv.GetInt64Value(constants.StakeLockUpBlocks)) {
-			return sdk.ZeroUint(), sdk.ZeroUint(), sdk.ZeroUint(), sdk.ZeroUint(), sdk.NewError(DefaultCodespace, CodeUnstakeWithin24Hours, "you cannot unstake for 24 hours after staking for this blockchain")
+			return sdk.ZeroUint(), sdk.ZeroUint(), sdk.ZeroUint(), sdk.ZeroUint(), sdk.ZeroUint(), sdk.NewError(DefaultCodespace, CodeUnstakeWithin24Hours, "you cannot unstake for 24 hours after staking for this blockchain")
 		}
 	}
 
@@ -77,7 +77,17 @@ func unstake(ctx sdk.Context, version semver.Version, keeper keep.Keeper, msg Ms
 	withdrawRune, withDrawAsset, unitAfter, err := calculateUnstake(poolUnits, poolRune, poolAsset, fStakerUnit, msg.UnstakeBasisPoints)
 	if err != nil {
 		ctx.Logger().Error("fail to unstake", "error", err)
-		return sdk.ZeroUint(), sdk.ZeroUint(), sdk.ZeroUint(), sdk.ZeroUint(), sdk.NewError(DefaultCodespace, CodeUnstakeFail, err.Error())
+		return sdk.ZeroUint(), sdk.ZeroUint(), sdk.ZeroUint(), sdk.ZeroUint(), sdk.ZeroUint(), sdk.NewError(DefaultCodespace, CodeUnstakeFail, err.Error())
+	}
+	unitsClaimed := common.SafeSub(fStakerUnit, unitAfter)
+	impLossProtection := sdk.ZeroUint()
+	// the reserve is handed back to its contributors on ragnarok, stakers unstaked by the ragnarok protocol aren't protected
+	if !msg.Tx.ID.Equals(common.BlankTxID) {
+		impLossProtection, err = calcImpLossProtection(ctx, keeper, cv, pool, stakerUnit, unitsClaimed, withdrawRune, withDrawAsset)
+		if err != nil {
+			ctx.Logger().Error("fail to calculate impermanent loss protection", "error", err)
+			impLossProtection = sdk.ZeroUint()
+		}
 	}
 	gasAsset := sdk.ZeroUint()
 	// If the pool is empty, and there is a gas asset, subtract required gas
@@ -87,7 +97,7 @@ func unstake(ctx sdk.Context, version semver.Version, keeper keep.Keeper, msg Ms
 			gasInfo, err := keeper.GetGas(ctx, pool.Asset)
 			if err != nil {
 				ctx.Logger().Error("fail to get gas for asset", "asset", pool.Asset, "error", err)
-				return sdk.ZeroUint(), sdk.ZeroUint(), sdk.ZeroUint(), sdk.ZeroUint(), sdk.NewError(DefaultCodespace, CodeUnstakeFail, err.Error())
+				return sdk.ZeroUint(), sdk.ZeroUint(), sdk.ZeroUint(), sdk.ZeroUint(), sdk.ZeroUint(), sdk.NewError(DefaultCodespace, CodeUnstakeFail, err.Error())
 			}
 			originalAsset := withDrawAsset
 			withDrawAsset = common.SafeSub(
@@ -114,6 +124,8 @@ func unstake(ctx sdk.Context, version semver.Version, keeper keep.Keeper, msg Ms
 
 	ctx.Logger().Info("pool after unstake", "pool unit", pool.PoolUnits, "balance RUNE", pool.BalanceRune, "balance asset", pool.BalanceAsset)
 	// update staker
+	stakerUnit.RuneDeposit = common.SafeSub(stakerUnit.RuneDeposit, common.GetShare(unitsClaimed, fStakerUnit, stakerUnit.RuneDeposit))
+	stakerUnit.AssetDeposit = common.SafeSub(stakerUnit.AssetDeposit, common.GetShare(unitsClaimed, fStakerUnit, stakerUnit.AssetDeposit))
 	stakerUnit.Units = unitAfter
 	stakerUnit.LastUnStakeHeight = ctx.BlockHeight()
 
@@ -128,14 +140,21 @@ func unstake(ctx sdk.Context, version semver.Version, keeper keep.Keeper, msg Ms
 
 	if err := keeper.SetPool(ctx, pool); err != nil {
 		ctx.Logger().Error("fail to save pool", "error", err)
-		return sdk.ZeroUint(), sdk.ZeroUint(), sdk.ZeroUint(), sdk.ZeroUint(), sdk.ErrInternal("fail to save pool")
+		return sdk.ZeroUint(), sdk.ZeroUint(), sdk.ZeroUint(), sdk.ZeroUint(), sdk.ZeroUint(), sdk.ErrInternal("fail to save pool")
 	}
 	if !stakerUnit.Units.IsZero() {
 		keeper.SetStaker(ctx, stakerUnit)
 	} else {
 		keeper.RemoveStaker(ctx, stakerUnit)
 	}
-	return withdrawRune, withDrawAsset, common.SafeSub(fStakerUnit, unitAfter), gasAsset, nil
+
+	// the reserve pays the impermanent loss protection on top of what the staker withdraws from the pool
+	impLossProtection, err = payImpLossProtection(ctx, keeper, impLossProtection)
+	if err != nil {
+		ctx.Logger().Error("fail to pay impermanent loss protection", "error", err)
+		impLossProtection = sdk.ZeroUint()
+	}
+	return withdrawRune.Add(impLossProtection), withDrawAsset, unitsClaimed, gasAsset, impLossProtection, nil
 }
 
 func calculateUnstake(poolUnits, poolRune, poolAsset, stakerUnits, withdrawBasisPoints sdk.Uint) (sdk.Uint, sdk.Uint, sdk.Uint, error) {
@@ -70,10 +70,12 @@ func (p *UnstakeTestKeeper) GetStaker(ctx sdk.Context, asset common.Asset, addr
 		return Staker{}, errors.New("simulate error for test")
 	}
 	staker := Staker{
-		Asset:       asset,
-		RuneAddress: addr,
-		Units:       sdk.ZeroUint(),
-		PendingRune: sdk.ZeroUint(),
+		Asset:        asset,
+		RuneAddress:  addr,
+		Units:        sdk.ZeroUint(),
+		PendingRune:  sdk.ZeroUint(),
+		RuneDeposit:  sdk.ZeroUint(),
+		AssetDeposit: sdk.ZeroUint(),
 	}
 	key := p.GetKey(ctx, prefixStaker, staker.Key())
 	if res, ok := p.store[key]; ok {
@@ -473,7 +475,7 @@ func (UnstakeSuite) TestUnstake(c *C) {
 		versionedEventManagerDummy := NewDummyVersionedEventMgr()
 		eventManager, err := versionedEventManagerDummy.GetEventManager(ctx, version)
 		c.Assert(err, IsNil)
-		r, asset, _, _, err := unstake(ctx, version, tc.ps, tc.msg, eventManager)
+		r, asset, _, _, _, err := unstake(ctx, version, tc.ps, tc.msg, eventManager)
 		if tc.expectedError != nil {
 			c.Assert(err, NotNil)
 			c.Check(err.Error(), Equals, tc.expectedError.Error())
@@ -511,6 +513,8 @@ func getUnstakeTestKeeper(c *C) keep.Keeper {
 		AssetAddress: runeAddress,
 		Units:        sdk.NewUint(100 * common.One),
 		PendingRune:  sdk.ZeroUint(),
+		RuneDeposit:  sdk.ZeroUint(),
+		AssetDeposit: sdk.ZeroUint(),
 	}
 	store.SetStaker(ctx, staker)
 	return store
@@ -20,6 +20,7 @@ type VersionedValidatorManager interface {
 	BeginBlock(ctx sdk.Context, version semver.Version, constAccessor constants.ConstantValues) error
 	EndBlock(ctx sdk.Context, version semver.Version, constAccessor constants.ConstantValues) []abci.ValidatorUpdate
 	RequestYggReturn(ctx sdk.Context, version semver.Version, node NodeAccount) error
+	ChurnPreview(ctx sdk.Context, version semver.Version, constAccessor constants.ConstantValues) (QueryResChurnPreview, error)
 }
 
 // VersionedValidatorMgr
@@ -74,3 +75,14 @@ func (vm *VersionedValidatorMgr) RequestYggReturn(ctx sdk.Context, version semve
 	}
 	return errBadVersion
 }
+
+// ChurnPreview return the outcome of a churn if it happened now, without persisting anything
+func (vm *VersionedValidatorMgr) ChurnPreview(ctx sdk.Context, version semver.Version, constAccessor constants.ConstantValues) (QueryResChurnPreview, error) {
+	if version.GTE(semver.MustParse("0.1.0")) {
+		if vm.v1ValidatorMgr == nil {
+			vm.v1ValidatorMgr = newValidatorMgrV1(vm.keeper, vm.versionedTxOutStore, vm.versionedVaultManager, vm.versionedEventManager)
+		}
+		return vm.v1ValidatorMgr.ChurnPreview(ctx, constAccessor)
+	}
+	return QueryResChurnPreview{}, errBadVersion
+}
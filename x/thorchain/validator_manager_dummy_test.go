@@ -27,6 +27,10 @@ func (VersionedValidatorDummyMgr) RequestYggReturn(ctx sdk.Context, version semv
 	return nil
 }
 
+func (VersionedValidatorDummyMgr) ChurnPreview(ctx sdk.Context, version semver.Version, constAccessor constants.ConstantValues) (QueryResChurnPreview, error) {
+	return QueryResChurnPreview{}, kaboom
+}
+
 // ValidatorDummyMgr is to manage a list of validators , and rotate them
 type ValidatorDummyMgr struct {
 }
@@ -9,6 +9,7 @@ import (
 	"github.com/blang/semver"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/libs/log"
 	tmtypes "github.com/tendermint/tendermint/types"
 
 	"gitlab.com/thorchain/thornode/common"
@@ -332,8 +333,8 @@ func (vm *validatorMgrV1) payNodeAccountBondAward(ctx sdk.Context, na NodeAccoun
 	// calc number of rune they are awarded
 	reward := vault.CalcNodeRewards(earnedBlocks)
 
-	// Add to their bond the amount rewarded
-	na.Bond = na.Bond.Add(reward)
+	// Add to their bond the amount rewarded, the operator takes its fee and the rest is split among the bond providers
+	na.AddBondReward(reward)
 
 	// Minus the number of rune THORNode have awarded them
 	vault.BondRewardRune = common.SafeSub(vault.BondRewardRune, reward)
@@ -544,25 +545,36 @@ func (vm *validatorMgrV1) ragnarokBond(ctx sdk.Context, nth int64) error {
 		if nth > 10 { // cap at 10
 			nth = 10
 		}
-		amt := na.Bond.MulUint64(uint64(nth)).QuoUint64(10)
+		// refund each bond provider the same fraction of its share of the bond
+		providers := na.GetBondProviders()
+		for i, bp := range providers {
+			amt := bp.Bond.MulUint64(uint64(nth)).QuoUint64(10)
+			if amt.IsZero() {
+				continue
+			}
 
-		// refund bond
-		txOutItem := &TxOutItem{
-			Chain:     common.RuneAsset().Chain,
-			ToAddress: na.BondAddress,
-			InHash:    common.BlankTxID,
-			Coin:      common.NewCoin(common.RuneAsset(), amt),
-			Memo:      NewRagnarokMemo(ctx.BlockHeight()).String(),
-		}
-		ok, err := txOutStore.TryAddTxOutItem(ctx, txOutItem)
-		if err != nil {
-			return err
+			// refund bond
+			txOutItem := &TxOutItem{
+				Chain:     common.RuneAsset().Chain,
+				ToAddress: bp.BondAddress,
+				InHash:    common.BlankTxID,
+				Coin:      common.NewCoin(common.RuneAsset(), amt),
+				Memo:      NewRagnarokMemo(ctx.BlockHeight()).String(),
+			}
+			ok, err := txOutStore.TryAddTxOutItem(ctx, txOutItem)
+			if err != nil {
+				return err
+			}
+			if !ok {
+				continue
+			}
+
+			providers[i].Bond = common.SafeSub(bp.Bond, amt)
+			na.Bond = common.SafeSub(na.Bond, amt)
 		}
-		if !ok {
-			continue
+		if len(na.BondProviders) > 0 {
+			na.BondProviders = providers
 		}
-
-		na.Bond = common.SafeSub(na.Bond, amt)
 		if err := vm.k.SetNodeAccount(ctx, na); err != nil {
 			return err
 		}
@@ -802,20 +814,46 @@ func (vm *validatorMgrV1) findBadActors(ctx sdk.Context) (NodeAccounts, error) {
 		return nil, nil
 	}
 
+	tracker, redline := rankBadActors(ctx, vm.k, nas)
+	if len(tracker) == 0 {
+		// no offenders, exit nicely
+		return nil, nil
+	}
+
+	// find any node accounts that have crossed the redline
+	for _, track := range tracker {
+		if redline.GTE(track.Score) {
+			badActors = append(badActors, track.NodeAccount)
+		}
+	}
+
+	// if no one crossed the redline, lets just grab the worse offender
+	if len(badActors) == 0 {
+		badActors = NodeAccounts{tracker[0].NodeAccount}
+	}
+
+	return badActors, nil
+}
+
+// badTracker the score of a node account, it gives a numerical representation of the behavior of the node account
+type badTracker struct {
+	Score       sdk.Dec
+	NodeAccount NodeAccount
+}
+
+// rankBadActors score the given active node accounts, worst first, along with the redline a score has to be at or
+// below to be churned out as a bad actor
+func rankBadActors(ctx sdk.Context, keeper keep.Keeper, nas NodeAccounts) ([]badTracker, sdk.Dec) {
 	// NOTE: Our score gives a numerical representation of the behavior our a
 	// node account. The lower the score, the worse behavior. The score is
 	// determined by relative to how many slash points they have over how long
 	// they have been an active node account.
-	type badTracker struct {
-		Score       sdk.Dec
-		NodeAccount NodeAccount
-	}
 	tracker := make([]badTracker, 0, len(nas))
 	totalScore := sdk.ZeroDec()
 
 	// Find bad actor relative to age / slashpoints
 	for _, na := range nas {
-		slashPts, err := vm.k.GetNodeAccountSlashPoints(ctx, na.NodeAddress)
+		slashPts, err := keeper.GetNodeAccountSlashPoints(ctx, na.NodeAddress)
 		if err != nil {
 			ctx.Logger().Error("fail to get node slash points", "error", err)
 		}
@@ -838,8 +876,7 @@ func (vm *validatorMgrV1) findBadActors(ctx sdk.Context) (NodeAccounts, error) {
 	}
 
 	if len(tracker) == 0 {
-		// no offenders, exit nicely
-		return nil, nil
+		return tracker, sdk.ZeroDec()
 	}
 
 	sort.SliceStable(tracker, func(i, j int) bool {
@@ -856,20 +893,7 @@ func (vm *validatorMgrV1) findBadActors(ctx sdk.Context) (NodeAccounts, error) {
 	// churn, as that could threaten the security of the funds. This logic to
 	// protect against this is not inside this function.
 	redline := avgScore.QuoInt64(3)
-
-	// find any node accounts that have crossed the redline
-	for _, track := range tracker {
-		if redline.GTE(track.Score) {
-			badActors = append(badActors, track.NodeAccount)
-		}
-	}
-
-	// if no one crossed the redline, lets just grab the worse offender
-	if len(badActors) == 0 {
-		badActors = NodeAccounts{tracker[0].NodeAccount}
-	}
-
-	return badActors, nil
+	return tracker, redline
 }
 
 // Iterate over active node accounts, finding the one that has been active longest
@@ -882,14 +906,20 @@ func (vm *validatorMgrV1) findOldActor(ctx sdk.Context) (NodeAccount, error) {
 
 	// TODO: return if we're at risk of loosing BTF
 
+	return findOldestNodeAccount(ctx, nas), nil
+}
+
+// findOldestNodeAccount return the node account that has been in its status the longest, an empty node account when
+// there is none
+func findOldestNodeAccount(ctx sdk.Context, nas NodeAccounts) NodeAccount {
+	na := NodeAccount{}
 	na.StatusSince = ctx.BlockHeight() // set the start status age to "now"
 	for _, n := range nas {
 		if n.StatusSince < na.StatusSince {
 			na = n
 		}
 	}
-
-	return na, nil
+	return na
 }
 
 // Mark an old to be churned out
@@ -1063,6 +1093,69 @@ func (vm *validatorMgrV1) nextVaultNodeAccounts(ctx sdk.Context, targetCount int
 	return active, rotation, nil
 }
 
+// ChurnPreview run the churn selection against the current state, and return who would leave, who would join and the
+// members of the new asgard vault. Everything runs in a cached context that is never written, so nothing is persisted.
+func (vm *validatorMgrV1) ChurnPreview(ctx sdk.Context, constAccessor constants.ConstantValues) (QueryResChurnPreview, error) {
+	preview := QueryResChurnPreview{
+		Height:  ctx.BlockHeight(),
+		Leaving: NodeAccounts{},
+		Joining: NodeAccounts{},
+		Members: NodeAccounts{},
+	}
+	if vm.k.RagnarokInProgress(ctx) {
+		return preview, errors.New("ragnarok is in progress, there is no churn")
+	}
+	cacheCtx, _ := ctx.CacheContext()
+	// nodes marked to leave in the cached context are not really marked, don't log them as such
+	cacheCtx = cacheCtx.WithLogger(log.NewNopLogger())
+
+	// mark bad and old actors as if this block was their turn to be checked
+	minimumNodesForBFT := constAccessor.GetInt64Value(constants.MinimumNodesForBFT)
+	totalActiveNodes, err := vm.k.TotalActiveNodeAccount(cacheCtx)
+	if err != nil {
+		return preview, err
+	}
+	if minimumNodesForBFT+2 < int64(totalActiveNodes) {
+		if err := vm.markBadActor(cacheCtx, 1); err != nil {
+			return preview, err
+		}
+		if err := vm.markOldActor(cacheCtx, 1); err != nil {
+			return preview, err
+		}
+	}
+
+	active, err := vm.k.ListActiveNodeAccounts(cacheCtx)
+	if err != nil {
+		return preview, err
+	}
+	desireValidatorSet, err := vm.k.GetMimir(cacheCtx, constants.DesireValidatorSet.String())
+	if desireValidatorSet < 0 || err != nil {
+		desireValidatorSet = constAccessor.GetInt64Value(constants.DesireValidatorSet)
+	}
+	next, rotation, err := vm.nextVaultNodeAccounts(cacheCtx, int(desireValidatorSet), constAccessor)
+	if err != nil {
+		return preview, err
+	}
+	preview.Rotation = rotation
+	if !rotation {
+		preview.Members = active
+		return preview, nil
+	}
+
+	preview.Members = next
+	for _, na := range active {
+		if !next.Contains(na) {
+			preview.Leaving = append(preview.Leaving, na)
+		}
+	}
+	for _, na := range next {
+		if !active.Contains(na) {
+			preview.Joining = append(preview.Joining, na)
+		}
+	}
+	return preview, nil
+}
+
 // findCountToRemove - find the number of node accounts to remove
 func findCountToRemove(blockHeight int64, active NodeAccounts) (toRemove int) {
 	// count number of node accounts that are a candidate to leaving
@@ -178,12 +178,16 @@ func (s *ValidatorManagerTestSuite) TestRagnarokChain(c *C) {
 			LastStakeHeight: 5,
 			Units:           btcPool.PoolUnits.QuoUint64(2),
 			PendingRune:     sdk.ZeroUint(),
+			RuneDeposit:     sdk.ZeroUint(),
+			AssetDeposit:    sdk.ZeroUint(),
 		},
 		Staker{
 			RuneAddress:     GetRandomRUNEAddress(),
 			LastStakeHeight: 10,
 			Units:           btcPool.PoolUnits.QuoUint64(2),
 			PendingRune:     sdk.ZeroUint(),
+			RuneDeposit:     sdk.ZeroUint(),
+			AssetDeposit:    sdk.ZeroUint(),
 		},
 	}
 
@@ -44,11 +44,16 @@ func Fund(ctx sdk.Context, keeper keep.Keeper, txOutStore TxOutStore, constAcces
 		return nil
 	}
 
-	// Gather list of all pools
+	// Gather list of all pools, priced at their time weighted average price, so
+	// the assets sent to a yggdrasil vault can't be skewed within a single block
 	pools, err := keeper.GetPools(ctx)
 	if err != nil {
 		return err
 	}
+	pools, err = getTWAPPools(ctx, keeper, pools, getTWAPWindow(ctx, keeper, constAccessor))
+	if err != nil {
+		return err
+	}
 
 	for _, na := range nodeAccs {
 		totalBond = totalBond.Add(na.Bond)